@@ -0,0 +1,512 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package column
+
+import (
+	"slices"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+// Arrow type mapping of the field types, used by FromArrow and ToArrow:
+//
+//	Bool                          boolean
+//	Int8, Int16, Int32, Int64     int8, int16, int32, int64
+//	Float, Double                 float32, float64
+//	VarChar, String               utf8 (large_utf8 is accepted as input)
+//	JSON                          utf8 of the json text (binary is accepted as input)
+//	Geometry                      utf8 of the WKT
+//	Timestamptz                   utf8 in RFC3339 (timestamp is accepted as input)
+//	Array                         list of the element type
+//	FloatVector                   fixed_size_list<float32>[dim] (list is accepted as input)
+//	Int8Vector                    fixed_size_list<int8>[dim] (list is accepted as input)
+//	BinaryVector                  fixed_size_binary[dim/8]
+//	Float16Vector, BFloat16Vector fixed_size_binary[dim*2]
+//	SparseFloatVector             map<uint32, float32>
+//
+// Null values of nullable fields are mapped to the validity bitmap of the arrow array.
+
+type stringArray interface {
+	arrow.Array
+	Value(int) string
+}
+
+type binaryArray interface {
+	arrow.Array
+	Value(int) []byte
+}
+
+// FromArrow converts the arrow array into a column of the field.
+// The values are copied, the array could be released once the column is created.
+func FromArrow(field *entity.Field, arr arrow.Array) (Column, error) {
+	switch field.DataType {
+	case entity.FieldTypeBool:
+		return fromArrowScalar(field, arr, (*array.Boolean).Value, NewColumnBool, NewNullableColumnBool)
+	case entity.FieldTypeInt8:
+		return fromArrowScalar(field, arr, (*array.Int8).Value, NewColumnInt8, NewNullableColumnInt8)
+	case entity.FieldTypeInt16:
+		return fromArrowScalar(field, arr, (*array.Int16).Value, NewColumnInt16, NewNullableColumnInt16)
+	case entity.FieldTypeInt32:
+		return fromArrowScalar(field, arr, (*array.Int32).Value, NewColumnInt32, NewNullableColumnInt32)
+	case entity.FieldTypeInt64:
+		return fromArrowScalar(field, arr, (*array.Int64).Value, NewColumnInt64, NewNullableColumnInt64)
+	case entity.FieldTypeFloat:
+		return fromArrowScalar(field, arr, (*array.Float32).Value, NewColumnFloat, NewNullableColumnFloat)
+	case entity.FieldTypeDouble:
+		return fromArrowScalar(field, arr, (*array.Float64).Value, NewColumnDouble, NewNullableColumnDouble)
+	case entity.FieldTypeVarChar:
+		return fromArrowScalar(field, arr, stringArray.Value, NewColumnVarChar, NewNullableColumnVarChar)
+	case entity.FieldTypeString:
+		return fromArrowScalar(field, arr, stringArray.Value, NewColumnString, NewNullableColumnString)
+	case entity.FieldTypeGeometry:
+		return fromArrowScalar(field, arr, stringArray.Value, NewColumnGeometryWKT, NewNullableColumnGeometryWKT)
+	case entity.FieldTypeJSON:
+		if _, ok := arr.(stringArray); ok {
+			return fromArrowScalar(field, arr, func(a stringArray, i int) []byte { return []byte(a.Value(i)) }, NewColumnJSONBytes, NewNullableColumnJSONBytes)
+		}
+		return fromArrowScalar(field, arr, func(a binaryArray, i int) []byte { return slices.Clone(a.Value(i)) }, NewColumnJSONBytes, NewNullableColumnJSONBytes)
+	case entity.FieldTypeTimestamptz:
+		if ts, ok := arr.(*array.Timestamp); ok {
+			toTime, err := ts.DataType().(*arrow.TimestampType).GetToTimeFunc()
+			if err != nil {
+				return nil, err
+			}
+			return fromArrowScalar(field, arr, func(a *array.Timestamp, i int) string { return toTime(a.Value(i)).Format(time.RFC3339Nano) },
+				NewColumnTimestamptzIsoString, NewNullableColumnTimestamptzIsoString)
+		}
+		return fromArrowScalar(field, arr, stringArray.Value, NewColumnTimestamptzIsoString, NewNullableColumnTimestamptzIsoString)
+	case entity.FieldTypeArray:
+		return arrayFromArrow(field, arr)
+	case entity.FieldTypeFloatVector, entity.FieldTypeInt8Vector,
+		entity.FieldTypeBinaryVector, entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+		return vectorFromArrow(field, arr)
+	case entity.FieldTypeSparseVector:
+		return fromArrow(field, arr, sparseFromArrow, func(name string, values []entity.SparseEmbedding, validData []bool) (Column, error) {
+			if validData == nil {
+				return NewColumnSparseVectors(name, values), nil
+			}
+			return NewNullableColumnSparseFloatVector(name, values, validData)
+		})
+	default:
+		return nil, errors.Newf("field %s of type %s is not supported by arrow conversion", field.Name, field.DataType.Name())
+	}
+}
+
+// fromArrow collects the non-null values of the array, the valid data is only set for nullable field.
+func fromArrow[A arrow.Array, T any](field *entity.Field, arr arrow.Array, value func(A, int) (T, error),
+	newColumn func(name string, values []T, validData []bool) (Column, error),
+) (Column, error) {
+	typed, ok := arr.(A)
+	if !ok {
+		return nil, errors.Newf("unexpected arrow type %s for field %s of type %s", arr.DataType(), field.Name, field.DataType.Name())
+	}
+	values := make([]T, 0, arr.Len()-arr.NullN())
+	var validData []bool
+	if field.Nullable {
+		validData = make([]bool, arr.Len())
+	}
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			if !field.Nullable {
+				return nil, errors.Newf("field %s is not nullable, but row %d is null", field.Name, i)
+			}
+			continue
+		}
+		v, err := value(typed, i)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s row %d", field.Name, i)
+		}
+		values = append(values, v)
+		if validData != nil {
+			validData[i] = true
+		}
+	}
+	return newColumn(field.Name, values, validData)
+}
+
+func fromArrowScalar[A arrow.Array, T any, C Column](field *entity.Field, arr arrow.Array, value func(A, int) T,
+	newColumn func(string, []T) C, newNullableColumn func(string, []T, []bool, ...ColumnOption[T]) (C, error),
+) (Column, error) {
+	return fromArrow(field, arr, func(a A, i int) (T, error) { return value(a, i), nil },
+		func(name string, values []T, validData []bool) (Column, error) {
+			if validData == nil {
+				return newColumn(name, values), nil
+			}
+			return newNullableColumn(name, values, validData)
+		})
+}
+
+// listValues returns the accessor of list values, which are copied from the element array.
+func listValues[E arrow.Array, T any](values func(E) []T) func(array.ListLike, int) ([]T, error) {
+	return func(list array.ListLike, i int) ([]T, error) {
+		elements, ok := list.ListValues().(E)
+		if !ok {
+			return nil, errors.Newf("unexpected arrow element type %s", list.ListValues().DataType())
+		}
+		start, end := list.ValueOffsets(i)
+		return slices.Clone(values(elements)[start:end]), nil
+	}
+}
+
+func stringListValues(list array.ListLike, i int) ([]string, error) {
+	elements, ok := list.ListValues().(stringArray)
+	if !ok {
+		return nil, errors.Newf("unexpected arrow element type %s", list.ListValues().DataType())
+	}
+	start, end := list.ValueOffsets(i)
+	values := make([]string, 0, end-start)
+	for j := start; j < end; j++ {
+		values = append(values, elements.Value(int(j)))
+	}
+	return values, nil
+}
+
+func boolListValues(list array.ListLike, i int) ([]bool, error) {
+	elements, ok := list.ListValues().(*array.Boolean)
+	if !ok {
+		return nil, errors.Newf("unexpected arrow element type %s", list.ListValues().DataType())
+	}
+	start, end := list.ValueOffsets(i)
+	values := make([]bool, 0, end-start)
+	for j := start; j < end; j++ {
+		values = append(values, elements.Value(int(j)))
+	}
+	return values, nil
+}
+
+func fromArrowList[T any, C Column](field *entity.Field, arr arrow.Array, value func(array.ListLike, int) ([]T, error),
+	newColumn func(string, [][]T) C, newNullableColumn func(string, [][]T, []bool, ...ColumnOption[[]T]) (C, error),
+) (Column, error) {
+	return fromArrow(field, arr, value, func(name string, values [][]T, validData []bool) (Column, error) {
+		if validData == nil {
+			return newColumn(name, values), nil
+		}
+		return newNullableColumn(name, values, validData)
+	})
+}
+
+func arrayFromArrow(field *entity.Field, arr arrow.Array) (Column, error) {
+	switch field.ElementType {
+	case entity.FieldTypeBool:
+		return fromArrowList(field, arr, boolListValues, NewColumnBoolArray, NewNullableColumnBoolArray)
+	case entity.FieldTypeInt8:
+		return fromArrowList(field, arr, listValues((*array.Int8).Int8Values), NewColumnInt8Array, NewNullableColumnInt8Array)
+	case entity.FieldTypeInt16:
+		return fromArrowList(field, arr, listValues((*array.Int16).Int16Values), NewColumnInt16Array, NewNullableColumnInt16Array)
+	case entity.FieldTypeInt32:
+		return fromArrowList(field, arr, listValues((*array.Int32).Int32Values), NewColumnInt32Array, NewNullableColumnInt32Array)
+	case entity.FieldTypeInt64:
+		return fromArrowList(field, arr, listValues((*array.Int64).Int64Values), NewColumnInt64Array, NewNullableColumnInt64Array)
+	case entity.FieldTypeFloat:
+		return fromArrowList(field, arr, listValues((*array.Float32).Float32Values), NewColumnFloatArray, NewNullableColumnFloatArray)
+	case entity.FieldTypeDouble:
+		return fromArrowList(field, arr, listValues((*array.Float64).Float64Values), NewColumnDoubleArray, NewNullableColumnDoubleArray)
+	case entity.FieldTypeVarChar, entity.FieldTypeString:
+		return fromArrowList(field, arr, stringListValues, NewColumnVarCharArray, NewNullableColumnVarCharArray)
+	default:
+		return nil, errors.Newf("array field %s of element type %s is not supported by arrow conversion", field.Name, field.ElementType.Name())
+	}
+}
+
+func vectorFromArrow(field *entity.Field, arr arrow.Array) (Column, error) {
+	dim64, err := field.GetDim()
+	if err != nil {
+		return nil, err
+	}
+	dim := int(dim64)
+	checkLen := func(l, expected int) error {
+		if l != expected {
+			return errors.Newf("vector length %d does not match dim %d", l, dim)
+		}
+		return nil
+	}
+	floatVector := func(list array.ListLike, i int) ([]float32, error) {
+		v, err := listValues((*array.Float32).Float32Values)(list, i)
+		if err != nil {
+			return nil, err
+		}
+		return v, checkLen(len(v), dim)
+	}
+	int8Vector := func(list array.ListLike, i int) ([]int8, error) {
+		v, err := listValues((*array.Int8).Int8Values)(list, i)
+		if err != nil {
+			return nil, err
+		}
+		return v, checkLen(len(v), dim)
+	}
+	bytesVector := func(size int) func(binaryArray, int) ([]byte, error) {
+		return func(a binaryArray, i int) ([]byte, error) {
+			v := slices.Clone(a.Value(i))
+			return v, checkLen(len(v), size)
+		}
+	}
+
+	switch field.DataType {
+	case entity.FieldTypeFloatVector:
+		return fromArrow(field, arr, floatVector, func(name string, values [][]float32, validData []bool) (Column, error) {
+			if validData == nil {
+				return NewColumnFloatVector(name, dim, values), nil
+			}
+			return NewNullableColumnFloatVector(name, dim, values, validData)
+		})
+	case entity.FieldTypeInt8Vector:
+		return fromArrow(field, arr, int8Vector, func(name string, values [][]int8, validData []bool) (Column, error) {
+			if validData == nil {
+				return NewColumnInt8Vector(name, dim, values), nil
+			}
+			return NewNullableColumnInt8Vector(name, dim, values, validData)
+		})
+	case entity.FieldTypeBinaryVector:
+		return fromArrow(field, arr, bytesVector(dim/8), func(name string, values [][]byte, validData []bool) (Column, error) {
+			if validData == nil {
+				return NewColumnBinaryVector(name, dim, values), nil
+			}
+			return NewNullableColumnBinaryVector(name, dim, values, validData)
+		})
+	case entity.FieldTypeFloat16Vector:
+		return fromArrow(field, arr, bytesVector(dim*2), func(name string, values [][]byte, validData []bool) (Column, error) {
+			if validData == nil {
+				return NewColumnFloat16Vector(name, dim, values), nil
+			}
+			return NewNullableColumnFloat16Vector(name, dim, values, validData)
+		})
+	default:
+		return fromArrow(field, arr, bytesVector(dim*2), func(name string, values [][]byte, validData []bool) (Column, error) {
+			if validData == nil {
+				return NewColumnBFloat16Vector(name, dim, values), nil
+			}
+			return NewNullableColumnBFloat16Vector(name, dim, values, validData)
+		})
+	}
+}
+
+func sparseFromArrow(m *array.Map, i int) (entity.SparseEmbedding, error) {
+	keys, ok := m.Keys().(*array.Uint32)
+	if !ok {
+		return nil, errors.Newf("unexpected sparse vector key type %s", m.Keys().DataType())
+	}
+	items, ok := m.Items().(*array.Float32)
+	if !ok {
+		return nil, errors.Newf("unexpected sparse vector value type %s", m.Items().DataType())
+	}
+	start, end := m.ValueOffsets(i)
+	return entity.NewSliceSparseEmbedding(slices.Clone(keys.Uint32Values()[start:end]), slices.Clone(items.Float32Values()[start:end]))
+}
+
+// ArrowType returns the arrow data type of the field, see FromArrow for the type mapping.
+func ArrowType(field *entity.Field) (arrow.DataType, error) {
+	switch field.DataType {
+	case entity.FieldTypeArray:
+		elementType, err := arrowScalarType(field.ElementType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "array field %s", field.Name)
+		}
+		return arrow.ListOf(elementType), nil
+	case entity.FieldTypeFloatVector, entity.FieldTypeInt8Vector,
+		entity.FieldTypeBinaryVector, entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+		dim, err := field.GetDim()
+		if err != nil {
+			return nil, err
+		}
+		return arrowVectorType(field.DataType, int(dim)), nil
+	case entity.FieldTypeSparseVector:
+		return arrowVectorType(field.DataType, 0), nil
+	default:
+		dataType, err := arrowScalarType(field.DataType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "field %s", field.Name)
+		}
+		return dataType, nil
+	}
+}
+
+func arrowScalarType(fieldType entity.FieldType) (arrow.DataType, error) {
+	switch fieldType {
+	case entity.FieldTypeBool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case entity.FieldTypeInt8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case entity.FieldTypeInt16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case entity.FieldTypeInt32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case entity.FieldTypeInt64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case entity.FieldTypeFloat:
+		return arrow.PrimitiveTypes.Float32, nil
+	case entity.FieldTypeDouble:
+		return arrow.PrimitiveTypes.Float64, nil
+	case entity.FieldTypeVarChar, entity.FieldTypeString, entity.FieldTypeJSON, entity.FieldTypeGeometry, entity.FieldTypeTimestamptz:
+		return arrow.BinaryTypes.String, nil
+	default:
+		return nil, errors.Newf("type %s is not supported by arrow conversion", fieldType.Name())
+	}
+}
+
+func arrowVectorType(fieldType entity.FieldType, dim int) arrow.DataType {
+	switch fieldType {
+	case entity.FieldTypeFloatVector:
+		return arrow.FixedSizeListOf(int32(dim), arrow.PrimitiveTypes.Float32)
+	case entity.FieldTypeInt8Vector:
+		return arrow.FixedSizeListOf(int32(dim), arrow.PrimitiveTypes.Int8)
+	case entity.FieldTypeBinaryVector:
+		return &arrow.FixedSizeBinaryType{ByteWidth: dim / 8}
+	case entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+		return &arrow.FixedSizeBinaryType{ByteWidth: dim * 2}
+	default:
+		return arrow.MapOf(arrow.PrimitiveTypes.Uint32, arrow.PrimitiveTypes.Float32)
+	}
+}
+
+// ToArrow converts the column into an arrow array, see FromArrow for the type mapping.
+// The returned array shall be released by the caller.
+func ToArrow(mem memory.Allocator, col Column) (arrow.Field, arrow.Array, error) {
+	var arr arrow.Array
+	var err error
+	switch c := col.(type) {
+	case *ColumnBool:
+		arr, err = toArrow(mem, col, c.Value, arrow.FixedWidthTypes.Boolean, (*array.BooleanBuilder).Append)
+	case *ColumnInt8:
+		arr, err = toArrow(mem, col, c.Value, arrow.PrimitiveTypes.Int8, (*array.Int8Builder).Append)
+	case *ColumnInt16:
+		arr, err = toArrow(mem, col, c.Value, arrow.PrimitiveTypes.Int16, (*array.Int16Builder).Append)
+	case *ColumnInt32:
+		arr, err = toArrow(mem, col, c.Value, arrow.PrimitiveTypes.Int32, (*array.Int32Builder).Append)
+	case *ColumnInt64:
+		arr, err = toArrow(mem, col, c.Value, arrow.PrimitiveTypes.Int64, (*array.Int64Builder).Append)
+	case *ColumnFloat:
+		arr, err = toArrow(mem, col, c.Value, arrow.PrimitiveTypes.Float32, (*array.Float32Builder).Append)
+	case *ColumnDouble:
+		arr, err = toArrow(mem, col, c.Value, arrow.PrimitiveTypes.Float64, (*array.Float64Builder).Append)
+	case *ColumnVarChar:
+		arr, err = toArrow(mem, col, c.Value, arrow.BinaryTypes.String, (*array.StringBuilder).Append)
+	case *ColumnString:
+		arr, err = toArrow(mem, col, c.Value, arrow.BinaryTypes.String, (*array.StringBuilder).Append)
+	case *ColumnGeometryWKT:
+		arr, err = toArrow(mem, col, c.Value, arrow.BinaryTypes.String, (*array.StringBuilder).Append)
+	case *ColumnTimestamptz:
+		arr, err = toArrow(mem, col, c.Value, arrow.BinaryTypes.String, (*array.StringBuilder).Append)
+	case *ColumnTimestampTzIsoString:
+		arr, err = toArrow(mem, col, c.Value, arrow.BinaryTypes.String, (*array.StringBuilder).Append)
+	case *ColumnDynamic:
+		// the dynamic column outputs the raw json of its key, or null if the key is absent
+		arr, err = toArrowDynamic(mem, c)
+	case *ColumnJSONBytes:
+		arr, err = toArrow(mem, col, c.Value, arrow.BinaryTypes.String, func(b *array.StringBuilder, v []byte) { b.Append(string(v)) })
+	case *ColumnBoolArray:
+		arr, err = toArrowList(mem, col, c.Value, arrow.FixedWidthTypes.Boolean, (*array.BooleanBuilder).AppendValues)
+	case *ColumnInt8Array:
+		arr, err = toArrowList(mem, col, c.Value, arrow.PrimitiveTypes.Int8, (*array.Int8Builder).AppendValues)
+	case *ColumnInt16Array:
+		arr, err = toArrowList(mem, col, c.Value, arrow.PrimitiveTypes.Int16, (*array.Int16Builder).AppendValues)
+	case *ColumnInt32Array:
+		arr, err = toArrowList(mem, col, c.Value, arrow.PrimitiveTypes.Int32, (*array.Int32Builder).AppendValues)
+	case *ColumnInt64Array:
+		arr, err = toArrowList(mem, col, c.Value, arrow.PrimitiveTypes.Int64, (*array.Int64Builder).AppendValues)
+	case *ColumnFloatArray:
+		arr, err = toArrowList(mem, col, c.Value, arrow.PrimitiveTypes.Float32, (*array.Float32Builder).AppendValues)
+	case *ColumnDoubleArray:
+		arr, err = toArrowList(mem, col, c.Value, arrow.PrimitiveTypes.Float64, (*array.Float64Builder).AppendValues)
+	case *ColumnVarCharArray:
+		arr, err = toArrowList(mem, col, c.Value, arrow.BinaryTypes.String, (*array.StringBuilder).AppendValues)
+	case *ColumnFloatVector:
+		arr, err = toArrowList(mem, col, func(i int) ([]float32, error) { v, err := c.Value(i); return v, err },
+			arrowVectorType(entity.FieldTypeFloatVector, c.Dim()), (*array.Float32Builder).AppendValues)
+	case *ColumnInt8Vector:
+		arr, err = toArrowList(mem, col, func(i int) ([]int8, error) { v, err := c.Value(i); return v, err },
+			arrowVectorType(entity.FieldTypeInt8Vector, c.Dim()), (*array.Int8Builder).AppendValues)
+	case *ColumnBinaryVector:
+		arr, err = toArrow(mem, col, c.Value, arrowVectorType(entity.FieldTypeBinaryVector, c.Dim()),
+			func(b *array.FixedSizeBinaryBuilder, v entity.BinaryVector) { b.Append(v) })
+	case *ColumnFloat16Vector:
+		arr, err = toArrow(mem, col, c.Value, arrowVectorType(entity.FieldTypeFloat16Vector, c.Dim()),
+			func(b *array.FixedSizeBinaryBuilder, v entity.Float16Vector) { b.Append(v) })
+	case *ColumnBFloat16Vector:
+		arr, err = toArrow(mem, col, c.Value, arrowVectorType(entity.FieldTypeBFloat16Vector, c.Dim()),
+			func(b *array.FixedSizeBinaryBuilder, v entity.BFloat16Vector) { b.Append(v) })
+	case *ColumnSparseFloatVector:
+		arr, err = toArrow(mem, col, c.Value, arrowVectorType(entity.FieldTypeSparseVector, 0), appendSparse)
+	default:
+		return arrow.Field{}, nil, errors.Newf("column %s of type %s is not supported by arrow conversion", col.Name(), col.Type().Name())
+	}
+	if err != nil {
+		return arrow.Field{}, nil, errors.Wrapf(err, "column %s", col.Name())
+	}
+	return arrow.Field{Name: col.Name(), Type: arr.DataType(), Nullable: col.Nullable()}, arr, nil
+}
+
+func toArrow[B array.Builder, T any](mem memory.Allocator, col Column, value func(int) (T, error), dataType arrow.DataType, appendValue func(B, T)) (arrow.Array, error) {
+	builder := array.NewBuilder(mem, dataType)
+	defer builder.Release()
+	typed := builder.(B)
+	builder.Reserve(col.Len())
+	for i := 0; i < col.Len(); i++ {
+		if isNull, err := col.IsNull(i); err != nil {
+			return nil, err
+		} else if isNull {
+			builder.AppendNull()
+			continue
+		}
+		v, err := value(i)
+		if err != nil {
+			return nil, err
+		}
+		appendValue(typed, v)
+	}
+	return builder.NewArray(), nil
+}
+
+func toArrowList[B array.Builder, T any](mem memory.Allocator, col Column, value func(int) ([]T, error), dataType arrow.DataType, appendValues func(B, []T, []bool)) (arrow.Array, error) {
+	if _, ok := dataType.(arrow.ListLikeType); !ok {
+		dataType = arrow.ListOf(dataType)
+	}
+	return toArrow(mem, col, value, dataType, func(b array.ListLikeBuilder, v []T) {
+		b.Append(true)
+		appendValues(b.ValueBuilder().(B), v, nil)
+	})
+}
+
+func toArrowDynamic(mem memory.Allocator, col *ColumnDynamic) (arrow.Array, error) {
+	builder := array.NewStringBuilder(mem)
+	defer builder.Release()
+	for i := 0; i < col.Len(); i++ {
+		v, err := col.Get(i)
+		if err != nil {
+			builder.AppendNull()
+			continue
+		}
+		builder.Append(v.(string))
+	}
+	return builder.NewArray(), nil
+}
+
+func appendSparse(b *array.MapBuilder, v entity.SparseEmbedding) {
+	b.Append(true)
+	keys := b.KeyBuilder().(*array.Uint32Builder)
+	items := b.ItemBuilder().(*array.Float32Builder)
+	for j := 0; j < v.Len(); j++ {
+		pos, value, _ := v.Get(j)
+		keys.Append(pos)
+		items.Append(value)
+	}
+}
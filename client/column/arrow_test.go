@@ -0,0 +1,190 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package column
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+type ArrowSuite struct {
+	suite.Suite
+
+	mem *memory.CheckedAllocator
+}
+
+func (s *ArrowSuite) SetupTest() {
+	s.mem = memory.NewCheckedAllocator(memory.NewGoAllocator())
+}
+
+func (s *ArrowSuite) TearDownTest() {
+	s.mem.AssertSize(s.T(), 0)
+}
+
+func (s *ArrowSuite) buildArray(dataType arrow.DataType, build func(b array.Builder)) arrow.Array {
+	b := array.NewBuilder(s.mem, dataType)
+	defer b.Release()
+	build(b)
+	return b.NewArray()
+}
+
+func (s *ArrowSuite) TestFromArrow() {
+	s.Run("nullable_scalar", func() {
+		arr := s.buildArray(arrow.PrimitiveTypes.Int64, func(b array.Builder) {
+			b.(*array.Int64Builder).AppendValues([]int64{1, 0, 3}, []bool{true, false, true})
+		})
+		defer arr.Release()
+
+		col, err := FromArrow(entity.NewField().WithName("a").WithDataType(entity.FieldTypeInt64).WithNullable(true), arr)
+		s.Require().NoError(err)
+		fd := col.FieldData()
+		s.Equal([]int64{1, 3}, fd.GetScalars().GetLongData().GetData())
+		s.Equal([]bool{true, false, true}, fd.GetValidData())
+
+		_, err = FromArrow(entity.NewField().WithName("a").WithDataType(entity.FieldTypeInt64), arr)
+		s.Error(err)
+		_, err = FromArrow(entity.NewField().WithName("a").WithDataType(entity.FieldTypeInt32), arr)
+		s.Error(err)
+	})
+
+	s.Run("string_json_timestamptz", func() {
+		arr := s.buildArray(arrow.BinaryTypes.LargeString, func(b array.Builder) {
+			b.(*array.LargeStringBuilder).AppendValues([]string{`{"a":1}`, `[1,2]`}, nil)
+		})
+		defer arr.Release()
+		col, err := FromArrow(entity.NewField().WithName("v").WithDataType(entity.FieldTypeVarChar), arr)
+		s.Require().NoError(err)
+		s.Equal([]string{`{"a":1}`, `[1,2]`}, col.FieldData().GetScalars().GetStringData().GetData())
+		col, err = FromArrow(entity.NewField().WithName("j").WithDataType(entity.FieldTypeJSON), arr)
+		s.Require().NoError(err)
+		s.Equal([][]byte{[]byte(`{"a":1}`), []byte(`[1,2]`)}, col.FieldData().GetScalars().GetJsonData().GetData())
+
+		ts := s.buildArray(&arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}, func(b array.Builder) {
+			b.(*array.TimestampBuilder).Append(arrow.Timestamp(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC).UnixMilli()))
+		})
+		defer ts.Release()
+		col, err = FromArrow(entity.NewField().WithName("t").WithDataType(entity.FieldTypeTimestamptz), ts)
+		s.Require().NoError(err)
+		s.Equal([]string{"2025-01-02T03:04:05Z"}, col.FieldData().GetScalars().GetStringData().GetData())
+	})
+
+	s.Run("vectors", func() {
+		// list is accepted as well as fixed size list
+		arr := s.buildArray(arrow.ListOf(arrow.PrimitiveTypes.Float32), func(b array.Builder) {
+			lb := b.(*array.ListBuilder)
+			lb.Append(true)
+			lb.ValueBuilder().(*array.Float32Builder).AppendValues([]float32{1, 2}, nil)
+			lb.Append(true)
+			lb.ValueBuilder().(*array.Float32Builder).AppendValues([]float32{3, 4}, nil)
+		})
+		defer arr.Release()
+		col, err := FromArrow(entity.NewField().WithName("vec").WithDataType(entity.FieldTypeFloatVector).WithDim(2), arr)
+		s.Require().NoError(err)
+		s.Equal([]float32{1, 2, 3, 4}, col.FieldData().GetVectors().GetFloatVector().GetData())
+		s.EqualValues(2, col.FieldData().GetVectors().GetDim())
+
+		_, err = FromArrow(entity.NewField().WithName("vec").WithDataType(entity.FieldTypeFloatVector).WithDim(4), arr)
+		s.Error(err)
+
+		sparse := s.buildArray(arrow.MapOf(arrow.PrimitiveTypes.Uint32, arrow.PrimitiveTypes.Float32), func(b array.Builder) {
+			mb := b.(*array.MapBuilder)
+			mb.Append(true)
+			mb.KeyBuilder().(*array.Uint32Builder).AppendValues([]uint32{1, 10}, nil)
+			mb.ItemBuilder().(*array.Float32Builder).AppendValues([]float32{0.5, 0.25}, nil)
+			mb.AppendNull()
+		})
+		defer sparse.Release()
+		col, err = FromArrow(entity.NewField().WithName("sparse").WithDataType(entity.FieldTypeSparseVector).WithNullable(true), sparse)
+		s.Require().NoError(err)
+		s.Equal([]bool{true, false}, col.FieldData().GetValidData())
+		s.EqualValues(11, col.FieldData().GetVectors().GetDim())
+	})
+
+	s.Run("array", func() {
+		arr := s.buildArray(arrow.ListOf(arrow.BinaryTypes.String), func(b array.Builder) {
+			lb := b.(*array.ListBuilder)
+			lb.Append(true)
+			lb.ValueBuilder().(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+			lb.Append(true)
+		})
+		defer arr.Release()
+		col, err := FromArrow(entity.NewField().WithName("arr").WithDataType(entity.FieldTypeArray).WithElementType(entity.FieldTypeVarChar), arr)
+		s.Require().NoError(err)
+		s.Equal([][]string{{"a", "b"}, {}}, col.(*ColumnVarCharArray).Data())
+	})
+}
+
+func (s *ArrowSuite) TestToArrow() {
+	s.Run("nullable_scalar", func() {
+		// search & query results are in sparse mode
+		col, err := NewNullableColumnVarChar("name", []string{"a", "", "c"}, []bool{true, false, true}, WithSparseNullableMode[string](true))
+		s.Require().NoError(err)
+		field, arr, err := ToArrow(s.mem, col)
+		s.Require().NoError(err)
+		defer arr.Release()
+		s.Equal(arrow.Field{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true}, field)
+		strs := arr.(*array.String)
+		s.Equal("a", strs.Value(0))
+		s.True(strs.IsNull(1))
+		s.Equal("c", strs.Value(2))
+	})
+
+	s.Run("round_trip", func() {
+		sparse1, err := entity.NewSliceSparseEmbedding([]uint32{1, 5}, []float32{0.1, 0.2})
+		s.Require().NoError(err)
+		sparse2, err := entity.NewSliceSparseEmbedding([]uint32{2}, []float32{0.3})
+		s.Require().NoError(err)
+		columns := []struct {
+			col   Column
+			field *entity.Field
+		}{
+			{NewColumnBool("bool", []bool{true, false}), entity.NewField().WithDataType(entity.FieldTypeBool)},
+			{NewColumnInt8("int8", []int8{1, 2}), entity.NewField().WithDataType(entity.FieldTypeInt8)},
+			{NewColumnDouble("double", []float64{1.5, 2.5}), entity.NewField().WithDataType(entity.FieldTypeDouble)},
+			{NewColumnJSONBytes("json", [][]byte{[]byte(`{"a":1}`), []byte(`{}`)}), entity.NewField().WithDataType(entity.FieldTypeJSON)},
+			{NewColumnInt64Array("int64_array", [][]int64{{1, 2}, {3}}), entity.NewField().WithDataType(entity.FieldTypeArray).WithElementType(entity.FieldTypeInt64)},
+			{NewColumnFloatVector("float_vector", 2, [][]float32{{1, 2}, {3, 4}}), entity.NewField().WithDataType(entity.FieldTypeFloatVector).WithDim(2)},
+			{NewColumnInt8Vector("int8_vector", 2, [][]int8{{1, 2}, {3, 4}}), entity.NewField().WithDataType(entity.FieldTypeInt8Vector).WithDim(2)},
+			{NewColumnBinaryVector("binary_vector", 16, [][]byte{{1, 2}, {3, 4}}), entity.NewField().WithDataType(entity.FieldTypeBinaryVector).WithDim(16)},
+			{NewColumnFloat16Vector("fp16_vector", 1, [][]byte{{1, 2}, {3, 4}}), entity.NewField().WithDataType(entity.FieldTypeFloat16Vector).WithDim(1)},
+			{NewColumnSparseVectors("sparse", []entity.SparseEmbedding{sparse1, sparse2}), entity.NewField().WithDataType(entity.FieldTypeSparseVector)},
+		}
+		for _, c := range columns {
+			field, arr, err := ToArrow(s.mem, c.col)
+			s.Require().NoError(err, c.col.Name())
+			expectedType, err := ArrowType(c.field)
+			s.Require().NoError(err)
+			s.True(arrow.TypeEqual(expectedType, field.Type), c.col.Name())
+
+			result, err := FromArrow(c.field.WithName(c.col.Name()), arr)
+			arr.Release()
+			s.Require().NoError(err, c.col.Name())
+			s.Equal(c.col.FieldData(), result.FieldData(), c.col.Name())
+		}
+	})
+}
+
+func TestArrow(t *testing.T) {
+	suite.Run(t, new(ArrowSuite))
+}
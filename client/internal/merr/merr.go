@@ -89,12 +89,14 @@ func (e *RPCError) Is(target error) bool {
 }
 
 var (
-	ErrServiceNotReady = newRPCError("service not ready", 1, commonpb.ErrorCode_NotReadyServe, true, false)
-	ErrServiceInternal = newRPCError("service internal error", 5, commonpb.ErrorCode_UnexpectedError, false, false)
+	ErrServiceNotReady  = newRPCError("service not ready", 1, commonpb.ErrorCode_NotReadyServe, true, false)
+	ErrServiceInternal  = newRPCError("service internal error", 5, commonpb.ErrorCode_UnexpectedError, false, false)
+	ErrServiceRateLimit = newRPCError("rate limit exceeded", 8, commonpb.ErrorCode_RateLimit, true, false)
 
 	ErrCollectionNotFound       = newRPCError("collection not found", 100, commonpb.ErrorCode_CollectionNotExists, false, false)
 	ErrCollectionSchemaMismatch = newRPCError("collection schema mismatch", 109, commonpb.ErrorCode_SchemaMismatch, false, true)
 	ErrIndexNotFound            = newRPCError("index not found", 700, commonpb.ErrorCode_IndexNotExist, false, false)
+	ErrAliasNotFound            = newRPCError("alias not found", 1600, commonpb.ErrorCode_UnexpectedError, false, false)
 	ErrParameterInvalid         = newRPCError("invalid parameter", 1100, commonpb.ErrorCode_IllegalArgument, false, true)
 
 	errUnexpected = newRPCError("unexpected error", unexpectedCode, commonpb.ErrorCode_UnexpectedError, false, false)
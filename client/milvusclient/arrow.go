@@ -0,0 +1,240 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+// ArrowScoreField is the name of the score column in the arrow record of search results.
+const ArrowScoreField = ScoreField
+
+var (
+	_ InsertOption = (*arrowDataOption)(nil)
+	_ UpsertOption = (*arrowDataOption)(nil)
+)
+
+// arrowDataOption writes an arrow record, the columns are matched with the fields by name,
+// see column.FromArrow for the type mapping.
+type arrowDataOption struct {
+	*columnBasedDataOption
+	record arrow.Record
+}
+
+// NewArrowInsertOption returns the insert or upsert option of the arrow record.
+// The record is only read during the request, the caller keeps its ownership.
+func NewArrowInsertOption(collName string, record arrow.Record) *arrowDataOption {
+	return &arrowDataOption{
+		columnBasedDataOption: &columnBasedDataOption{
+			collName: collName,
+		},
+		record: record,
+	}
+}
+
+func (opt *arrowDataOption) WithPartition(partitionName string) *arrowDataOption {
+	opt.columnBasedDataOption.WithPartition(partitionName)
+	return opt
+}
+
+func (opt *arrowDataOption) WithNamespace(namespace string) *arrowDataOption {
+	opt.columnBasedDataOption.WithNamespace(namespace)
+	return opt
+}
+
+func (opt *arrowDataOption) WithPartialUpdate(partialUpdate bool) *arrowDataOption {
+	opt.columnBasedDataOption.WithPartialUpdate(partialUpdate)
+	return opt
+}
+
+func (opt *arrowDataOption) InsertRequest(coll *entity.Collection) (*milvuspb.InsertRequest, error) {
+	columns, err := arrowColumns(coll.Schema, opt.record)
+	if err != nil {
+		return nil, err
+	}
+	opt.columnBasedDataOption.columns = columns
+	return opt.columnBasedDataOption.InsertRequest(coll)
+}
+
+func (opt *arrowDataOption) UpsertRequest(coll *entity.Collection) (*milvuspb.UpsertRequest, error) {
+	columns, err := arrowColumns(coll.Schema, opt.record)
+	if err != nil {
+		return nil, err
+	}
+	opt.columnBasedDataOption.columns = columns
+	return opt.columnBasedDataOption.UpsertRequest(coll)
+}
+
+// arrowColumns converts the record columns by the schema,
+// the columns not in the schema are written into the dynamic field if it's enabled.
+func arrowColumns(schema *entity.Schema, record arrow.Record) ([]column.Column, error) {
+	if record == nil {
+		return nil, errors.New("arrow record is nil")
+	}
+	fields := make(map[string]*entity.Field)
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+	columns := make([]column.Column, 0, record.NumCols())
+	for i, arr := range record.Columns() {
+		name := record.ColumnName(i)
+		field, ok := fields[name]
+		if !ok {
+			if !schema.EnableDynamicField {
+				return nil, errors.Newf("field %s does not exist in collection %s", name, schema.CollectionName)
+			}
+			fieldType, err := dynamicArrowFieldType(arr.DataType())
+			if err != nil {
+				return nil, errors.Wrapf(err, "dynamic column %s", name)
+			}
+			field = entity.NewField().WithName(name).WithDataType(fieldType).WithNullable(arr.NullN() > 0)
+		}
+		col, err := column.FromArrow(field, arr)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+func dynamicArrowFieldType(dataType arrow.DataType) (entity.FieldType, error) {
+	switch dataType.ID() {
+	case arrow.BOOL:
+		return entity.FieldTypeBool, nil
+	case arrow.INT8:
+		return entity.FieldTypeInt8, nil
+	case arrow.INT16:
+		return entity.FieldTypeInt16, nil
+	case arrow.INT32:
+		return entity.FieldTypeInt32, nil
+	case arrow.INT64:
+		return entity.FieldTypeInt64, nil
+	case arrow.FLOAT32:
+		return entity.FieldTypeFloat, nil
+	case arrow.FLOAT64:
+		return entity.FieldTypeDouble, nil
+	case arrow.STRING, arrow.LARGE_STRING:
+		return entity.FieldTypeVarChar, nil
+	default:
+		return entity.FieldTypeNone, errors.Newf("arrow type %s is not supported", dataType)
+	}
+}
+
+// InsertArrow inserts the arrow record into the collection.
+func (c *Client) InsertArrow(ctx context.Context, collName string, record arrow.Record, callOptions ...grpc.CallOption) (InsertResult, error) {
+	return c.Insert(ctx, NewArrowInsertOption(collName, record), callOptions...)
+}
+
+// QueryArrow queries the collection and returns the result as an arrow record,
+// which shall be released by the caller.
+func (c *Client) QueryArrow(ctx context.Context, option QueryOption, callOptions ...grpc.CallOption) (arrow.Record, error) {
+	rs, err := c.Query(ctx, option, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return rs.ArrowRecord(memory.DefaultAllocator)
+}
+
+// SearchArrow searches the collection and returns an arrow record for each query vector,
+// with the primary key, the output fields and the score in ArrowScoreField.
+// The records shall be released by the caller.
+func (c *Client) SearchArrow(ctx context.Context, option SearchOption, callOptions ...grpc.CallOption) ([]arrow.Record, error) {
+	resultSets, err := c.Search(ctx, option, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]arrow.Record, 0, len(resultSets))
+	for _, rs := range resultSets {
+		if rs.Err != nil {
+			err = rs.Err
+		} else {
+			var record arrow.Record
+			record, err = rs.ArrowRecord(memory.DefaultAllocator)
+			records = append(records, record)
+		}
+		if err != nil {
+			for _, record := range records {
+				record.Release()
+			}
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// ArrowRecord converts the result set into an arrow record, see column.FromArrow for the type mapping.
+// The primary key is included, and the scores are appended as ArrowScoreField for search results.
+// The record shall be released by the caller.
+func (rs *ResultSet) ArrowRecord(mem memory.Allocator) (arrow.Record, error) {
+	var fields []arrow.Field
+	var arrays []arrow.Array
+	defer func() {
+		for _, arr := range arrays {
+			arr.Release()
+		}
+	}()
+	appendColumn := func(col column.Column) error {
+		field, arr, err := column.ToArrow(mem, col)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, field)
+		arrays = append(arrays, arr)
+		return nil
+	}
+
+	if rs.IDs != nil && rs.GetColumn(rs.IDs.Name()) == nil {
+		if err := appendColumn(rs.IDs); err != nil {
+			return nil, err
+		}
+	}
+	for _, col := range rs.Fields {
+		if err := appendColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	if rs.Scores != nil {
+		builder := array.NewFloat32Builder(mem)
+		builder.AppendValues(rs.Scores, nil)
+		fields = append(fields, arrow.Field{Name: ArrowScoreField, Type: arrow.PrimitiveTypes.Float32})
+		arrays = append(arrays, builder.NewArray())
+		builder.Release()
+	}
+
+	var rows int64
+	if len(arrays) > 0 {
+		rows = int64(arrays[0].Len())
+	}
+	for i, arr := range arrays {
+		if int64(arr.Len()) != rows {
+			return nil, errors.Newf("column %s has %d rows, expected %d", fields[i].Name, arr.Len(), rows)
+		}
+	}
+	// the record retains the arrays, which are released by the deferred function
+	return array.NewRecord(arrow.NewSchema(fields, nil), arrays, rows), nil
+}
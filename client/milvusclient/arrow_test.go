@@ -0,0 +1,168 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+type ArrowSuite struct {
+	MockSuiteBase
+
+	schema *entity.Schema
+}
+
+func (s *ArrowSuite) SetupSuite() {
+	s.MockSuiteBase.SetupSuite()
+	s.schema = entity.NewSchema().WithDynamicFieldEnabled(true).
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("tag").WithDataType(entity.FieldTypeVarChar).WithMaxLength(64).WithNullable(true)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(2))
+}
+
+func (s *ArrowSuite) record(mem memory.Allocator) arrow.Record {
+	b := array.NewRecordBuilder(mem, arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "tag", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "vector", Type: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Float32)},
+		{Name: "extra", Type: arrow.PrimitiveTypes.Float64},
+	}, nil))
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "", "c"}, []bool{true, false, true})
+	vb := b.Field(2).(*array.FixedSizeListBuilder)
+	for i := 0; i < 3; i++ {
+		vb.Append(true)
+		vb.ValueBuilder().(*array.Float32Builder).AppendValues([]float32{float32(i), float32(i)}, nil)
+	}
+	b.Field(3).(*array.Float64Builder).AppendValues([]float64{0.1, 0.2, 0.3}, nil)
+	return b.NewRecord()
+}
+
+func (s *ArrowSuite) TestInsertArrow() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(s.T(), 0)
+	record := s.record(mem)
+	defer record.Release()
+
+	s.mock.EXPECT().Insert(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.InsertRequest) (*milvuspb.MutationResult, error) {
+		s.EqualValues(3, req.GetNumRows())
+		s.Equal([]int64{1, 2, 3}, bufferedFieldData(req.GetFieldsData(), "id").GetScalars().GetLongData().GetData())
+		tag := bufferedFieldData(req.GetFieldsData(), "tag")
+		s.Equal([]string{"a", "c"}, tag.GetScalars().GetStringData().GetData())
+		s.Equal([]bool{true, false, true}, tag.GetValidData())
+		s.Equal([]float32{0, 0, 1, 1, 2, 2}, bufferedFieldData(req.GetFieldsData(), "vector").GetVectors().GetFloatVector().GetData())
+		// the column not in schema is written into dynamic field
+		dynamic, ok := lo.Find(req.GetFieldsData(), func(fd *schemapb.FieldData) bool { return fd.GetIsDynamic() })
+		s.Require().True(ok)
+		s.JSONEq(`{"extra":0.1}`, string(dynamic.GetScalars().GetJsonData().GetData()[0]))
+		return &milvuspb.MutationResult{
+			Status:    merr.Success(),
+			InsertCnt: 3,
+			IDs:       &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{1, 2, 3}}}},
+		}, nil
+	}).Once()
+
+	result, err := s.client.InsertArrow(ctx, collName, record)
+	s.Require().NoError(err)
+	s.EqualValues(3, result.InsertCount)
+
+	// dim mismatch is reported as schema mismatch, which is retried with the latest schema
+	dim4 := entity.NewSchema().WithName(collName).
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(4))
+	s.mock.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+		Status: merr.Success(),
+		Schema: dim4.ProtoMessage(),
+	}, nil)
+	s.setupCache(collName, dim4)
+	_, err = s.client.Insert(ctx, NewArrowInsertOption(collName, record))
+	s.Error(err)
+}
+
+func (s *ArrowSuite) TestQueryAndSearchArrow() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	tag := &schemapb.FieldData{
+		Type:      schemapb.DataType_VarChar,
+		FieldName: "tag",
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{
+			StringData: &schemapb.StringArray{Data: []string{"a", ""}},
+		}}},
+		ValidData: []bool{true, false},
+	}
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).Return(&milvuspb.QueryResults{
+		Status:     merr.Success(),
+		FieldsData: []*schemapb.FieldData{s.getInt64FieldData("id", []int64{1, 2}), tag},
+	}, nil).Once()
+
+	record, err := s.client.QueryArrow(ctx, NewQueryOption(collName).WithFilter("id > 0").WithOutputFields("id", "tag"))
+	s.Require().NoError(err)
+	s.EqualValues(2, record.NumRows())
+	s.Equal([]string{"id", "tag"}, []string{record.ColumnName(0), record.ColumnName(1)})
+	s.Equal([]int64{1, 2}, record.Column(0).(*array.Int64).Int64Values())
+	s.True(record.Column(1).IsNull(1))
+	record.Release()
+
+	s.mock.EXPECT().Search(mock.Anything, mock.Anything).Return(&milvuspb.SearchResults{
+		Status: merr.Success(),
+		Results: &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       2,
+			FieldsData: []*schemapb.FieldData{tag},
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{2, 1}}}},
+			Scores:     []float32{0.9, 0.8},
+			Topks:      []int64{2},
+		},
+	}, nil).Once()
+
+	records, err := s.client.SearchArrow(ctx, NewSearchOption(collName, 2, []entity.Vector{entity.FloatVector{1, 1}}).WithOutputFields("tag"))
+	s.Require().NoError(err)
+	s.Require().Len(records, 1)
+	record = records[0]
+	s.Equal("id", record.ColumnName(0))
+	s.Equal([]int64{2, 1}, record.Column(0).(*array.Int64).Int64Values())
+	s.Equal(ArrowScoreField, record.ColumnName(2))
+	s.Equal([]float32{0.9, 0.8}, record.Column(2).(*array.Float32).Float32Values())
+	record.Release()
+}
+
+func TestArrow(t *testing.T) {
+	suite.Run(t, new(ArrowSuite))
+}
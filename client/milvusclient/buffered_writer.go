@@ -0,0 +1,442 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+// BufferedWriteResult is the result of a batch flushed by BufferedWriter.
+type BufferedWriteResult struct {
+	Rows  int
+	Bytes int
+	// Retries is the number of retries on retriable errors.
+	Retries int
+	// IDs are the primary keys of the written rows, nil if the batch failed.
+	IDs column.Column
+	Err error
+}
+
+// BufferedWriter buffers the written rows, and flushes them in batches by row count, bytes or age.
+// The batches are sent by concurrent requests, writes are blocked when all requests are in flight.
+// The order of the batches is not guaranteed, the failures are reported by the callback,
+// and the first one is returned by Flush or Close.
+type BufferedWriter struct {
+	ctx    context.Context
+	client *Client
+	opt    *bufferedWriterOption
+
+	mu     sync.Mutex
+	batch  *writeBatch
+	closed bool
+
+	errMu sync.Mutex
+	err   error
+
+	slots    chan struct{}
+	inflight sync.WaitGroup
+	closeCh  chan struct{}
+	loopWg   sync.WaitGroup
+}
+
+type writeBatch struct {
+	fieldsData []*schemapb.FieldData
+	rows       int
+	bytes      int
+	createTime time.Time
+}
+
+// NewBufferedWriter creates a BufferedWriter, the context bounds the lifetime of the requests sent by the writer.
+func (c *Client) NewBufferedWriter(ctx context.Context, option BufferedWriterOption) (*BufferedWriter, error) {
+	if err := option.ValidateParams(); err != nil {
+		return nil, err
+	}
+	opt := option.writerOption()
+	w := &BufferedWriter{
+		ctx:     ctx,
+		client:  c,
+		opt:     opt,
+		slots:   make(chan struct{}, opt.concurrency),
+		closeCh: make(chan struct{}),
+	}
+	w.loopWg.Add(1)
+	go w.flushLoop()
+	return w, nil
+}
+
+// Write buffers the rows, which are structs or map[string]any as in NewRowBasedInsertOption.
+func (w *BufferedWriter) Write(ctx context.Context, rows ...any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	coll, err := w.client.getCollection(ctx, w.opt.collectionName)
+	if err != nil {
+		return err
+	}
+	return w.write(ctx, 0, len(rows), func(begin, end int) ([]*schemapb.FieldData, error) {
+		return w.convert(coll, NewRowBasedInsertOption(w.opt.collectionName, rows[begin:end]...))
+	})
+}
+
+// WriteColumns buffers the rows in the columns, the columns shall have the same length.
+func (w *BufferedWriter) WriteColumns(ctx context.Context, columns ...column.Column) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	coll, err := w.client.getCollection(ctx, w.opt.collectionName)
+	if err != nil {
+		return err
+	}
+	return w.write(ctx, 0, columns[0].Len(), func(begin, end int) ([]*schemapb.FieldData, error) {
+		sliced := make([]column.Column, 0, len(columns))
+		for _, col := range columns {
+			sliced = append(sliced, col.Slice(begin, end))
+		}
+		return w.convert(coll, NewColumnBasedInsertOption(w.opt.collectionName, sliced...))
+	})
+}
+
+// convert validates the rows against the collection schema, and converts them to the fields data.
+func (w *BufferedWriter) convert(coll *entity.Collection, option interface {
+	InsertOption
+	UpsertOption
+},
+) ([]*schemapb.FieldData, error) {
+	if w.opt.upsert {
+		req, err := option.UpsertRequest(coll)
+		if err != nil {
+			return nil, err
+		}
+		return req.GetFieldsData(), nil
+	}
+	req, err := option.InsertRequest(coll)
+	if err != nil {
+		return nil, err
+	}
+	return req.GetFieldsData(), nil
+}
+
+// write converts the rows in [begin, end), the rows are split if they exceed the max rows or max bytes.
+func (w *BufferedWriter) write(ctx context.Context, begin, end int, convert func(begin, end int) ([]*schemapb.FieldData, error)) error {
+	rows := end - begin
+	if rows > w.opt.maxRows {
+		for ; begin < end; begin += w.opt.maxRows {
+			if err := w.write(ctx, begin, min(begin+w.opt.maxRows, end), convert); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	fieldsData, err := convert(begin, end)
+	if err != nil {
+		return err
+	}
+	size := fieldsDataSize(fieldsData)
+	if size > w.opt.maxBytes {
+		if rows == 1 {
+			return errors.Newf("the size of a single row %d exceeds the max bytes %d", size, w.opt.maxBytes)
+		}
+		mid := begin + rows/2
+		if err := w.write(ctx, begin, mid, convert); err != nil {
+			return err
+		}
+		return w.write(ctx, mid, end, convert)
+	}
+	return w.append(ctx, fieldsData, rows, size)
+}
+
+func (w *BufferedWriter) append(ctx context.Context, fieldsData []*schemapb.FieldData, rows, size int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return errors.New("buffered writer is closed")
+	}
+
+	if w.batch != nil && (w.batch.rows+rows > w.opt.maxRows || w.batch.bytes+size > w.opt.maxBytes) {
+		if err := w.dispatch(ctx); err != nil {
+			return err
+		}
+	}
+	if w.batch == nil {
+		w.batch = &writeBatch{
+			fieldsData: make([]*schemapb.FieldData, 0, len(fieldsData)),
+			createTime: time.Now(),
+		}
+	}
+	w.batch.merge(fieldsData)
+	w.batch.rows += rows
+	w.batch.bytes += size
+	if w.batch.rows >= w.opt.maxRows {
+		return w.dispatch(ctx)
+	}
+	return nil
+}
+
+// dispatch sends the buffered batch once a request slot is available, w.mu shall be held.
+func (w *BufferedWriter) dispatch(ctx context.Context) error {
+	if w.batch == nil {
+		return nil
+	}
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+	batch := w.batch
+	w.batch = nil
+	w.inflight.Add(1)
+	go func() {
+		defer func() {
+			<-w.slots
+			w.inflight.Done()
+		}()
+		w.send(batch)
+	}()
+	return nil
+}
+
+// send sends the batch, and retries on the retriable errors with exponential backoff.
+func (w *BufferedWriter) send(batch *writeBatch) {
+	result := BufferedWriteResult{
+		Rows:  batch.rows,
+		Bytes: batch.bytes,
+	}
+	option := &bufferedBatchOption{
+		collectionName: w.opt.collectionName,
+		partitionName:  w.opt.partitionName,
+		fieldsData:     batch.fieldsData,
+		rows:           batch.rows,
+	}
+	backoff := w.opt.retryBackoff
+	for {
+		if w.opt.upsert {
+			var resp UpsertResult
+			resp, result.Err = w.client.Upsert(w.ctx, option)
+			result.IDs = resp.IDs
+		} else {
+			var resp InsertResult
+			resp, result.Err = w.client.Insert(w.ctx, option)
+			result.IDs = resp.IDs
+		}
+		if result.Err == nil || !isRetriableWriteErr(result.Err) || uint(result.Retries) >= w.opt.maxRetry {
+			break
+		}
+		result.Retries++
+		select {
+		case <-time.After(backoff):
+		case <-w.ctx.Done():
+			result.Err = w.ctx.Err()
+		}
+		if w.ctx.Err() != nil {
+			break
+		}
+		backoff = min(backoff*2, maxBufferedRetryBackoff)
+	}
+
+	if result.Err != nil {
+		result.IDs = nil
+		w.errMu.Lock()
+		if w.err == nil {
+			w.err = result.Err
+		}
+		w.errMu.Unlock()
+	}
+	if w.opt.callback != nil {
+		w.opt.callback(result)
+	}
+}
+
+func isRetriableWriteErr(err error) bool {
+	return merr.IsRetryableErr(err) || errors.Is(err, merr.ErrServiceRateLimit)
+}
+
+// flushLoop flushes the batch older than the flush interval.
+func (w *BufferedWriter) flushLoop() {
+	defer w.loopWg.Done()
+	ticker := time.NewTicker(max(w.opt.flushInterval/2, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.closeCh:
+			return
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			if w.batch != nil && time.Since(w.batch.createTime) >= w.opt.flushInterval {
+				// the error is only returned if the writer context is done
+				_ = w.dispatch(w.ctx)
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// Flush sends the buffered rows and waits for all in-flight requests,
+// it returns the first error of the batches since the last Flush.
+func (w *BufferedWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	err := w.dispatch(ctx)
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	w.errMu.Lock()
+	defer w.errMu.Unlock()
+	err = w.err
+	w.err = nil
+	return err
+}
+
+// Close flushes the buffered rows and stops the writer, no more rows can be written after Close.
+func (w *BufferedWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.closeCh)
+	w.mu.Unlock()
+	w.loopWg.Wait()
+	return w.Flush(ctx)
+}
+
+// merge appends the fields data to the batch, the fields are matched by name.
+func (b *writeBatch) merge(fieldsData []*schemapb.FieldData) {
+	for _, fd := range fieldsData {
+		dst, ok := findFieldData(b.fieldsData, fd)
+		if !ok {
+			// clone the first one, since the field data may share the memory with the user data
+			b.fieldsData = append(b.fieldsData, proto.Clone(fd).(*schemapb.FieldData))
+			continue
+		}
+		mergeFieldData(dst, fd)
+	}
+}
+
+func findFieldData(fieldsData []*schemapb.FieldData, target *schemapb.FieldData) (*schemapb.FieldData, bool) {
+	for _, fd := range fieldsData {
+		if fd.GetFieldName() == target.GetFieldName() && fd.GetIsDynamic() == target.GetIsDynamic() {
+			return fd, true
+		}
+	}
+	return nil, false
+}
+
+// mergeFieldData appends the rows of src to dst. The repeated fields are appended by proto.Merge,
+// while the vectors encoded as bytes are replaced by proto.Merge, which are concatenated here.
+func mergeFieldData(dst, src *schemapb.FieldData) {
+	if srcStruct := src.GetStructArrays(); srcStruct != nil && dst.GetStructArrays() != nil {
+		for _, sub := range srcStruct.GetFields() {
+			if dstSub, ok := findFieldData(dst.GetStructArrays().GetFields(), sub); ok {
+				mergeFieldData(dstSub, sub)
+			}
+		}
+		dst.ValidData = append(dst.ValidData, src.GetValidData()...)
+		return
+	}
+
+	dstVectors := dst.GetVectors()
+	switch data := src.GetVectors().GetData().(type) {
+	case *schemapb.VectorField_BinaryVector:
+		dstVectors.Data = &schemapb.VectorField_BinaryVector{BinaryVector: append(dstVectors.GetBinaryVector(), data.BinaryVector...)}
+	case *schemapb.VectorField_Float16Vector:
+		dstVectors.Data = &schemapb.VectorField_Float16Vector{Float16Vector: append(dstVectors.GetFloat16Vector(), data.Float16Vector...)}
+	case *schemapb.VectorField_Bfloat16Vector:
+		dstVectors.Data = &schemapb.VectorField_Bfloat16Vector{Bfloat16Vector: append(dstVectors.GetBfloat16Vector(), data.Bfloat16Vector...)}
+	case *schemapb.VectorField_Int8Vector:
+		dstVectors.Data = &schemapb.VectorField_Int8Vector{Int8Vector: append(dstVectors.GetInt8Vector(), data.Int8Vector...)}
+	case *schemapb.VectorField_SparseFloatVector:
+		dim := max(dstVectors.GetSparseFloatVector().GetDim(), data.SparseFloatVector.GetDim())
+		proto.Merge(dst, src)
+		dst.GetVectors().GetSparseFloatVector().Dim = dim
+		return
+	default:
+		proto.Merge(dst, src)
+		return
+	}
+	dst.ValidData = append(dst.ValidData, src.GetValidData()...)
+}
+
+func fieldsDataSize(fieldsData []*schemapb.FieldData) int {
+	size := 0
+	for _, fd := range fieldsData {
+		size += proto.Size(fd)
+	}
+	return size
+}
+
+// bufferedBatchOption is the insert and upsert option of a batch merged by BufferedWriter.
+type bufferedBatchOption struct {
+	collectionName string
+	partitionName  string
+	fieldsData     []*schemapb.FieldData
+	rows           int
+}
+
+func (opt *bufferedBatchOption) CollectionName() string {
+	return opt.collectionName
+}
+
+func (opt *bufferedBatchOption) InsertRequest(_ *entity.Collection) (*milvuspb.InsertRequest, error) {
+	return &milvuspb.InsertRequest{
+		CollectionName: opt.collectionName,
+		PartitionName:  opt.partitionName,
+		FieldsData:     opt.fieldsData,
+		NumRows:        uint32(opt.rows),
+	}, nil
+}
+
+func (opt *bufferedBatchOption) UpsertRequest(_ *entity.Collection) (*milvuspb.UpsertRequest, error) {
+	return &milvuspb.UpsertRequest{
+		CollectionName: opt.collectionName,
+		PartitionName:  opt.partitionName,
+		FieldsData:     opt.fieldsData,
+		NumRows:        uint32(opt.rows),
+	}, nil
+}
+
+func (opt *bufferedBatchOption) WriteBackPKs(_ *entity.Schema, _ column.Column) error {
+	return nil
+}
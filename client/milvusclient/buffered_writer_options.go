@@ -0,0 +1,154 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultBufferedMaxRows       = 1000
+	defaultBufferedMaxBytes      = 16 << 20
+	defaultBufferedFlushInterval = time.Second
+	defaultBufferedConcurrency   = 4
+	defaultBufferedMaxRetry      = 5
+	defaultBufferedRetryBackoff  = 100 * time.Millisecond
+	maxBufferedRetryBackoff      = 3 * time.Second
+
+	// serverMaxMessageSize is the default max receive message size of the proxy (proxy.grpc.serverMaxRecvSize).
+	serverMaxMessageSize = 64 << 20
+)
+
+// BufferedWriterOption is the option for BufferedWriter.
+type BufferedWriterOption interface {
+	CollectionName() string
+	ValidateParams() error
+	writerOption() *bufferedWriterOption
+}
+
+type bufferedWriterOption struct {
+	collectionName string
+	partitionName  string
+	upsert         bool
+
+	maxRows       int
+	maxBytes      int
+	flushInterval time.Duration
+	concurrency   int
+	maxRetry      uint
+	retryBackoff  time.Duration
+
+	callback func(result BufferedWriteResult)
+}
+
+func (opt *bufferedWriterOption) CollectionName() string {
+	return opt.collectionName
+}
+
+func (opt *bufferedWriterOption) ValidateParams() error {
+	if opt.collectionName == "" {
+		return errors.New("collection name is empty")
+	}
+	if opt.maxRows <= 0 {
+		return errors.Newf("max rows must be positive, got %d", opt.maxRows)
+	}
+	if opt.maxBytes <= 0 || opt.maxBytes > serverMaxMessageSize {
+		return errors.Newf("max bytes must be in (0, %d], got %d", serverMaxMessageSize, opt.maxBytes)
+	}
+	if opt.flushInterval <= 0 {
+		return errors.Newf("flush interval must be positive, got %s", opt.flushInterval)
+	}
+	if opt.concurrency <= 0 {
+		return errors.Newf("concurrency must be positive, got %d", opt.concurrency)
+	}
+	return nil
+}
+
+func (opt *bufferedWriterOption) writerOption() *bufferedWriterOption {
+	return opt
+}
+
+// WithPartition sets the partition which the rows are written to.
+func (opt *bufferedWriterOption) WithPartition(partitionName string) *bufferedWriterOption {
+	opt.partitionName = partitionName
+	return opt
+}
+
+// WithUpsert makes the writer upsert the rows instead of inserting them.
+func (opt *bufferedWriterOption) WithUpsert(upsert bool) *bufferedWriterOption {
+	opt.upsert = upsert
+	return opt
+}
+
+// WithMaxRows sets the number of buffered rows which triggers a flush.
+func (opt *bufferedWriterOption) WithMaxRows(maxRows int) *bufferedWriterOption {
+	opt.maxRows = maxRows
+	return opt
+}
+
+// WithMaxBytes sets the buffered size in bytes which triggers a flush,
+// which is also the max size of a request, it shall not exceed the max message size of the server.
+func (opt *bufferedWriterOption) WithMaxBytes(maxBytes int) *bufferedWriterOption {
+	opt.maxBytes = maxBytes
+	return opt
+}
+
+// WithFlushInterval sets the max age of the buffered rows before they are flushed.
+func (opt *bufferedWriterOption) WithFlushInterval(interval time.Duration) *bufferedWriterOption {
+	opt.flushInterval = interval
+	return opt
+}
+
+// WithConcurrency sets the max number of concurrent write requests,
+// writes are blocked when all requests are in flight.
+func (opt *bufferedWriterOption) WithConcurrency(concurrency int) *bufferedWriterOption {
+	opt.concurrency = concurrency
+	return opt
+}
+
+// WithMaxRetry sets the max retry times of a batch on retriable errors, such as rate limit.
+func (opt *bufferedWriterOption) WithMaxRetry(maxRetry uint) *bufferedWriterOption {
+	opt.maxRetry = maxRetry
+	return opt
+}
+
+// WithRetryBackoff sets the initial backoff of retrying, which is doubled for each retry.
+func (opt *bufferedWriterOption) WithRetryBackoff(backoff time.Duration) *bufferedWriterOption {
+	opt.retryBackoff = backoff
+	return opt
+}
+
+// WithCallback sets the callback invoked with the result of each flushed batch,
+// it's called from the goroutine sending the batch, and shall not block.
+func (opt *bufferedWriterOption) WithCallback(callback func(result BufferedWriteResult)) *bufferedWriterOption {
+	opt.callback = callback
+	return opt
+}
+
+func NewBufferedWriterOption(collectionName string) *bufferedWriterOption {
+	return &bufferedWriterOption{
+		collectionName: collectionName,
+		maxRows:        defaultBufferedMaxRows,
+		maxBytes:       defaultBufferedMaxBytes,
+		flushInterval:  defaultBufferedFlushInterval,
+		concurrency:    defaultBufferedConcurrency,
+		maxRetry:       defaultBufferedMaxRetry,
+		retryBackoff:   defaultBufferedRetryBackoff,
+	}
+}
@@ -0,0 +1,248 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+type BufferedWriterSuite struct {
+	MockSuiteBase
+
+	schema *entity.Schema
+}
+
+type bufferedRow struct {
+	ID     int64     `milvus:"name:id"`
+	Vector []float32 `milvus:"name:vector"`
+	Binary []byte    `milvus:"name:binary"`
+}
+
+func (s *BufferedWriterSuite) SetupSuite() {
+	s.MockSuiteBase.SetupSuite()
+	s.schema = entity.NewSchema().
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(4)).
+		WithField(entity.NewField().WithName("binary").WithDataType(entity.FieldTypeBinaryVector).WithDim(16))
+}
+
+func (s *BufferedWriterSuite) rows(begin, end int) []any {
+	return lo.RepeatBy(end-begin, func(i int) any {
+		return &bufferedRow{
+			ID:     int64(begin + i),
+			Vector: lo.RepeatBy(4, func(int) float32 { return rand.Float32() }),
+			Binary: []byte{byte(i), byte(i)},
+		}
+	})
+}
+
+func bufferedFieldData(fieldsData []*schemapb.FieldData, name string) *schemapb.FieldData {
+	fd, _ := lo.Find(fieldsData, func(fd *schemapb.FieldData) bool { return fd.GetFieldName() == name })
+	return fd
+}
+
+// mockInsert records the inserted ids, and checks the merged fields data is consistent.
+func (s *BufferedWriterSuite) mockInsert(mu *sync.Mutex, inserted *[]int64) {
+	s.mock.EXPECT().Insert(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.InsertRequest) (*milvuspb.MutationResult, error) {
+		ids := bufferedFieldData(req.GetFieldsData(), "id").GetScalars().GetLongData().GetData()
+		s.Equal(len(ids)*4, len(bufferedFieldData(req.GetFieldsData(), "vector").GetVectors().GetFloatVector().GetData()))
+		s.Equal(len(ids)*2, len(bufferedFieldData(req.GetFieldsData(), "binary").GetVectors().GetBinaryVector()))
+		s.EqualValues(len(ids), req.GetNumRows())
+		mu.Lock()
+		*inserted = append(*inserted, ids...)
+		mu.Unlock()
+		return &milvuspb.MutationResult{
+			Status:    merr.Success(),
+			InsertCnt: int64(len(ids)),
+			IDs:       &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		}, nil
+	})
+}
+
+func (s *BufferedWriterSuite) TestFlushByRows() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	mu := &sync.Mutex{}
+	var inserted []int64
+	s.mockInsert(mu, &inserted)
+
+	var results []BufferedWriteResult
+	w, err := s.client.NewBufferedWriter(ctx, NewBufferedWriterOption(collName).
+		WithMaxRows(10).
+		WithFlushInterval(time.Hour).
+		WithConcurrency(2).
+		WithCallback(func(result BufferedWriteResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, result)
+		}))
+	s.Require().NoError(err)
+
+	for i := 0; i < 25; i += 5 {
+		s.NoError(w.Write(ctx, s.rows(i, i+5)...))
+	}
+	// the large write is split by max rows
+	s.NoError(w.Write(ctx, s.rows(25, 50)...))
+	s.NoError(w.Close(ctx))
+
+	s.ElementsMatch(lo.RangeFrom(int64(0), 50), inserted)
+	// 10, 10, 5 (flushed by the next split write), 10, 10, 5 (flushed by Close)
+	s.Equal(6, len(results))
+	for _, result := range results {
+		s.NoError(result.Err)
+		s.Equal(result.Rows, result.IDs.Len())
+		s.LessOrEqual(result.Rows, 10)
+	}
+	s.Error(w.Write(ctx, s.rows(0, 1)...))
+}
+
+func (s *BufferedWriterSuite) TestFlushByBytesAndAge() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	mu := &sync.Mutex{}
+	var inserted []int64
+	s.mockInsert(mu, &inserted)
+
+	size := fieldsDataSize(lo.Must(NewRowBasedInsertOption(collName, s.rows(0, 10)...).
+		InsertRequest(&entity.Collection{Schema: s.schema})).GetFieldsData())
+	w, err := s.client.NewBufferedWriter(ctx, NewBufferedWriterOption(collName).
+		WithMaxBytes(size).
+		WithFlushInterval(50*time.Millisecond))
+	s.Require().NoError(err)
+
+	// the write exceeding the max bytes is split
+	s.NoError(w.Write(ctx, s.rows(0, 30)...))
+	s.NoError(w.Write(ctx, s.rows(30, 31)...))
+	// the buffered row is flushed after the flush interval
+	s.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(inserted) == 31
+	}, 5*time.Second, 10*time.Millisecond)
+	s.NoError(w.Close(ctx))
+
+	_, err = s.client.NewBufferedWriter(ctx, NewBufferedWriterOption(collName).WithMaxBytes(serverMaxMessageSize+1))
+	s.Error(err)
+}
+
+func (s *BufferedWriterSuite) TestWriteColumns() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	var upserted []int64
+	s.mock.EXPECT().Upsert(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.UpsertRequest) (*milvuspb.MutationResult, error) {
+		ids := bufferedFieldData(req.GetFieldsData(), "id").GetScalars().GetLongData().GetData()
+		s.Equal(len(ids)*4, len(bufferedFieldData(req.GetFieldsData(), "vector").GetVectors().GetFloatVector().GetData()))
+		s.Equal(len(ids)*2, len(bufferedFieldData(req.GetFieldsData(), "binary").GetVectors().GetBinaryVector()))
+		upserted = append(upserted, ids...)
+		return &milvuspb.MutationResult{
+			Status:    merr.Success(),
+			UpsertCnt: int64(len(ids)),
+			IDs:       &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		}, nil
+	}).Once()
+
+	w, err := s.client.NewBufferedWriter(ctx, NewBufferedWriterOption(collName).WithUpsert(true).WithFlushInterval(time.Hour))
+	s.Require().NoError(err)
+	for i := 0; i < 3; i++ {
+		ids := []int64{int64(i * 2), int64(i*2 + 1)}
+		vectors := lo.RepeatBy(2, func(int) []float32 { return lo.RepeatBy(4, func(int) float32 { return rand.Float32() }) })
+		s.NoError(w.WriteColumns(ctx,
+			column.NewColumnInt64("id", ids),
+			column.NewColumnFloatVector("vector", 4, vectors),
+			column.NewColumnBinaryVector("binary", 16, [][]byte{{1, 2}, {3, 4}})))
+	}
+	// the invalid columns are rejected without buffering
+	s.Error(w.WriteColumns(ctx,
+		column.NewColumnInt64("id", []int64{100, 101}),
+		column.NewColumnFloatVector("vector", 4, [][]float32{{1, 2, 3, 4}}),
+		column.NewColumnBinaryVector("binary", 16, [][]byte{{1, 2}})))
+
+	s.NoError(w.Flush(ctx))
+	s.Equal([]int64{0, 1, 2, 3, 4, 5}, upserted)
+	s.NoError(w.Close(ctx))
+}
+
+func (s *BufferedWriterSuite) TestRetry() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	rateLimited := &milvuspb.MutationResult{Status: &commonpb.Status{
+		ErrorCode: commonpb.ErrorCode_RateLimit,
+		Reason:    "rate limit exceeded",
+	}}
+	s.mock.EXPECT().Insert(mock.Anything, mock.Anything).Return(rateLimited, nil).Twice()
+	mu := &sync.Mutex{}
+	var inserted []int64
+	s.mockInsert(mu, &inserted)
+
+	var results []BufferedWriteResult
+	w, err := s.client.NewBufferedWriter(ctx, NewBufferedWriterOption(collName).
+		WithRetryBackoff(time.Millisecond).
+		WithCallback(func(result BufferedWriteResult) {
+			results = append(results, result)
+		}))
+	s.Require().NoError(err)
+	s.NoError(w.Write(ctx, s.rows(0, 5)...))
+	s.NoError(w.Flush(ctx))
+	s.Equal(5, len(inserted))
+	s.Require().Equal(1, len(results))
+	s.Equal(2, results[0].Retries)
+
+	// the non-retriable error is reported, and returned by Flush
+	s.resetMock()
+	s.mock.EXPECT().Insert(mock.Anything, mock.Anything).Return(&milvuspb.MutationResult{
+		Status: merr.Status(merr.WrapErrParameterInvalid("valid", "invalid")),
+	}, nil).Once()
+	s.NoError(w.Write(ctx, s.rows(5, 10)...))
+	s.Error(w.Flush(ctx))
+	s.Require().Equal(2, len(results))
+	s.Error(results[1].Err)
+	s.Equal(0, results[1].Retries)
+	s.Nil(results[1].IDs)
+	s.NoError(w.Close(ctx))
+}
+
+func TestBufferedWriter(t *testing.T) {
+	suite.Run(t, new(BufferedWriterSuite))
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -48,10 +49,17 @@ type Client struct {
 	telemetryService milvuspb.ClientTelemetryServiceClient
 	config           *ClientConfig
 
+	// endpoints are the connections to the proxies, conn and service point to the first connected one.
+	endpoints     []*endpoint
+	nextEndpoint  atomic.Uint64
+	connectMut    sync.Mutex
+	closeCh       chan struct{}
+	closeOnce     sync.Once
+	healthCheckWg sync.WaitGroup
+
 	// mutable status
-	stateMut   sync.RWMutex
-	currentDB  string
-	identifier string // Identifier for this connection
+	stateMut  sync.RWMutex
+	currentDB string
 
 	metadataHeaders map[string]string
 
@@ -59,6 +67,9 @@ type Client struct {
 
 	// Telemetry manager for metrics collection and heartbeat
 	telemetry *ClientTelemetryManager
+
+	// hedger sends the hedged requests, nil if hedging is not enabled.
+	hedger *hedger
 }
 
 func New(ctx context.Context, config *ClientConfig) (*Client, error) {
@@ -69,18 +80,25 @@ func New(ctx context.Context, config *ClientConfig) (*Client, error) {
 	c := &Client{
 		config:    config,
 		currentDB: config.DBName,
+		closeCh:   make(chan struct{}),
+	}
+	if config.Hedging != nil {
+		c.hedger = newHedger(config.Hedging)
 	}
 
-	// Parse remote address.
-	addr := c.config.getParsedAddress()
+	// Parse remote addresses.
+	endpoints, err := c.resolveEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// parse authentication parameters
 	c.parseAuthentication()
 	// Parse grpc options
 	options := c.dialOptions()
 
-	// Connect the grpc server.
-	if err := c.connect(ctx, addr, options...); err != nil {
+	// Connect the grpc servers.
+	if err := c.connect(ctx, endpoints, options...); err != nil {
 		return nil, err
 	}
 
@@ -92,6 +110,11 @@ func New(ctx context.Context, config *ClientConfig) (*Client, error) {
 	c.telemetry = NewClientTelemetryManager(c, config.TelemetryConfig)
 	c.telemetry.Start()
 
+	if c.config.isMultiEndpoint() {
+		c.healthCheckWg.Add(1)
+		go c.healthCheckLoop()
+	}
+
 	return c, nil
 }
 
@@ -112,13 +135,23 @@ func (c *Client) dialOptions() []grpc.DialOption {
 	options = append(options, DefaultGrpcOpts...)
 	options = append(options, c.config.DialOptions...)
 
+	// The hedging interceptor is the outermost one, so that each of the calls is retried on its own.
+	if c.hedger != nil {
+		options = append(options, grpc.WithChainUnaryInterceptor(c.HedgingUnaryInterceptor()))
+	}
+
+	// With multiple endpoints, the unavailable calls fail over to other endpoints instead of retrying.
+	retryCodes := []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+	if c.config.isMultiEndpoint() {
+		retryCodes = []codes.Code{codes.ResourceExhausted}
+	}
 	options = append(options,
 		grpc.WithChainUnaryInterceptor(grpc_retry.UnaryClientInterceptor(
 			grpc_retry.WithMax(6),
 			grpc_retry.WithBackoff(func(attempt uint) time.Duration {
 				return 60 * time.Millisecond * time.Duration(math.Pow(3, float64(attempt)))
 			}),
-			grpc_retry.WithCodes(codes.Unavailable, codes.ResourceExhausted)),
+			grpc_retry.WithCodes(retryCodes...)),
 
 		// c.getRetryOnRateLimitInterceptor(),
 		))
@@ -150,16 +183,26 @@ func (c *Client) Close(ctx context.Context) error {
 	if c.telemetry != nil {
 		c.telemetry.Stop()
 	}
+	if c.closeCh != nil {
+		c.closeOnce.Do(func() { close(c.closeCh) })
+	}
+	c.healthCheckWg.Wait()
 
 	if c.conn == nil {
 		return nil
 	}
-	err := c.conn.Close()
-	if err != nil {
-		return err
+	var closeErr error
+	for _, ep := range c.endpoints {
+		if err := ep.close(); err != nil {
+			closeErr = errors.CombineErrors(closeErr, err)
+		}
+	}
+	if closeErr != nil {
+		return closeErr
 	}
 	c.conn = nil
 	c.service = nil
+	c.telemetryService = nil
 	return nil
 }
 
@@ -175,36 +218,93 @@ func (c *Client) getCurrentDB() string {
 	return c.currentDB
 }
 
-func (c *Client) setIdentifier(identifier string) {
-	c.stateMut.Lock()
-	defer c.stateMut.Unlock()
-	c.identifier = identifier
-}
-
-func (c *Client) connect(ctx context.Context, addr string, options ...grpc.DialOption) error {
-	if addr == "" {
+// connect dials the endpoints concurrently and calls Connect on them.
+// With multiple endpoints, it fails only if none of the endpoints is connected,
+// the failed ones are marked unhealthy and redialed by the health check.
+func (c *Client) connect(ctx context.Context, endpoints []*endpoint, options ...grpc.DialOption) error {
+	if len(endpoints) == 0 {
 		return errors.New("address is empty")
 	}
-	conn, err := grpc.DialContext(ctx, addr, options...)
-	if err != nil {
-		return err
+	c.endpoints = endpoints
+
+	errs := make([]error, len(endpoints))
+	wg := sync.WaitGroup{}
+	for i, ep := range endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			errs[i] = c.dialEndpoint(ctx, ep, options...)
+		}(i, ep)
 	}
+	wg.Wait()
 
-	c.conn = conn
-	c.service = milvuspb.NewMilvusServiceClient(c.conn)
-	c.telemetryService = milvuspb.NewClientTelemetryServiceClient(c.conn)
-
-	if !c.config.DisableConn {
-		err = c.connectInternal(ctx)
-		if err != nil {
-			return err
+	var connected *endpoint
+	for i, ep := range endpoints {
+		if errs[i] == nil && !c.config.DisableConn {
+			errs[i] = c.connectEndpoint(ctx, ep)
+		}
+		if errs[i] != nil {
+			ep.setHealth(false, errs[i])
+			continue
+		}
+		ep.setHealth(true, nil)
+		if connected == nil {
+			connected = ep
 		}
 	}
+	if connected == nil {
+		for _, ep := range endpoints {
+			ep.close()
+		}
+		if len(endpoints) == 1 {
+			return errs[0]
+		}
+		var err error
+		for _, epErr := range errs {
+			err = errors.CombineErrors(err, epErr)
+		}
+		return errors.Wrap(err, "failed to connect to any endpoint")
+	}
 
+	c.conn = connected.getConn()
+	c.service = connected.getService()
+	c.telemetryService = connected.getTelemetryService()
 	return nil
 }
 
+// connectInternal calls Connect on all connected endpoints, with multiple endpoints,
+// it fails only if none of the endpoints succeeds, and the failed ones are marked unhealthy.
 func (c *Client) connectInternal(ctx context.Context) error {
+	var firstErr error
+	connected := false
+	for _, ep := range c.endpoints {
+		if ep.getService() == nil {
+			continue
+		}
+		if err := c.connectEndpoint(ctx, ep); err != nil {
+			if len(c.endpoints) > 1 {
+				ep.setHealth(false, err)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		connected = true
+	}
+	if connected {
+		return nil
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return merr.WrapErrServiceNotReady("SDK", 0, "not connected")
+}
+
+func (c *Client) connectEndpoint(ctx context.Context, ep *endpoint) error {
+	c.connectMut.Lock()
+	defer c.connectMut.Unlock()
+
 	hostName, err := os.Hostname()
 	if err != nil {
 		return err
@@ -220,7 +320,7 @@ func (c *Client) connectInternal(ctx context.Context) error {
 		},
 	}
 
-	resp, err := c.service.Connect(ctx, req)
+	resp, err := ep.getService().Connect(ctx, req)
 	if err != nil {
 		status, ok := status.FromError(err)
 		if ok {
@@ -242,7 +342,7 @@ func (c *Client) connectInternal(ctx context.Context) error {
 	}
 
 	c.config.setServerInfo(resp.GetServerInfo().GetBuildTags())
-	c.setIdentifier(strconv.FormatInt(resp.GetIdentifier(), 10))
+	ep.setIdentifier(strconv.FormatInt(resp.GetIdentifier(), 10))
 	if c.collCache != nil {
 		c.collCache.Reset()
 	}
@@ -251,6 +351,19 @@ func (c *Client) connectInternal(ctx context.Context) error {
 }
 
 func (c *Client) callService(fn func(milvusService milvuspb.MilvusServiceClient) error) error {
+	return c.callServiceWithFailover(fn, true)
+}
+
+// callWriteService calls the service for the writes which are not idempotent, e.g. insert,
+// they are not failed over to another endpoint once the request is sent.
+func (c *Client) callWriteService(fn func(milvusService milvuspb.MilvusServiceClient) error) error {
+	return c.callServiceWithFailover(fn, false)
+}
+
+func (c *Client) callServiceWithFailover(fn func(milvusService milvuspb.MilvusServiceClient) error, idempotent bool) error {
+	if len(c.endpoints) > 1 {
+		return c.callEndpoints(fn, idempotent)
+	}
 	service := c.service
 	if service == nil {
 		return merr.WrapErrServiceNotReady("SDK", 0, "not connected")
@@ -259,10 +372,31 @@ func (c *Client) callService(fn func(milvusService milvuspb.MilvusServiceClient)
 	return fn(c.service)
 }
 
+// GetService returns the service of a healthy endpoint.
 func (c *Client) GetService() milvuspb.MilvusServiceClient {
+	if len(c.endpoints) > 1 {
+		if ep := c.pickEndpoint(nil); ep != nil {
+			return ep.getService()
+		}
+		return nil
+	}
 	return c.service
 }
 
+// getTelemetryService returns the telemetry service of a healthy endpoint, nil if not connected.
+func (c *Client) getTelemetryService() milvuspb.ClientTelemetryServiceClient {
+	if len(c.endpoints) > 1 {
+		if ep := c.pickEndpoint(nil); ep != nil {
+			return ep.getTelemetryService()
+		}
+		return nil
+	}
+	if c.service == nil {
+		return nil
+	}
+	return c.telemetryService
+}
+
 // GetTelemetry returns the telemetry manager for this client
 func (c *Client) GetTelemetry() *ClientTelemetryManager {
 	return c.telemetry
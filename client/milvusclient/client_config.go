@@ -54,6 +54,19 @@ type ClientConfig struct {
 	Password string // Password for auth.
 	DBName   string // DBName for this client.
 
+	// Endpoints are the remote addresses of multiple proxies, ["proxy-0:19530", "proxy-1:19530"].
+	// The calls are spread across the healthy endpoints and fail over to another endpoint on Unavailable.
+	// The first endpoint is used as Address if Address is empty.
+	Endpoints []string
+	// ResolveEndpoints resolves the host of each endpoint by DNS when connecting,
+	// every resolved address is connected as an endpoint, with the host name as the authority.
+	ResolveEndpoints bool
+	// HealthCheckInterval is the interval of checking the endpoints with the grpc health service,
+	// only used with multiple endpoints, default 5 seconds.
+	HealthCheckInterval time.Duration
+	// Hedging enables the hedged requests of search and query with multiple endpoints, nil to disable.
+	Hedging *HedgingConfig
+
 	EnableTLSAuth bool   // Enable TLS Auth for transport security.
 	APIKey        string // API key
 
@@ -68,9 +81,10 @@ type ClientConfig struct {
 	// TelemetryConfig for client telemetry settings
 	TelemetryConfig *TelemetryConfig
 
-	ServerVersion string // ServerVersion
-	parsedAddress *url.URL
-	flags         uint64 // internal flags
+	ServerVersion   string // ServerVersion
+	parsedAddress   *url.URL
+	parsedEndpoints []string
+	flags           uint64 // internal flags
 }
 
 type RetryRateLimitOption struct {
@@ -79,19 +93,12 @@ type RetryRateLimitOption struct {
 }
 
 func (cfg *ClientConfig) parse() error {
-	// Prepend default fake tcp:// scheme for remote address.
-	address := cfg.Address
-	if !regexValidScheme.MatchString(address) {
-		address = fmt.Sprintf("tcp://%s", address)
+	if cfg.Address == "" && len(cfg.Endpoints) > 0 {
+		cfg.Address = cfg.Endpoints[0]
 	}
-
-	remoteURL, err := url.Parse(address)
+	remoteURL, err := parseRemoteURL(cfg.Address)
 	if err != nil {
-		return errors.Wrap(err, "milvus address parse fail")
-	}
-	// Remote Host should never be empty.
-	if remoteURL.Host == "" {
-		return errors.New("empty remote host of milvus address")
+		return err
 	}
 	// Use DBName in remote url path.
 	if cfg.DBName == "" {
@@ -105,14 +112,63 @@ func (cfg *ClientConfig) parse() error {
 		remoteURL.Host += ":443"
 	}
 	cfg.parsedAddress = remoteURL
+
+	cfg.parsedEndpoints = make([]string, 0, len(cfg.Endpoints))
+	for _, endpoint := range cfg.Endpoints {
+		endpointURL, err := parseRemoteURL(endpoint)
+		if err != nil {
+			return err
+		}
+		if endpointURL.Port() == "" && cfg.EnableTLSAuth {
+			endpointURL.Host += ":443"
+		}
+		cfg.parsedEndpoints = append(cfg.parsedEndpoints, endpointURL.Host)
+	}
 	return nil
 }
 
+func parseRemoteURL(address string) (*url.URL, error) {
+	// Prepend default fake tcp:// scheme for remote address.
+	if !regexValidScheme.MatchString(address) {
+		address = fmt.Sprintf("tcp://%s", address)
+	}
+
+	remoteURL, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "milvus address parse fail")
+	}
+	// Remote Host should never be empty.
+	if remoteURL.Host == "" {
+		return nil, errors.New("empty remote host of milvus address")
+	}
+	return remoteURL, nil
+}
+
 // Get parsed remote milvus address, should be called after parse was called.
 func (c *ClientConfig) getParsedAddress() string {
 	return c.parsedAddress.Host
 }
 
+// getParsedEndpoints returns the parsed endpoints, which is the parsed address if no endpoint is configured.
+func (c *ClientConfig) getParsedEndpoints() []string {
+	if len(c.parsedEndpoints) == 0 {
+		return []string{c.getParsedAddress()}
+	}
+	return c.parsedEndpoints
+}
+
+// isMultiEndpoint returns whether the client may connect to multiple proxies.
+func (c *ClientConfig) isMultiEndpoint() bool {
+	return len(c.Endpoints) > 1 || c.ResolveEndpoints
+}
+
+func (c *ClientConfig) getHealthCheckInterval() time.Duration {
+	if c.HealthCheckInterval <= 0 {
+		return defaultHealthCheckInterval
+	}
+	return c.HealthCheckInterval
+}
+
 // useDatabase change the inner db name.
 func (c *ClientConfig) useDatabase(dbName string) {
 	c.DBName = dbName
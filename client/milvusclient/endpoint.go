@@ -0,0 +1,388 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+const (
+	defaultHealthCheckInterval = 5 * time.Second
+	// endpointDialTimeout bounds the dialing of each endpoint with multiple endpoints,
+	// so that an unreachable proxy doesn't block the others.
+	endpointDialTimeout = 5 * time.Second
+	healthCheckTimeout  = 3 * time.Second
+)
+
+// EndpointHealth is the health status of an endpoint observed by the client.
+type EndpointHealth struct {
+	Address string `json:"address"`
+	Healthy bool   `json:"healthy"`
+	// LastError is the error which marked the endpoint unhealthy.
+	LastError string `json:"last_error,omitempty"`
+	// LastCheckTime is the unix milliseconds of the last health check.
+	LastCheckTime int64 `json:"last_check_time,omitempty"`
+	// Failovers is the number of calls failed over from the endpoint to another one.
+	Failovers int64 `json:"failovers"`
+}
+
+// endpoint is the connection to one proxy,
+// the identifier returned by Connect is kept per connection.
+type endpoint struct {
+	address string
+	// authority is the host name which the address is resolved from, empty if not resolved.
+	authority string
+
+	mu               sync.RWMutex
+	conn             *grpc.ClientConn
+	service          milvuspb.MilvusServiceClient
+	telemetryService milvuspb.ClientTelemetryServiceClient
+	health           healthpb.HealthClient
+	identifier       string
+	lastErr          error
+	lastCheck        time.Time
+
+	healthy   atomic.Bool
+	failovers atomic.Int64
+}
+
+func newEndpoint(address, authority string) *endpoint {
+	return &endpoint{
+		address:   address,
+		authority: authority,
+	}
+}
+
+func (ep *endpoint) setConn(conn *grpc.ClientConn) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	ep.conn = conn
+	if conn == nil {
+		ep.service = nil
+		ep.telemetryService = nil
+		ep.health = nil
+		return
+	}
+	ep.service = milvuspb.NewMilvusServiceClient(conn)
+	ep.telemetryService = milvuspb.NewClientTelemetryServiceClient(conn)
+	ep.health = healthpb.NewHealthClient(conn)
+}
+
+func (ep *endpoint) getConn() *grpc.ClientConn {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.conn
+}
+
+func (ep *endpoint) getService() milvuspb.MilvusServiceClient {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.service
+}
+
+func (ep *endpoint) getTelemetryService() milvuspb.ClientTelemetryServiceClient {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.telemetryService
+}
+
+func (ep *endpoint) getHealthClient() healthpb.HealthClient {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.health
+}
+
+func (ep *endpoint) setIdentifier(identifier string) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	ep.identifier = identifier
+}
+
+func (ep *endpoint) getIdentifier() string {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.identifier
+}
+
+func (ep *endpoint) isHealthy() bool {
+	return ep.healthy.Load()
+}
+
+// isConnectable returns false if the connection is broken or closed,
+// the requests on it fail without being sent until it's reconnected.
+func (ep *endpoint) isConnectable() bool {
+	conn := ep.getConn()
+	if conn == nil {
+		return false
+	}
+	state := conn.GetState()
+	return state != connectivity.TransientFailure && state != connectivity.Shutdown
+}
+
+// setHealth records the result of checking or calling the endpoint.
+func (ep *endpoint) setHealth(healthy bool, err error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	ep.healthy.Store(healthy)
+	ep.lastErr = err
+	ep.lastCheck = time.Now()
+}
+
+func (ep *endpoint) getHealth() *EndpointHealth {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	h := &EndpointHealth{
+		Address:   ep.address,
+		Healthy:   ep.healthy.Load(),
+		Failovers: ep.failovers.Load(),
+	}
+	if ep.lastErr != nil {
+		h.LastError = ep.lastErr.Error()
+	}
+	if !ep.lastCheck.IsZero() {
+		h.LastCheckTime = ep.lastCheck.UnixMilli()
+	}
+	return h
+}
+
+// identifierInterceptor attaches the identifier of the connection to the requests.
+func (ep *endpoint) identifierInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if identifier := ep.getIdentifier(); identifier != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, identifierHeader, identifier)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (ep *endpoint) close() error {
+	conn := ep.getConn()
+	if conn == nil {
+		return nil
+	}
+	ep.setConn(nil)
+	return conn.Close()
+}
+
+// resolveEndpoints returns the endpoints to connect,
+// the host of each endpoint is resolved to multiple addresses if ResolveEndpoints is enabled.
+func (c *Client) resolveEndpoints(ctx context.Context) ([]*endpoint, error) {
+	var endpoints []*endpoint
+	for _, addr := range c.config.getParsedEndpoints() {
+		if !c.config.ResolveEndpoints {
+			endpoints = append(endpoints, newEndpoint(addr, ""))
+			continue
+		}
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse endpoint %s", addr)
+		}
+		ips, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve endpoint %s", addr)
+		}
+		for _, ip := range ips {
+			endpoints = append(endpoints, newEndpoint(net.JoinHostPort(ip, port), addr))
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("address is empty")
+	}
+	return endpoints, nil
+}
+
+// dialEndpoint dials the endpoint, the identifier of the endpoint is attached to its requests.
+func (c *Client) dialEndpoint(ctx context.Context, ep *endpoint, options ...grpc.DialOption) error {
+	options = append(append([]grpc.DialOption{}, options...), grpc.WithChainUnaryInterceptor(ep.identifierInterceptor()))
+	if ep.authority != "" {
+		options = append(options, grpc.WithAuthority(ep.authority))
+	}
+	if c.config.isMultiEndpoint() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, endpointDialTimeout)
+		defer cancel()
+	}
+	conn, err := grpc.DialContext(ctx, ep.address, options...)
+	if err != nil {
+		return err
+	}
+	ep.setConn(conn)
+	return nil
+}
+
+// pickEndpoint picks the next endpoint in round-robin, which is not tried yet.
+// The healthy endpoints are preferred, the unhealthy ones are picked only if all healthy ones are tried,
+// since they may have recovered before the next health check.
+func (c *Client) pickEndpoint(tried map[*endpoint]struct{}) *endpoint {
+	n := len(c.endpoints)
+	if n == 0 {
+		return nil
+	}
+	start := int(c.nextEndpoint.Add(1) % uint64(n))
+	var fallback *endpoint
+	for i := 0; i < n; i++ {
+		ep := c.endpoints[(start+i)%n]
+		if _, ok := tried[ep]; ok || ep.getService() == nil {
+			continue
+		}
+		if ep.isHealthy() {
+			return ep
+		}
+		if fallback == nil {
+			fallback = ep
+		}
+	}
+	return fallback
+}
+
+// callEndpoints calls fn on the healthy endpoints,
+// and fails over to the next endpoint if the endpoint is unavailable.
+// If fn is not idempotent, e.g. insert, the call fails over only if the request is not sent,
+// since the unavailable request may have been applied by the proxy.
+func (c *Client) callEndpoints(fn func(milvusService milvuspb.MilvusServiceClient) error, idempotent bool) error {
+	tried := make(map[*endpoint]struct{}, len(c.endpoints))
+	var lastErr error
+	for ep := c.pickEndpoint(tried); ep != nil; ep = c.pickEndpoint(tried) {
+		tried[ep] = struct{}{}
+		if !idempotent && !ep.isConnectable() {
+			// the request fails before it's sent on the broken connection
+			err := merr.WrapErrServiceNotReady("SDK", 0, fmt.Sprintf("endpoint %s is not connected", ep.address))
+			ep.setHealth(false, err)
+			ep.failovers.Add(1)
+			lastErr = err
+			continue
+		}
+		err := fn(ep.getService())
+		if !isUnavailable(err) {
+			return err
+		}
+		ep.setHealth(false, err)
+		if !idempotent {
+			return err
+		}
+		ep.failovers.Add(1)
+		lastErr = err
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return merr.WrapErrServiceNotReady("SDK", 0, "no available endpoint")
+}
+
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unavailable
+}
+
+// healthCheckLoop checks the endpoints periodically until the client is closed.
+func (c *Client) healthCheckLoop() {
+	defer c.healthCheckWg.Done()
+	ticker := time.NewTicker(c.config.getHealthCheckInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-ticker.C:
+			c.checkEndpoints()
+		}
+	}
+}
+
+func (c *Client) checkEndpoints() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.closeCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	wg := sync.WaitGroup{}
+	for _, ep := range c.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			c.checkEndpoint(ctx, ep)
+		}(ep)
+	}
+	wg.Wait()
+}
+
+// checkEndpoint checks the endpoint with the grpc health service,
+// the endpoint is redialed if it's not connected, and reconnected when it turns healthy,
+// since the proxy may be restarted and the identifier needs to be refreshed.
+func (c *Client) checkEndpoint(ctx context.Context, ep *endpoint) {
+	if ep.getConn() == nil {
+		if err := c.dialEndpoint(ctx, ep, c.dialOptions()...); err != nil {
+			ep.setHealth(false, err)
+			return
+		}
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	resp, err := ep.getHealthClient().Check(checkCtx, &healthpb.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		// the server doesn't register the health service, treat it as serving
+		err = nil
+	} else if err == nil && resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		err = fmt.Errorf("endpoint %s is %s", ep.address, resp.GetStatus().String())
+	}
+	if err != nil {
+		ep.setHealth(false, err)
+		return
+	}
+
+	if !ep.isHealthy() && !c.config.DisableConn {
+		if err := c.connectEndpoint(ctx, ep); err != nil {
+			ep.setHealth(false, err)
+			return
+		}
+	}
+	ep.setHealth(true, nil)
+}
+
+// endpointHealth returns the health status of all endpoints.
+func (c *Client) endpointHealth() []*EndpointHealth {
+	result := make([]*EndpointHealth, 0, len(c.endpoints))
+	for _, ep := range c.endpoints {
+		result = append(result, ep.getHealth())
+	}
+	return result
+}
@@ -0,0 +1,254 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+)
+
+type mockEndpointServer struct {
+	lis    *bufconn.Listener
+	svr    *grpc.Server
+	mock   *MilvusServiceServer
+	health *health.Server
+}
+
+type EndpointSuite struct {
+	suite.Suite
+
+	servers map[string]*mockEndpointServer
+}
+
+func (s *EndpointSuite) SetupTest() {
+	s.servers = make(map[string]*mockEndpointServer)
+	for i, addr := range []string{"ep0:19530", "ep1:19530"} {
+		server := &mockEndpointServer{
+			lis:    bufconn.Listen(bufSize),
+			svr:    grpc.NewServer(),
+			mock:   &MilvusServiceServer{},
+			health: health.NewServer(),
+		}
+		milvuspb.RegisterMilvusServiceServer(server.svr, server.mock)
+		healthpb.RegisterHealthServer(server.svr, server.health)
+		identifier := int64(i + 1)
+		server.mock.EXPECT().Connect(mock.Anything, mock.Anything).Return(&milvuspb.ConnectResponse{
+			Status:     &commonpb.Status{},
+			Identifier: identifier,
+		}, nil).Maybe()
+		go server.svr.Serve(server.lis)
+		s.servers[addr] = server
+	}
+}
+
+func (s *EndpointSuite) TearDownTest() {
+	for _, server := range s.servers {
+		server.svr.Stop()
+		server.lis.Close()
+	}
+}
+
+func (s *EndpointSuite) dialer(ctx context.Context, addr string) (net.Conn, error) {
+	return s.servers[addr].lis.DialContext(ctx)
+}
+
+func (s *EndpointSuite) newClient() *Client {
+	c, err := New(context.Background(), &ClientConfig{
+		Endpoints:           []string{"ep0:19530", "ep1:19530"},
+		HealthCheckInterval: time.Hour,
+		DialOptions: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(s.dialer),
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(2, len(c.endpoints))
+	return c
+}
+
+func (s *EndpointSuite) mockListDatabases(addr string, identifier string) {
+	s.servers[addr].mock.EXPECT().ListDatabases(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, req *milvuspb.ListDatabasesRequest) (*milvuspb.ListDatabasesResponse, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			s.Equal([]string{identifier}, md.Get(identifierHeader))
+			return &milvuspb.ListDatabasesResponse{Status: &commonpb.Status{}, DbNames: []string{addr}}, nil
+		}).Maybe()
+}
+
+func (s *EndpointSuite) TestRoundRobin() {
+	c := s.newClient()
+	defer c.Close(context.Background())
+
+	// the identifier is kept per connection
+	s.mockListDatabases("ep0:19530", "1")
+	s.mockListDatabases("ep1:19530", "2")
+
+	called := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		dbNames, err := c.ListDatabase(context.Background(), NewListDatabaseOption())
+		s.NoError(err)
+		s.Require().Equal(1, len(dbNames))
+		called[dbNames[0]]++
+	}
+	// the heartbeat may pick the endpoints as well
+	s.Greater(called["ep0:19530"], 0)
+	s.Greater(called["ep1:19530"], 0)
+}
+
+func (s *EndpointSuite) TestFailover() {
+	c := s.newClient()
+	defer c.Close(context.Background())
+
+	s.servers["ep0:19530"].mock.EXPECT().ListDatabases(mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Unavailable, "mock unavailable")).Maybe()
+	s.mockListDatabases("ep1:19530", "2")
+
+	for i := 0; i < 4; i++ {
+		dbNames, err := c.ListDatabase(context.Background(), NewListDatabaseOption())
+		s.NoError(err)
+		s.Equal([]string{"ep1:19530"}, dbNames)
+	}
+
+	// the unavailable endpoint is marked unhealthy, and not picked until it recovers
+	healths := c.GetTelemetry().GetEndpointHealth()
+	s.Require().Equal(2, len(healths))
+	s.False(healths[0].Healthy)
+	s.Contains(healths[0].LastError, "mock unavailable")
+	s.Equal(int64(1), healths[0].Failovers)
+	s.True(healths[1].Healthy)
+	s.Contains(c.GetTelemetry().buildClientInfo().GetReserved()["endpoints"], "mock unavailable")
+
+	// the health check recovers the endpoint and reconnects it
+	c.checkEndpoints()
+	s.True(c.endpoints[0].isHealthy())
+	s.servers["ep0:19530"].mock.AssertNumberOfCalls(s.T(), "Connect", 2)
+
+	// all endpoints are unavailable
+	s.servers["ep1:19530"].health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	c.checkEndpoints()
+	s.False(c.endpoints[1].isHealthy())
+	s.servers["ep1:19530"].mock.ExpectedCalls = nil
+	s.servers["ep1:19530"].mock.EXPECT().ListDatabases(mock.Anything, mock.Anything).
+		Return(nil, status.Error(codes.Unavailable, "mock unavailable")).Maybe()
+	_, err := c.ListDatabase(context.Background(), NewListDatabaseOption())
+	s.Equal(codes.Unavailable, status.Code(err))
+}
+
+func (s *EndpointSuite) TestWriteFailover() {
+	c := s.newClient()
+	defer c.Close(context.Background())
+
+	// the sent write is not failed over, since it may have been applied by the unavailable proxy
+	called := 0
+	err := c.callWriteService(func(milvusService milvuspb.MilvusServiceClient) error {
+		called++
+		return status.Error(codes.Unavailable, "mock unavailable")
+	})
+	s.Equal(codes.Unavailable, status.Code(err))
+	s.Equal(1, called)
+	unhealthy := lo.Filter(c.endpoints, func(ep *endpoint, _ int) bool { return !ep.isHealthy() })
+	s.Require().Equal(1, len(unhealthy))
+	s.Equal(int64(0), unhealthy[0].failovers.Load())
+
+	// the write is failed over if the connection is broken before sending
+	c.checkEndpoints()
+	s.True(c.endpoints[0].isHealthy())
+	s.True(c.endpoints[1].isHealthy())
+	s.NoError(c.endpoints[0].getConn().Close())
+	for i := 0; i < 2; i++ {
+		var services []milvuspb.MilvusServiceClient
+		err = c.callWriteService(func(milvusService milvuspb.MilvusServiceClient) error {
+			services = append(services, milvusService)
+			return nil
+		})
+		s.NoError(err)
+		s.Equal([]milvuspb.MilvusServiceClient{c.endpoints[1].getService()}, services)
+	}
+	s.False(c.endpoints[0].isHealthy())
+	s.Equal(int64(1), c.endpoints[0].failovers.Load())
+}
+
+func (s *EndpointSuite) TestUseDatabase() {
+	c := s.newClient()
+	defer c.Close(context.Background())
+
+	// the database is switched on all connections
+	s.NoError(c.UseDatabase(context.Background(), NewUseDatabaseOption("db1")))
+	s.servers["ep0:19530"].mock.AssertNumberOfCalls(s.T(), "Connect", 2)
+	s.servers["ep1:19530"].mock.AssertNumberOfCalls(s.T(), "Connect", 2)
+	s.Equal("db1", c.getCurrentDB())
+}
+
+func (s *EndpointSuite) TestConnectPartially() {
+	// the unreachable endpoint doesn't fail the client
+	s.servers["ep1:19530"].svr.Stop()
+	c := s.newClient()
+	defer c.Close(context.Background())
+	s.True(c.endpoints[0].isHealthy())
+	s.False(c.endpoints[1].isHealthy())
+	s.NotNil(c.GetService())
+
+	s.servers["ep0:19530"].svr.Stop()
+	_, err := New(context.Background(), &ClientConfig{
+		Endpoints: []string{"ep0:19530", "ep1:19530"},
+		DialOptions: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(s.dialer),
+		},
+	})
+	s.Error(err)
+}
+
+func TestEndpoint(t *testing.T) {
+	suite.Run(t, new(EndpointSuite))
+}
+
+func TestClientConfig_ParseEndpoints(t *testing.T) {
+	cfg := &ClientConfig{Endpoints: []string{"https://proxy-0/db1", "proxy-1:19530"}}
+	assert.NoError(t, cfg.parse())
+	assert.Equal(t, "https://proxy-0/db1", cfg.Address)
+	assert.Equal(t, "db1", cfg.DBName)
+	assert.True(t, cfg.EnableTLSAuth)
+	assert.Equal(t, []string{"proxy-0:443", "proxy-1:19530"}, cfg.getParsedEndpoints())
+	assert.True(t, cfg.isMultiEndpoint())
+
+	cfg = &ClientConfig{Address: "localhost:19530"}
+	assert.NoError(t, cfg.parse())
+	assert.Equal(t, []string{"localhost:19530"}, cfg.getParsedEndpoints())
+	assert.False(t, cfg.isMultiEndpoint())
+
+	cfg = &ClientConfig{Endpoints: []string{"localhost:19530", ""}}
+	assert.Error(t, cfg.parse())
+}
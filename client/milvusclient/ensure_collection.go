@@ -0,0 +1,461 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+// PlanActionType is the type of a change planned by EnsureCollection.
+type PlanActionType string
+
+const (
+	PlanCreateCollection PlanActionType = "create_collection"
+	PlanAddField         PlanActionType = "add_field"
+	PlanAddFunction      PlanActionType = "add_function"
+	PlanDropIndex        PlanActionType = "drop_index"
+	PlanCreateIndex      PlanActionType = "create_index"
+	PlanAlterProperties  PlanActionType = "alter_properties"
+	PlanCreateAlias      PlanActionType = "create_alias"
+	PlanSwitchAlias      PlanActionType = "switch_alias"
+)
+
+// PlanAction is a change planned by EnsureCollection.
+type PlanAction struct {
+	Type PlanActionType
+	// Target is the name of the changed field, function, index or alias, empty for the collection itself.
+	Target string
+	// Detail describes the change.
+	Detail string
+
+	apply func(ctx context.Context, c *Client) error
+}
+
+func (a *PlanAction) String() string {
+	prefix := "~"
+	switch a.Type {
+	case PlanCreateCollection, PlanAddField, PlanAddFunction, PlanCreateIndex, PlanCreateAlias:
+		prefix = "+"
+	case PlanDropIndex:
+		prefix = "-"
+	}
+	if a.Target == "" {
+		return fmt.Sprintf("%s %s: %s", prefix, a.Type, a.Detail)
+	}
+	return fmt.Sprintf("%s %s %s: %s", prefix, a.Type, a.Target, a.Detail)
+}
+
+// EnsureCollectionPlan is the changes to reconcile a collection with its desired state,
+// the actions are applied in order.
+type EnsureCollectionPlan struct {
+	CollectionName string
+	Actions        []*PlanAction
+	// Violations are the illegal changes which can't be applied, such as changing a vector dimension.
+	Violations []string
+	// Applied reports whether the actions are applied.
+	Applied bool
+}
+
+// HasChanges reports whether the collection differs from the desired state.
+func (p *EnsureCollectionPlan) HasChanges() bool {
+	return len(p.Actions) > 0 || len(p.Violations) > 0
+}
+
+// String formats the plan for review, like `terraform plan`.
+func (p *EnsureCollectionPlan) String() string {
+	sb := strings.Builder{}
+	if !p.HasChanges() {
+		fmt.Fprintf(&sb, "collection %s is up to date\n", p.CollectionName)
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "collection %s: %d change(s)\n", p.CollectionName, len(p.Actions))
+	for _, action := range p.Actions {
+		fmt.Fprintf(&sb, "  %s\n", action.String())
+	}
+	for _, violation := range p.Violations {
+		fmt.Fprintf(&sb, "  ! %s\n", violation)
+	}
+	return sb.String()
+}
+
+func (p *EnsureCollectionPlan) add(actionType PlanActionType, target, detail string, apply func(ctx context.Context, c *Client) error) {
+	p.Actions = append(p.Actions, &PlanAction{Type: actionType, Target: target, Detail: detail, apply: apply})
+}
+
+func (p *EnsureCollectionPlan) reject(format string, args ...any) {
+	p.Violations = append(p.Violations, fmt.Sprintf(format, args...))
+}
+
+// EnsureCollection compares the desired schema, functions, indexes, properties and aliases
+// with the live collection, and plans the changes to reconcile them.
+// The plan is applied unless dry run is set, it fails without applying anything if there are illegal changes.
+func (c *Client) EnsureCollection(ctx context.Context, option EnsureCollectionOption, callOptions ...grpc.CallOption) (*EnsureCollectionPlan, error) {
+	if err := option.ValidateParams(); err != nil {
+		return nil, err
+	}
+	desired := option.desired()
+	plan, err := c.planCollection(ctx, desired, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Violations) > 0 {
+		return plan, merr.WrapErrParameterInvalid("legal changes", strings.Join(plan.Violations, "; "),
+			fmt.Sprintf("collection %s can't be reconciled", desired.name))
+	}
+	if desired.dryRun {
+		return plan, nil
+	}
+
+	for _, action := range plan.Actions {
+		if err := action.apply(ctx, c); err != nil {
+			return plan, errors.Wrapf(err, "failed to apply %s", action.String())
+		}
+	}
+	plan.Applied = true
+	if len(plan.Actions) > 0 && c.collCache != nil {
+		c.collCache.Evict(desired.name)
+	}
+	return plan, nil
+}
+
+func (c *Client) planCollection(ctx context.Context, desired *ensureCollectionOption, callOptions ...grpc.CallOption) (*EnsureCollectionPlan, error) {
+	plan := &EnsureCollectionPlan{CollectionName: desired.name}
+	has, err := c.HasCollection(ctx, NewHasCollectionOption(desired.name), callOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !has {
+		plan.add(PlanCreateCollection, "", fmt.Sprintf("%d field(s), %d function(s)", len(desired.schema.Fields), len(desired.schema.Functions)),
+			func(ctx context.Context, c *Client) error {
+				opt := NewCreateCollectionOption(desired.name, desired.schema).WithShardNum(desired.shardNum)
+				for key, value := range desired.properties {
+					opt.WithProperty(key, value)
+				}
+				return c.CreateCollection(ctx, opt, callOptions...)
+			})
+		for _, idx := range desired.indexes {
+			planCreateIndex(plan, desired.name, idx, callOptions...)
+		}
+	} else {
+		coll, err := c.DescribeCollection(ctx, NewDescribeCollectionOption(desired.name), callOptions...)
+		if err != nil {
+			return nil, err
+		}
+		planFields(plan, desired, coll.Schema, callOptions...)
+		planFunctions(plan, desired, coll.Schema, callOptions...)
+		if err := c.planIndexes(ctx, plan, desired, callOptions...); err != nil {
+			return nil, err
+		}
+		planProperties(plan, desired, coll.Properties, callOptions...)
+	}
+
+	if err := c.planAliases(ctx, plan, desired, callOptions...); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func planFields(plan *EnsureCollectionPlan, desired *ensureCollectionOption, live *entity.Schema, callOptions ...grpc.CallOption) {
+	if desired.schema.EnableDynamicField != live.EnableDynamicField {
+		plan.reject("dynamic field can't be changed from %t to %t", live.EnableDynamicField, desired.schema.EnableDynamicField)
+	}
+
+	liveFields := make(map[string]*entity.Field)
+	for _, field := range live.Fields {
+		if !field.IsDynamic {
+			liveFields[field.Name] = field
+		}
+	}
+	for _, field := range desired.schema.Fields {
+		liveField, ok := liveFields[field.Name]
+		if !ok {
+			if !field.Nullable && field.DefaultValue == nil {
+				plan.reject("new field %s must be nullable or have a default value", field.Name)
+				continue
+			}
+			if field.PrimaryKey || field.IsPartitionKey {
+				plan.reject("new field %s can't be primary key or partition key", field.Name)
+				continue
+			}
+			plan.add(PlanAddField, field.Name, field.DataType.String(), func(ctx context.Context, c *Client) error {
+				if field.DataType == entity.FieldTypeArray && field.StructSchema != nil {
+					return c.AddCollectionStructField(ctx, NewAddCollectionStructFieldOption(desired.name, field), callOptions...)
+				}
+				return c.AddCollectionField(ctx, NewAddCollectionFieldOption(desired.name, field), callOptions...)
+			})
+			continue
+		}
+		delete(liveFields, field.Name)
+		for _, diff := range diffField(liveField, field) {
+			plan.reject("field %s: %s", field.Name, diff)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(liveFields)) {
+		plan.reject("field %s can't be dropped", name)
+	}
+}
+
+// diffField returns the illegal differences between the live field and the desired one.
+func diffField(live, desired *entity.Field) []string {
+	var diffs []string
+	if live.DataType != desired.DataType {
+		diffs = append(diffs, fmt.Sprintf("data type can't be changed from %s to %s", live.DataType.String(), desired.DataType.String()))
+	}
+	if live.ElementType != desired.ElementType {
+		diffs = append(diffs, fmt.Sprintf("element type can't be changed from %s to %s", live.ElementType.String(), desired.ElementType.String()))
+	}
+	if live.PrimaryKey != desired.PrimaryKey {
+		diffs = append(diffs, "primary key can't be changed")
+	}
+	if live.PrimaryKey && live.AutoID != desired.AutoID {
+		diffs = append(diffs, "auto id can't be changed")
+	}
+	if live.IsPartitionKey != desired.IsPartitionKey {
+		diffs = append(diffs, "partition key can't be changed")
+	}
+	if live.Nullable != desired.Nullable {
+		diffs = append(diffs, "nullable can't be changed")
+	}
+	for _, key := range slices.Sorted(maps.Keys(desired.TypeParams)) {
+		if liveValue := live.TypeParams[key]; liveValue != desired.TypeParams[key] {
+			diffs = append(diffs, fmt.Sprintf("%s can't be changed from %q to %q", key, liveValue, desired.TypeParams[key]))
+		}
+	}
+	return diffs
+}
+
+func planFunctions(plan *EnsureCollectionPlan, desired *ensureCollectionOption, live *entity.Schema, callOptions ...grpc.CallOption) {
+	liveFunctions := make(map[string]*entity.Function)
+	for _, fn := range live.Functions {
+		liveFunctions[fn.Name] = fn
+	}
+	for _, fn := range desired.schema.Functions {
+		liveFn, ok := liveFunctions[fn.Name]
+		if !ok {
+			plan.add(PlanAddFunction, fn.Name, fmt.Sprintf("%s(%s) -> %s", fn.Type.String(),
+				strings.Join(fn.InputFieldNames, ", "), strings.Join(fn.OutputFieldNames, ", ")),
+				func(ctx context.Context, c *Client) error {
+					return c.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+						resp, err := milvusService.AddCollectionFunction(ctx, &milvuspb.AddCollectionFunctionRequest{
+							CollectionName: desired.name,
+							FunctionSchema: fn.ProtoMessage(),
+						}, callOptions...)
+						return merr.CheckRPCCall(resp, err)
+					})
+				})
+			continue
+		}
+		delete(liveFunctions, fn.Name)
+		if liveFn.Type != fn.Type ||
+			!slices.Equal(liveFn.InputFieldNames, fn.InputFieldNames) ||
+			!slices.Equal(liveFn.OutputFieldNames, fn.OutputFieldNames) {
+			plan.reject("function %s can't be changed", fn.Name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(liveFunctions)) {
+		plan.reject("function %s can't be dropped", name)
+	}
+}
+
+func (c *Client) planIndexes(ctx context.Context, plan *EnsureCollectionPlan, desired *ensureCollectionOption, callOptions ...grpc.CallOption) error {
+	var liveIndexes []*milvuspb.IndexDescription
+	err := c.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+		resp, err := milvusService.DescribeIndex(ctx, &milvuspb.DescribeIndexRequest{CollectionName: desired.name}, callOptions...)
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			if errors.Is(err, merr.ErrIndexNotFound) {
+				return nil
+			}
+			return err
+		}
+		liveIndexes = resp.GetIndexDescriptions()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	matched := make(map[string]struct{})
+	for _, idx := range desired.indexes {
+		live, ok := findLiveIndex(liveIndexes, idx)
+		if !ok {
+			planCreateIndex(plan, desired.name, idx, callOptions...)
+			continue
+		}
+		matched[live.GetIndexName()] = struct{}{}
+		if live.GetFieldName() != idx.FieldName {
+			plan.reject("index %s is built on field %s, not %s", live.GetIndexName(), live.GetFieldName(), idx.FieldName)
+			continue
+		}
+		if diff := diffIndexParams(liveIndexParams(live), idx.Index.Params()); diff != "" {
+			planDropIndex(plan, desired.name, live.GetIndexName(), "params changed: "+diff, callOptions...)
+			planCreateIndex(plan, desired.name, idx, callOptions...)
+		}
+	}
+	if desired.pruneIndexes {
+		for _, live := range liveIndexes {
+			if _, ok := matched[live.GetIndexName()]; !ok {
+				planDropIndex(plan, desired.name, live.GetIndexName(), "not declared", callOptions...)
+			}
+		}
+	}
+	return c.checkDropIndexes(ctx, plan, desired.name, callOptions...)
+}
+
+// checkDropIndexes rejects dropping the indexes of a loaded collection, which fails in the server,
+// the collection is not released implicitly since it would stop serving the searches.
+func (c *Client) checkDropIndexes(ctx context.Context, plan *EnsureCollectionPlan, collectionName string, callOptions ...grpc.CallOption) error {
+	var drops []*PlanAction
+	for _, action := range plan.Actions {
+		if action.Type == PlanDropIndex {
+			drops = append(drops, action)
+		}
+	}
+	if len(drops) == 0 {
+		return nil
+	}
+	var state commonpb.LoadState
+	err := c.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+		resp, err := milvusService.GetLoadState(ctx, &milvuspb.GetLoadStateRequest{CollectionName: collectionName}, callOptions...)
+		state = resp.GetState()
+		return merr.CheckRPCCall(resp, err)
+	})
+	if err != nil {
+		return err
+	}
+	if state != commonpb.LoadState_LoadStateLoaded && state != commonpb.LoadState_LoadStateLoading {
+		return nil
+	}
+	for _, drop := range drops {
+		plan.reject("index %s can't be dropped (%s) while collection %s is loaded, release the collection first",
+			drop.Target, drop.Detail, collectionName)
+	}
+	return nil
+}
+
+// findLiveIndex matches the index by name, or by field if the index name is not specified.
+func findLiveIndex(liveIndexes []*milvuspb.IndexDescription, idx DesiredIndex) (*milvuspb.IndexDescription, bool) {
+	for _, live := range liveIndexes {
+		if live.GetIndexName() == idx.name() {
+			return live, true
+		}
+	}
+	if idx.IndexName == "" {
+		for _, live := range liveIndexes {
+			if live.GetFieldName() == idx.FieldName {
+				return live, true
+			}
+		}
+	}
+	return nil, false
+}
+
+const indexParamsKey = "params"
+
+// liveIndexParams flattens the type specific params, which are returned as a json object in the "params" key.
+func liveIndexParams(live *milvuspb.IndexDescription) map[string]string {
+	params := entity.KvPairsMap(live.GetParams())
+	nested := make(map[string]any)
+	if err := json.Unmarshal([]byte(params[indexParamsKey]), &nested); err == nil {
+		for key, value := range nested {
+			params[key] = fmt.Sprint(value)
+		}
+	}
+	return params
+}
+
+// diffIndexParams returns the declared params which differ from the live ones.
+func diffIndexParams(live, desired map[string]string) string {
+	var diffs []string
+	for _, key := range slices.Sorted(maps.Keys(desired)) {
+		if live[key] != desired[key] {
+			diffs = append(diffs, fmt.Sprintf("%s %q -> %q", key, live[key], desired[key]))
+		}
+	}
+	return strings.Join(diffs, ", ")
+}
+
+func planCreateIndex(plan *EnsureCollectionPlan, collectionName string, idx DesiredIndex, callOptions ...grpc.CallOption) {
+	plan.add(PlanCreateIndex, idx.name(), fmt.Sprintf("%s on field %s", idx.Index.IndexType(), idx.FieldName),
+		func(ctx context.Context, c *Client) error {
+			opt := NewCreateIndexOption(collectionName, idx.FieldName, idx.Index)
+			if idx.IndexName != "" {
+				opt.WithIndexName(idx.IndexName)
+			}
+			_, err := c.CreateIndex(ctx, opt, callOptions...)
+			return err
+		})
+}
+
+func planDropIndex(plan *EnsureCollectionPlan, collectionName, indexName, reason string, callOptions ...grpc.CallOption) {
+	plan.add(PlanDropIndex, indexName, reason, func(ctx context.Context, c *Client) error {
+		return c.DropIndex(ctx, NewDropIndexOption(collectionName, indexName), callOptions...)
+	})
+}
+
+func planProperties(plan *EnsureCollectionPlan, desired *ensureCollectionOption, live map[string]string, callOptions ...grpc.CallOption) {
+	changed := make(map[string]string)
+	var diffs []string
+	for _, key := range slices.Sorted(maps.Keys(desired.properties)) {
+		if value, ok := live[key]; !ok || value != desired.properties[key] {
+			changed[key] = desired.properties[key]
+			diffs = append(diffs, fmt.Sprintf("%s %q -> %q", key, live[key], desired.properties[key]))
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	plan.add(PlanAlterProperties, "", strings.Join(diffs, ", "), func(ctx context.Context, c *Client) error {
+		opt := NewAlterCollectionPropertiesOption(desired.name)
+		for key, value := range changed {
+			opt.WithProperty(key, value)
+		}
+		return c.AlterCollectionProperties(ctx, opt, callOptions...)
+	})
+}
+
+func (c *Client) planAliases(ctx context.Context, plan *EnsureCollectionPlan, desired *ensureCollectionOption, callOptions ...grpc.CallOption) error {
+	for _, alias := range desired.aliases {
+		live, err := c.DescribeAlias(ctx, NewDescribeAliasOption(alias), callOptions...)
+		switch {
+		case errors.Is(err, merr.ErrAliasNotFound):
+			plan.add(PlanCreateAlias, alias, "-> "+desired.name, func(ctx context.Context, c *Client) error {
+				return c.CreateAlias(ctx, NewCreateAliasOption(desired.name, alias), callOptions...)
+			})
+		case err != nil:
+			return err
+		case live.CollectionName != desired.name:
+			plan.add(PlanSwitchAlias, alias, fmt.Sprintf("%s -> %s", live.CollectionName, desired.name), func(ctx context.Context, c *Client) error {
+				return c.AlterAlias(ctx, NewAlterAliasOption(alias, desired.name), callOptions...)
+			})
+		}
+	}
+	return nil
+}
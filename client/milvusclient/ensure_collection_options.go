@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/index"
+)
+
+// EnsureCollectionOption describes the desired state of a collection for EnsureCollection.
+type EnsureCollectionOption interface {
+	CollectionName() string
+	ValidateParams() error
+	desired() *ensureCollectionOption
+}
+
+// DesiredIndex is an index declared on a field, the index name defaults to the field name.
+type DesiredIndex struct {
+	FieldName string
+	IndexName string
+	Index     index.Index
+}
+
+func (idx DesiredIndex) name() string {
+	if idx.IndexName != "" {
+		return idx.IndexName
+	}
+	return idx.FieldName
+}
+
+type ensureCollectionOption struct {
+	name       string
+	schema     *entity.Schema
+	shardNum   int32
+	indexes    []DesiredIndex
+	properties map[string]string
+	aliases    []string
+
+	pruneIndexes bool
+	dryRun       bool
+}
+
+func (opt *ensureCollectionOption) CollectionName() string {
+	return opt.name
+}
+
+func (opt *ensureCollectionOption) ValidateParams() error {
+	if opt.name == "" {
+		return errors.New("collection name is empty")
+	}
+	if opt.schema == nil {
+		return errors.New("collection schema is nil")
+	}
+	names := make(map[string]struct{})
+	for _, idx := range opt.indexes {
+		if idx.Index == nil {
+			return errors.Newf("index of field %s is nil", idx.FieldName)
+		}
+		if _, ok := names[idx.name()]; ok {
+			return errors.Newf("duplicate index %s", idx.name())
+		}
+		names[idx.name()] = struct{}{}
+	}
+	return nil
+}
+
+func (opt *ensureCollectionOption) desired() *ensureCollectionOption {
+	return opt
+}
+
+// WithShardNum sets the shard number used when the collection is created.
+func (opt *ensureCollectionOption) WithShardNum(shardNum int32) *ensureCollectionOption {
+	opt.shardNum = shardNum
+	return opt
+}
+
+// WithIndex declares an index on the field, the index is recreated if its params change,
+// which is rejected if the collection is loaded.
+func (opt *ensureCollectionOption) WithIndex(fieldName string, idx index.Index) *ensureCollectionOption {
+	return opt.WithNamedIndex(fieldName, "", idx)
+}
+
+// WithNamedIndex declares a named index on the field.
+func (opt *ensureCollectionOption) WithNamedIndex(fieldName, indexName string, idx index.Index) *ensureCollectionOption {
+	opt.indexes = append(opt.indexes, DesiredIndex{FieldName: fieldName, IndexName: indexName, Index: idx})
+	return opt
+}
+
+// WithProperty declares a collection property, the properties not declared are left unchanged.
+func (opt *ensureCollectionOption) WithProperty(key, value string) *ensureCollectionOption {
+	opt.properties[key] = value
+	return opt
+}
+
+// WithAlias declares an alias of the collection, the alias is switched if it points to another collection.
+func (opt *ensureCollectionOption) WithAlias(aliases ...string) *ensureCollectionOption {
+	opt.aliases = append(opt.aliases, aliases...)
+	return opt
+}
+
+// WithPruneIndexes drops the live indexes which are not declared, which is rejected if the collection is loaded.
+func (opt *ensureCollectionOption) WithPruneIndexes(prune bool) *ensureCollectionOption {
+	opt.pruneIndexes = prune
+	return opt
+}
+
+// WithDryRun only returns the plan without applying it.
+func (opt *ensureCollectionOption) WithDryRun(dryRun bool) *ensureCollectionOption {
+	opt.dryRun = dryRun
+	return opt
+}
+
+func NewEnsureCollectionOption(name string, schema *entity.Schema) *ensureCollectionOption {
+	return &ensureCollectionOption{
+		name:       name,
+		schema:     schema,
+		shardNum:   1,
+		properties: make(map[string]string),
+	}
+}
@@ -0,0 +1,248 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/common"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/index"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+type EnsureCollectionSuite struct {
+	MockSuiteBase
+}
+
+func (s *EnsureCollectionSuite) schema(collName string, dim int64) *entity.Schema {
+	return entity.NewSchema().WithName(collName).
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(dim))
+}
+
+func (s *EnsureCollectionSuite) mockLive(collName string, schema *entity.Schema, properties map[string]string, indexes []*milvuspb.IndexDescription) {
+	s.mock.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+		Status:         merr.Success(),
+		CollectionName: collName,
+		Schema:         schema.ProtoMessage(),
+		Properties:     entity.MapKvPairs(properties),
+	}, nil)
+	s.mock.EXPECT().DescribeIndex(mock.Anything, mock.Anything).Return(&milvuspb.DescribeIndexResponse{
+		Status:            merr.Success(),
+		IndexDescriptions: indexes,
+	}, nil)
+}
+
+func (s *EnsureCollectionSuite) TestCreate() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.resetMock()
+
+	s.mock.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+		Status: merr.Status(merr.WrapErrCollectionNotFound(collName)),
+	}, nil)
+	s.mock.EXPECT().DescribeAlias(mock.Anything, mock.Anything).Return(&milvuspb.DescribeAliasResponse{
+		Status: merr.Status(merr.ErrAliasNotFound),
+	}, nil)
+	var calls []string
+	s.mock.EXPECT().CreateCollection(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.CreateCollectionRequest) (*commonpb.Status, error) {
+		s.Equal(collName, req.GetCollectionName())
+		s.EqualValues(2, req.GetShardsNum())
+		s.Equal("3600", entity.KvPairsMap(req.GetProperties())[common.CollectionTTLConfigKey])
+		calls = append(calls, "create_collection")
+		return merr.Success(), nil
+	}).Once()
+	s.mock.EXPECT().CreateIndex(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.CreateIndexRequest) (*commonpb.Status, error) {
+		s.Equal("vector", req.GetFieldName())
+		calls = append(calls, "create_index")
+		return merr.Success(), nil
+	}).Once()
+	s.mock.EXPECT().CreateAlias(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.CreateAliasRequest) (*commonpb.Status, error) {
+		s.Equal(collName, req.GetCollectionName())
+		s.Equal("latest", req.GetAlias())
+		calls = append(calls, "create_alias")
+		return merr.Success(), nil
+	}).Once()
+
+	plan, err := s.client.EnsureCollection(ctx, NewEnsureCollectionOption(collName, s.schema(collName, 128)).
+		WithShardNum(2).
+		WithProperty(common.CollectionTTLConfigKey, "3600").
+		WithIndex("vector", index.NewHNSWIndex(entity.L2, 16, 64)).
+		WithAlias("latest"))
+	s.Require().NoError(err)
+	s.True(plan.Applied)
+	s.Equal([]PlanActionType{PlanCreateCollection, PlanCreateIndex, PlanCreateAlias}, lo.Map(plan.Actions, func(a *PlanAction, _ int) PlanActionType { return a.Type }))
+	s.Equal([]string{"create_collection", "create_index", "create_alias"}, calls)
+}
+
+func (s *EnsureCollectionSuite) TestReconcile() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.resetMock()
+
+	live := s.schema(collName, 128)
+	s.mockLive(collName, live, map[string]string{common.MmapEnabledKey: "false"}, []*milvuspb.IndexDescription{
+		{
+			IndexName: "vector",
+			FieldName: "vector",
+			Params: entity.MapKvPairs(map[string]string{
+				index.IndexTypeKey:  string(index.HNSW),
+				index.MetricTypeKey: string(entity.L2),
+				indexParamsKey:      `{"M":8,"efConstruction":64}`,
+			}),
+		},
+		{IndexName: "stale", FieldName: "id", Params: entity.MapKvPairs(map[string]string{index.IndexTypeKey: "STL_SORT"})},
+	})
+	s.mock.EXPECT().DescribeAlias(mock.Anything, mock.Anything).Return(&milvuspb.DescribeAliasResponse{
+		Status:     merr.Success(),
+		Alias:      "latest",
+		Collection: "coll_previous",
+	}, nil)
+
+	desired := s.schema(collName, 128).
+		WithField(entity.NewField().WithName("tag").WithDataType(entity.FieldTypeVarChar).WithMaxLength(64).WithNullable(true))
+	option := NewEnsureCollectionOption(collName, desired).
+		WithIndex("vector", index.NewHNSWIndex(entity.L2, 16, 64)).
+		WithProperty(common.MmapEnabledKey, "true").
+		WithAlias("latest").
+		WithPruneIndexes(true).
+		WithDryRun(true)
+	s.mock.EXPECT().GetLoadState(mock.Anything, mock.Anything).Return(&milvuspb.GetLoadStateResponse{
+		Status: merr.Success(),
+		State:  commonpb.LoadState_LoadStateNotLoad,
+	}, nil)
+
+	// dry run plans the changes without applying them
+	plan, err := s.client.EnsureCollection(ctx, option)
+	s.Require().NoError(err)
+	s.False(plan.Applied)
+	s.True(plan.HasChanges())
+	s.Equal([]PlanActionType{PlanAddField, PlanDropIndex, PlanCreateIndex, PlanDropIndex, PlanAlterProperties, PlanSwitchAlias},
+		lo.Map(plan.Actions, func(a *PlanAction, _ int) PlanActionType { return a.Type }))
+	s.Contains(plan.String(), "~ switch_alias latest: coll_previous -> "+collName)
+	s.Contains(plan.String(), `M "8" -> "16"`)
+	s.mock.AssertNotCalled(s.T(), "AddCollectionField", mock.Anything, mock.Anything)
+
+	s.mock.EXPECT().AddCollectionField(mock.Anything, mock.Anything).Return(merr.Success(), nil).Once()
+	s.mock.EXPECT().DropIndex(mock.Anything, mock.Anything).Return(merr.Success(), nil).Twice()
+	s.mock.EXPECT().CreateIndex(mock.Anything, mock.Anything).Return(merr.Success(), nil).Once()
+	s.mock.EXPECT().AlterCollection(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+		s.Equal("true", entity.KvPairsMap(req.GetProperties())[common.MmapEnabledKey])
+		return merr.Success(), nil
+	}).Once()
+	s.mock.EXPECT().AlterAlias(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.AlterAliasRequest) (*commonpb.Status, error) {
+		s.Equal(collName, req.GetCollectionName())
+		return merr.Success(), nil
+	}).Once()
+
+	plan, err = s.client.EnsureCollection(ctx, option.WithDryRun(false))
+	s.Require().NoError(err)
+	s.True(plan.Applied)
+}
+
+func (s *EnsureCollectionSuite) TestDropIndexOfLoadedCollection() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.resetMock()
+
+	s.mockLive(collName, s.schema(collName, 128), nil, []*milvuspb.IndexDescription{
+		{
+			IndexName: "vector",
+			FieldName: "vector",
+			Params: entity.MapKvPairs(map[string]string{
+				index.IndexTypeKey:  string(index.HNSW),
+				index.MetricTypeKey: string(entity.L2),
+				indexParamsKey:      `{"M":8,"efConstruction":64}`,
+			}),
+		},
+	})
+	s.mock.EXPECT().GetLoadState(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.GetLoadStateRequest) (*milvuspb.GetLoadStateResponse, error) {
+		s.Equal(collName, req.GetCollectionName())
+		return &milvuspb.GetLoadStateResponse{Status: merr.Success(), State: commonpb.LoadState_LoadStateLoaded}, nil
+	})
+
+	// the index params change is rejected in planning, instead of failing after applying the other changes
+	plan, err := s.client.EnsureCollection(ctx, NewEnsureCollectionOption(collName, s.schema(collName, 128)).
+		WithIndex("vector", index.NewHNSWIndex(entity.L2, 16, 64)).
+		WithProperty(common.MmapEnabledKey, "true"))
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+	s.Require().NotNil(plan)
+	s.False(plan.Applied)
+	s.Len(plan.Violations, 1)
+	s.Contains(err.Error(), "index vector can't be dropped")
+	s.Contains(err.Error(), "release the collection first")
+	s.mock.AssertNotCalled(s.T(), "DropIndex", mock.Anything, mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "AlterCollection", mock.Anything, mock.Anything)
+
+	// the load state is not checked if no index is dropped
+	s.resetMock()
+	s.mockLive(collName, s.schema(collName, 128), nil, nil)
+	s.mock.EXPECT().CreateIndex(mock.Anything, mock.Anything).Return(merr.Success(), nil).Once()
+	plan, err = s.client.EnsureCollection(ctx, NewEnsureCollectionOption(collName, s.schema(collName, 128)).
+		WithIndex("vector", index.NewHNSWIndex(entity.L2, 16, 64)))
+	s.Require().NoError(err)
+	s.True(plan.Applied)
+	s.mock.AssertNotCalled(s.T(), "GetLoadState", mock.Anything, mock.Anything)
+}
+
+func (s *EnsureCollectionSuite) TestUpToDate() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.resetMock()
+
+	s.mockLive(collName, s.schema(collName, 128), nil, nil)
+	plan, err := s.client.EnsureCollection(ctx, NewEnsureCollectionOption(collName, s.schema(collName, 128)))
+	s.Require().NoError(err)
+	s.False(plan.HasChanges())
+	s.Contains(plan.String(), "up to date")
+}
+
+func (s *EnsureCollectionSuite) TestIllegalChanges() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.resetMock()
+
+	s.mockLive(collName, s.schema(collName, 128), nil, nil)
+	desired := s.schema(collName, 256).
+		WithField(entity.NewField().WithName("required").WithDataType(entity.FieldTypeInt64))
+	plan, err := s.client.EnsureCollection(ctx, NewEnsureCollectionOption(collName, desired).
+		WithIndex("vector", index.NewHNSWIndex(entity.L2, 16, 64)))
+	s.Error(err)
+	s.ErrorIs(err, merr.ErrParameterInvalid)
+	s.Require().NotNil(plan)
+	s.False(plan.Applied)
+	s.Len(plan.Violations, 2)
+	s.Contains(err.Error(), `dim can't be changed from "128" to "256"`)
+	s.Contains(err.Error(), "new field required must be nullable or have a default value")
+	s.mock.AssertNotCalled(s.T(), "CreateIndex", mock.Anything, mock.Anything)
+
+	_, err = s.client.EnsureCollection(ctx, NewEnsureCollectionOption(collName, nil))
+	s.Error(err)
+}
+
+func TestEnsureCollection(t *testing.T) {
+	suite.Run(t, new(EnsureCollectionSuite))
+}
@@ -0,0 +1,329 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+const (
+	defaultHedgingPercentile  = 0.95
+	defaultHedgingMinSamples  = 20
+	defaultHedgingBudgetRatio = 0.1
+	defaultHedgingBudgetBurst = 10
+
+	// hedgingLatencySamples is the number of recent latencies kept per method.
+	hedgingLatencySamples = 1000
+	// hedgingRefreshEvery is the number of samples between recalculating the hedging delay.
+	hedgingRefreshEvery = 16
+)
+
+// hedgeableMethods are the read methods which are safe to be sent twice.
+// Get is sent as Query by the client.
+var hedgeableMethods = []string{
+	milvuspb.MilvusService_Search_FullMethodName,
+	milvuspb.MilvusService_HybridSearch_FullMethodName,
+	milvuspb.MilvusService_Query_FullMethodName,
+}
+
+// HedgingConfig enables hedged requests of search, hybrid search, query and get with multiple endpoints.
+// If a request has not returned within the percentile of the observed latency of the method,
+// a duplicate is sent to another healthy endpoint, the first good response wins and the other is canceled.
+type HedgingConfig struct {
+	// Percentile of the observed latency after which the duplicate is sent, default 0.95.
+	Percentile float64
+	// MinSamples is the number of observed latencies of the method required before hedging, default 20.
+	MinSamples int
+	// MinDelay is the lower bound of the delay before the duplicate is sent.
+	MinDelay time.Duration
+	// BudgetRatio is the max ratio of the duplicates to the requests, default 0.1,
+	// every request earns BudgetRatio token and every duplicate costs one token.
+	BudgetRatio float64
+	// BudgetBurst is the max number of the tokens saved for the bursts of slow requests, default 10.
+	BudgetBurst int
+}
+
+func (cfg *HedgingConfig) getPercentile() float64 {
+	if cfg.Percentile <= 0 || cfg.Percentile >= 1 {
+		return defaultHedgingPercentile
+	}
+	return cfg.Percentile
+}
+
+func (cfg *HedgingConfig) getMinSamples() int {
+	if cfg.MinSamples <= 0 {
+		return defaultHedgingMinSamples
+	}
+	return cfg.MinSamples
+}
+
+func (cfg *HedgingConfig) getBudgetRatio() float64 {
+	if cfg.BudgetRatio <= 0 {
+		return defaultHedgingBudgetRatio
+	}
+	return cfg.BudgetRatio
+}
+
+func (cfg *HedgingConfig) getBudgetBurst() int {
+	if cfg.BudgetBurst <= 0 {
+		return defaultHedgingBudgetBurst
+	}
+	return cfg.BudgetBurst
+}
+
+// HedgingStats are the counters of the hedged requests.
+type HedgingStats struct {
+	// Requests is the number of the hedgeable requests.
+	Requests int64 `json:"requests"`
+	// Hedged is the number of the duplicates sent.
+	Hedged int64 `json:"hedged"`
+	// HedgeWins is the number of the requests answered by the duplicate.
+	HedgeWins int64 `json:"hedge_wins"`
+	// Throttled is the number of the duplicates not sent for running out of the budget.
+	Throttled int64 `json:"throttled"`
+	// Canceled is the number of the slower calls canceled after the other one won.
+	Canceled int64 `json:"canceled"`
+}
+
+// latencyTracker keeps the recent latencies of a method,
+// and the delay of hedging which is recalculated periodically.
+type latencyTracker struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	total   int64
+
+	delay atomic.Int64
+}
+
+func newLatencyTracker() *latencyTracker {
+	t := &latencyTracker{samples: make([]time.Duration, hedgingLatencySamples)}
+	t.delay.Store(-1)
+	return t
+}
+
+func (t *latencyTracker) record(latency time.Duration, percentile float64, minSamples int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.samples[t.next] = latency
+	t.next = (t.next + 1) % len(t.samples)
+	t.total++
+	if t.total < int64(minSamples) || (t.total%hedgingRefreshEvery != 0 && t.total != int64(minSamples)) {
+		return
+	}
+
+	n := len(t.samples)
+	if t.total < int64(n) {
+		n = int(t.total)
+	}
+	sorted := make([]time.Duration, n)
+	copy(sorted, t.samples[:n])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(math.Ceil(percentile*float64(n))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	t.delay.Store(int64(sorted[idx]))
+}
+
+// getDelay returns the delay of hedging, false if there are not enough samples.
+func (t *latencyTracker) getDelay() (time.Duration, bool) {
+	delay := t.delay.Load()
+	return time.Duration(delay), delay >= 0
+}
+
+// hedgingBudget is the token bucket limiting the ratio of the duplicates.
+type hedgingBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	ratio  float64
+	burst  float64
+}
+
+func (b *hedgingBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+b.ratio)
+}
+
+func (b *hedgingBudget) acquire() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// hedger sends the duplicates of the slow requests.
+type hedger struct {
+	config   *HedgingConfig
+	trackers map[string]*latencyTracker
+	budget   *hedgingBudget
+
+	requests  atomic.Int64
+	hedged    atomic.Int64
+	hedgeWins atomic.Int64
+	throttled atomic.Int64
+	canceled  atomic.Int64
+}
+
+func newHedger(config *HedgingConfig) *hedger {
+	h := &hedger{
+		config:   config,
+		trackers: make(map[string]*latencyTracker, len(hedgeableMethods)),
+		// the budget starts full, as the latency is unknown at the beginning anyway
+		budget: &hedgingBudget{
+			tokens: float64(config.getBudgetBurst()),
+			ratio:  config.getBudgetRatio(),
+			burst:  float64(config.getBudgetBurst()),
+		},
+	}
+	for _, method := range hedgeableMethods {
+		h.trackers[method] = newLatencyTracker()
+	}
+	return h
+}
+
+func (h *hedger) stats() *HedgingStats {
+	return &HedgingStats{
+		Requests:  h.requests.Load(),
+		Hedged:    h.hedged.Load(),
+		HedgeWins: h.hedgeWins.Load(),
+		Throttled: h.throttled.Load(),
+		Canceled:  h.canceled.Load(),
+	}
+}
+
+type hedgedResult struct {
+	reply proto.Message
+	err   error
+	hedge bool
+}
+
+func (r *hedgedResult) ok() bool {
+	return r.err == nil && merr.Ok(getResultStatus(r.reply))
+}
+
+// invoke calls the method on the connection, and sends the duplicate to the endpoint picked by pick
+// if the call doesn't return within the delay. The result of the first good response is merged into the reply,
+// or the result of the original call if neither succeeds.
+func (h *hedger) invoke(ctx context.Context, tracker *latencyTracker, pick func() *endpoint,
+	method string, req any, reply proto.Message, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	h.requests.Add(1)
+	h.budget.deposit()
+	start := time.Now()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan *hedgedResult, 2)
+	primary := reply.ProtoReflect().New().Interface()
+	go func() {
+		err := invoker(ctx, method, req, primary, cc, opts...)
+		results <- &hedgedResult{reply: primary, err: err}
+	}()
+
+	var timerC <-chan time.Time
+	if delay, ok := tracker.getDelay(); ok {
+		if delay < h.config.MinDelay {
+			delay = h.config.MinDelay
+		}
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		timerC = timer.C
+	}
+
+	pending := 1
+	var failed *hedgedResult
+	for pending > 0 {
+		select {
+		case <-timerC:
+			timerC = nil
+			ep := pick()
+			if ep == nil {
+				continue
+			}
+			if !h.budget.acquire() {
+				h.throttled.Add(1)
+				continue
+			}
+			h.hedged.Add(1)
+			pending++
+			hedgeReply := reply.ProtoReflect().New().Interface()
+			go func() {
+				// the duplicate goes through the interceptors of the other connection, without hedging again
+				err := ep.getConn().Invoke(context.WithValue(ctx, DisableHedging, true), method, req, hedgeReply, opts...)
+				results <- &hedgedResult{reply: hedgeReply, err: err, hedge: true}
+			}()
+		case result := <-results:
+			pending--
+			if result.ok() {
+				if pending > 0 {
+					h.canceled.Add(1)
+				}
+				if result.hedge {
+					h.hedgeWins.Add(1)
+				}
+				tracker.record(time.Since(start), h.config.getPercentile(), h.config.getMinSamples())
+				proto.Merge(reply, result.reply)
+				return nil
+			}
+			if failed == nil || !result.hedge {
+				failed = result
+			}
+		}
+	}
+	proto.Merge(reply, failed.reply)
+	return failed.err
+}
+
+// pickHedgeEndpoint picks a healthy endpoint other than the connection of the original call.
+func (c *Client) pickHedgeEndpoint(cc *grpc.ClientConn) *endpoint {
+	tried := make(map[*endpoint]struct{}, 1)
+	for _, ep := range c.endpoints {
+		if ep.getConn() == cc {
+			tried[ep] = struct{}{}
+		}
+	}
+	ep := c.pickEndpoint(tried)
+	if ep == nil || !ep.isHealthy() {
+		return nil
+	}
+	return ep
+}
+
+// hedgingStats returns the counters of the hedged requests, nil if hedging is not enabled.
+func (c *Client) hedgingStats() *HedgingStats {
+	if c.hedger == nil {
+		return nil
+	}
+	return c.hedger.stats()
+}
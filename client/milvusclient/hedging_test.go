@@ -0,0 +1,236 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	tracker := newLatencyTracker()
+	_, ok := tracker.getDelay()
+	assert.False(t, ok)
+
+	for i := 1; i <= 19; i++ {
+		tracker.record(time.Duration(i)*time.Millisecond, 0.9, 20)
+	}
+	_, ok = tracker.getDelay()
+	assert.False(t, ok)
+
+	// the delay is calculated once there are enough samples
+	tracker.record(20*time.Millisecond, 0.9, 20)
+	delay, ok := tracker.getDelay()
+	assert.True(t, ok)
+	assert.Equal(t, 18*time.Millisecond, delay)
+
+	// and recalculated periodically, with the recent samples only
+	for i := 0; i < hedgingLatencySamples; i++ {
+		tracker.record(time.Millisecond, 0.9, 20)
+	}
+	delay, _ = tracker.getDelay()
+	assert.Equal(t, time.Millisecond, delay)
+}
+
+func TestHedgingBudget(t *testing.T) {
+	h := newHedger(&HedgingConfig{BudgetRatio: 0.5, BudgetBurst: 2})
+	assert.True(t, h.budget.acquire())
+	assert.True(t, h.budget.acquire())
+	assert.False(t, h.budget.acquire())
+
+	h.budget.deposit()
+	assert.False(t, h.budget.acquire())
+	h.budget.deposit()
+	assert.True(t, h.budget.acquire())
+
+	// the tokens are capped by the burst
+	for i := 0; i < 10; i++ {
+		h.budget.deposit()
+	}
+	assert.True(t, h.budget.acquire())
+	assert.True(t, h.budget.acquire())
+	assert.False(t, h.budget.acquire())
+}
+
+type HedgingSuite struct {
+	suite.Suite
+
+	servers  map[string]*mockEndpointServer
+	calls    *atomic.Int32
+	canceled chan struct{}
+}
+
+func (s *HedgingSuite) SetupTest() {
+	s.servers = make(map[string]*mockEndpointServer)
+	for i, addr := range []string{"ep0:19530", "ep1:19530"} {
+		server := &mockEndpointServer{
+			lis:  bufconn.Listen(bufSize),
+			svr:  grpc.NewServer(),
+			mock: &MilvusServiceServer{},
+		}
+		milvuspb.RegisterMilvusServiceServer(server.svr, server.mock)
+		server.mock.EXPECT().Connect(mock.Anything, mock.Anything).Return(&milvuspb.ConnectResponse{
+			Status:     &commonpb.Status{},
+			Identifier: int64(i + 1),
+		}, nil).Maybe()
+		go server.svr.Serve(server.lis)
+		s.servers[addr] = server
+	}
+	s.calls = &atomic.Int32{}
+	s.canceled = make(chan struct{}, 2)
+}
+
+func (s *HedgingSuite) TearDownTest() {
+	for _, server := range s.servers {
+		server.svr.Stop()
+		server.lis.Close()
+	}
+}
+
+func (s *HedgingSuite) dialer(ctx context.Context, addr string) (net.Conn, error) {
+	return s.servers[addr].lis.DialContext(ctx)
+}
+
+func (s *HedgingSuite) newHedgingClient(config *HedgingConfig) *Client {
+	c, err := New(context.Background(), &ClientConfig{
+		Endpoints:           []string{"ep0:19530", "ep1:19530"},
+		HealthCheckInterval: time.Hour,
+		Hedging:             config,
+		DialOptions: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(s.dialer),
+		},
+	})
+	s.Require().NoError(err)
+	// the delay of hedging is 10ms
+	tracker := c.hedger.trackers[milvuspb.MilvusService_Query_FullMethodName]
+	for i := 0; i < config.getMinSamples(); i++ {
+		tracker.record(10*time.Millisecond, config.getPercentile(), config.getMinSamples())
+	}
+	return c
+}
+
+// mockSlowFirstQuery makes the first query received by any endpoint slow for the duration,
+// and the others return immediately with the address of the endpoint.
+func (s *HedgingSuite) mockSlowFirstQuery(slow time.Duration) {
+	// the handlers of the canceled calls may outlive the test, so they don't refer to the suite
+	calls, canceled := s.calls, s.canceled
+	for addr, server := range s.servers {
+		addr := addr
+		server.mock.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, req *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+				if calls.Add(1) == 1 {
+					select {
+					case <-ctx.Done():
+						canceled <- struct{}{}
+						return nil, ctx.Err()
+					case <-time.After(slow):
+					}
+				}
+				return &milvuspb.QueryResults{Status: &commonpb.Status{}, CollectionName: addr}, nil
+			}).Maybe()
+	}
+}
+
+func (s *HedgingSuite) query(c *Client, ctx context.Context) (*milvuspb.QueryResults, error) {
+	var resp *milvuspb.QueryResults
+	err := c.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+		var err error
+		resp, err = milvusService.Query(ctx, &milvuspb.QueryRequest{CollectionName: "coll"})
+		return err
+	})
+	return resp, err
+}
+
+func (s *HedgingSuite) TestHedgeWins() {
+	c := s.newHedgingClient(&HedgingConfig{})
+	defer c.Close(context.Background())
+	s.mockSlowFirstQuery(time.Minute)
+
+	start := time.Now()
+	resp, err := s.query(c, context.Background())
+	s.Require().NoError(err)
+	s.Less(time.Since(start), 10*time.Second)
+	s.NotEmpty(resp.GetCollectionName())
+	s.Equal(int32(2), s.calls.Load())
+
+	// the slow call is canceled
+	select {
+	case <-s.canceled:
+	case <-time.After(10 * time.Second):
+		s.Fail("the slow call is not canceled")
+	}
+
+	stats := c.GetTelemetry().GetHedgingStats()
+	s.Equal(&HedgingStats{Requests: 1, Hedged: 1, HedgeWins: 1, Canceled: 1}, stats)
+	s.Contains(c.GetTelemetry().buildClientInfo().GetReserved()["hedging"], `"hedge_wins":1`)
+}
+
+func (s *HedgingSuite) TestBudget() {
+	c := s.newHedgingClient(&HedgingConfig{BudgetRatio: 0.01, BudgetBurst: 1})
+	defer c.Close(context.Background())
+
+	s.mockSlowFirstQuery(100 * time.Millisecond)
+	_, err := s.query(c, context.Background())
+	s.Require().NoError(err)
+
+	// the budget runs out, the slow call is not hedged
+	s.calls.Store(0)
+	_, err = s.query(c, context.Background())
+	s.Require().NoError(err)
+	s.Equal(int32(1), s.calls.Load())
+	stats := c.GetTelemetry().GetHedgingStats()
+	s.Equal(int64(2), stats.Requests)
+	s.Equal(int64(1), stats.Hedged)
+	s.Equal(int64(1), stats.Throttled)
+}
+
+func (s *HedgingSuite) TestFastCallNotHedged() {
+	c := s.newHedgingClient(&HedgingConfig{MinDelay: time.Minute})
+	defer c.Close(context.Background())
+	s.mockSlowFirstQuery(0)
+
+	for i := 0; i < 3; i++ {
+		_, err := s.query(c, context.Background())
+		s.Require().NoError(err)
+	}
+	s.Equal(int32(3), s.calls.Load())
+	s.Equal(int64(0), c.GetTelemetry().GetHedgingStats().Hedged)
+
+	// the hedging is disabled by the context
+	ctx := context.WithValue(context.Background(), DisableHedging, true)
+	_, err := s.query(c, ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(3), c.GetTelemetry().GetHedgingStats().Requests)
+}
+
+func TestHedging(t *testing.T) {
+	suite.Run(t, new(HedgingSuite))
+}
@@ -26,6 +26,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
@@ -66,9 +67,6 @@ func (c *Client) state(ctx context.Context) context.Context {
 	if c.currentDB != "" {
 		ctx = metadata.AppendToOutgoingContext(ctx, databaseHeader, c.currentDB)
 	}
-	if c.identifier != "" {
-		ctx = metadata.AppendToOutgoingContext(ctx, identifierHeader, c.identifier)
-	}
 
 	return ctx
 }
@@ -78,12 +76,36 @@ func (c *Client) extraInfo(ctx context.Context) context.Context {
 	return ctx
 }
 
+// HedgingUnaryInterceptor sends a duplicate of the slow search, query and get calls to another endpoint,
+// the first good response wins, see HedgingConfig.
+func (c *Client) HedgingUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if c.hedger == nil || hedgingDisabled(ctx) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		tracker, ok := c.hedger.trackers[method]
+		msg, isProto := reply.(proto.Message)
+		if !ok || !isProto {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		pick := func() *endpoint { return c.pickHedgeEndpoint(cc) }
+		return c.hedger.invoke(ctx, tracker, pick, method, req, msg, cc, invoker, opts...)
+	}
+}
+
+func hedgingDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(DisableHedging).(bool)
+	return disabled
+}
+
 // ref: https://github.com/grpc-ecosystem/go-grpc-middleware
 
 type ctxKey int
 
 const (
 	RetryOnRateLimit ctxKey = iota
+	// DisableHedging disables the hedged requests of the call if the value is true.
+	DisableHedging
 )
 
 // RetryOnRateLimitInterceptor returns a new retrying unary client interceptor.
@@ -555,10 +555,36 @@ func (m *ClientTelemetryManager) buildClientInfo() *commonpb.ClientInfo {
 		if dbName := m.client.getCurrentDB(); dbName != "" {
 			clientInfo.Reserved["db_name"] = dbName
 		}
+		if len(m.client.endpoints) > 1 {
+			if bytes, err := json.Marshal(m.client.endpointHealth()); err == nil {
+				clientInfo.Reserved["endpoints"] = string(bytes)
+			}
+		}
+		if stats := m.client.hedgingStats(); stats != nil {
+			if bytes, err := json.Marshal(stats); err == nil {
+				clientInfo.Reserved["hedging"] = string(bytes)
+			}
+		}
 	}
 	return clientInfo
 }
 
+// GetEndpointHealth returns the health status of the endpoints the client connects to.
+func (m *ClientTelemetryManager) GetEndpointHealth() []*EndpointHealth {
+	if m.client == nil {
+		return nil
+	}
+	return m.client.endpointHealth()
+}
+
+// GetHedgingStats returns the counters of the hedged requests, nil if hedging is not enabled.
+func (m *ClientTelemetryManager) GetHedgingStats() *HedgingStats {
+	if m.client == nil {
+		return nil
+	}
+	return m.client.hedgingStats()
+}
+
 // Stop stops the background heartbeat goroutine
 func (m *ClientTelemetryManager) Stop() {
 	if m.closed.Swap(true) {
@@ -602,7 +628,11 @@ func (m *ClientTelemetryManager) sendHeartbeat() {
 		return
 	}
 
-	if m.client == nil || m.client.service == nil {
+	if m.client == nil {
+		return
+	}
+	telemetryService := m.client.getTelemetryService()
+	if telemetryService == nil {
 		return
 	}
 
@@ -666,7 +696,7 @@ func (m *ClientTelemetryManager) sendHeartbeat() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, err := m.client.telemetryService.ClientHeartbeat(ctx, req)
+	resp, err := telemetryService.ClientHeartbeat(ctx, req)
 	if err != nil {
 		// Log error but continue - telemetry is best-effort
 		return
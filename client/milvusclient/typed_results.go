@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"io"
+	"iter"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/row"
+)
+
+// ScoreField is the name which the search score is decoded into,
+// e.g. a struct field tagged `milvus:"name:_score"` receives the score of the hit.
+const ScoreField = "_score"
+
+// resultIterator is implemented by both QueryIterator and SearchIterator.
+type resultIterator interface {
+	Next(ctx context.Context) (ResultSet, error)
+}
+
+// UnmarshalAs decodes the rows of the result set into T, which shall be a struct or a pointer to struct,
+// the primary key and the search score are decoded as well.
+// See row.Decoder for how the columns are mapped to the struct fields.
+func UnmarshalAs[T any](rs ResultSet) ([]T, error) {
+	decoder, err := row.NewDecoder[T]()
+	if err != nil {
+		return nil, err
+	}
+	return decoder.DecodeAll(rs.decodeColumns())
+}
+
+// decodeColumns returns the output fields, with the ids if not output and the scores if any.
+func (rs *ResultSet) decodeColumns() []column.Column {
+	columns := make([]column.Column, 0, len(rs.Fields)+2)
+	columns = append(columns, rs.Fields...)
+	if rs.IDs != nil && rs.GetColumn(rs.IDs.Name()) == nil {
+		columns = append(columns, rs.IDs)
+	}
+	if rs.Scores != nil {
+		columns = append(columns, column.NewColumnFloat(ScoreField, rs.Scores))
+	}
+	return columns
+}
+
+// QueryAs queries the collection and decodes the result rows into T.
+func QueryAs[T any](ctx context.Context, c *Client, option QueryOption, callOptions ...grpc.CallOption) ([]T, error) {
+	rs, err := c.Query(ctx, option, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalAs[T](rs)
+}
+
+// SearchAs searches the collection and decodes the hits of each query vector into T.
+func SearchAs[T any](ctx context.Context, c *Client, option SearchOption, callOptions ...grpc.CallOption) ([][]T, error) {
+	resultSets, err := c.Search(ctx, option, callOptions...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([][]T, 0, len(resultSets))
+	for _, rs := range resultSets {
+		if rs.Err != nil {
+			return nil, rs.Err
+		}
+		hits, err := UnmarshalAs[T](rs)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, hits)
+	}
+	return results, nil
+}
+
+// All returns the rows of the query or search iterator as a sequence, batches are fetched lazily as the sequence is consumed.
+// The sequence stops after yielding the first error, including the error of the context,
+// breaking the loop stops fetching the remaining batches.
+//
+//	for row, err := range milvusclient.All[Record](ctx, it) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func All[T any](ctx context.Context, it resultIterator) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		decoder, err := row.NewDecoder[T]()
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			rs, err := it.Next(ctx)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			columns := rs.decodeColumns()
+			for i := 0; i < rs.ResultCount; i++ {
+				v, err := decoder.Decode(columns, i)
+				if !yield(v, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/internal/merr"
+)
+
+type typedRecord struct {
+	ID    int64   `milvus:"name:id"`
+	Tag   *string `milvus:"name:tag"`
+	Score float32 `milvus:"name:_score"`
+}
+
+// batchIterator returns the batches in order, and io.EOF after all batches are consumed.
+type batchIterator struct {
+	batches []ResultSet
+	calls   int
+}
+
+func (it *batchIterator) Next(ctx context.Context) (ResultSet, error) {
+	it.calls++
+	if len(it.batches) == 0 {
+		return ResultSet{}, io.EOF
+	}
+	rs := it.batches[0]
+	it.batches = it.batches[1:]
+	return rs, nil
+}
+
+type TypedResultsSuite struct {
+	MockSuiteBase
+
+	schema *entity.Schema
+}
+
+func (s *TypedResultsSuite) SetupSuite() {
+	s.MockSuiteBase.SetupSuite()
+	s.schema = entity.NewSchema().
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("tag").WithDataType(entity.FieldTypeVarChar).WithMaxLength(64).WithNullable(true)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(2))
+}
+
+func (s *TypedResultsSuite) tagFieldData() *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_VarChar,
+		FieldName: "tag",
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{
+			StringData: &schemapb.StringArray{Data: []string{"a", ""}},
+		}}},
+		ValidData: []bool{true, false},
+	}
+}
+
+func (s *TypedResultsSuite) TestQueryAs() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).Return(&milvuspb.QueryResults{
+		Status:     merr.Success(),
+		FieldsData: []*schemapb.FieldData{s.getInt64FieldData("id", []int64{1, 2}), s.tagFieldData()},
+	}, nil).Once()
+
+	records, err := QueryAs[typedRecord](ctx, s.client, NewQueryOption(collName).WithFilter("id > 0").WithOutputFields("id", "tag"))
+	s.Require().NoError(err)
+	s.Require().Len(records, 2)
+	s.EqualValues(1, records[0].ID)
+	s.Require().NotNil(records[0].Tag)
+	s.Equal("a", *records[0].Tag)
+	s.EqualValues(2, records[1].ID)
+	s.Nil(records[1].Tag)
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).Return(nil, merr.WrapErrServiceInternal("mocked")).Once()
+	_, err = QueryAs[typedRecord](ctx, s.client, NewQueryOption(collName).WithFilter("id > 0"))
+	s.Error(err)
+}
+
+func (s *TypedResultsSuite) TestSearchAs() {
+	ctx := context.Background()
+	collName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collName, s.schema)
+	s.resetMock()
+
+	s.mock.EXPECT().Search(mock.Anything, mock.Anything).Return(&milvuspb.SearchResults{
+		Status: merr.Success(),
+		Results: &schemapb.SearchResultData{
+			NumQueries: 2,
+			TopK:       1,
+			FieldsData: []*schemapb.FieldData{s.tagFieldData()},
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: []int64{2, 1}}}},
+			Scores:     []float32{0.9, 0.8},
+			Topks:      []int64{1, 1},
+		},
+	}, nil).Once()
+
+	hits, err := SearchAs[*typedRecord](ctx, s.client, NewSearchOption(collName, 1, []entity.Vector{entity.FloatVector{1, 1}, entity.FloatVector{0, 1}}).WithOutputFields("tag"))
+	s.Require().NoError(err)
+	s.Require().Len(hits, 2)
+	s.Require().Len(hits[0], 1)
+	s.EqualValues(2, hits[0][0].ID)
+	s.Equal(float32(0.9), hits[0][0].Score)
+	s.Equal("a", *hits[0][0].Tag)
+	s.EqualValues(1, hits[1][0].ID)
+	s.Equal(float32(0.8), hits[1][0].Score)
+	s.Nil(hits[1][0].Tag)
+}
+
+func (s *TypedResultsSuite) TestAll() {
+	batch := func(ids ...int64) ResultSet {
+		return ResultSet{ResultCount: len(ids), Fields: DataSet{column.NewColumnInt64("id", ids)}}
+	}
+
+	s.Run("all_batches", func() {
+		it := &batchIterator{batches: []ResultSet{batch(1, 2), batch(3)}}
+		var ids []int64
+		for record, err := range All[typedRecord](context.Background(), it) {
+			s.Require().NoError(err)
+			ids = append(ids, record.ID)
+		}
+		s.Equal([]int64{1, 2, 3}, ids)
+	})
+
+	s.Run("early_break", func() {
+		it := &batchIterator{batches: []ResultSet{batch(1, 2), batch(3)}}
+		for record, err := range All[typedRecord](context.Background(), it) {
+			s.Require().NoError(err)
+			if record.ID == 2 {
+				break
+			}
+		}
+		// the second batch is never fetched
+		s.Equal(1, it.calls)
+	})
+
+	s.Run("context_canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		it := &batchIterator{batches: []ResultSet{batch(1), batch(2)}}
+		var errs []error
+		for _, err := range All[typedRecord](ctx, it) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			cancel()
+		}
+		s.Require().Len(errs, 1)
+		s.ErrorIs(errs[0], context.Canceled)
+		s.Equal(1, it.calls)
+	})
+
+	s.Run("decode_error", func() {
+		type mismatch struct {
+			ID string `milvus:"name:id"`
+		}
+		it := &batchIterator{batches: []ResultSet{batch(1, 2)}}
+		count := 0
+		for _, err := range All[mismatch](context.Background(), it) {
+			s.Error(err)
+			count++
+		}
+		s.Equal(1, count)
+	})
+
+	s.Run("query_iterator", func() {
+		ctx := context.Background()
+		collName := fmt.Sprintf("coll_%s", s.randString(6))
+		s.resetMock()
+
+		s.mock.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+			CollectionID: 1,
+			Schema:       s.schema.ProtoMessage(),
+		}, nil).Once()
+		s.mock.EXPECT().Query(mock.Anything, mock.Anything).Return(&milvuspb.QueryResults{
+			Status:     merr.Success(),
+			FieldsData: []*schemapb.FieldData{s.getInt64FieldData("id", []int64{1, 2}), s.tagFieldData()},
+		}, nil).Once()
+		s.mock.EXPECT().Query(mock.Anything, mock.Anything).Return(&milvuspb.QueryResults{
+			Status:     merr.Success(),
+			FieldsData: []*schemapb.FieldData{},
+		}, nil).Once()
+
+		it, err := s.client.QueryIterator(ctx, NewQueryIteratorOption(collName).WithOutputFields("id", "tag").WithBatchSize(2))
+		s.Require().NoError(err)
+
+		var records []typedRecord
+		for record, err := range All[typedRecord](ctx, it) {
+			s.Require().NoError(err)
+			records = append(records, record)
+		}
+		s.Require().Len(records, 2)
+		s.Equal("a", *records[0].Tag)
+		s.Nil(records[1].Tag)
+	})
+}
+
+func TestTypedResults(t *testing.T) {
+	suite.Run(t, new(TypedResultsSuite))
+}
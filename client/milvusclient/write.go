@@ -48,7 +48,7 @@ func (c *Client) Insert(ctx context.Context, option InsertOption, callOptions ..
 			return collection.UpdateTimestamp, merr.WrapErrCollectionSchemaMisMatch(err)
 		}
 
-		return collection.UpdateTimestamp, c.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+		return collection.UpdateTimestamp, c.callWriteService(func(milvusService milvuspb.MilvusServiceClient) error {
 			resp, err := milvusService.Insert(ctx, req, callOptions...)
 
 			err = merr.CheckRPCCall(resp, err)
@@ -85,7 +85,7 @@ func (c *Client) Delete(ctx context.Context, option DeleteOption, callOptions ..
 		c.recordOperation("Delete", collectionName, startTime, err)
 	}()
 
-	err = c.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+	err = c.callWriteService(func(milvusService milvuspb.MilvusServiceClient) error {
 		resp, err := milvusService.Delete(ctx, req, callOptions...)
 		if err = merr.CheckRPCCall(resp, err); err != nil {
 			return err
@@ -115,7 +115,7 @@ func (c *Client) Upsert(ctx context.Context, option UpsertOption, callOptions ..
 			// return schema mismatch err to retry with newer schema
 			return collection.UpdateTimestamp, merr.WrapErrCollectionSchemaMisMatch(err)
 		}
-		return collection.UpdateTimestamp, c.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+		return collection.UpdateTimestamp, c.callWriteService(func(milvusService milvuspb.MilvusServiceClient) error {
 			resp, err := milvusService.Upsert(ctx, req, callOptions...)
 			if err = merr.CheckRPCCall(resp, err); err != nil {
 				return err
@@ -0,0 +1,143 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package row
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/client/v3/column"
+	"github.com/milvus-io/milvus/client/v3/entity"
+)
+
+// Decoder decodes the rows of columns into T, which shall be a struct or a pointer to struct.
+// The columns are matched with the struct fields by the `milvus:"name:xxx"` tag or the field name,
+// pointer fields are set to nil for null values, and json values are unmarshaled if the field is not bytes.
+type Decoder[T any] struct {
+	structType reflect.Type
+	isPtr      bool
+	candidate  *ReceiverCandidate
+}
+
+// NewDecoder returns the decoder of T, the struct fields are parsed once and reused for all rows.
+func NewDecoder[T any]() (*Decoder[T], error) {
+	t := reflect.TypeFor[T]()
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.Newf("decode target must be struct or pointer to struct, got %s", reflect.TypeFor[T]())
+	}
+	return &Decoder[T]{
+		structType: t,
+		isPtr:      isPtr,
+		candidate:  GetReceiverCandidate(t),
+	}, nil
+}
+
+// Decode decodes the idx-th row of the columns, the columns without matched struct field are ignored.
+func (d *Decoder[T]) Decode(columns []column.Column, idx int) (T, error) {
+	var result T
+	ptr := reflect.New(d.structType)
+	data := ptr.Elem()
+	for _, col := range columns {
+		fidx, ok := d.candidate.Name2FieldIndex(col.Name())
+		if !ok {
+			continue
+		}
+		if err := decodeValue(data.Field(fidx), col, idx); err != nil {
+			return result, errors.Wrapf(err, "failed to decode column %s of row %d", col.Name(), idx)
+		}
+	}
+	if d.isPtr {
+		return ptr.Interface().(T), nil
+	}
+	return data.Interface().(T), nil
+}
+
+// DecodeAll decodes all the rows of the columns.
+func (d *Decoder[T]) DecodeAll(columns []column.Column) ([]T, error) {
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	result := make([]T, 0, columns[0].Len())
+	for i := 0; i < columns[0].Len(); i++ {
+		v, err := d.Decode(columns, i)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+func decodeValue(field reflect.Value, col column.Column, idx int) error {
+	isNull, err := col.IsNull(idx)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		field.SetZero()
+		return nil
+	}
+
+	target := field
+	if field.Kind() == reflect.Ptr {
+		target = reflect.New(field.Type().Elem()).Elem()
+	}
+	if dynamic, ok := col.(*column.ColumnDynamic); ok {
+		// the dynamic column returns the raw json of its key, error means the key is absent
+		raw, err := dynamic.Get(idx)
+		if err != nil {
+			field.SetZero()
+			return nil
+		}
+		if err := json.Unmarshal([]byte(raw.(string)), target.Addr().Interface()); err != nil {
+			return err
+		}
+	} else {
+		val, err := col.Get(idx)
+		if err != nil {
+			return err
+		}
+		if err := setValue(target, reflect.ValueOf(val), col.Type()); err != nil {
+			return err
+		}
+	}
+	if field.Kind() == reflect.Ptr {
+		field.Set(target.Addr())
+	}
+	return nil
+}
+
+func setValue(target reflect.Value, v reflect.Value, fieldType entity.FieldType) error {
+	switch {
+	case v.Type().AssignableTo(target.Type()):
+		target.Set(v)
+	// named types of the same kind, such as entity.FloatVector and []float32
+	case v.Kind() == target.Kind() && v.Type().ConvertibleTo(target.Type()):
+		target.Set(v.Convert(target.Type()))
+	case fieldType == entity.FieldTypeJSON:
+		return json.Unmarshal(v.Bytes(), target.Addr().Interface())
+	default:
+		return errors.Newf("cannot assign value of type %s to field of type %s", v.Type(), target.Type())
+	}
+	return nil
+}
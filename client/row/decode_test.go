@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package row
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/client/v3/column"
+)
+
+type decodeMeta struct {
+	Color string `json:"color"`
+}
+
+type decodeRow struct {
+	ID     int64     `milvus:"name:id"`
+	Tag    *string   `milvus:"name:tag"`
+	Vector []float32 `milvus:"name:vector"`
+	Meta   decodeMeta
+	Raw    []byte  `milvus:"name:raw"`
+	Extra  *int64  `milvus:"name:extra"`
+	Score  float32 `milvus:"name:_score"`
+}
+
+func decodeColumns(t *testing.T) []column.Column {
+	tag, err := column.NewNullableColumnVarChar("tag", []string{"a", ""}, []bool{true, false}, column.WithSparseNullableMode[string](true))
+	require.NoError(t, err)
+	dynamic := column.NewColumnJSONBytes("$meta", [][]byte{[]byte(`{"extra":10}`), []byte(`{}`)}).WithIsDynamic(true)
+	return []column.Column{
+		column.NewColumnInt64("id", []int64{1, 2}),
+		tag,
+		column.NewColumnFloatVector("vector", 2, [][]float32{{0.1, 0.2}, {0.3, 0.4}}),
+		column.NewColumnJSONBytes("Meta", [][]byte{[]byte(`{"color":"red"}`), []byte(`{"color":"blue"}`)}),
+		column.NewColumnJSONBytes("raw", [][]byte{[]byte(`{}`), []byte(`[]`)}),
+		column.NewColumnDynamic(dynamic, "extra"),
+		column.NewColumnFloat("_score", []float32{0.9, 0.8}),
+		column.NewColumnInt64("unknown", []int64{0, 0}),
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		decoder, err := NewDecoder[decodeRow]()
+		require.NoError(t, err)
+
+		rows, err := decoder.DecodeAll(decodeColumns(t))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.EqualValues(t, 1, rows[0].ID)
+		require.NotNil(t, rows[0].Tag)
+		assert.Equal(t, "a", *rows[0].Tag)
+		assert.Nil(t, rows[1].Tag)
+		// named vector type is converted into the slice
+		assert.Equal(t, []float32{0.3, 0.4}, rows[1].Vector)
+		assert.Equal(t, "red", rows[0].Meta.Color)
+		assert.Equal(t, []byte(`[]`), rows[1].Raw)
+		require.NotNil(t, rows[0].Extra)
+		assert.EqualValues(t, 10, *rows[0].Extra)
+		assert.Nil(t, rows[1].Extra)
+		assert.Equal(t, float32(0.8), rows[1].Score)
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		decoder, err := NewDecoder[*decodeRow]()
+		require.NoError(t, err)
+
+		row, err := decoder.Decode(decodeColumns(t), 1)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, row.ID)
+	})
+
+	t.Run("invalid_target", func(t *testing.T) {
+		_, err := NewDecoder[int64]()
+		assert.Error(t, err)
+		_, err = NewDecoder[map[string]any]()
+		assert.Error(t, err)
+	})
+
+	t.Run("type_mismatch", func(t *testing.T) {
+		type mismatch struct {
+			ID string `milvus:"name:id"`
+		}
+		decoder, err := NewDecoder[mismatch]()
+		require.NoError(t, err)
+		_, err = decoder.DecodeAll(decodeColumns(t))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// sealKeyEnv is the environment variable of the HMAC key, the key is not accepted as a flag
+// since the command line could be seen by the other users.
+const sealKeyEnv = "AUDIT_LOG_SEAL_KEY"
+
+var (
+	localDir = flag.String("dir", "", "Local directory of the audit log, the object storage in milvus.yaml is used if empty")
+	rootPath = flag.String("rootPath", "audit_log", "Audit log root path under the storage root path")
+	keyFile  = flag.String("keyFile", "", "File of the HMAC key to verify the seal signatures, the same as proxy.auditLog.sealKey, "+
+		"the key is read from the "+sealKeyEnv+" environment variable if empty")
+)
+
+// readSealKey reads the seal key from the key file or the environment variable.
+func readSealKey() ([]byte, error) {
+	if *keyFile == "" {
+		return []byte(os.Getenv(sealKeyEnv)), nil
+	}
+	content, err := os.ReadFile(*keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimRight(string(content), "\r\n")), nil
+}
+
+func main() {
+	flag.Parse()
+
+	ctx := context.TODO()
+	sealKey, err := readSealKey()
+	if err != nil {
+		mlog.Fatal(ctx, "failed to read the seal key", mlog.String("keyFile", *keyFile), mlog.Err(err))
+	}
+	var cm storage.ChunkManager
+	if *localDir != "" {
+		cm = storage.NewLocalChunkManager(objectstorage.RootPath(*localDir))
+	} else {
+		paramtable.Init()
+		cm, err = storage.NewChunkManagerFactoryWithParam(paramtable.Get()).NewPersistentStorageChunkManager(ctx)
+		if err != nil {
+			mlog.Fatal(ctx, "failed to create chunk manager", mlog.Err(err))
+		}
+	}
+
+	report, err := auditlog.Verify(ctx, cm, *rootPath, sealKey)
+	if err != nil {
+		mlog.Fatal(ctx, "failed to verify audit log", mlog.Err(err))
+	}
+
+	fmt.Printf("Nodes: %d\t\tSegments: %d\t\tEntries: %d\n", report.Nodes, report.Segments, report.Entries)
+	for _, problem := range report.Problems {
+		fmt.Println(problem)
+	}
+	if !report.OK() {
+		fmt.Printf("Audit log verification failed, %d problems found\n", len(report.Problems))
+		os.Exit(1)
+	}
+	fmt.Println("Audit log verification passed")
+}
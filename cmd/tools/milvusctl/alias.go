@@ -0,0 +1,107 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func aliasCommand() *command {
+	return &command{
+		name:    "alias",
+		summary: "Manage collection aliases",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, usage: "[COLLECTION]", summary: "List the aliases, of a collection if specified", run: aliasList},
+			{name: "create", usage: "COLLECTION ALIAS", summary: "Create an alias of a collection", run: aliasCreate},
+			{name: "describe", usage: "ALIAS", summary: "Describe an alias", run: aliasDescribe},
+			{name: "alter", usage: "ALIAS COLLECTION", summary: "Point an alias to another collection", run: aliasAlter},
+			{name: "drop", usage: "ALIAS", summary: "Drop an alias", run: aliasDrop},
+		},
+	}
+}
+
+func aliasList(inv *invocation) error {
+	if err := inv.parse("[COLLECTION]"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListAliases(inv.ctx, milvusclient.NewListAliasesOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"ALIAS"}, nameRows(names))
+}
+
+func aliasCreate(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "ALIAS"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.CreateAlias(inv.ctx, milvusclient.NewCreateAliasOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Alias %q of collection %q created.", inv.arg(1), inv.arg(0))
+}
+
+func aliasDescribe(inv *invocation) error {
+	if err := inv.parse("ALIAS"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	alias, err := cli.DescribeAlias(inv.ctx, milvusclient.NewDescribeAliasOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(alias, []string{"ALIAS", "COLLECTION", "DATABASE"}, [][]string{{alias.Alias, alias.CollectionName, alias.DbName}})
+}
+
+func aliasAlter(inv *invocation) error {
+	if err := inv.parse("ALIAS", "COLLECTION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.AlterAlias(inv.ctx, milvusclient.NewAlterAliasOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Alias %q now points to collection %q.", inv.arg(0), inv.arg(1))
+}
+
+func aliasDrop(inv *invocation) error {
+	if err := inv.parse("ALIAS"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropAlias(inv.ctx, milvusclient.NewDropAliasOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Alias %q dropped.", inv.arg(0))
+}
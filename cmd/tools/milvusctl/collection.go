@@ -0,0 +1,456 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func collectionCommand() *command {
+	return &command{
+		name:    "collection",
+		aliases: []string{"coll"},
+		summary: "Manage collections",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, summary: "List collections", run: collectionList},
+			{name: "create", usage: "[NAME]", summary: "Create a collection from a yaml spec, or a quick one with -dim", run: collectionCreate},
+			{name: "describe", usage: "NAME", summary: "Describe a collection", run: collectionDescribe},
+			{name: "rename", usage: "NAME NEW_NAME", summary: "Rename a collection", run: collectionRename},
+			{name: "alter", usage: "NAME", summary: "Alter the properties of a collection", run: collectionAlter},
+			{name: "drop", usage: "NAME", summary: "Drop a collection", run: collectionDrop},
+			{name: "load", usage: "NAME", summary: "Load a collection", run: collectionLoad},
+			{name: "release", usage: "NAME", summary: "Release a collection", run: collectionRelease},
+			{name: "load-state", usage: "NAME", summary: "Show the load state of a collection or its partitions", run: collectionLoadState},
+			{name: "replicas", usage: "NAME", summary: "Show the replicas of a loaded collection", run: collectionReplicas},
+			{name: "stats", usage: "NAME", summary: "Show the statistics of a collection", run: collectionStats},
+			{name: "flush", usage: "NAME", summary: "Flush a collection", run: collectionFlush},
+		},
+	}
+}
+
+func collectionList(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListCollections(inv.ctx, milvusclient.NewListCollectionOption())
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"NAME"}, nameRows(names))
+}
+
+func collectionCreate(inv *invocation) error {
+	file := inv.flags.String("f", "", "yaml spec of the collection, - for stdin")
+	dim := inv.flags.Int64("dim", 0, "create a quick collection with an auto id primary key, a vector field of dim and the dynamic field, which is indexed and loaded")
+	metric := inv.flags.String("metric", string(entity.COSINE), "metric type of the quick collection")
+	if err := inv.parse("[NAME]"); err != nil {
+		return err
+	}
+
+	var opt milvusclient.CreateCollectionOption
+	name := inv.arg(0)
+	switch {
+	case *file != "" && *dim > 0:
+		return fmt.Errorf("%w: -f and -dim are exclusive", errUsage)
+	case *file != "":
+		spec, err := readCollectionSpec(*file, inv.stdin)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			spec.Name = name
+		}
+		name = spec.Name
+		if opt, err = spec.createOption(); err != nil {
+			return err
+		}
+	case *dim > 0:
+		if name == "" {
+			return fmt.Errorf("%w: NAME is required for the quick collection", errUsage)
+		}
+		opt = milvusclient.SimpleCreateCollectionOptions(name, *dim).WithMetricType(entity.MetricType(strings.ToUpper(*metric)))
+	default:
+		return fmt.Errorf("%w: either -f or -dim is required", errUsage)
+	}
+
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.CreateCollection(inv.ctx, opt); err != nil {
+		return err
+	}
+	return inv.done("Collection %q created.", name)
+}
+
+type fieldView struct {
+	ID            int64             `json:"id"`
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	ElementType   string            `json:"elementType,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	PrimaryKey    bool              `json:"primaryKey,omitempty"`
+	AutoID        bool              `json:"autoID,omitempty"`
+	PartitionKey  bool              `json:"partitionKey,omitempty"`
+	ClusteringKey bool              `json:"clusteringKey,omitempty"`
+	Nullable      bool              `json:"nullable,omitempty"`
+	IsDynamic     bool              `json:"isDynamic,omitempty"`
+	TypeParams    map[string]string `json:"typeParams,omitempty"`
+}
+
+type collectionView struct {
+	ID                 int64             `json:"id"`
+	Name               string            `json:"name"`
+	Description        string            `json:"description,omitempty"`
+	ShardsNum          int32             `json:"shardsNum"`
+	ConsistencyLevel   string            `json:"consistencyLevel"`
+	EnableDynamicField bool              `json:"enableDynamicField"`
+	VirtualChannels    []string          `json:"virtualChannels,omitempty"`
+	Properties         map[string]string `json:"properties,omitempty"`
+	Fields             []*fieldView      `json:"fields"`
+}
+
+func newCollectionView(coll *entity.Collection) *collectionView {
+	view := &collectionView{
+		ID:                 coll.ID,
+		Name:               coll.Name,
+		Description:        coll.Schema.Description,
+		ShardsNum:          coll.ShardNum,
+		ConsistencyLevel:   commonpb.ConsistencyLevel(coll.ConsistencyLevel).String(),
+		EnableDynamicField: coll.Schema.EnableDynamicField,
+		VirtualChannels:    coll.VirtualChannels,
+		Properties:         coll.Properties,
+	}
+	for _, f := range coll.Schema.Fields {
+		fv := &fieldView{
+			ID:            f.ID,
+			Name:          f.Name,
+			Type:          f.DataType.Name(),
+			Description:   f.Description,
+			PrimaryKey:    f.PrimaryKey,
+			AutoID:        f.AutoID,
+			PartitionKey:  f.IsPartitionKey,
+			ClusteringKey: f.IsClusteringKey,
+			Nullable:      f.Nullable,
+			IsDynamic:     f.IsDynamic,
+			TypeParams:    f.TypeParams,
+		}
+		if f.ElementType != entity.FieldTypeNone {
+			fv.ElementType = f.ElementType.Name()
+		}
+		view.Fields = append(view.Fields, fv)
+	}
+	return view
+}
+
+func collectionDescribe(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	coll, err := cli.DescribeCollection(inv.ctx, milvusclient.NewDescribeCollectionOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	view := newCollectionView(coll)
+	if inv.global.output == outputTable {
+		tw := tabwriter.NewWriter(inv.stdout, 0, 0, 1, ' ', 0)
+		fmt.Fprintf(tw, "Name:\t%s\n", view.Name)
+		fmt.Fprintf(tw, "ID:\t%d\n", view.ID)
+		fmt.Fprintf(tw, "Description:\t%s\n", view.Description)
+		fmt.Fprintf(tw, "Shards:\t%d\n", view.ShardsNum)
+		fmt.Fprintf(tw, "Consistency Level:\t%s\n", view.ConsistencyLevel)
+		fmt.Fprintf(tw, "Dynamic Field:\t%t\n", view.EnableDynamicField)
+		fmt.Fprintf(tw, "Properties:\t%s\n\n", formatMap(view.Properties))
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	rows := make([][]string, 0, len(view.Fields))
+	for _, f := range view.Fields {
+		var flags []string
+		for _, flag := range []struct {
+			name string
+			set  bool
+		}{
+			{"primary_key", f.PrimaryKey},
+			{"auto_id", f.AutoID},
+			{"partition_key", f.PartitionKey},
+			{"clustering_key", f.ClusteringKey},
+			{"nullable", f.Nullable},
+			{"dynamic", f.IsDynamic},
+		} {
+			if flag.set {
+				flags = append(flags, flag.name)
+			}
+		}
+		typeName := f.Type
+		if f.ElementType != "" {
+			typeName += "<" + f.ElementType + ">"
+		}
+		rows = append(rows, []string{strconv.FormatInt(f.ID, 10), f.Name, typeName, strings.Join(flags, ","), formatMap(f.TypeParams)})
+	}
+	return inv.print(view, []string{"FIELD_ID", "FIELD", "TYPE", "FLAGS", "PARAMS"}, rows)
+}
+
+func collectionRename(inv *invocation) error {
+	if err := inv.parse("NAME", "NEW_NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.RenameCollection(inv.ctx, milvusclient.NewRenameCollectionOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Collection %q renamed to %q.", inv.arg(0), inv.arg(1))
+}
+
+func collectionAlter(inv *invocation) error {
+	properties := kvFlag{}
+	var dropKeys listFlag
+	inv.flags.Var(properties, "property", "property to set, key=value, repeatable")
+	inv.flags.Var(&dropKeys, "drop-property", "key of the property to drop, repeatable")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	if len(properties) == 0 && len(dropKeys) == 0 {
+		return fmt.Errorf("%w: -property or -drop-property is required", errUsage)
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if len(properties) > 0 {
+		opt := milvusclient.NewAlterCollectionPropertiesOption(inv.arg(0))
+		for k, v := range properties {
+			opt.WithProperty(k, v)
+		}
+		if err := cli.AlterCollectionProperties(inv.ctx, opt); err != nil {
+			return err
+		}
+	}
+	if len(dropKeys) > 0 {
+		if err := cli.DropCollectionProperties(inv.ctx, milvusclient.NewDropCollectionPropertiesOption(inv.arg(0), dropKeys...)); err != nil {
+			return err
+		}
+	}
+	return inv.done("Collection %q altered.", inv.arg(0))
+}
+
+func collectionDrop(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropCollection(inv.ctx, milvusclient.NewDropCollectionOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Collection %q dropped.", inv.arg(0))
+}
+
+func collectionLoad(inv *invocation) error {
+	replicas := inv.flags.Int("replicas", 0, "number of replicas, default the server config")
+	var resourceGroups, fields listFlag
+	inv.flags.Var(&resourceGroups, "resource-group", "resource groups to load the replicas into, repeatable")
+	inv.flags.Var(&fields, "load-field", "fields to load, default all the fields, repeatable")
+	refresh := inv.flags.Bool("refresh", false, "refresh the loaded collection to load the new segments")
+	wait := inv.flags.Bool("wait", true, "wait until the collection is fully loaded")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewLoadCollectionOption(inv.arg(0)).WithRefresh(*refresh)
+	if *replicas > 0 {
+		opt.WithReplica(*replicas)
+	}
+	if len(resourceGroups) > 0 {
+		opt.WithResourceGroup(resourceGroups...)
+	}
+	if len(fields) > 0 {
+		opt.WithLoadFields(fields...)
+	}
+	task, err := cli.LoadCollection(inv.ctx, opt)
+	if err != nil {
+		return err
+	}
+	if !*wait {
+		return inv.done("Collection %q loading.", inv.arg(0))
+	}
+	if err := task.Await(inv.ctx); err != nil {
+		return err
+	}
+	return inv.done("Collection %q loaded.", inv.arg(0))
+}
+
+func collectionRelease(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.ReleaseCollection(inv.ctx, milvusclient.NewReleaseCollectionOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Collection %q released.", inv.arg(0))
+}
+
+type loadStateView struct {
+	Collection string   `json:"collection"`
+	Partitions []string `json:"partitions,omitempty"`
+	State      string   `json:"state"`
+	Progress   int64    `json:"progress"`
+}
+
+// loadStateName returns the short name of the load state, e.g. "Loaded".
+func loadStateName(state entity.LoadStateCode) string {
+	return strings.TrimPrefix(commonpb.LoadState(state).String(), "LoadState")
+}
+
+func collectionLoadState(inv *invocation) error {
+	var partitions listFlag
+	inv.flags.Var(&partitions, "partition", "partitions to check, default the whole collection, repeatable")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	state, err := cli.GetLoadState(inv.ctx, milvusclient.NewGetLoadStateOption(inv.arg(0), partitions...))
+	if err != nil {
+		return err
+	}
+	view := &loadStateView{
+		Collection: inv.arg(0),
+		Partitions: partitions,
+		State:      loadStateName(state.State),
+		Progress:   state.Progress,
+	}
+	return inv.print(view, []string{"COLLECTION", "PARTITIONS", "STATE", "PROGRESS"},
+		[][]string{{view.Collection, strings.Join(view.Partitions, ","), view.State, fmt.Sprintf("%d%%", view.Progress)}})
+}
+
+func collectionReplicas(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	replicas, err := cli.DescribeReplica(inv.ctx, milvusclient.NewDescribeReplicaOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(replicas))
+	for _, replica := range replicas {
+		channels := make([]string, 0, len(replica.Shards))
+		for _, shard := range replica.Shards {
+			channels = append(channels, fmt.Sprintf("%s(leader=%d)", shard.ChannelName, shard.ShardLeader))
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(replica.ReplicaID, 10),
+			replica.ResourceGroupName,
+			formatInt64s(replica.Nodes),
+			strings.Join(channels, ","),
+		})
+	}
+	return inv.print(replicas, []string{"REPLICA_ID", "RESOURCE_GROUP", "NODES", "SHARDS"}, rows)
+}
+
+func formatInt64s(values []int64) string {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, strconv.FormatInt(v, 10))
+	}
+	return strings.Join(items, ",")
+}
+
+func collectionStats(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	stats, err := cli.GetCollectionStats(inv.ctx, milvusclient.NewGetCollectionStatsOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(stats, []string{"KEY", "VALUE"}, mapRows(stats))
+}
+
+// mapRows returns the rows of the key value pairs sorted by key.
+func mapRows(m map[string]string) [][]string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := make([][]string, 0, len(m))
+	for _, k := range keys {
+		rows = append(rows, []string{k, m[k]})
+	}
+	return rows
+}
+
+func collectionFlush(inv *invocation) error {
+	wait := inv.flags.Bool("wait", true, "wait until the flush is done")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	task, err := cli.Flush(inv.ctx, milvusclient.NewFlushOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	if *wait {
+		if err := task.Await(inv.ctx); err != nil {
+			return err
+		}
+	}
+	return inv.done("Collection %q flushed.", inv.arg(0))
+}
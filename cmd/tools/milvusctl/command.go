@@ -0,0 +1,361 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// errUsage is returned when the command line is malformed, the usage is printed for it.
+var errUsage = errors.New("invalid usage")
+
+// command is a node of the command tree, the leaf commands have run set.
+type command struct {
+	name        string
+	aliases     []string
+	usage       string // usage of the positional arguments, e.g. "COLLECTION NAME"
+	summary     string
+	run         func(inv *invocation) error
+	subcommands []*command
+}
+
+func (c *command) find(name string) *command {
+	for _, sub := range c.subcommands {
+		if sub.name == name {
+			return sub
+		}
+		for _, alias := range sub.aliases {
+			if alias == name {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+func (c *command) printHelp(w io.Writer, path string) {
+	if c.summary != "" {
+		fmt.Fprintf(w, "%s\n\n", c.summary)
+	}
+	if c.run != nil {
+		fmt.Fprintf(w, "Usage:\n  %s [flags] %s\n", path, c.usage)
+		return
+	}
+	fmt.Fprintf(w, "Usage:\n  %s <command> [flags]\n\nCommands:\n", path)
+	for _, sub := range c.subcommands {
+		name := sub.name
+		if len(sub.aliases) > 0 {
+			name += " (" + strings.Join(sub.aliases, ", ") + ")"
+		}
+		fmt.Fprintf(w, "  %-28s %s\n", name, sub.summary)
+	}
+	fmt.Fprintf(w, "\nUse \"%s <command> -h\" for more information about a command.\n", path)
+}
+
+// globalOptions are the flags accepted by all the commands.
+type globalOptions struct {
+	configPath string
+	context    string
+	address    string
+	username   string
+	password   string
+	token      string
+	database   string
+	tls        bool
+	output     string
+	timeout    time.Duration
+}
+
+// register registers the global flags, the current values are the defaults,
+// so the flags parsed before the command are kept.
+func (g *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.configPath, "config", g.configPath, "path of the milvusctl config, default $MILVUSCTL_CONFIG or ~/.milvusctl/config.yaml")
+	fs.StringVar(&g.context, "context", g.context, "name of the context to use, default the current context")
+	fs.StringVar(&g.address, "address", g.address, "address of milvus, overrides the context")
+	fs.StringVar(&g.username, "username", g.username, "username of milvus, overrides the context")
+	fs.StringVar(&g.password, "password", g.password, "password of milvus, overrides the context")
+	fs.StringVar(&g.token, "token", g.token, "token or api key of milvus, overrides the context")
+	fs.StringVar(&g.database, "db", g.database, "database to use, overrides the context")
+	fs.BoolVar(&g.tls, "tls", g.tls, "enable tls, overrides the context")
+	fs.StringVar(&g.output, "output", g.output, "output format: table, json or yaml")
+	fs.StringVar(&g.output, "o", g.output, "shorthand of -output")
+	fs.DurationVar(&g.timeout, "timeout", g.timeout, "timeout of connecting to milvus")
+}
+
+// invocation is the run of a leaf command.
+type invocation struct {
+	ctx    context.Context
+	path   string
+	cmd    *command
+	flags  *flag.FlagSet
+	args   []string
+	rest   []string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	global *globalOptions
+	client *milvusclient.Client
+}
+
+// parse parses the flags and the positional arguments, which are named by names.
+// The optional names are in brackets, e.g. "[NAME]", and the last name ending with "..." takes the remaining arguments.
+func (inv *invocation) parse(names ...string) error {
+	var positional []string
+	rest := inv.rest
+	for {
+		if err := inv.flags.Parse(rest); err != nil {
+			return err
+		}
+		rest = inv.flags.Args()
+		if len(rest) == 0 {
+			break
+		}
+		positional = append(positional, rest[0])
+		rest = rest[1:]
+	}
+
+	required, variadic := 0, false
+	for _, name := range names {
+		if !strings.HasPrefix(name, "[") {
+			required++
+		}
+		variadic = variadic || strings.HasSuffix(name, "...")
+	}
+	if len(positional) < required || (!variadic && len(positional) > len(names)) {
+		return fmt.Errorf("%w: expected arguments %s, got %q", errUsage, strings.Join(names, " "), positional)
+	}
+	inv.args = positional
+	return nil
+}
+
+// arg returns the i-th positional argument, or empty string for the absent optional argument.
+func (inv *invocation) arg(i int) string {
+	if i < len(inv.args) {
+		return inv.args[i]
+	}
+	return ""
+}
+
+// connect returns the client connected to the milvus of the resolved context.
+func (inv *invocation) connect() (*milvusclient.Client, error) {
+	if inv.client != nil {
+		return inv.client, nil
+	}
+	cfg, err := inv.clientConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(inv.ctx, inv.global.timeout)
+	defer cancel()
+	cli, err := milvusclient.New(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.Address, err)
+	}
+	inv.client = cli
+	return cli, nil
+}
+
+func (inv *invocation) clientConfig() (*milvusclient.ClientConfig, error) {
+	profile, err := inv.profile()
+	if err != nil {
+		return nil, err
+	}
+	return &milvusclient.ClientConfig{
+		Address:       profile.Address,
+		Username:      profile.Username,
+		Password:      profile.Password,
+		APIKey:        profile.Token,
+		DBName:        profile.Database,
+		EnableTLSAuth: profile.TLS,
+	}, nil
+}
+
+// profile returns the context selected by the flags, the explicit flags override the fields of the context.
+func (inv *invocation) profile() (*Context, error) {
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return nil, err
+	}
+	name := inv.global.context
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	profile := &Context{Address: defaultAddress}
+	if name != "" {
+		found := cfg.context(name)
+		if found == nil {
+			return nil, fmt.Errorf("context %s not found", name)
+		}
+		clone := *found
+		profile = &clone
+	}
+	g := inv.global
+	for _, override := range []struct {
+		value  string
+		target *string
+	}{
+		{g.address, &profile.Address},
+		{g.username, &profile.Username},
+		{g.password, &profile.Password},
+		{g.token, &profile.Token},
+		{g.database, &profile.Database},
+	} {
+		if override.value != "" {
+			*override.target = override.value
+		}
+	}
+	profile.TLS = profile.TLS || g.tls
+	return profile, nil
+}
+
+func (inv *invocation) print(value any, header []string, rows [][]string) error {
+	p, err := newPrinter(inv.global.output, inv.stdout)
+	if err != nil {
+		return err
+	}
+	return p.print(value, header, rows)
+}
+
+// done prints the message of the succeeded operation, which has no result.
+func (inv *invocation) done(format string, args ...any) error {
+	if inv.global.output != "" && inv.global.output != outputTable {
+		return inv.print(map[string]string{"message": fmt.Sprintf(format, args...)}, nil, nil)
+	}
+	_, err := fmt.Fprintf(inv.stdout, format+"\n", args...)
+	return err
+}
+
+// run runs the command line and returns the exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	global := &globalOptions{output: outputTable, timeout: 10 * time.Second}
+	cmd, path := rootCommand(), "milvusctl"
+
+	for cmd.run == nil {
+		fs := flag.NewFlagSet(path, flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		global.register(fs)
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				cmd.printHelp(stdout, path)
+				return exitOK
+			}
+			fmt.Fprintf(stderr, "Error: %s\n\n", err)
+			cmd.printHelp(stderr, path)
+			return exitUsage
+		}
+		args = fs.Args()
+		if len(args) == 0 || args[0] == "help" {
+			cmd.printHelp(stdout, path)
+			return exitOK
+		}
+		sub := cmd.find(args[0])
+		if sub == nil {
+			fmt.Fprintf(stderr, "Error: unknown command %q for %s\n\n", args[0], path)
+			cmd.printHelp(stderr, path)
+			return exitUsage
+		}
+		cmd, path, args = sub, path+" "+sub.name, args[1:]
+	}
+
+	fs := flag.NewFlagSet(path, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	global.register(fs)
+	fs.Usage = func() {
+		cmd.printHelp(stderr, path)
+		fmt.Fprintln(stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	inv := &invocation{
+		ctx:    ctx,
+		path:   path,
+		cmd:    cmd,
+		flags:  fs,
+		rest:   args,
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		global: global,
+	}
+	err := cmd.run(inv)
+	if inv.client != nil {
+		inv.client.Close(context.Background())
+	}
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "Error: %s\n\n", err)
+		fs.Usage()
+		return exitUsage
+	default:
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return exitError
+	}
+}
+
+// kvFlag is the repeatable flag of key=value pairs.
+type kvFlag map[string]string
+
+func (f kvFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for k, v := range f {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f kvFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("expect key=value, got %q", value)
+	}
+	f[k] = v
+	return nil
+}
+
+// listFlag is the repeatable flag, which also accepts comma separated values.
+type listFlag []string
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *listFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*f = append(*f, item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultAddress = "localhost:19530"
+	configEnv      = "MILVUSCTL_CONFIG"
+)
+
+// Config is the milvusctl config, which keeps the named contexts of the milvus clusters like kubeconfig.
+//
+//	current-context: prod
+//	contexts:
+//	  - name: prod
+//	    address: https://milvus.example.com:19530
+//	    token: user:password
+//	    database: default
+type Config struct {
+	CurrentContext string     `yaml:"current-context" json:"current-context"`
+	Contexts       []*Context `yaml:"contexts" json:"contexts"`
+}
+
+// Context is the connection profile of a milvus cluster.
+type Context struct {
+	Name     string `yaml:"name" json:"name"`
+	Address  string `yaml:"address" json:"address"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+	Token    string `yaml:"token,omitempty" json:"token,omitempty"`
+	Database string `yaml:"database,omitempty" json:"database,omitempty"`
+	TLS      bool   `yaml:"tls,omitempty" json:"tls,omitempty"`
+	// RESTAddress is the address of the restful api used by import, default the address with http scheme.
+	RESTAddress string `yaml:"rest-address,omitempty" json:"rest-address,omitempty"`
+}
+
+func (c *Config) context(name string) *Context {
+	for _, ctx := range c.Contexts {
+		if ctx.Name == name {
+			return ctx
+		}
+	}
+	return nil
+}
+
+func configPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	if path = os.Getenv(configEnv); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".milvusctl", "config.yaml"), nil
+}
+
+// loadConfig loads the config, the empty config is returned if the file does not exist.
+func loadConfig(path string) (*Config, error) {
+	path, err := configPath(path)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	if err := yaml.Unmarshal(bs, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// saveConfig saves the config, which is only readable by the owner as it contains the credentials.
+func saveConfig(path string, cfg *Config) error {
+	path, err := configPath(path)
+	if err != nil {
+		return err
+	}
+	bs, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, bs, 0o600)
+}
+
+func configCommand() *command {
+	return &command{
+		name:    "config",
+		summary: "Manage the contexts of milvus clusters",
+		subcommands: []*command{
+			{name: "view", summary: "Show the config", run: configView},
+			{name: "get-contexts", summary: "List the contexts", run: configGetContexts},
+			{name: "current-context", summary: "Show the current context", run: configCurrentContext},
+			{name: "use-context", usage: "NAME", summary: "Set the current context", run: configUseContext},
+			{name: "set-context", usage: "NAME", summary: "Create or update a context with the connection flags", run: configSetContext},
+			{name: "delete-context", usage: "NAME", summary: "Delete a context", run: configDeleteContext},
+		},
+	}
+}
+
+// redacted returns the copy of the config without the credentials.
+func (c *Config) redacted() *Config {
+	clone := &Config{CurrentContext: c.CurrentContext}
+	for _, ctx := range c.Contexts {
+		ctxClone := *ctx
+		if ctxClone.Password != "" {
+			ctxClone.Password = "REDACTED"
+		}
+		if ctxClone.Token != "" {
+			ctxClone.Token = "REDACTED"
+		}
+		clone.Contexts = append(clone.Contexts, &ctxClone)
+	}
+	return clone
+}
+
+func configView(inv *invocation) error {
+	raw := inv.flags.Bool("raw", false, "show the credentials")
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return err
+	}
+	if !*raw {
+		cfg = cfg.redacted()
+	}
+	if inv.global.output == outputTable {
+		bs, err := yaml.Marshal(cfg)
+		if err != nil {
+			return err
+		}
+		_, err = inv.stdout.Write(bs)
+		return err
+	}
+	return inv.print(cfg, nil, nil)
+}
+
+func configGetContexts(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(cfg.Contexts))
+	for _, ctx := range cfg.Contexts {
+		current := ""
+		if ctx.Name == cfg.CurrentContext {
+			current = "*"
+		}
+		rows = append(rows, []string{current, ctx.Name, ctx.Address, ctx.Username, ctx.Database})
+	}
+	return inv.print(cfg.redacted().Contexts, []string{"CURRENT", "NAME", "ADDRESS", "USERNAME", "DATABASE"}, rows)
+}
+
+func configCurrentContext(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return err
+	}
+	if cfg.CurrentContext == "" {
+		return errors.New("current context is not set")
+	}
+	return inv.done("%s", cfg.CurrentContext)
+}
+
+func configUseContext(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return err
+	}
+	if cfg.context(inv.arg(0)) == nil {
+		return fmt.Errorf("context %s not found", inv.arg(0))
+	}
+	cfg.CurrentContext = inv.arg(0)
+	if err := saveConfig(inv.global.configPath, cfg); err != nil {
+		return err
+	}
+	return inv.done("Switched to context %q.", inv.arg(0))
+}
+
+func configSetContext(inv *invocation) error {
+	restAddress := inv.flags.String("rest-address", "", "address of the restful api used by import")
+	current := inv.flags.Bool("current", false, "set the context as the current context")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return err
+	}
+	name := inv.arg(0)
+	ctx := cfg.context(name)
+	created := ctx == nil
+	if created {
+		ctx = &Context{Name: name, Address: defaultAddress}
+		cfg.Contexts = append(cfg.Contexts, ctx)
+	}
+	// only the flags set explicitly are updated
+	var parseErr error
+	inv.flags.Visit(func(f *flag.Flag) {
+		value := f.Value.String()
+		switch f.Name {
+		case "address":
+			ctx.Address = value
+		case "username":
+			ctx.Username = value
+		case "password":
+			ctx.Password = value
+		case "token":
+			ctx.Token = value
+		case "db":
+			ctx.Database = value
+		case "tls":
+			ctx.TLS, parseErr = strconv.ParseBool(value)
+		case "rest-address":
+			ctx.RESTAddress = *restAddress
+		}
+	})
+	if parseErr != nil {
+		return parseErr
+	}
+	if *current || cfg.CurrentContext == "" {
+		cfg.CurrentContext = name
+	}
+	if err := saveConfig(inv.global.configPath, cfg); err != nil {
+		return err
+	}
+	if created {
+		return inv.done("Context %q created.", name)
+	}
+	return inv.done("Context %q modified.", name)
+}
+
+func configDeleteContext(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return err
+	}
+	name := inv.arg(0)
+	if cfg.context(name) == nil {
+		return fmt.Errorf("context %s not found", name)
+	}
+	contexts := cfg.Contexts[:0]
+	for _, ctx := range cfg.Contexts {
+		if ctx.Name != name {
+			contexts = append(contexts, ctx)
+		}
+	}
+	cfg.Contexts = contexts
+	if cfg.CurrentContext == name {
+		cfg.CurrentContext = ""
+	}
+	if err := saveConfig(inv.global.configPath, cfg); err != nil {
+		return err
+	}
+	return inv.done("Context %q deleted.", name)
+}
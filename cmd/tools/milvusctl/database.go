@@ -0,0 +1,159 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func databaseCommand() *command {
+	return &command{
+		name:    "database",
+		aliases: []string{"db"},
+		summary: "Manage databases",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, summary: "List databases", run: databaseList},
+			{name: "create", usage: "NAME", summary: "Create a database", run: databaseCreate},
+			{name: "describe", usage: "NAME", summary: "Describe a database", run: databaseDescribe},
+			{name: "alter", usage: "NAME", summary: "Alter the properties of a database", run: databaseAlter},
+			{name: "drop", usage: "NAME", summary: "Drop a database", run: databaseDrop},
+			{name: "use", usage: "NAME", summary: "Set the database of the current context", run: databaseUse},
+		},
+	}
+}
+
+func databaseList(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListDatabase(inv.ctx, milvusclient.NewListDatabaseOption())
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"NAME"}, nameRows(names))
+}
+
+func databaseCreate(inv *invocation) error {
+	properties := kvFlag{}
+	inv.flags.Var(properties, "property", "property of the database, key=value, repeatable")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewCreateDatabaseOption(inv.arg(0))
+	for k, v := range properties {
+		opt.WithProperty(k, v)
+	}
+	if err := cli.CreateDatabase(inv.ctx, opt); err != nil {
+		return err
+	}
+	return inv.done("Database %q created.", inv.arg(0))
+}
+
+func databaseDescribe(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	db, err := cli.DescribeDatabase(inv.ctx, milvusclient.NewDescribeDatabaseOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(db, []string{"NAME", "PROPERTIES"}, [][]string{{db.Name, formatMap(db.Properties)}})
+}
+
+func databaseAlter(inv *invocation) error {
+	properties := kvFlag{}
+	var dropKeys listFlag
+	inv.flags.Var(properties, "property", "property to set, key=value, repeatable")
+	inv.flags.Var(&dropKeys, "drop-property", "key of the property to drop, repeatable")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	if len(properties) == 0 && len(dropKeys) == 0 {
+		return fmt.Errorf("%w: -property or -drop-property is required", errUsage)
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if len(properties) > 0 {
+		opt := milvusclient.NewAlterDatabasePropertiesOption(inv.arg(0))
+		for k, v := range properties {
+			opt.WithProperty(k, v)
+		}
+		if err := cli.AlterDatabaseProperties(inv.ctx, opt); err != nil {
+			return err
+		}
+	}
+	if len(dropKeys) > 0 {
+		if err := cli.DropDatabaseProperties(inv.ctx, milvusclient.NewDropDatabasePropertiesOption(inv.arg(0), dropKeys...)); err != nil {
+			return err
+		}
+	}
+	return inv.done("Database %q altered.", inv.arg(0))
+}
+
+func databaseDrop(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropDatabase(inv.ctx, milvusclient.NewDropDatabaseOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Database %q dropped.", inv.arg(0))
+}
+
+func databaseUse(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(inv.global.configPath)
+	if err != nil {
+		return err
+	}
+	name := inv.global.context
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	ctx := cfg.context(name)
+	if ctx == nil {
+		return errors.New("no context to update, create one with \"milvusctl config set-context\"")
+	}
+	ctx.Database = inv.arg(0)
+	if err := saveConfig(inv.global.configPath, cfg); err != nil {
+		return err
+	}
+	return inv.done("Context %q now uses database %q.", name, inv.arg(0))
+}
@@ -0,0 +1,242 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/client/v3/bulkwriter"
+)
+
+const (
+	importStateCompleted = "Completed"
+	importStateFailed    = "Failed"
+)
+
+func importCommand() *command {
+	return &command{
+		name:    "import",
+		summary: "Manage bulk import jobs through the restful api",
+		subcommands: []*command{
+			{name: "create", usage: "COLLECTION", summary: "Create an import job of the files in the object storage", run: importCreate},
+			{name: "list", aliases: []string{"ls"}, usage: "COLLECTION", summary: "List the import jobs of a collection", run: importList},
+			{name: "describe", usage: "JOB_ID", summary: "Show the progress of an import job", run: importDescribe},
+			{name: "abort", usage: "JOB_ID", summary: "Abort an import job", run: importAbort},
+		},
+	}
+}
+
+// restEndpoint returns the address of the restful api and the api key of the resolved context.
+func (inv *invocation) restEndpoint() (string, string, error) {
+	profile, err := inv.profile()
+	if err != nil {
+		return "", "", err
+	}
+	apiKey := profile.Token
+	if apiKey == "" && profile.Username != "" {
+		apiKey = profile.Username + ":" + profile.Password
+	}
+	if profile.RESTAddress != "" {
+		return strings.TrimSuffix(profile.RESTAddress, "/"), apiKey, nil
+	}
+	address := profile.Address
+	if !strings.Contains(address, "://") {
+		scheme := "http://"
+		if profile.TLS {
+			scheme = "https://"
+		}
+		address = scheme + address
+	}
+	return strings.TrimSuffix(address, "/"), apiKey, nil
+}
+
+// fileGroupsFlag is the repeatable flag of the import files, each value is a group of comma separated files.
+type fileGroupsFlag [][]string
+
+func (f *fileGroupsFlag) String() string {
+	groups := make([]string, 0, len(*f))
+	for _, group := range *f {
+		groups = append(groups, strings.Join(group, ","))
+	}
+	return strings.Join(groups, " ")
+}
+
+func (f *fileGroupsFlag) Set(value string) error {
+	var group listFlag
+	if err := group.Set(value); err != nil {
+		return err
+	}
+	if len(group) == 0 {
+		return fmt.Errorf("empty file")
+	}
+	*f = append(*f, group)
+	return nil
+}
+
+func importCreate(inv *invocation) error {
+	var files fileGroupsFlag
+	inv.flags.Var(&files, "file", "file to import, repeatable, the comma separated files are imported as one group, e.g. the numpy files of the fields")
+	partition := inv.flags.String("partition", "", "partition to import into")
+	options := kvFlag{}
+	inv.flags.Var(options, "option", "import option, key=value, repeatable")
+	watch := inv.flags.Bool("watch", false, "watch the progress until the job is done")
+	interval := inv.flags.Duration("interval", 2*time.Second, "interval of watching the progress")
+	if err := inv.parse("COLLECTION"); err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("%w: -file is required", errUsage)
+	}
+	uri, apiKey, err := inv.restEndpoint()
+	if err != nil {
+		return err
+	}
+	opt := bulkwriter.NewBulkImportOption(uri, inv.arg(0), files).WithAPIKey(apiKey)
+	if *partition != "" {
+		opt.WithPartition(*partition)
+	}
+	for k, v := range options {
+		opt.WithOption(k, v)
+	}
+	resp, err := bulkwriter.BulkImport(inv.ctx, opt)
+	if err != nil {
+		return err
+	}
+	if *watch {
+		return inv.watchImport(uri, apiKey, resp.Data.JobID, *interval)
+	}
+	return inv.print(resp.Data, []string{"JOB_ID"}, [][]string{{resp.Data.JobID}})
+}
+
+func importList(inv *invocation) error {
+	if err := inv.parse("COLLECTION"); err != nil {
+		return err
+	}
+	uri, apiKey, err := inv.restEndpoint()
+	if err != nil {
+		return err
+	}
+	resp, err := bulkwriter.ListImportJobs(inv.ctx, bulkwriter.NewListImportJobsOption(uri, inv.arg(0)).WithAPIKey(apiKey))
+	if err != nil {
+		return err
+	}
+	var records []*bulkwriter.ImportJobRecord
+	if resp.Data != nil {
+		records = resp.Data.Records
+	}
+	rows := make([][]string, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, []string{record.JobID, record.CollectionName, record.State, fmt.Sprintf("%d%%", record.Progress), record.Reason})
+	}
+	return inv.print(records, []string{"JOB_ID", "COLLECTION", "STATE", "PROGRESS", "REASON"}, rows)
+}
+
+func importDescribe(inv *invocation) error {
+	watch := inv.flags.Bool("watch", false, "watch the progress until the job is done")
+	interval := inv.flags.Duration("interval", 2*time.Second, "interval of watching the progress")
+	if err := inv.parse("JOB_ID"); err != nil {
+		return err
+	}
+	uri, apiKey, err := inv.restEndpoint()
+	if err != nil {
+		return err
+	}
+	if *watch {
+		return inv.watchImport(uri, apiKey, inv.arg(0), *interval)
+	}
+	progress, err := inv.importProgress(uri, apiKey, inv.arg(0))
+	if err != nil {
+		return err
+	}
+	return inv.printImportProgress(progress)
+}
+
+func (inv *invocation) importProgress(uri, apiKey, jobID string) (*bulkwriter.ImportProgressData, error) {
+	resp, err := bulkwriter.GetImportProgress(inv.ctx, bulkwriter.NewGetImportProgressOption(uri, jobID).WithAPIKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("import job %s not found", jobID)
+	}
+	return resp.Data, nil
+}
+
+func (inv *invocation) printImportProgress(progress *bulkwriter.ImportProgressData) error {
+	return inv.print(progress, []string{"JOB_ID", "COLLECTION", "STATE", "PROGRESS", "ROWS", "REASON"}, [][]string{{
+		progress.JobID,
+		progress.CollectionName,
+		progress.State,
+		fmt.Sprintf("%d%%", progress.Progress),
+		strconv.FormatInt(progress.ImportedRows, 10) + "/" + strconv.FormatInt(progress.TotalRows, 10),
+		progress.Reason,
+	}})
+}
+
+// watchImport polls the progress until the job is completed or failed,
+// the progress is printed on change for the table output, and only the final progress for the others.
+func (inv *invocation) watchImport(uri, apiKey, jobID string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := ""
+	for {
+		progress, err := inv.importProgress(uri, apiKey, jobID)
+		if err != nil {
+			return err
+		}
+		done := progress.State == importStateCompleted || progress.State == importStateFailed
+		if inv.global.output == outputTable {
+			line := fmt.Sprintf("%s\t%s\t%d%%\t%d/%d rows", jobID, progress.State, progress.Progress, progress.ImportedRows, progress.TotalRows)
+			if line != last {
+				fmt.Fprintln(inv.stdout, line)
+				last = line
+			}
+		} else if done {
+			if err := inv.printImportProgress(progress); err != nil {
+				return err
+			}
+		}
+		if progress.State == importStateFailed {
+			return fmt.Errorf("import job %s failed: %s", jobID, progress.Reason)
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-inv.ctx.Done():
+			return inv.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func importAbort(inv *invocation) error {
+	if err := inv.parse("JOB_ID"); err != nil {
+		return err
+	}
+	uri, apiKey, err := inv.restEndpoint()
+	if err != nil {
+		return err
+	}
+	if _, err := bulkwriter.AbortImport(inv.ctx, bulkwriter.NewAbortImportOption(uri, inv.arg(0)).WithAPIKey(apiKey)); err != nil {
+		return err
+	}
+	return inv.done("Import job %s aborted.", inv.arg(0))
+}
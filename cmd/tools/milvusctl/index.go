@@ -0,0 +1,141 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strconv"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/client/v3/index"
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func indexCommand() *command {
+	return &command{
+		name:    "index",
+		aliases: []string{"idx"},
+		summary: "Manage indexes",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, usage: "COLLECTION", summary: "List the indexes of a collection", run: indexList},
+			{name: "create", usage: "COLLECTION FIELD", summary: "Create an index on a field", run: indexCreate},
+			{name: "describe", usage: "COLLECTION INDEX", summary: "Describe an index", run: indexDescribe},
+			{name: "drop", usage: "COLLECTION INDEX", summary: "Drop an index", run: indexDrop},
+		},
+	}
+}
+
+func indexList(inv *invocation) error {
+	if err := inv.parse("COLLECTION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListIndexes(inv.ctx, milvusclient.NewListIndexOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"NAME"}, nameRows(names))
+}
+
+func indexCreate(inv *invocation) error {
+	name := inv.flags.String("name", "", "name of the index, default the field name")
+	indexType := inv.flags.String("type", string(index.AUTOINDEX), "type of the index, e.g. HNSW, IVF_FLAT, INVERTED")
+	metric := inv.flags.String("metric", "", "metric type of the vector index, e.g. COSINE, IP, L2")
+	params := kvFlag{}
+	inv.flags.Var(params, "param", "build param of the index, key=value, repeatable")
+	wait := inv.flags.Bool("wait", true, "wait until the index is built")
+	if err := inv.parse("COLLECTION", "FIELD"); err != nil {
+		return err
+	}
+	opt, err := (&indexSpec{
+		Field:      inv.arg(1),
+		Name:       *name,
+		Type:       *indexType,
+		MetricType: *metric,
+		Params:     params,
+	}).indexOption(inv.arg(0))
+	if err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	task, err := cli.CreateIndex(inv.ctx, opt)
+	if err != nil {
+		return err
+	}
+	if *wait {
+		if err := task.Await(inv.ctx); err != nil {
+			return err
+		}
+	}
+	return inv.done("Index on %s.%s created.", inv.arg(0), inv.arg(1))
+}
+
+type indexView struct {
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Params      map[string]string `json:"params"`
+	State       string            `json:"state"`
+	TotalRows   int64             `json:"totalRows"`
+	IndexedRows int64             `json:"indexedRows"`
+	PendingRows int64             `json:"pendingRows"`
+}
+
+func indexDescribe(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "INDEX"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	desc, err := cli.DescribeIndex(inv.ctx, milvusclient.NewDescribeIndexOption(inv.arg(0), inv.arg(1)))
+	if err != nil {
+		return err
+	}
+	view := &indexView{
+		Name:        desc.Name(),
+		Type:        desc.Params()[index.IndexTypeKey],
+		Params:      desc.Params(),
+		State:       commonpb.IndexState(desc.State).String(),
+		TotalRows:   desc.TotalRows,
+		IndexedRows: desc.IndexedRows,
+		PendingRows: desc.PendingIndexRows,
+	}
+	return inv.print(view, []string{"NAME", "TYPE", "STATE", "INDEXED_ROWS", "TOTAL_ROWS", "PARAMS"}, [][]string{{
+		view.Name, view.Type, view.State,
+		strconv.FormatInt(view.IndexedRows, 10), strconv.FormatInt(view.TotalRows, 10), formatMap(view.Params),
+	}})
+}
+
+func indexDrop(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "INDEX"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropIndex(inv.ctx, milvusclient.NewDropIndexOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Index %q of collection %q dropped.", inv.arg(1), inv.arg(0))
+}
@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// milvusctl is the command line tool for the everyday operations of milvus, built on the go client.
+//
+//	milvusctl config set-context local --address localhost:19530
+//	milvusctl collection create -f books.yaml
+//	milvusctl collection load books --wait
+//	milvusctl -o json collection describe books
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// rootCommand returns the command tree of milvusctl.
+func rootCommand() *command {
+	return &command{
+		name: "milvusctl",
+		subcommands: []*command{
+			configCommand(),
+			databaseCommand(),
+			collectionCommand(),
+			indexCommand(),
+			partitionCommand(),
+			aliasCommand(),
+			importCommand(),
+			snapshotCommand(),
+			roleCommand(),
+			userCommand(),
+			privilegeGroupCommand(),
+			resourceGroupCommand(),
+			replicateCommand(),
+		},
+	}
+}
@@ -0,0 +1,300 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/milvustest"
+)
+
+const testCollectionSpec = `
+name: books
+description: the book catalog
+consistencyLevel: Strong
+fields:
+  - name: id
+    type: Int64
+    primaryKey: true
+  - name: title
+    type: VarChar
+    maxLength: 256
+  - name: embedding
+    type: FloatVector
+    dim: 4
+indexes:
+  - field: embedding
+    type: HNSW
+    metricType: L2
+    params:
+      M: "16"
+`
+
+type MilvusctlSuite struct {
+	suite.Suite
+
+	server *milvustest.Server
+	dir    string
+	config string
+}
+
+func (s *MilvusctlSuite) SetupSuite() {
+	s.server = milvustest.NewServer()
+	s.Require().NoError(s.server.Start())
+}
+
+func (s *MilvusctlSuite) TearDownSuite() {
+	s.server.Stop()
+}
+
+func (s *MilvusctlSuite) SetupTest() {
+	s.server.Reset()
+	s.dir = s.T().TempDir()
+	s.config = filepath.Join(s.dir, "config.yaml")
+}
+
+// run runs milvusctl against the fake server and returns the exit code, stdout and stderr.
+func (s *MilvusctlSuite) run(args ...string) (int, string, string) {
+	return s.runWithStdin("", args...)
+}
+
+// runWithStdin runs milvusctl with the input read by the "-f -" flags.
+func (s *MilvusctlSuite) runWithStdin(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-config", s.config, "-address", s.server.Addr()}, args...)
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// mustRun runs milvusctl and requires it to succeed.
+func (s *MilvusctlSuite) mustRun(args ...string) string {
+	code, stdout, stderr := s.run(args...)
+	s.Require().Equal(exitOK, code, "milvusctl %s: %s", strings.Join(args, " "), stderr)
+	return stdout
+}
+
+func (s *MilvusctlSuite) createBooks() {
+	spec := filepath.Join(s.dir, "books.yaml")
+	s.Require().NoError(os.WriteFile(spec, []byte(testCollectionSpec), 0o600))
+	s.mustRun("collection", "create", "-f", spec)
+}
+
+func (s *MilvusctlSuite) TestHelpAndUsage() {
+	code, stdout, _ := s.run()
+	s.Equal(exitOK, code)
+	s.Contains(stdout, "collection")
+	s.Contains(stdout, "resource-group")
+
+	code, stdout, _ = s.run("help")
+	s.Equal(exitOK, code)
+	s.Contains(stdout, "Commands:")
+
+	code, _, stderr := s.run("unknown")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, `unknown command "unknown"`)
+
+	code, _, stderr = s.run("collection", "describe")
+	s.Equal(exitUsage, code)
+	s.Contains(stderr, "expected arguments NAME")
+
+	code, _, _ = s.run("collection", "list", "-o", "xml")
+	s.Equal(exitUsage, code)
+
+	code, _, _ = s.run("collection", "create", "quick")
+	s.Equal(exitUsage, code)
+}
+
+func (s *MilvusctlSuite) TestConfig() {
+	s.mustRun("config", "set-context", "local", "-address", "localhost:19531", "-username", "root", "-password", "Milvus")
+	s.mustRun("config", "set-context", "remote", "-address", "remote:19530", "-token", "secret", "-rest-address", "https://remote")
+
+	stdout := s.mustRun("config", "current-context")
+	s.Equal("local\n", stdout)
+
+	s.mustRun("config", "use-context", "remote")
+	stdout = s.mustRun("config", "get-contexts")
+	s.Contains(stdout, "* ")
+	s.Regexp(`\*\s+remote`, stdout)
+
+	stdout = s.mustRun("config", "view")
+	s.NotContains(stdout, "secret")
+	s.NotContains(stdout, "Milvus")
+	stdout = s.mustRun("config", "view", "-raw")
+	s.Contains(stdout, "secret")
+
+	code, _, stderr := s.run("config", "use-context", "missing")
+	s.Equal(exitError, code)
+	s.Contains(stderr, "missing")
+
+	s.mustRun("config", "delete-context", "remote")
+	stdout = s.mustRun("config", "get-contexts")
+	s.NotContains(stdout, "remote")
+}
+
+func (s *MilvusctlSuite) TestCollection() {
+	s.createBooks()
+
+	stdout := s.mustRun("collection", "list")
+	s.Contains(stdout, "books")
+
+	stdout = s.mustRun("collection", "describe", "books", "-o", "json")
+	var view collectionView
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &view))
+	s.Equal("books", view.Name)
+	s.Equal("Strong", view.ConsistencyLevel)
+	s.Require().Len(view.Fields, 3)
+	s.True(view.Fields[0].PrimaryKey)
+	s.Equal("4", view.Fields[2].TypeParams["dim"])
+
+	stdout = s.mustRun("collection", "describe", "books")
+	s.Contains(stdout, "embedding")
+	s.Contains(stdout, "FloatVector")
+
+	stdout = s.mustRun("index", "list", "books")
+	s.Contains(stdout, "embedding")
+	stdout = s.mustRun("index", "describe", "books", "embedding", "-o", "yaml")
+	s.Contains(stdout, "type: HNSW")
+	s.Contains(stdout, "state: Finished")
+
+	s.mustRun("collection", "load", "books")
+	stdout = s.mustRun("collection", "load-state", "books")
+	s.Contains(stdout, "Loaded")
+	s.mustRun("collection", "release", "books")
+	stdout = s.mustRun("collection", "load-state", "books", "-o", "json")
+	s.Contains(stdout, "NotLoad")
+
+	s.mustRun("collection", "drop", "books")
+	code, _, _ := s.run("collection", "describe", "books")
+	s.Equal(exitError, code)
+}
+
+func (s *MilvusctlSuite) TestReadStdin() {
+	code, _, stderr := s.runWithStdin(testCollectionSpec, "collection", "create", "-f", "-")
+	s.Require().Equal(exitOK, code, stderr)
+	stdout := s.mustRun("collection", "describe", "books")
+	s.Contains(stdout, "embedding")
+
+	// the replicate configuration is read from the input of the invocation
+	code, _, stderr = s.runWithStdin("clusters: [", "replicate", "update-config", "-f", "-")
+	s.Equal(exitError, code)
+	s.Contains(stderr, "invalid replicate configuration -")
+}
+
+func (s *MilvusctlSuite) TestQuickCollection() {
+	s.mustRun("collection", "create", "quick", "-dim", "8", "-metric", "l2")
+	stdout := s.mustRun("collection", "describe", "quick", "-o", "json")
+	s.Contains(stdout, `"dim": "8"`)
+}
+
+func (s *MilvusctlSuite) TestPartitionAndAlias() {
+	s.createBooks()
+
+	s.mustRun("partition", "create", "books", "novels")
+	stdout := s.mustRun("partition", "list", "books")
+	s.Contains(stdout, "_default")
+	s.Contains(stdout, "novels")
+	s.mustRun("part", "drop", "books", "novels")
+	stdout = s.mustRun("partition", "list", "books", "-o", "json")
+	s.NotContains(stdout, "novels")
+
+	s.mustRun("alias", "create", "books", "library")
+	stdout = s.mustRun("alias", "describe", "library")
+	s.Contains(stdout, "books")
+	stdout = s.mustRun("alias", "list", "books")
+	s.Contains(stdout, "library")
+	s.mustRun("alias", "drop", "library")
+	stdout = s.mustRun("alias", "list", "books")
+	s.NotContains(stdout, "library")
+}
+
+func (s *MilvusctlSuite) TestDoneOutput() {
+	s.createBooks()
+	stdout := s.mustRun("partition", "create", "books", "novels", "-o", "json")
+	var result map[string]string
+	s.Require().NoError(json.Unmarshal([]byte(stdout), &result))
+	s.Equal(`Partition "novels" of collection "books" created.`, result["message"])
+}
+
+func TestMilvusctl(t *testing.T) {
+	suite.Run(t, new(MilvusctlSuite))
+}
+
+func TestParseCollectionSpec(t *testing.T) {
+	spec, err := parseCollectionSpec([]byte(testCollectionSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := spec.createOption(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string]string{
+		"unknown key":     "name: c\nunknown: 1\n",
+		"unknown type":    "name: c\nfields:\n  - name: id\n    type: Int128\n    primaryKey: true\n",
+		"no name":         "fields:\n  - name: id\n    type: Int64\n    primaryKey: true\n",
+		"bad consistency": "name: c\nconsistencyLevel: Weak\nfields:\n  - name: id\n    type: Int64\n    primaryKey: true\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := parseCollectionSpec([]byte(data))
+			if err == nil {
+				_, err = spec.createOption()
+			}
+			if err == nil {
+				t.Fatalf("expect error for %s", name)
+			}
+		})
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	value := map[string]any{"name": "books", "rows": 3}
+	header := []string{"NAME", "ROWS"}
+	rows := [][]string{{"books", "3"}}
+
+	var buf bytes.Buffer
+	p, err := newPrinter(outputTable, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.print(value, header, rows); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "NAME    ROWS\nbooks   3\n" {
+		t.Fatalf("unexpected table %q", got)
+	}
+
+	buf.Reset()
+	p, _ = newPrinter(outputYAML, &buf)
+	if err := p.print(value, header, rows); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "name: books\nrows: 3\n" {
+		t.Fatalf("unexpected yaml %q", got)
+	}
+
+	if _, err := newPrinter("xml", &buf); err == nil {
+		t.Fatal("expect error of unknown format")
+	}
+}
@@ -0,0 +1,144 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func partitionCommand() *command {
+	return &command{
+		name:    "partition",
+		aliases: []string{"part"},
+		summary: "Manage partitions",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, usage: "COLLECTION", summary: "List the partitions of a collection", run: partitionList},
+			{name: "create", usage: "COLLECTION PARTITION", summary: "Create a partition", run: partitionCreate},
+			{name: "drop", usage: "COLLECTION PARTITION", summary: "Drop a partition", run: partitionDrop},
+			{name: "load", usage: "COLLECTION PARTITION...", summary: "Load partitions", run: partitionLoad},
+			{name: "release", usage: "COLLECTION PARTITION...", summary: "Release partitions", run: partitionRelease},
+			{name: "stats", usage: "COLLECTION PARTITION", summary: "Show the statistics of a partition", run: partitionStats},
+		},
+	}
+}
+
+func partitionList(inv *invocation) error {
+	if err := inv.parse("COLLECTION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListPartitions(inv.ctx, milvusclient.NewListPartitionOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"NAME"}, nameRows(names))
+}
+
+func partitionCreate(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "PARTITION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.CreatePartition(inv.ctx, milvusclient.NewCreatePartitionOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Partition %q of collection %q created.", inv.arg(1), inv.arg(0))
+}
+
+func partitionDrop(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "PARTITION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropPartition(inv.ctx, milvusclient.NewDropPartitionOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Partition %q of collection %q dropped.", inv.arg(1), inv.arg(0))
+}
+
+func partitionLoad(inv *invocation) error {
+	replicas := inv.flags.Int("replicas", 0, "number of replicas, default the server config")
+	var resourceGroups listFlag
+	inv.flags.Var(&resourceGroups, "resource-group", "resource groups to load the replicas into, repeatable")
+	wait := inv.flags.Bool("wait", true, "wait until the partitions are fully loaded")
+	if err := inv.parse("COLLECTION", "PARTITION..."); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	partitions := inv.args[1:]
+	opt := milvusclient.NewLoadPartitionsOption(inv.arg(0), partitions...)
+	if *replicas > 0 {
+		opt.WithReplica(*replicas)
+	}
+	if len(resourceGroups) > 0 {
+		opt.WithResourceGroup(resourceGroups...)
+	}
+	task, err := cli.LoadPartitions(inv.ctx, opt)
+	if err != nil {
+		return err
+	}
+	if *wait {
+		if err := task.Await(inv.ctx); err != nil {
+			return err
+		}
+	}
+	return inv.done("Partitions %s of collection %q loaded.", strings.Join(partitions, ","), inv.arg(0))
+}
+
+func partitionRelease(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "PARTITION..."); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	partitions := inv.args[1:]
+	if err := cli.ReleasePartitions(inv.ctx, milvusclient.NewReleasePartitionsOptions(inv.arg(0), partitions...)); err != nil {
+		return err
+	}
+	return inv.done("Partitions %s of collection %q released.", strings.Join(partitions, ","), inv.arg(0))
+}
+
+func partitionStats(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "PARTITION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	stats, err := cli.GetPartitionStats(inv.ctx, milvusclient.NewGetPartitionStatsOption(inv.arg(0), inv.arg(1)))
+	if err != nil {
+		return err
+	}
+	return inv.print(stats, []string{"KEY", "VALUE"}, mapRows(stats))
+}
@@ -0,0 +1,127 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// printer prints the results in the output format.
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case "", outputTable:
+		format = outputTable
+	case outputJSON, outputYAML:
+	default:
+		return nil, fmt.Errorf("%w: unknown output format %q, expect table, json or yaml", errUsage, format)
+	}
+	return &printer{format: format, w: w}, nil
+}
+
+// print prints the value in json or yaml, or the table of the header and the rows in table format.
+// The yaml output has the same keys as json, and the proto messages are printed with protojson.
+func (p *printer) print(value any, header []string, rows [][]string) error {
+	switch p.format {
+	case outputJSON:
+		bs, err := marshalJSON(value)
+		if err != nil {
+			return err
+		}
+		var out bytes.Buffer
+		if err := json.Indent(&out, bs, "", "  "); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+		_, err = out.WriteTo(p.w)
+		return err
+	case outputYAML:
+		bs, err := marshalJSON(value)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err := json.Unmarshal(bs, &generic); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(out)
+		return err
+	default:
+		return p.printTable(header, rows)
+	}
+}
+
+func (p *printer) printTable(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 0, 3, ' ', 0)
+	if len(header) > 0 {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func marshalJSON(value any) ([]byte, error) {
+	if msg, ok := value.(proto.Message); ok {
+		return protojson.Marshal(msg)
+	}
+	return json.Marshal(value)
+}
+
+// formatMap formats the map as the sorted "k=v" pairs for the table.
+func formatMap[V any](m map[string]V) string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// nameRows returns the rows of the single column names.
+func nameRows(names []string) [][]string {
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []string{name})
+	}
+	return rows
+}
@@ -0,0 +1,366 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func roleCommand() *command {
+	return &command{
+		name:    "role",
+		summary: "Manage roles and their privileges",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, summary: "List the roles", run: roleList},
+			{name: "create", usage: "ROLE", summary: "Create a role", run: roleCreate},
+			{name: "describe", usage: "ROLE", summary: "Show the privileges granted to a role", run: roleDescribe},
+			{name: "drop", usage: "ROLE", summary: "Drop a role", run: roleDrop},
+			{name: "grant", usage: "ROLE PRIVILEGE", summary: "Grant a privilege or privilege group to a role", run: roleGrant},
+			{name: "revoke", usage: "ROLE PRIVILEGE", summary: "Revoke a privilege or privilege group from a role", run: roleRevoke},
+		},
+	}
+}
+
+func roleList(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListRoles(inv.ctx, milvusclient.NewListRoleOption())
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"ROLE"}, nameRows(names))
+}
+
+func roleCreate(inv *invocation) error {
+	if err := inv.parse("ROLE"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.CreateRole(inv.ctx, milvusclient.NewCreateRoleOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Role %q created.", inv.arg(0))
+}
+
+func roleDescribe(inv *invocation) error {
+	db := inv.flags.String("grant-db", "", "only show the grants on the database")
+	if err := inv.parse("ROLE"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewDescribeRoleOption(inv.arg(0))
+	if *db != "" {
+		opt.WithDbName(*db)
+	}
+	role, err := cli.DescribeRole(inv.ctx, opt)
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(role.Privileges))
+	for _, grant := range role.Privileges {
+		rows = append(rows, []string{grant.DbName, grant.Object, grant.ObjectName, grant.Privilege, grant.Grantor})
+	}
+	return inv.print(role, []string{"DATABASE", "OBJECT", "OBJECT_NAME", "PRIVILEGE", "GRANTOR"}, rows)
+}
+
+func roleDrop(inv *invocation) error {
+	force := inv.flags.Bool("force", false, "drop the role even if it still has grants")
+	if err := inv.parse("ROLE"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropRole(inv.ctx, milvusclient.NewDropRoleOption(inv.arg(0)).WithForce(*force)); err != nil {
+		return err
+	}
+	return inv.done("Role %q dropped.", inv.arg(0))
+}
+
+// grantFlags registers the flags of the grant scope shared by role grant and revoke.
+func grantFlags(inv *invocation) (db, collection *string) {
+	db = inv.flags.String("grant-db", "*", "database of the grant, * for all databases")
+	collection = inv.flags.String("collection", "*", "collection of the grant, * for all collections")
+	return db, collection
+}
+
+func roleGrant(inv *invocation) error {
+	db, collection := grantFlags(inv)
+	if err := inv.parse("ROLE", "PRIVILEGE"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.GrantV2(inv.ctx, milvusclient.NewGrantV2Option(inv.arg(0), inv.arg(1), *db, *collection)); err != nil {
+		return err
+	}
+	return inv.done("Privilege %s on %s.%s granted to role %q.", inv.arg(1), *db, *collection, inv.arg(0))
+}
+
+func roleRevoke(inv *invocation) error {
+	db, collection := grantFlags(inv)
+	if err := inv.parse("ROLE", "PRIVILEGE"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.RevokeV2(inv.ctx, milvusclient.NewRevokeV2Option(inv.arg(0), inv.arg(1), *db, *collection)); err != nil {
+		return err
+	}
+	return inv.done("Privilege %s on %s.%s revoked from role %q.", inv.arg(1), *db, *collection, inv.arg(0))
+}
+
+func userCommand() *command {
+	return &command{
+		name:    "user",
+		summary: "Manage users",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, summary: "List the users", run: userList},
+			{name: "create", usage: "USER", summary: "Create a user", run: userCreate},
+			{name: "describe", usage: "USER", summary: "Show the roles of a user", run: userDescribe},
+			{name: "drop", usage: "USER", summary: "Drop a user", run: userDrop},
+			{name: "passwd", usage: "USER", summary: "Change the password of a user", run: userPasswd},
+			{name: "grant-role", usage: "USER ROLE", summary: "Grant a role to a user", run: userGrantRole},
+			{name: "revoke-role", usage: "USER ROLE", summary: "Revoke a role from a user", run: userRevokeRole},
+		},
+	}
+}
+
+func userList(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListUsers(inv.ctx, milvusclient.NewListUserOption())
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"USER"}, nameRows(names))
+}
+
+func userCreate(inv *invocation) error {
+	password := inv.flags.String("new-password", "", "password of the user")
+	description := inv.flags.String("description", "", "description of the user")
+	if err := inv.parse("USER"); err != nil {
+		return err
+	}
+	if *password == "" {
+		return fmt.Errorf("%w: -new-password is required", errUsage)
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewCreateUserOption(inv.arg(0), *password)
+	if *description != "" {
+		opt.WithDescription(*description)
+	}
+	if err := cli.CreateUser(inv.ctx, opt); err != nil {
+		return err
+	}
+	return inv.done("User %q created.", inv.arg(0))
+}
+
+func userDescribe(inv *invocation) error {
+	if err := inv.parse("USER"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	user, err := cli.DescribeUser(inv.ctx, milvusclient.NewDescribeUserOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(user, []string{"USER", "ROLES", "DESCRIPTION"}, [][]string{{user.UserName, strings.Join(user.Roles, ","), user.Description}})
+}
+
+func userDrop(inv *invocation) error {
+	if err := inv.parse("USER"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropUser(inv.ctx, milvusclient.NewDropUserOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("User %q dropped.", inv.arg(0))
+}
+
+func userPasswd(inv *invocation) error {
+	oldPassword := inv.flags.String("old-password", "", "current password of the user")
+	newPassword := inv.flags.String("new-password", "", "new password of the user")
+	if err := inv.parse("USER"); err != nil {
+		return err
+	}
+	if *newPassword == "" {
+		return fmt.Errorf("%w: -new-password is required", errUsage)
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.UpdatePassword(inv.ctx, milvusclient.NewUpdatePasswordOption(inv.arg(0), *oldPassword, *newPassword)); err != nil {
+		return err
+	}
+	return inv.done("Password of user %q updated.", inv.arg(0))
+}
+
+func userGrantRole(inv *invocation) error {
+	if err := inv.parse("USER", "ROLE"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.GrantRole(inv.ctx, milvusclient.NewGrantRoleOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Role %q granted to user %q.", inv.arg(1), inv.arg(0))
+}
+
+func userRevokeRole(inv *invocation) error {
+	if err := inv.parse("USER", "ROLE"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.RevokeRole(inv.ctx, milvusclient.NewRevokeRoleOption(inv.arg(0), inv.arg(1))); err != nil {
+		return err
+	}
+	return inv.done("Role %q revoked from user %q.", inv.arg(1), inv.arg(0))
+}
+
+func privilegeGroupCommand() *command {
+	return &command{
+		name:    "privilege-group",
+		aliases: []string{"pg"},
+		summary: "Manage privilege groups",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, summary: "List the privilege groups", run: privilegeGroupList},
+			{name: "create", usage: "GROUP", summary: "Create a privilege group", run: privilegeGroupCreate},
+			{name: "drop", usage: "GROUP", summary: "Drop a privilege group", run: privilegeGroupDrop},
+			{name: "add", usage: "GROUP PRIVILEGE...", summary: "Add privileges to a privilege group", run: privilegeGroupAdd},
+			{name: "remove", usage: "GROUP PRIVILEGE...", summary: "Remove privileges from a privilege group", run: privilegeGroupRemove},
+		},
+	}
+}
+
+func privilegeGroupList(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	groups, err := cli.ListPrivilegeGroups(inv.ctx, milvusclient.NewListPrivilegeGroupsOption())
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		rows = append(rows, []string{group.GroupName, strings.Join(group.Privileges, ",")})
+	}
+	return inv.print(groups, []string{"GROUP", "PRIVILEGES"}, rows)
+}
+
+func privilegeGroupCreate(inv *invocation) error {
+	if err := inv.parse("GROUP"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.CreatePrivilegeGroup(inv.ctx, milvusclient.NewCreatePrivilegeGroupOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Privilege group %q created.", inv.arg(0))
+}
+
+func privilegeGroupDrop(inv *invocation) error {
+	if err := inv.parse("GROUP"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropPrivilegeGroup(inv.ctx, milvusclient.NewDropPrivilegeGroupOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Privilege group %q dropped.", inv.arg(0))
+}
+
+func privilegeGroupAdd(inv *invocation) error {
+	if err := inv.parse("GROUP", "PRIVILEGE..."); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	privileges := inv.args[1:]
+	if err := cli.AddPrivilegesToGroup(inv.ctx, milvusclient.NewAddPrivilegesToGroupOption(inv.arg(0), privileges...)); err != nil {
+		return err
+	}
+	return inv.done("Privileges %s added to group %q.", strings.Join(privileges, ","), inv.arg(0))
+}
+
+func privilegeGroupRemove(inv *invocation) error {
+	if err := inv.parse("GROUP", "PRIVILEGE..."); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	privileges := inv.args[1:]
+	if err := cli.RemovePrivilegesFromGroup(inv.ctx, milvusclient.NewRemovePrivilegesFromGroupOption(inv.arg(0), privileges...)); err != nil {
+		return err
+	}
+	return inv.done("Privileges %s removed from group %q.", strings.Join(privileges, ","), inv.arg(0))
+}
@@ -0,0 +1,153 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+)
+
+func replicateCommand() *command {
+	return &command{
+		name:    "replicate",
+		summary: "Manage the cross cluster replication",
+		subcommands: []*command{
+			{name: "get-config", summary: "Show the replicate configuration", run: replicateGetConfig},
+			{name: "update-config", summary: "Update the replicate configuration from a yaml or json file", run: replicateUpdateConfig},
+			{name: "info", summary: "Show the replicate checkpoint of a target pchannel", run: replicateInfo},
+		},
+	}
+}
+
+func replicateGetConfig(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	config, err := cli.GetReplicateConfiguration(inv.ctx)
+	if err != nil {
+		return err
+	}
+	// the configuration is nested, which does not fit in a table, print it in yaml instead.
+	if inv.global.output == "" || inv.global.output == outputTable {
+		p, err := newPrinter(outputYAML, inv.stdout)
+		if err != nil {
+			return err
+		}
+		return p.print(config, nil, nil)
+	}
+	return inv.print(config, nil, nil)
+}
+
+// readReplicateConfiguration reads the configuration in yaml or json, "-" for stdin.
+// The keys are the protojson names of commonpb.ReplicateConfiguration.
+func readReplicateConfiguration(path string, stdin io.Reader) (*commonpb.ReplicateConfiguration, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// yaml is a superset of json, convert it to json for protojson.
+	var generic any
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return nil, fmt.Errorf("invalid replicate configuration %s: %w", path, err)
+	}
+	bs, err := json.Marshal(generic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid replicate configuration %s: %w", path, err)
+	}
+	config := &commonpb.ReplicateConfiguration{}
+	if err := protojson.Unmarshal(bs, config); err != nil {
+		return nil, fmt.Errorf("invalid replicate configuration %s: %w", path, err)
+	}
+	return config, nil
+}
+
+func replicateUpdateConfig(inv *invocation) error {
+	file := inv.flags.String("f", "", "yaml or json file of the replicate configuration, - for stdin")
+	forcePromote := inv.flags.Bool("force-promote", false, "promote the cluster to primary even if the source cluster is unavailable")
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", errUsage)
+	}
+	config, err := readReplicateConfiguration(*file, inv.stdin)
+	if err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	req := &milvuspb.UpdateReplicateConfigurationRequest{
+		ReplicateConfiguration: config,
+		ForcePromote:           *forcePromote,
+	}
+	if err := cli.UpdateReplicateConfiguration(inv.ctx, req); err != nil {
+		return err
+	}
+	return inv.done("Replicate configuration updated.")
+}
+
+func replicateInfo(inv *invocation) error {
+	sourceCluster := inv.flags.String("source-cluster", "", "id of the source cluster")
+	targetPChannel := inv.flags.String("target-pchannel", "", "pchannel of this cluster to show the checkpoint of")
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	if *sourceCluster == "" || *targetPChannel == "" {
+		return fmt.Errorf("%w: -source-cluster and -target-pchannel are required", errUsage)
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	resp, err := cli.GetReplicateInfo(inv.ctx, &milvuspb.GetReplicateInfoRequest{
+		SourceClusterId: *sourceCluster,
+		TargetPchannel:  *targetPChannel,
+	})
+	if err != nil {
+		return err
+	}
+	if inv.global.output == "" || inv.global.output == outputTable {
+		p, err := newPrinter(outputYAML, inv.stdout)
+		if err != nil {
+			return err
+		}
+		return p.print(resp, nil, nil)
+	}
+	return inv.print(resp, nil, nil)
+}
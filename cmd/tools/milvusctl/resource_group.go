@@ -0,0 +1,190 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func resourceGroupCommand() *command {
+	return &command{
+		name:    "resource-group",
+		aliases: []string{"rg"},
+		summary: "Manage resource groups",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, summary: "List the resource groups", run: resourceGroupList},
+			{name: "create", usage: "NAME", summary: "Create a resource group", run: resourceGroupCreate},
+			{name: "describe", usage: "NAME", summary: "Describe a resource group", run: resourceGroupDescribe},
+			{name: "update", usage: "NAME", summary: "Update the node requests and limits of a resource group", run: resourceGroupUpdate},
+			{name: "drop", usage: "NAME", summary: "Drop a resource group", run: resourceGroupDrop},
+			{name: "transfer-replica", usage: "COLLECTION SOURCE TARGET", summary: "Transfer replicas of a collection between resource groups", run: resourceGroupTransferReplica},
+		},
+	}
+}
+
+func resourceGroupList(inv *invocation) error {
+	if err := inv.parse(); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListResourceGroups(inv.ctx, milvusclient.NewListResourceGroupsOption())
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"NAME"}, nameRows(names))
+}
+
+func resourceGroupCreate(inv *invocation) error {
+	requests := inv.flags.Int("requests", 0, "number of query nodes requested by the resource group")
+	limits := inv.flags.Int("limits", 0, "max number of query nodes of the resource group")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewCreateResourceGroupOption(inv.arg(0)).WithNodeRequest(*requests).WithNodeLimit(*limits)
+	if err := cli.CreateResourceGroup(inv.ctx, opt); err != nil {
+		return err
+	}
+	return inv.done("Resource group %q created.", inv.arg(0))
+}
+
+type resourceGroupView struct {
+	Name           string            `json:"name"`
+	Capacity       int32             `json:"capacity"`
+	AvailableNodes int32             `json:"availableNodes"`
+	Requests       int32             `json:"requests"`
+	Limits         int32             `json:"limits"`
+	LoadedReplicas map[string]int32  `json:"loadedReplicas,omitempty"`
+	OutgoingNodes  map[string]int32  `json:"outgoingNodes,omitempty"`
+	IncomingNodes  map[string]int32  `json:"incomingNodes,omitempty"`
+	Nodes          []entity.NodeInfo `json:"nodes,omitempty"`
+}
+
+func resourceGroupDescribe(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	rg, err := cli.DescribeResourceGroup(inv.ctx, milvusclient.NewDescribeResourceGroupOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	view := &resourceGroupView{
+		Name:           rg.Name,
+		Capacity:       rg.Capacity,
+		AvailableNodes: rg.NumAvailableNode,
+		LoadedReplicas: rg.NumLoadedReplica,
+		OutgoingNodes:  rg.NumOutgoingNode,
+		IncomingNodes:  rg.NumIncomingNode,
+		Nodes:          rg.Nodes,
+	}
+	if rg.Config != nil {
+		view.Requests = rg.Config.Requests.NodeNum
+		view.Limits = rg.Config.Limits.NodeNum
+	}
+	return inv.print(view, []string{"NAME", "AVAILABLE_NODES", "REQUESTS", "LIMITS", "LOADED_REPLICAS"}, [][]string{{
+		view.Name,
+		strconv.Itoa(int(view.AvailableNodes)),
+		strconv.Itoa(int(view.Requests)),
+		strconv.Itoa(int(view.Limits)),
+		formatMap(view.LoadedReplicas),
+	}})
+}
+
+func resourceGroupUpdate(inv *invocation) error {
+	requests := inv.flags.Int("requests", -1, "number of query nodes requested by the resource group")
+	limits := inv.flags.Int("limits", -1, "max number of query nodes of the resource group")
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	if *requests < 0 && *limits < 0 {
+		return fmt.Errorf("%w: -requests or -limits is required", errUsage)
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	// the update replaces the whole config, so start from the current one to keep the unspecified fields.
+	rg, err := cli.DescribeResourceGroup(inv.ctx, milvusclient.NewDescribeResourceGroupOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	config := rg.Config
+	if config == nil {
+		config = &entity.ResourceGroupConfig{}
+	}
+	if *requests >= 0 {
+		config.Requests.NodeNum = int32(*requests)
+	}
+	if *limits >= 0 {
+		config.Limits.NodeNum = int32(*limits)
+	}
+	if err := cli.UpdateResourceGroup(inv.ctx, milvusclient.NewUpdateResourceGroupOption(inv.arg(0), config)); err != nil {
+		return err
+	}
+	return inv.done("Resource group %q updated.", inv.arg(0))
+}
+
+func resourceGroupDrop(inv *invocation) error {
+	if err := inv.parse("NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropResourceGroup(inv.ctx, milvusclient.NewDropResourceGroupOption(inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Resource group %q dropped.", inv.arg(0))
+}
+
+func resourceGroupTransferReplica(inv *invocation) error {
+	replicas := inv.flags.Int64("replicas", 1, "number of replicas to transfer")
+	if err := inv.parse("COLLECTION", "SOURCE", "TARGET"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	profile, err := inv.profile()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewTransferReplicaOption(inv.arg(0), inv.arg(1), inv.arg(2), *replicas)
+	if profile.Database != "" {
+		opt.WithDBName(profile.Database)
+	}
+	if err := cli.TransferReplica(inv.ctx, opt); err != nil {
+		return err
+	}
+	return inv.done("%d replicas of collection %q transferred from %q to %q.", *replicas, inv.arg(0), inv.arg(1), inv.arg(2))
+}
@@ -0,0 +1,307 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/client/v3/entity"
+	"github.com/milvus-io/milvus/client/v3/index"
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+// collectionSpec is the yaml definition of a collection, e.g.
+//
+//	name: books
+//	description: the book catalog
+//	enableDynamicField: true
+//	consistencyLevel: Bounded
+//	properties:
+//	  collection.ttl.seconds: "86400"
+//	fields:
+//	  - name: id
+//	    type: Int64
+//	    primaryKey: true
+//	    autoID: true
+//	  - name: title
+//	    type: VarChar
+//	    maxLength: 256
+//	  - name: embedding
+//	    type: FloatVector
+//	    dim: 768
+//	indexes:
+//	  - field: embedding
+//	    type: HNSW
+//	    metricType: COSINE
+//	    params:
+//	      M: "16"
+//	      efConstruction: "200"
+type collectionSpec struct {
+	Name               string            `yaml:"name"`
+	Description        string            `yaml:"description"`
+	EnableDynamicField bool              `yaml:"enableDynamicField"`
+	ShardsNum          int32             `yaml:"shardsNum"`
+	ConsistencyLevel   string            `yaml:"consistencyLevel"`
+	Properties         map[string]string `yaml:"properties"`
+	Fields             []*fieldSpec      `yaml:"fields"`
+	Indexes            []*indexSpec      `yaml:"indexes"`
+}
+
+type fieldSpec struct {
+	Name           string            `yaml:"name"`
+	Description    string            `yaml:"description"`
+	Type           string            `yaml:"type"`
+	ElementType    string            `yaml:"elementType"`
+	PrimaryKey     bool              `yaml:"primaryKey"`
+	AutoID         bool              `yaml:"autoID"`
+	PartitionKey   bool              `yaml:"partitionKey"`
+	ClusteringKey  bool              `yaml:"clusteringKey"`
+	Nullable       bool              `yaml:"nullable"`
+	DefaultValue   *string           `yaml:"defaultValue"`
+	Dim            int64             `yaml:"dim"`
+	MaxLength      int64             `yaml:"maxLength"`
+	MaxCapacity    int64             `yaml:"maxCapacity"`
+	EnableAnalyzer bool              `yaml:"enableAnalyzer"`
+	EnableMatch    bool              `yaml:"enableMatch"`
+	TypeParams     map[string]string `yaml:"typeParams"`
+}
+
+type indexSpec struct {
+	Field      string            `yaml:"field"`
+	Name       string            `yaml:"name"`
+	Type       string            `yaml:"type"`
+	MetricType string            `yaml:"metricType"`
+	Params     map[string]string `yaml:"params"`
+}
+
+// readCollectionSpec reads the spec from the file, "-" stands for stdin.
+func readCollectionSpec(path string, stdin io.Reader) (*collectionSpec, error) {
+	var bs []byte
+	var err error
+	if path == "-" {
+		bs, err = io.ReadAll(stdin)
+	} else {
+		bs, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseCollectionSpec(bs)
+}
+
+func parseCollectionSpec(bs []byte) (*collectionSpec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(bs))
+	decoder.KnownFields(true)
+	spec := &collectionSpec{}
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("failed to parse collection spec: %w", err)
+	}
+	return spec, nil
+}
+
+// parseDataType parses the data type name case-insensitively, e.g. "FloatVector" or "varchar".
+func parseDataType(name string) (entity.FieldType, error) {
+	for typeName, value := range schemapb.DataType_value {
+		if strings.EqualFold(typeName, name) && value != int32(schemapb.DataType_None) {
+			return entity.FieldType(value), nil
+		}
+	}
+	return entity.FieldTypeNone, fmt.Errorf("unknown data type %q", name)
+}
+
+func parseConsistencyLevel(name string) (entity.ConsistencyLevel, error) {
+	if name == "" {
+		return entity.DefaultConsistencyLevel, nil
+	}
+	for levelName, value := range commonpb.ConsistencyLevel_value {
+		if strings.EqualFold(levelName, name) {
+			return entity.ConsistencyLevel(value), nil
+		}
+	}
+	return entity.DefaultConsistencyLevel, fmt.Errorf("unknown consistency level %q", name)
+}
+
+func (f *fieldSpec) field() (*entity.Field, error) {
+	if f.Name == "" {
+		return nil, fmt.Errorf("field name is required")
+	}
+	dataType, err := parseDataType(f.Type)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", f.Name, err)
+	}
+	field := entity.NewField().
+		WithName(f.Name).
+		WithDescription(f.Description).
+		WithDataType(dataType).
+		WithIsPrimaryKey(f.PrimaryKey).
+		WithIsAutoID(f.AutoID).
+		WithIsPartitionKey(f.PartitionKey).
+		WithIsClusteringKey(f.ClusteringKey).
+		WithNullable(f.Nullable)
+	if f.ElementType != "" {
+		elementType, err := parseDataType(f.ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		field.WithElementType(elementType)
+	}
+	if f.Dim > 0 {
+		field.WithDim(f.Dim)
+	}
+	if f.MaxLength > 0 {
+		field.WithMaxLength(f.MaxLength)
+	}
+	if f.MaxCapacity > 0 {
+		field.WithMaxCapacity(f.MaxCapacity)
+	}
+	if f.EnableAnalyzer {
+		field.WithEnableAnalyzer(true)
+	}
+	if f.EnableMatch {
+		field.WithEnableMatch(true)
+	}
+	for k, v := range f.TypeParams {
+		field.WithTypeParams(k, v)
+	}
+	if f.DefaultValue != nil {
+		if err := setDefaultValue(field, *f.DefaultValue); err != nil {
+			return nil, fmt.Errorf("field %s: invalid default value: %w", f.Name, err)
+		}
+	}
+	return field, nil
+}
+
+func setDefaultValue(field *entity.Field, value string) error {
+	switch field.DataType {
+	case entity.FieldTypeBool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.WithDefaultValueBool(v)
+	case entity.FieldTypeInt8, entity.FieldTypeInt16, entity.FieldTypeInt32:
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return err
+		}
+		field.WithDefaultValueInt(int32(v))
+	case entity.FieldTypeInt64:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.WithDefaultValueLong(v)
+	case entity.FieldTypeFloat:
+		v, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return err
+		}
+		field.WithDefaultValueFloat(float32(v))
+	case entity.FieldTypeDouble:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.WithDefaultValueDouble(v)
+	case entity.FieldTypeVarChar, entity.FieldTypeString:
+		field.WithDefaultValueString(value)
+	default:
+		return fmt.Errorf("default value is not supported for %s", field.DataType.Name())
+	}
+	return nil
+}
+
+// schema returns the collection schema of the spec.
+func (s *collectionSpec) schema() (*entity.Schema, error) {
+	if len(s.Fields) == 0 {
+		return nil, fmt.Errorf("collection %s has no field", s.Name)
+	}
+	schema := entity.NewSchema().
+		WithName(s.Name).
+		WithDescription(s.Description).
+		WithDynamicFieldEnabled(s.EnableDynamicField)
+	for _, fs := range s.Fields {
+		field, err := fs.field()
+		if err != nil {
+			return nil, err
+		}
+		schema.WithField(field)
+	}
+	return schema, nil
+}
+
+// indexOption returns the create index option of the spec.
+func (s *indexSpec) indexOption(collectionName string) (milvusclient.CreateIndexOption, error) {
+	if s.Field == "" || s.Type == "" {
+		return nil, fmt.Errorf("field and type are required for index")
+	}
+	params := map[string]string{index.IndexTypeKey: s.Type}
+	if s.MetricType != "" {
+		params[index.MetricTypeKey] = s.MetricType
+	}
+	for k, v := range s.Params {
+		params[k] = v
+	}
+	opt := milvusclient.NewCreateIndexOption(collectionName, s.Field, index.NewGenericIndex(s.Name, params))
+	if s.Name != "" {
+		opt.WithIndexName(s.Name)
+	}
+	return opt, nil
+}
+
+// createOption returns the create collection option of the spec, along with the indexes.
+func (s *collectionSpec) createOption() (milvusclient.CreateCollectionOption, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("collection name is required")
+	}
+	schema, err := s.schema()
+	if err != nil {
+		return nil, err
+	}
+	level, err := parseConsistencyLevel(s.ConsistencyLevel)
+	if err != nil {
+		return nil, err
+	}
+	opt := milvusclient.NewCreateCollectionOption(s.Name, schema).WithConsistencyLevel(level)
+	if s.ShardsNum > 0 {
+		opt.WithShardNum(s.ShardsNum)
+	}
+	for k, v := range s.Properties {
+		opt.WithProperty(k, v)
+	}
+	indexOpts := make([]milvusclient.CreateIndexOption, 0, len(s.Indexes))
+	for _, is := range s.Indexes {
+		indexOpt, err := is.indexOption(s.Name)
+		if err != nil {
+			return nil, err
+		}
+		indexOpts = append(indexOpts, indexOpt)
+	}
+	if len(indexOpts) > 0 {
+		opt.WithIndexOptions(indexOpts...)
+	}
+	return opt, nil
+}
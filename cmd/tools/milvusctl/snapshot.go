@@ -0,0 +1,274 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/client/v3/milvusclient"
+)
+
+func snapshotCommand() *command {
+	return &command{
+		name:    "snapshot",
+		aliases: []string{"snap"},
+		summary: "Manage collection snapshots",
+		subcommands: []*command{
+			{name: "list", aliases: []string{"ls"}, usage: "COLLECTION", summary: "List the snapshots of a collection", run: snapshotList},
+			{name: "create", usage: "COLLECTION NAME", summary: "Take a snapshot of a collection", run: snapshotCreate},
+			{name: "describe", usage: "COLLECTION NAME", summary: "Describe a snapshot", run: snapshotDescribe},
+			{name: "drop", usage: "COLLECTION NAME", summary: "Drop a snapshot", run: snapshotDrop},
+			{name: "export", usage: "COLLECTION NAME S3_PATH", summary: "Export a snapshot to the object storage", run: snapshotExport},
+			{name: "restore", usage: "COLLECTION NAME TARGET_COLLECTION", summary: "Restore a snapshot into a new collection", run: snapshotRestore},
+			{name: "restore-state", usage: "JOB_ID", summary: "Show the state of a restore job", run: snapshotRestoreState},
+			{name: "restore-jobs", usage: "[COLLECTION]", summary: "List the restore jobs", run: snapshotRestoreJobs},
+		},
+	}
+}
+
+func snapshotList(inv *invocation) error {
+	if err := inv.parse("COLLECTION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	names, err := cli.ListSnapshots(inv.ctx, milvusclient.NewListSnapshotsOption(inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	return inv.print(names, []string{"NAME"}, nameRows(names))
+}
+
+func snapshotCreate(inv *invocation) error {
+	description := inv.flags.String("description", "", "description of the snapshot")
+	if err := inv.parse("COLLECTION", "NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewCreateSnapshotOption(inv.arg(1), inv.arg(0)).WithDescription(*description)
+	if err := cli.CreateSnapshot(inv.ctx, opt); err != nil {
+		return err
+	}
+	return inv.done("Snapshot %q of collection %q created.", inv.arg(1), inv.arg(0))
+}
+
+type snapshotView struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Collection  string   `json:"collection"`
+	Partitions  []string `json:"partitions,omitempty"`
+	CreateTime  string   `json:"createTime"`
+	S3Location  string   `json:"s3Location,omitempty"`
+}
+
+func snapshotDescribe(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	resp, err := cli.DescribeSnapshot(inv.ctx, milvusclient.NewDescribeSnapshotOption(inv.arg(1), inv.arg(0)))
+	if err != nil {
+		return err
+	}
+	view := &snapshotView{
+		Name:        resp.GetName(),
+		Description: resp.GetDescription(),
+		Collection:  resp.GetCollectionName(),
+		Partitions:  resp.GetPartitionNames(),
+		CreateTime:  formatMillis(uint64(resp.GetCreateTs())),
+		S3Location:  resp.GetS3Location(),
+	}
+	return inv.print(view, []string{"NAME", "COLLECTION", "PARTITIONS", "CREATED", "S3_LOCATION"},
+		[][]string{{view.Name, view.Collection, strings.Join(view.Partitions, ","), view.CreateTime, view.S3Location}})
+}
+
+// formatMillis formats the unix milliseconds in RFC3339, empty for zero.
+func formatMillis(ms uint64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(int64(ms)).UTC().Format(time.RFC3339)
+}
+
+func snapshotDrop(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "NAME"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	if err := cli.DropSnapshot(inv.ctx, milvusclient.NewDropSnapshotOption(inv.arg(1), inv.arg(0))); err != nil {
+		return err
+	}
+	return inv.done("Snapshot %q of collection %q dropped.", inv.arg(1), inv.arg(0))
+}
+
+func snapshotExport(inv *invocation) error {
+	if err := inv.parse("COLLECTION", "NAME", "S3_PATH"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	location, err := cli.ExportSnapshot(inv.ctx, milvusclient.NewExportSnapshotOption(inv.arg(1), inv.arg(0), inv.arg(2)))
+	if err != nil {
+		return err
+	}
+	return inv.print(map[string]string{"metadataURI": location}, []string{"METADATA_URI"}, [][]string{{location}})
+}
+
+func snapshotRestore(inv *invocation) error {
+	targetDB := inv.flags.String("target-db", "", "database of the target collection, default the current database")
+	wait := inv.flags.Bool("wait", false, "wait until the restore job is done")
+	interval := inv.flags.Duration("interval", 2*time.Second, "interval of checking the restore job")
+	if err := inv.parse("COLLECTION", "NAME", "TARGET_COLLECTION"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewRestoreSnapshotOption(inv.arg(1), inv.arg(0), inv.arg(2))
+	if *targetDB != "" {
+		opt.WithTargetDbName(*targetDB)
+	}
+	jobID, err := cli.RestoreSnapshot(inv.ctx, opt)
+	if err != nil {
+		return err
+	}
+	if !*wait {
+		return inv.print(map[string]int64{"jobID": jobID}, []string{"JOB_ID"}, [][]string{{strconv.FormatInt(jobID, 10)}})
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		info, err := cli.GetRestoreSnapshotState(inv.ctx, milvusclient.NewGetRestoreSnapshotStateOption(jobID))
+		if err != nil {
+			return err
+		}
+		switch info.GetState() {
+		case milvuspb.RestoreSnapshotState_RestoreSnapshotCompleted:
+			return printRestoreJobs(inv, info, []*milvuspb.RestoreSnapshotInfo{info})
+		case milvuspb.RestoreSnapshotState_RestoreSnapshotFailed:
+			return fmt.Errorf("restore job %d failed: %s", jobID, info.GetReason())
+		}
+		select {
+		case <-inv.ctx.Done():
+			return inv.ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func snapshotRestoreState(inv *invocation) error {
+	if err := inv.parse("JOB_ID"); err != nil {
+		return err
+	}
+	jobID, err := strconv.ParseInt(inv.arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid job id %q", errUsage, inv.arg(0))
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	info, err := cli.GetRestoreSnapshotState(inv.ctx, milvusclient.NewGetRestoreSnapshotStateOption(jobID))
+	if err != nil {
+		return err
+	}
+	return printRestoreJobs(inv, info, []*milvuspb.RestoreSnapshotInfo{info})
+}
+
+func snapshotRestoreJobs(inv *invocation) error {
+	if err := inv.parse("[COLLECTION]"); err != nil {
+		return err
+	}
+	cli, err := inv.connect()
+	if err != nil {
+		return err
+	}
+	opt := milvusclient.NewListRestoreSnapshotJobsOption()
+	if inv.arg(0) != "" {
+		opt.WithCollectionName(inv.arg(0))
+	}
+	jobs, err := cli.ListRestoreSnapshotJobs(inv.ctx, opt)
+	if err != nil {
+		return err
+	}
+	views := make([]*restoreJobView, 0, len(jobs))
+	for _, job := range jobs {
+		views = append(views, newRestoreJobView(job))
+	}
+	return printRestoreJobs(inv, views, jobs)
+}
+
+type restoreJobView struct {
+	JobID      int64  `json:"jobID"`
+	Snapshot   string `json:"snapshot"`
+	Database   string `json:"database"`
+	Collection string `json:"collection"`
+	State      string `json:"state"`
+	Progress   int32  `json:"progress"`
+	Reason     string `json:"reason,omitempty"`
+	StartTime  string `json:"startTime,omitempty"`
+	TimeCostMs uint64 `json:"timeCostMs"`
+}
+
+func newRestoreJobView(info *milvuspb.RestoreSnapshotInfo) *restoreJobView {
+	return &restoreJobView{
+		JobID:      info.GetJobId(),
+		Snapshot:   info.GetSnapshotName(),
+		Database:   info.GetDbName(),
+		Collection: info.GetCollectionName(),
+		State:      strings.TrimPrefix(info.GetState().String(), "RestoreSnapshot"),
+		Progress:   info.GetProgress(),
+		Reason:     info.GetReason(),
+		StartTime:  formatMillis(info.GetStartTime()),
+		TimeCostMs: info.GetTimeCost(),
+	}
+}
+
+// printRestoreJobs prints the value for json and yaml, and the table of the jobs.
+func printRestoreJobs(inv *invocation, value any, jobs []*milvuspb.RestoreSnapshotInfo) error {
+	if info, ok := value.(*milvuspb.RestoreSnapshotInfo); ok {
+		value = newRestoreJobView(info)
+	}
+	rows := make([][]string, 0, len(jobs))
+	for _, job := range jobs {
+		view := newRestoreJobView(job)
+		rows = append(rows, []string{
+			strconv.FormatInt(view.JobID, 10), view.Snapshot, view.Database, view.Collection,
+			view.State, fmt.Sprintf("%d%%", view.Progress), view.Reason,
+		})
+	}
+	return inv.print(value, []string{"JOB_ID", "SNAPSHOT", "DATABASE", "COLLECTION", "STATE", "PROGRESS", "REASON"}, rows)
+}
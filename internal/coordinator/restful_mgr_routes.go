@@ -51,6 +51,7 @@ func RegisterMgrRoute(s *mixCoordImpl) {
 			{management.StreamingNodeDistributionPath, s.GetStreamingNodeDistribution},
 			{management.StreamingTransferPath, s.TransferStreamingChannel},
 			{management.DataGCPath, s.HandleDatacoordGC}, // This route is unique, so it's included here.
+			{management.ContinuousImportPath, s.HandleContinuousImport},
 			// WAL
 			{management.WALAlterPath, s.HandleAlterWAL},
 			// config
@@ -169,6 +170,90 @@ func (s *mixCoordImpl) ControlDatacoordGC(w http.ResponseWriter, req *http.Reque
 	w.Write([]byte(`{"msg": "OK"}`))
 }
 
+// HandleContinuousImport handles the requests to list, pause, resume and drop continuous imports.
+//
+//	GET    /management/continuous_import?collection_id=1
+//	GET    /management/continuous_import?id=1
+//	PUT    /management/continuous_import {"id": 1, "status": "paused"}
+//	DELETE /management/continuous_import?id=1
+func (s *mixCoordImpl) HandleContinuousImport(w http.ResponseWriter, req *http.Request) {
+	logger := mlog.With(mlog.String("Scope", "ContinuousImport"))
+	switch req.Method {
+	case http.MethodGet:
+		if str := req.URL.Query().Get("id"); str != "" {
+			id, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				writeJSONError(w, fmt.Sprintf("invalid id: %s", str), http.StatusBadRequest)
+				return
+			}
+			info, err := s.datacoordServer.DescribeContinuousImport(req.Context(), id)
+			if err != nil {
+				writeJSONError(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+				"msg":               "OK",
+				"continuous_import": info,
+			})
+			return
+		}
+		var collectionID int64
+		if str := req.URL.Query().Get("collection_id"); str != "" {
+			var err error
+			collectionID, err = strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				writeJSONError(w, fmt.Sprintf("invalid collection_id: %s", str), http.StatusBadRequest)
+				return
+			}
+		}
+		writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"msg":                "OK",
+			"continuous_imports": s.datacoordServer.ListContinuousImports(req.Context(), collectionID),
+		})
+	case http.MethodPut:
+		var requestBody struct {
+			ID     int64  `json:"id"`
+			Status string `json:"status"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&requestBody); err != nil {
+			writeJSONError(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		var err error
+		switch requestBody.Status {
+		case "paused":
+			err = s.datacoordServer.PauseContinuousImport(req.Context(), requestBody.ID)
+		case "running", "resumed":
+			err = s.datacoordServer.ResumeContinuousImport(req.Context(), requestBody.ID)
+		default:
+			writeJSONError(w, "Invalid status value. Use 'paused', 'running' or 'resumed'.", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			logger.Info(req.Context(), "failed to update continuous import", mlog.Int64("id", requestBody.ID), mlog.Err(err))
+			writeJSONError(w, fmt.Sprintf("failed to update continuous import: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		logger.Info(req.Context(), "continuous import updated", mlog.Int64("id", requestBody.ID), mlog.String("status", requestBody.Status))
+		writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+	case http.MethodDelete:
+		id, err := strconv.ParseInt(req.URL.Query().Get("id"), 10, 64)
+		if err != nil {
+			writeJSONError(w, "invalid or missing id", http.StatusBadRequest)
+			return
+		}
+		if err = s.datacoordServer.DropContinuousImport(req.Context(), id); err != nil {
+			logger.Info(req.Context(), "failed to drop continuous import", mlog.Int64("id", id), mlog.Err(err))
+			writeJSONError(w, fmt.Sprintf("failed to drop continuous import: %s", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		logger.Info(req.Context(), "continuous import dropped", mlog.Int64("id", id))
+		writeJSONResponse(w, http.StatusOK, map[string]string{"msg": "OK"})
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 // HandleStreamingNodes handles GET requests to list streaming and query nodes.
 func (s *mixCoordImpl) HandleStreamingNodes(w http.ResponseWriter, req *http.Request) {
 	logger := mlog.With(mlog.String("Scope", "Rolling"))
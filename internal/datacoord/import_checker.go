@@ -274,6 +274,14 @@ func (c *importChecker) checkPreImportingJob(job ImportJob) {
 		log.Info(c.ctx, "import job preimport done", mlog.String("state", state.String()), mlog.Duration("jobTimeCost/preimport", preImportDuration))
 	}
 
+	if importutilv2.IsDryRun(job.GetOptions()) {
+		// dry-run ends after preimport, no segment is allocated and no data is written,
+		// the report is built from the results of the preimport tasks when the progress is queried
+		updateJobState(internalpb.ImportJobState_Completed,
+			UpdateJobCompleteTime(time.Now().Format("2006-01-02T15:04:05Z07:00")))
+		return
+	}
+
 	if totalRows == 0 {
 		if job.GetAutoCommit() {
 			// auto-commit: no data to import, skip Uncommitted directly to Completed
@@ -352,7 +360,7 @@ func (c *importChecker) checkSortingJob(job ImportJob) {
 
 	// Skip stats stage if not enable stats or is l0 import.
 	if !enableSortCompaction() ||
-		importutilv2.IsL0Import(job.GetOptions()) {
+		isL0OnlyImport(job.GetOptions()) {
 		updateJobState(internalpb.ImportJobState_IndexBuilding, "")
 		return
 	}
@@ -376,6 +384,11 @@ func (c *importChecker) checkSortingJob(job ImportJob) {
 				doneCnt++
 				continue
 			}
+			if originSegment.GetLevel() == datapb.SegmentLevel_L0 {
+				// deletions of upsert import, no need to sort
+				doneCnt++
+				continue
+			}
 			if targetSegment != nil {
 				// sort compaction is already done
 				doneCnt++
@@ -425,9 +438,12 @@ func (c *importChecker) checkIndexBuildingJob(job ImportJob) {
 		targetSegmentIDs = originSegmentIDs
 	}
 
-	healthySegments := c.meta.GetSegments(targetSegmentIDs, isSegmentHealthy)
+	healthySegments := c.meta.GetSegments(targetSegmentIDs, func(segment *SegmentInfo) bool {
+		// L0 segments of upsert import have no index to wait for.
+		return isSegmentHealthy(segment) && segment.GetLevel() != datapb.SegmentLevel_L0
+	})
 	unindexed := c.meta.indexMeta.GetUnindexedSegments(job.GetCollectionID(), healthySegments)
-	if Params.DataCoordCfg.WaitForIndex.GetAsBool() && len(unindexed) > 0 && !importutilv2.IsL0Import(job.GetOptions()) {
+	if Params.DataCoordCfg.WaitForIndex.GetAsBool() && len(unindexed) > 0 && !isL0OnlyImport(job.GetOptions()) {
 		for _, segmentID := range unindexed {
 			select {
 			case getBuildIndexChSingleton() <- segmentID: // accelerate index building:
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -33,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	broker2 "github.com/milvus-io/milvus/internal/datacoord/broker"
 	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
@@ -54,6 +56,7 @@ type ImportCheckerSuite struct {
 func (s *ImportCheckerSuite) SetupTest() {
 	catalog := mocks.NewDataCoordCatalog(s.T())
 	catalog.EXPECT().ListImportJobs(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListContinuousImports(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListPreImportTasks(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListImportTasks(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListChannelCheckpoint(mock.Anything).Return(nil, nil)
@@ -576,6 +579,7 @@ func TestImportCheckerCompaction(t *testing.T) {
 	// prepare objects
 	catalog := mocks.NewDataCoordCatalog(t)
 	catalog.EXPECT().ListImportJobs(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListContinuousImports(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListPreImportTasks(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListImportTasks(mock.Anything).Return(nil, nil)
 	catalog.EXPECT().ListChannelCheckpoint(mock.Anything).Return(nil, nil)
@@ -983,3 +987,67 @@ func (s *ImportCheckerSuite) TestCheckPreImporting_EmptyImport_AutoCommitTrue()
 
 	s.Equal(internalpb.ImportJobState_Completed, s.importMeta.GetJob(context.TODO(), s.jobID).GetState())
 }
+
+func (s *ImportCheckerSuite) TestCheckPreImporting_DryRun() {
+	catalog := s.importMeta.(*importMeta).catalog.(*mocks.DataCoordCatalog)
+
+	alloc := s.alloc
+	alloc.EXPECT().AllocN(mock.Anything).RunAndReturn(func(n int64) (int64, int64, error) {
+		id := rand.Int63()
+		return id, id + n, nil
+	})
+	catalog.EXPECT().SavePreImportTask(mock.Anything, mock.Anything).Return(nil)
+	s.manuallyUpdateJob(s.jobID, func(job ImportJob) {
+		job.(*importJob).Options = []*commonpb.KeyValuePair{
+			{Key: importutilv2.DryRun, Value: "true"},
+			{Key: importutilv2.DryRunMaxErrors, Value: "2"},
+		}
+	})
+	s.checker.checkPendingJob(s.importMeta.GetJob(context.TODO(), s.jobID))
+	s.Equal(internalpb.ImportJobState_PreImporting, s.importMeta.GetJob(context.TODO(), s.jobID).GetState())
+
+	// one file of the first task mismatches the schema, the other files are valid
+	preimportTasks := s.importMeta.GetTaskBy(context.TODO(), WithJob(s.jobID), WithType(PreImportTaskType))
+	for i, t := range preimportTasks {
+		fileStats := lo.Map(t.GetFileStats(), func(stat *datapb.ImportFileStats, _ int) *datapb.ImportFileStats {
+			return &datapb.ImportFileStats{ImportFile: stat.GetImportFile(), TotalRows: 10, TotalMemorySize: 100}
+		})
+		results := lo.Map(fileStats, func(_ *datapb.ImportFileStats, _ int) *importutilv2.DryRunFileResult {
+			return &importutilv2.DryRunFileResult{}
+		})
+		if i == 0 {
+			results[0].SchemaMismatches = []string{"field 'pk' is missing"}
+			results[0].Errors = []string{"row error 1", "row error 2"}
+		}
+		encoded, err := importutilv2.MarshalDryRunResults(results)
+		s.NoError(err)
+		err = s.importMeta.UpdateTask(context.TODO(), t.GetTaskID(),
+			UpdateState(datapb.ImportTaskStateV2_Completed), UpdateFileStats(fileStats), UpdateDryRunResults(encoded))
+		s.NoError(err)
+	}
+
+	// the job completes after preimport without import tasks
+	catalog.EXPECT().SaveImportJob(mock.Anything, mock.Anything).Return(nil)
+	s.checker.checkPreImportingJob(s.importMeta.GetJob(context.TODO(), s.jobID))
+	job := s.importMeta.GetJob(context.TODO(), s.jobID)
+	s.Equal(internalpb.ImportJobState_Completed, job.GetState())
+	s.Equal(0, len(s.importMeta.GetTaskBy(context.TODO(), WithJob(s.jobID), WithType(ImportTaskType))))
+	// the report is not persisted as the reason of the job
+	s.Empty(job.GetReason())
+
+	progress, state, importedRows, totalRows, reason := GetJobProgress(context.TODO(), s.jobID, s.importMeta, s.checker.meta)
+	s.Equal(int64(100), progress)
+	s.Equal(internalpb.ImportJobState_Completed, state)
+	s.Equal(int64(0), importedRows)
+	s.Equal(int64(30), totalRows)
+
+	// the results of the files are returned by the task progresses
+	s.Empty(reason)
+	progresses := GetTaskProgresses(context.TODO(), s.jobID, s.importMeta, s.checker.meta)
+	s.Equal(3, len(progresses))
+	invalid := lo.Filter(progresses, func(p *internalpb.ImportTaskProgress, _ int) bool {
+		return p.GetReason() != ""
+	})
+	s.Equal(1, len(invalid))
+	s.Contains(invalid[0].GetReason(), "field 'pk' is missing")
+}
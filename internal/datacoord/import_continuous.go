@@ -0,0 +1,235 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+)
+
+// continuousImportLag is the lag statistics of continuous import, refreshed by each scan.
+// It is kept in memory only, so it is reset on datacoord restart or failover until the next scan.
+type continuousImportLag struct {
+	lastScanTime     time.Time
+	unimportedFiles  int
+	oldestUnimported time.Time
+}
+
+type UpdateContinuousImportAction func(ci ContinuousImport)
+
+func UpdateContinuousImportPaused(paused bool) UpdateContinuousImportAction {
+	return func(ci ContinuousImport) {
+		ci.(*continuousImport).GetContinuousState().Paused = paused
+	}
+}
+
+func UpdateContinuousImportReason(reason string) UpdateContinuousImportAction {
+	return func(ci ContinuousImport) {
+		ci.(*continuousImport).Reason = reason
+	}
+}
+
+// UpdateContinuousImportRunningJob records the import job submitted for the files.
+func UpdateContinuousImportRunningJob(jobID int64, files map[string]int64, submitTime time.Time) UpdateContinuousImportAction {
+	return func(ci ContinuousImport) {
+		state := ci.(*continuousImport).GetContinuousState()
+		state.RunningJobID = jobID
+		state.RunningFiles = files
+		state.RunningJobSubmitTime = submitTime.UnixNano()
+	}
+}
+
+// UpdateContinuousImportJobDone clears the running import job,
+// the running files are marked as imported if the job succeeded.
+func UpdateContinuousImportJobDone(succeeded bool) UpdateContinuousImportAction {
+	return func(ci ContinuousImport) {
+		c := ci.(*continuousImport)
+		state := c.GetContinuousState()
+		if succeeded {
+			if state.TrackedFiles == nil {
+				state.TrackedFiles = make(map[string]int64)
+			}
+			for path, modifyTime := range state.GetRunningFiles() {
+				state.TrackedFiles[path] = modifyTime
+				state.Watermark = max(state.GetWatermark(), modifyTime)
+			}
+			// the files out of the lookback window don't need to be tracked anymore
+			lowerBound := state.GetWatermark() - Params.DataCoordCfg.ImportContinuousLookbackWindow.GetAsDuration(time.Second).Nanoseconds()
+			for path, modifyTime := range state.GetTrackedFiles() {
+				if modifyTime < lowerBound {
+					delete(state.TrackedFiles, path)
+				}
+			}
+			c.lag.unimportedFiles = max(0, c.lag.unimportedFiles-len(state.GetRunningFiles()))
+			if c.lag.unimportedFiles == 0 {
+				c.lag.oldestUnimported = time.Time{}
+			}
+		}
+		state.RunningJobID = 0
+		state.RunningFiles = nil
+		state.RunningJobSubmitTime = 0
+	}
+}
+
+func UpdateContinuousImportScanTime(scanTime time.Time) UpdateContinuousImportAction {
+	return func(ci ContinuousImport) {
+		ci.(*continuousImport).lag.lastScanTime = scanTime
+	}
+}
+
+// UpdateContinuousImportLag refreshes the lag statistics after scanning the prefix.
+func UpdateContinuousImportLag(scanTime time.Time, unimportedFiles int, oldestUnimported time.Time) UpdateContinuousImportAction {
+	return func(ci ContinuousImport) {
+		ci.(*continuousImport).lag = continuousImportLag{
+			lastScanTime:     scanTime,
+			unimportedFiles:  unimportedFiles,
+			oldestUnimported: oldestUnimported,
+		}
+	}
+}
+
+// ContinuousImport watches a prefix of the object storage,
+// and creates import jobs for the files landed under the prefix periodically.
+type ContinuousImport interface {
+	GetJobID() int64
+	GetDbID() int64
+	GetCollectionID() int64
+	GetCollectionName() string
+	GetPartitionIDs() []int64
+	GetVchannels() []string
+	GetSchema() *schemapb.CollectionSchema
+	GetOptions() []*commonpb.KeyValuePair
+	GetReason() string
+	GetCreateTime() string
+	GetPrefix() string
+	GetInterval() time.Duration
+	IsPaused() bool
+	GetWatermark() time.Time
+	IsImported(path string, modifyTime time.Time) bool
+	GetRunningJobID() int64
+	GetRunningFiles() map[string]int64
+	GetRunningJobSubmitTime() time.Time
+	GetLastScanTime() time.Time
+	GetUnimportedFiles() int
+	GetLag() time.Duration
+	// GetJobOptions returns the options of the import jobs created by the continuous import.
+	GetJobOptions() []*commonpb.KeyValuePair
+	Clone() ContinuousImport
+}
+
+type continuousImport struct {
+	*datapb.ImportJob
+
+	lag continuousImportLag
+}
+
+func newContinuousImport(job *datapb.ImportJob) *continuousImport {
+	if job.GetContinuousState() == nil {
+		job.ContinuousState = &datapb.ContinuousImportState{}
+	}
+	return &continuousImport{
+		ImportJob: job,
+	}
+}
+
+func (c *continuousImport) GetPrefix() string {
+	return c.GetFiles()[0].GetPaths()[0]
+}
+
+func (c *continuousImport) GetInterval() time.Duration {
+	interval, err := importutilv2.GetContinuousInterval(c.GetOptions())
+	if err != nil || interval == 0 {
+		return Params.DataCoordCfg.ImportContinuousScanInterval.GetAsDuration(time.Second)
+	}
+	return interval
+}
+
+func (c *continuousImport) IsPaused() bool {
+	return c.GetContinuousState().GetPaused()
+}
+
+func (c *continuousImport) GetWatermark() time.Time {
+	return unixNanoToTime(c.GetContinuousState().GetWatermark())
+}
+
+func (c *continuousImport) IsImported(path string, modifyTime time.Time) bool {
+	state := c.GetContinuousState()
+	if _, ok := state.GetTrackedFiles()[path]; ok {
+		return true
+	}
+	if state.GetWatermark() == 0 {
+		return false
+	}
+	lowerBound := state.GetWatermark() - Params.DataCoordCfg.ImportContinuousLookbackWindow.GetAsDuration(time.Second).Nanoseconds()
+	return modifyTime.UnixNano() < lowerBound
+}
+
+func (c *continuousImport) GetRunningJobID() int64 {
+	return c.GetContinuousState().GetRunningJobID()
+}
+
+func (c *continuousImport) GetRunningFiles() map[string]int64 {
+	return c.GetContinuousState().GetRunningFiles()
+}
+
+func (c *continuousImport) GetRunningJobSubmitTime() time.Time {
+	return unixNanoToTime(c.GetContinuousState().GetRunningJobSubmitTime())
+}
+
+func (c *continuousImport) GetLastScanTime() time.Time {
+	return c.lag.lastScanTime
+}
+
+func (c *continuousImport) GetUnimportedFiles() int {
+	return c.lag.unimportedFiles
+}
+
+// GetLag returns how long the oldest unimported file has been waiting since it landed.
+func (c *continuousImport) GetLag() time.Duration {
+	if c.lag.unimportedFiles == 0 || c.lag.oldestUnimported.IsZero() {
+		return 0
+	}
+	return time.Since(c.lag.oldestUnimported)
+}
+
+func (c *continuousImport) GetJobOptions() []*commonpb.KeyValuePair {
+	return lo.Filter(c.GetOptions(), func(kv *commonpb.KeyValuePair, _ int) bool {
+		return kv.GetKey() != importutilv2.Continuous &&
+			kv.GetKey() != importutilv2.ContinuousInterval
+	})
+}
+
+func (c *continuousImport) Clone() ContinuousImport {
+	return &continuousImport{
+		ImportJob: proto.Clone(c.ImportJob).(*datapb.ImportJob),
+		lag:       c.lag,
+	}
+}
+
+func unixNanoToTime(nano int64) time.Time {
+	if nano == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nano)
+}
@@ -0,0 +1,283 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	mocks2 "github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func newTestContinuousImportMeta(t *testing.T) ImportMeta {
+	catalog := mocks.NewDataCoordCatalog(t)
+	catalog.EXPECT().ListImportJobs(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListContinuousImports(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListPreImportTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().ListImportTasks(mock.Anything).Return(nil, nil)
+	catalog.EXPECT().SaveImportJob(mock.Anything, mock.Anything).Return(nil).Maybe()
+	catalog.EXPECT().DropImportJob(mock.Anything, mock.Anything).Return(nil).Maybe()
+	catalog.EXPECT().SaveContinuousImport(mock.Anything, mock.Anything).Return(nil).Maybe()
+	catalog.EXPECT().DropContinuousImport(mock.Anything, mock.Anything).Return(nil).Maybe()
+	importMeta, err := NewImportMeta(context.TODO(), catalog, nil, nil)
+	assert.NoError(t, err)
+	return importMeta
+}
+
+func newTestContinuousImport() *continuousImport {
+	return newContinuousImport(&datapb.ImportJob{
+		JobID:        1,
+		CollectionID: 100,
+		PartitionIDs: []int64{200},
+		Vchannels:    []string{"ch0"},
+		Files:        []*internalpb.ImportFile{{Paths: []string{"lake/"}}},
+		Options: []*commonpb.KeyValuePair{
+			{Key: importutilv2.Continuous, Value: "true"},
+			{Key: importutilv2.ContinuousInterval, Value: "1h"},
+			{Key: importutilv2.Mode, Value: importutilv2.ModeUpsert},
+		},
+	})
+}
+
+func TestContinuousImport_State(t *testing.T) {
+	ci := newTestContinuousImport()
+	assert.Equal(t, "lake/", ci.GetPrefix())
+	assert.Equal(t, time.Hour, ci.GetInterval())
+	assert.Equal(t, 1, len(ci.GetJobOptions()))
+	assert.True(t, ci.GetWatermark().IsZero())
+
+	now := time.Now()
+	UpdateContinuousImportLag(now, 2, now.Add(-time.Minute))(ci)
+	UpdateContinuousImportRunningJob(10, map[string]int64{"lake/a.parquet": now.UnixNano()}, now)(ci)
+	assert.True(t, ci.GetLag() >= time.Minute)
+
+	// the state is persisted along with the job, and restored from it
+	restored := newContinuousImport(proto.Clone(ci.ImportJob).(*datapb.ImportJob))
+	assert.Equal(t, int64(10), restored.GetRunningJobID())
+	assert.Equal(t, now.UnixNano(), restored.GetRunningJobSubmitTime().UnixNano())
+	assert.Equal(t, 1, len(restored.GetRunningFiles()))
+
+	UpdateContinuousImportJobDone(true)(ci)
+	assert.Equal(t, int64(0), ci.GetRunningJobID())
+	assert.Equal(t, 1, ci.GetUnimportedFiles())
+	assert.Equal(t, now.UnixNano(), ci.GetWatermark().UnixNano())
+	assert.True(t, ci.IsImported("lake/a.parquet", now))
+	assert.False(t, ci.IsImported("lake/b.parquet", now))
+	assert.True(t, ci.IsImported("lake/c.parquet", now.Add(-24*time.Hour)))
+}
+
+func TestContinuousImportWatcher(t *testing.T) {
+	ctx := context.TODO()
+	importMeta := newTestContinuousImportMeta(t)
+	ci := newTestContinuousImport()
+	assert.NoError(t, importMeta.AddContinuousImport(ctx, ci))
+
+	now := time.Now()
+	objects := []*storage.ChunkObjectInfo{
+		{FilePath: "lake/b.parquet", ModifyTime: now},
+		{FilePath: "lake/a.parquet", ModifyTime: now.Add(-time.Minute)},
+		{FilePath: "lake/c.npy", ModifyTime: now},
+		{FilePath: "lake/_SUCCESS", ModifyTime: now},
+	}
+	cm := mocks2.NewChunkManager(t)
+	cm.EXPECT().WalkWithPrefix(mock.Anything, "lake/", true, mock.Anything).RunAndReturn(
+		func(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+			for _, obj := range objects {
+				if !walkFunc(obj) {
+					break
+				}
+			}
+			return nil
+		})
+
+	handler := NewNMockHandler(t)
+	handler.EXPECT().GetCollection(mock.Anything, int64(100)).Return(&collectionInfo{ID: 100, VChannelNames: []string{"ch0"}}, nil)
+
+	var submitted []*internalpb.ImportRequestInternal
+	importFn := func(ctx context.Context, in *internalpb.ImportRequestInternal) (*internalpb.ImportResponse, error) {
+		submitted = append(submitted, in)
+		return &internalpb.ImportResponse{Status: merr.Success(), JobID: "1000"}, nil
+	}
+	w := NewContinuousImportWatcher(ctx, cm, handler, importMeta, importFn).(*continuousImportWatcher)
+
+	// first scan submits an import job with the supported files, ordered by modification time
+	w.check(importMeta.GetContinuousImport(ctx, 1))
+	assert.Equal(t, 1, len(submitted))
+	assert.Equal(t, "lake/a.parquet", submitted[0].GetFiles()[0].GetPaths()[0])
+	assert.Equal(t, "lake/b.parquet", submitted[0].GetFiles()[1].GetPaths()[0])
+	assert.Equal(t, 1, len(submitted[0].GetOptions()))
+	ci = importMeta.GetContinuousImport(ctx, 1).(*continuousImport)
+	assert.Equal(t, int64(1000), ci.GetRunningJobID())
+	assert.Equal(t, 2, ci.GetUnimportedFiles())
+
+	// the job is not created yet, or still running
+	w.check(ci)
+	assert.NoError(t, importMeta.AddJob(ctx, &importJob{ImportJob: &datapb.ImportJob{
+		JobID: 1000, CollectionID: 100, State: internalpb.ImportJobState_Importing,
+	}}))
+	w.check(ci)
+	assert.Equal(t, 1, len(submitted))
+
+	// the job completed, the files are marked as imported
+	assert.NoError(t, importMeta.UpdateJob(ctx, 1000, UpdateJobState(internalpb.ImportJobState_Completed)))
+	w.check(ci)
+	ci = importMeta.GetContinuousImport(ctx, 1).(*continuousImport)
+	assert.Equal(t, int64(0), ci.GetRunningJobID())
+	assert.Equal(t, 0, ci.GetUnimportedFiles())
+	assert.Equal(t, now.UnixNano(), ci.GetWatermark().UnixNano())
+
+	// new file landed, it's imported in the next scan
+	objects = append(objects, &storage.ChunkObjectInfo{FilePath: "lake/d.parquet", ModifyTime: now.Add(time.Second)})
+	UpdateContinuousImportScanTime(time.Time{})(ci)
+	w.check(ci)
+	assert.Equal(t, 2, len(submitted))
+	assert.Equal(t, 1, len(submitted[1].GetFiles()))
+	assert.Equal(t, "lake/d.parquet", submitted[1].GetFiles()[0].GetPaths()[0])
+
+	// the failed job pauses the continuous import
+	assert.NoError(t, importMeta.RemoveJob(ctx, 1000))
+	assert.NoError(t, importMeta.AddJob(ctx, &importJob{ImportJob: &datapb.ImportJob{
+		JobID: 1000, CollectionID: 100, State: internalpb.ImportJobState_Failed, Reason: "mock reason",
+	}}))
+	w.check(importMeta.GetContinuousImport(ctx, 1))
+	ci = importMeta.GetContinuousImport(ctx, 1).(*continuousImport)
+	assert.True(t, ci.IsPaused())
+	assert.Contains(t, ci.GetReason(), "mock reason")
+	assert.Equal(t, int64(0), ci.GetRunningJobID())
+}
+
+func TestContinuousImportWatcher_SubmitFailed(t *testing.T) {
+	ctx := context.TODO()
+	importMeta := newTestContinuousImportMeta(t)
+	assert.NoError(t, importMeta.AddContinuousImport(ctx, newTestContinuousImport()))
+
+	cm := mocks2.NewChunkManager(t)
+	cm.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, prefix string, recursive bool, walkFunc storage.ChunkObjectWalkFunc) error {
+			walkFunc(&storage.ChunkObjectInfo{FilePath: "lake/a.json", ModifyTime: time.Now()})
+			return nil
+		})
+	handler := NewNMockHandler(t)
+	handler.EXPECT().GetCollection(mock.Anything, mock.Anything).Return(&collectionInfo{ID: 100}, nil).Once()
+	w := NewContinuousImportWatcher(ctx, cm, handler, importMeta,
+		func(ctx context.Context, in *internalpb.ImportRequestInternal) (*internalpb.ImportResponse, error) {
+			return nil, errors.New("mock error")
+		}).(*continuousImportWatcher)
+
+	// the failure is recorded and retried after the interval
+	w.check(importMeta.GetContinuousImport(ctx, 1))
+	ci := importMeta.GetContinuousImport(ctx, 1)
+	assert.Contains(t, ci.GetReason(), "mock error")
+	assert.Equal(t, int64(0), ci.GetRunningJobID())
+	w.check(ci)
+
+	// the continuous import is removed after the collection is dropped
+	handler.EXPECT().GetCollection(mock.Anything, mock.Anything).Return(nil, merr.WrapErrCollectionNotFound(100))
+	UpdateContinuousImportScanTime(time.Time{})(ci)
+	w.check(ci)
+	assert.Nil(t, importMeta.GetContinuousImport(ctx, 1))
+}
+
+func TestContinuousImportWatcher_RunningJob(t *testing.T) {
+	ctx := context.TODO()
+	importMeta := newTestContinuousImportMeta(t)
+	assert.NoError(t, importMeta.AddContinuousImport(ctx, newTestContinuousImport()))
+	w := NewContinuousImportWatcher(ctx, nil, nil, importMeta, nil).(*continuousImportWatcher)
+
+	// the job of the paused continuous import is still observed, but no scan happens
+	now := time.Now()
+	assert.NoError(t, importMeta.UpdateContinuousImport(ctx, 1, UpdateContinuousImportPaused(true),
+		UpdateContinuousImportRunningJob(1000, map[string]int64{"lake/a.parquet": now.UnixNano()}, now)))
+	assert.NoError(t, importMeta.AddJob(ctx, &importJob{ImportJob: &datapb.ImportJob{
+		JobID: 1000, CollectionID: 100, State: internalpb.ImportJobState_Completed,
+	}}))
+	w.check(importMeta.GetContinuousImport(ctx, 1))
+	ci := importMeta.GetContinuousImport(ctx, 1)
+	assert.True(t, ci.IsPaused())
+	assert.Equal(t, int64(0), ci.GetRunningJobID())
+	assert.True(t, ci.IsImported("lake/a.parquet", now))
+
+	// the missing job is waited for a while, and then treated as failed
+	assert.NoError(t, importMeta.UpdateContinuousImport(ctx, 1,
+		UpdateContinuousImportRunningJob(1001, map[string]int64{"lake/b.parquet": now.UnixNano()}, now)))
+	w.check(importMeta.GetContinuousImport(ctx, 1))
+	assert.Equal(t, int64(1001), importMeta.GetContinuousImport(ctx, 1).GetRunningJobID())
+
+	assert.NoError(t, importMeta.UpdateContinuousImport(ctx, 1,
+		UpdateContinuousImportRunningJob(1001, map[string]int64{"lake/b.parquet": now.UnixNano()}, now.Add(-Params.DataCoordCfg.ImportContinuousJobMissingTimeout.GetAsDuration(time.Second)))))
+	w.check(importMeta.GetContinuousImport(ctx, 1))
+	ci = importMeta.GetContinuousImport(ctx, 1)
+	assert.Equal(t, int64(0), ci.GetRunningJobID())
+	assert.Contains(t, ci.GetReason(), "not found")
+	assert.False(t, ci.IsImported("lake/b.parquet", now))
+}
+
+func TestContinuousImport_Progress(t *testing.T) {
+	ctx := context.TODO()
+	s := &Server{importMeta: newTestContinuousImportMeta(t)}
+	s.stateCode.Store(commonpb.StateCode_Healthy)
+	assert.NoError(t, s.importMeta.AddContinuousImport(ctx, newTestContinuousImport()))
+
+	// the id returned for the continuous import can be used to query the progress
+	resp, err := s.GetImportProgress(ctx, &internalpb.GetImportProgressRequest{JobID: "1"})
+	assert.NoError(t, merr.CheckRPCCall(resp, err))
+	assert.Equal(t, internalpb.ImportJobState_Importing, resp.GetState())
+	assert.Equal(t, int64(100), resp.GetProgress())
+
+	// the progress of the running job
+	now := time.Now()
+	assert.NoError(t, s.importMeta.UpdateContinuousImport(ctx, 1,
+		UpdateContinuousImportRunningJob(1000, map[string]int64{"lake/a.parquet": now.UnixNano()}, now)))
+	assert.NoError(t, s.importMeta.AddJob(ctx, &importJob{ImportJob: &datapb.ImportJob{
+		JobID: 1000, CollectionID: 100, State: internalpb.ImportJobState_PreImporting,
+	}}))
+	resp, err = s.GetImportProgress(ctx, &internalpb.GetImportProgressRequest{JobID: "1"})
+	assert.NoError(t, merr.CheckRPCCall(resp, err))
+	assert.Equal(t, internalpb.ImportJobState_Importing, resp.GetState())
+	assert.Equal(t, int64(40), resp.GetProgress())
+
+	// paused by the user or by a failure
+	assert.NoError(t, s.importMeta.UpdateContinuousImport(ctx, 1, UpdateContinuousImportPaused(true)))
+	resp, err = s.GetImportProgress(ctx, &internalpb.GetImportProgressRequest{JobID: "1"})
+	assert.NoError(t, merr.CheckRPCCall(resp, err))
+	assert.Equal(t, internalpb.ImportJobState_Pending, resp.GetState())
+	assert.NoError(t, s.importMeta.UpdateContinuousImport(ctx, 1, UpdateContinuousImportReason("mock reason")))
+	resp, err = s.GetImportProgress(ctx, &internalpb.GetImportProgressRequest{JobID: "1"})
+	assert.NoError(t, merr.CheckRPCCall(resp, err))
+	assert.Equal(t, internalpb.ImportJobState_Failed, resp.GetState())
+	assert.Equal(t, "mock reason", resp.GetReason())
+
+	info, err := s.DescribeContinuousImport(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "paused", info.State)
+	assert.Equal(t, int64(1000), info.RunningJobID)
+	_, err = s.DescribeContinuousImport(ctx, 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,258 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datacoord
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+type ContinuousImportWatcher interface {
+	Start()
+	Close()
+}
+
+type continuousImportWatcher struct {
+	ctx        context.Context
+	cm         storage.ChunkManager
+	handler    Handler
+	importMeta ImportMeta
+
+	// importFn submits an import request, just like the request from proxy.
+	importFn func(ctx context.Context, in *internalpb.ImportRequestInternal) (*internalpb.ImportResponse, error)
+
+	closeOnce sync.Once
+	closeChan chan struct{}
+}
+
+func NewContinuousImportWatcher(ctx context.Context,
+	cm storage.ChunkManager,
+	handler Handler,
+	importMeta ImportMeta,
+	importFn func(ctx context.Context, in *internalpb.ImportRequestInternal) (*internalpb.ImportResponse, error),
+) ContinuousImportWatcher {
+	return &continuousImportWatcher{
+		ctx:        ctx,
+		cm:         cm,
+		handler:    handler,
+		importMeta: importMeta,
+		importFn:   importFn,
+		closeChan:  make(chan struct{}),
+	}
+}
+
+func (w *continuousImportWatcher) Start() {
+	mlog.Info(w.ctx, "start continuous import watcher")
+	ticker := time.NewTicker(Params.DataCoordCfg.ImportCheckIntervalHigh.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.closeChan:
+			mlog.Info(w.ctx, "continuous import watcher exited")
+			return
+		case <-ticker.C:
+			for _, ci := range w.importMeta.ListContinuousImports(w.ctx) {
+				w.check(ci)
+			}
+		}
+	}
+}
+
+func (w *continuousImportWatcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closeChan)
+	})
+}
+
+// check observes the running import job, even if the continuous import is paused,
+// and scans the prefix for the unimported files if it's not paused.
+func (w *continuousImportWatcher) check(ci ContinuousImport) {
+	logger := mlog.With(mlog.FieldJobID(ci.GetJobID()), mlog.String("prefix", ci.GetPrefix()))
+	if jobID := ci.GetRunningJobID(); jobID != 0 {
+		job := w.importMeta.GetJob(w.ctx, jobID)
+		var actions []UpdateContinuousImportAction
+		switch {
+		case job == nil:
+			// the job is created asynchronously after the import message is acknowledged
+			if time.Since(ci.GetRunningJobSubmitTime()) < Params.DataCoordCfg.ImportContinuousJobMissingTimeout.GetAsDuration(time.Second) {
+				return
+			}
+			// the job is lost or removed, the files will be imported again by the next scan
+			reason := fmt.Sprintf("import job %d not found", jobID)
+			actions = append(actions, UpdateContinuousImportJobDone(false), UpdateContinuousImportReason(reason))
+		case job.GetState() == internalpb.ImportJobState_Completed:
+			actions = append(actions, UpdateContinuousImportJobDone(true), UpdateContinuousImportReason(""))
+		case job.GetState() == internalpb.ImportJobState_Failed:
+			// pause the continuous import to avoid failing repeatedly,
+			// the files of the failed job will be retried after resuming.
+			reason := fmt.Sprintf("import job %d failed: %s", jobID, job.GetReason())
+			actions = append(actions, UpdateContinuousImportJobDone(false),
+				UpdateContinuousImportPaused(true), UpdateContinuousImportReason(reason))
+		default:
+			return
+		}
+		if err := w.importMeta.UpdateContinuousImport(w.ctx, ci.GetJobID(), actions...); err != nil {
+			logger.Warn(w.ctx, "failed to update continuous import", mlog.Err(err))
+			return
+		}
+		logger.Info(w.ctx, "import job of continuous import done",
+			mlog.Int64("importJobID", jobID), mlog.Bool("found", job != nil))
+		ci = w.importMeta.GetContinuousImport(w.ctx, ci.GetJobID())
+		if ci == nil {
+			return
+		}
+	}
+	if ci.IsPaused() {
+		return
+	}
+
+	// scan the prefix when it's time, or when there are still files waiting to be imported,
+	// back off to the interval if the last scan failed.
+	waiting := ci.GetUnimportedFiles() > 0 && ci.GetReason() == ""
+	if !waiting && time.Since(ci.GetLastScanTime()) < ci.GetInterval() {
+		return
+	}
+	w.scan(ci)
+}
+
+type continuousImportFile struct {
+	path       string
+	modifyTime time.Time
+}
+
+func (w *continuousImportWatcher) scan(ci ContinuousImport) {
+	logger := mlog.With(mlog.FieldJobID(ci.GetJobID()), mlog.String("prefix", ci.GetPrefix()))
+	scanTime := time.Now()
+	files, err := w.listUnimportedFiles(ci)
+	if err != nil {
+		logger.Warn(w.ctx, "failed to list files of continuous import", mlog.Err(err))
+		w.update(ci, UpdateContinuousImportScanTime(scanTime),
+			UpdateContinuousImportReason(fmt.Sprintf("list files failed: %s", err.Error())))
+		return
+	}
+	var oldest time.Time
+	if len(files) > 0 {
+		oldest = files[0].modifyTime
+	}
+	actions := []UpdateContinuousImportAction{UpdateContinuousImportLag(scanTime, len(files), oldest)}
+	if ci.GetRunningJobID() != 0 || len(files) == 0 {
+		w.update(ci, actions...)
+		return
+	}
+
+	maxFiles := Params.DataCoordCfg.MaxFilesPerImportReq.GetAsInt()
+	if len(files) > maxFiles {
+		files = files[:maxFiles]
+	}
+	jobID, err := w.submit(ci, files)
+	if err != nil {
+		logger.Warn(w.ctx, "failed to submit import job of continuous import", mlog.Err(err))
+		if errors.Is(err, merr.ErrCollectionNotFound) {
+			if err = w.importMeta.RemoveContinuousImport(w.ctx, ci.GetJobID()); err != nil {
+				logger.Warn(w.ctx, "failed to remove continuous import", mlog.Err(err))
+			}
+			return
+		}
+		w.update(ci, append(actions, UpdateContinuousImportReason(fmt.Sprintf("submit import job failed: %s", err.Error())))...)
+		return
+	}
+	runningFiles := make(map[string]int64, len(files))
+	for _, file := range files {
+		runningFiles[file.path] = file.modifyTime.UnixNano()
+	}
+	actions = append(actions, UpdateContinuousImportRunningJob(jobID, runningFiles, time.Now()), UpdateContinuousImportReason(""))
+	if err = w.importMeta.UpdateContinuousImport(w.ctx, ci.GetJobID(), actions...); err != nil {
+		// the files would be imported again if the job is lost, only log here
+		logger.Warn(w.ctx, "failed to record import job of continuous import", mlog.Int64("importJobID", jobID), mlog.Err(err))
+		return
+	}
+	logger.Info(w.ctx, "import job of continuous import submitted",
+		mlog.Int64("importJobID", jobID), mlog.Int("fileNum", len(files)))
+}
+
+// listUnimportedFiles returns the supported files which are not imported yet, ordered by modification time.
+func (w *continuousImportWatcher) listUnimportedFiles(ci ContinuousImport) ([]*continuousImportFile, error) {
+	files := make([]*continuousImportFile, 0)
+	err := w.cm.WalkWithPrefix(w.ctx, ci.GetPrefix(), true, func(info *storage.ChunkObjectInfo) bool {
+		fileType, err := importutilv2.GetFileType(&internalpb.ImportFile{Paths: []string{info.FilePath}})
+		if err != nil || fileType == importutilv2.Numpy {
+			// numpy files of the same batch must be imported together, which can't be recognized by prefix
+			return true
+		}
+		if ci.IsImported(info.FilePath, info.ModifyTime) {
+			return true
+		}
+		files = append(files, &continuousImportFile{path: info.FilePath, modifyTime: info.ModifyTime})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].modifyTime.Equal(files[j].modifyTime) {
+			return files[i].path < files[j].path
+		}
+		return files[i].modifyTime.Before(files[j].modifyTime)
+	})
+	return files, nil
+}
+
+func (w *continuousImportWatcher) submit(ci ContinuousImport, files []*continuousImportFile) (int64, error) {
+	collection, err := w.handler.GetCollection(w.ctx, ci.GetCollectionID())
+	if err != nil {
+		return 0, err
+	}
+	if collection == nil {
+		return 0, merr.WrapErrCollectionNotFound(ci.GetCollectionID())
+	}
+	importFiles := make([]*internalpb.ImportFile, 0, len(files))
+	for _, file := range files {
+		importFiles = append(importFiles, &internalpb.ImportFile{Paths: []string{file.path}})
+	}
+	resp, err := w.importFn(w.ctx, &internalpb.ImportRequestInternal{
+		DbID:           ci.GetDbID(),
+		CollectionID:   ci.GetCollectionID(),
+		CollectionName: ci.GetCollectionName(),
+		PartitionIDs:   ci.GetPartitionIDs(),
+		ChannelNames:   collection.VChannelNames,
+		Schema:         collection.Schema,
+		Files:          importFiles,
+		Options:        ci.GetJobOptions(),
+	})
+	if err = merr.CheckRPCCall(resp, err); err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(resp.GetJobID(), 10, 64)
+}
+
+func (w *continuousImportWatcher) update(ci ContinuousImport, actions ...UpdateContinuousImportAction) {
+	if err := w.importMeta.UpdateContinuousImport(w.ctx, ci.GetJobID(), actions...); err != nil {
+		mlog.Warn(w.ctx, "failed to update continuous import", mlog.FieldJobID(ci.GetJobID()), mlog.Err(err))
+	}
+}
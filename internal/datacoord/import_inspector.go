@@ -19,14 +19,21 @@ package datacoord
 import (
 	"context"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/samber/lo"
+
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus/internal/datacoord/allocator"
 	"github.com/milvus-io/milvus/internal/datacoord/task"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
 const (
@@ -36,6 +43,9 @@ const (
 type ImportInspector interface {
 	Start()
 	Close()
+	// GetQueuePosition returns the position of the job among the jobs waiting to be scheduled,
+	// it returns 0 if the job is not waiting.
+	GetQueuePosition(jobID int64) int
 }
 
 type importInspector struct {
@@ -45,17 +55,29 @@ type importInspector struct {
 	importMeta ImportMeta
 	scheduler  task.GlobalScheduler
 
+	// admitted are the pending tasks which have been enqueued into the scheduler,
+	// they are counted as running tasks when applying the concurrency limits.
+	admitted typeutil.UniqueSet
+	// limiters throttle the import throughput of collections by collection.bulkLoadRate.max.mb.
+	limiters map[int64]*ratelimitutil.Limiter
+
+	positionMu     sync.RWMutex
+	queuePositions map[int64]int
+
 	closeOnce sync.Once
 	closeChan chan struct{}
 }
 
 func NewImportInspector(ctx context.Context, meta *meta, importMeta ImportMeta, scheduler task.GlobalScheduler) ImportInspector {
 	return &importInspector{
-		ctx:        ctx,
-		meta:       meta,
-		importMeta: importMeta,
-		scheduler:  scheduler,
-		closeChan:  make(chan struct{}),
+		ctx:            ctx,
+		meta:           meta,
+		importMeta:     importMeta,
+		scheduler:      scheduler,
+		admitted:       typeutil.NewUniqueSet(),
+		limiters:       make(map[int64]*ratelimitutil.Limiter),
+		queuePositions: make(map[int64]int),
+		closeChan:      make(chan struct{}),
 	}
 }
 
@@ -96,27 +118,187 @@ func (s *importInspector) reloadFromMeta() {
 	}
 }
 
+func (s *importInspector) GetQueuePosition(jobID int64) int {
+	s.positionMu.RLock()
+	defer s.positionMu.RUnlock()
+	return s.queuePositions[jobID]
+}
+
+// importQueueItem is a pending task waiting to be admitted into the scheduler.
+type importQueueItem struct {
+	task     ImportTask
+	job      ImportJob
+	priority int
+}
+
 func (s *importInspector) inspect() {
 	jobs := s.importMeta.GetJobBy(s.ctx)
 	sort.Slice(jobs, func(i, j int) bool {
 		return jobs[i].GetJobID() < jobs[j].GetJobID()
 	})
+	var (
+		waiting         = make([]*importQueueItem, 0)
+		admitted        = typeutil.NewUniqueSet()
+		dbTasks         = make(map[int64]int)
+		collectionTasks = make(map[int64]int)
+	)
 	for _, job := range jobs {
 		tasks := s.importMeta.GetTaskBy(s.ctx, WithJob(job.GetJobID()))
 		for _, task := range tasks {
 			switch task.GetState() {
 			case datapb.ImportTaskStateV2_Pending:
-				switch task.GetType() {
-				case PreImportTaskType:
-					s.processPendingPreImport(task)
-				case ImportTaskType:
-					s.processPendingImport(task)
+				if !s.admitted.Contain(task.GetTaskID()) {
+					waiting = append(waiting, &importQueueItem{task: task, job: job, priority: getImportPriority(job)})
+					continue
 				}
+				// the task may be dropped from the scheduler after failing on the worker,
+				// enqueue it again, which is a no-op if the task is still in the scheduler.
+				admitted.Insert(task.GetTaskID())
+				dbTasks[job.GetDbID()]++
+				collectionTasks[job.GetCollectionID()]++
+				s.enqueue(task)
+			case datapb.ImportTaskStateV2_InProgress:
+				dbTasks[job.GetDbID()]++
+				collectionTasks[job.GetCollectionID()]++
 			case datapb.ImportTaskStateV2_Failed:
 				s.processFailed(task)
 			}
 		}
 	}
+	// the tasks which are no longer pending don't need to be tracked
+	s.admitted = admitted
+	s.admit(waiting, dbTasks, collectionTasks)
+}
+
+// admit enqueues the waiting tasks into the scheduler in a fair order: the tasks of the jobs with
+// higher priority go first, then the tasks of the databases and collections with fewer running tasks.
+// The tasks exceeding the concurrency limits or the throughput limit wait for the next round.
+func (s *importInspector) admit(waiting []*importQueueItem, dbTasks, collectionTasks map[int64]int) {
+	var (
+		maxPerDB         = Params.DataCoordCfg.ImportMaxTasksPerDB.GetAsInt()
+		maxPerCollection = Params.DataCoordCfg.ImportMaxTasksPerCollection.GetAsInt()
+		positions        = make(map[int64]int)
+	)
+	s.refreshLimiters(waiting)
+	less := func(a, b *importQueueItem) bool {
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		if dbTasks[a.job.GetDbID()] != dbTasks[b.job.GetDbID()] {
+			return dbTasks[a.job.GetDbID()] < dbTasks[b.job.GetDbID()]
+		}
+		if collectionTasks[a.job.GetCollectionID()] != collectionTasks[b.job.GetCollectionID()] {
+			return collectionTasks[a.job.GetCollectionID()] < collectionTasks[b.job.GetCollectionID()]
+		}
+		if a.job.GetJobID() != b.job.GetJobID() {
+			return a.job.GetJobID() < b.job.GetJobID()
+		}
+		return a.task.GetTaskID() < b.task.GetTaskID()
+	}
+	for len(waiting) > 0 {
+		// the running tasks change after each admission, so pick the next one every time
+		next := 0
+		for i := 1; i < len(waiting); i++ {
+			if less(waiting[i], waiting[next]) {
+				next = i
+			}
+		}
+		item := waiting[next]
+		waiting = append(waiting[:next], waiting[next+1:]...)
+
+		dbID, collectionID := item.job.GetDbID(), item.job.GetCollectionID()
+		if (maxPerDB > 0 && dbTasks[dbID] >= maxPerDB) ||
+			(maxPerCollection > 0 && collectionTasks[collectionID] >= maxPerCollection) ||
+			!s.allowThroughput(item) {
+			if _, ok := positions[item.job.GetJobID()]; !ok {
+				positions[item.job.GetJobID()] = len(positions) + 1
+			}
+			continue
+		}
+		s.admitted.Insert(item.task.GetTaskID())
+		dbTasks[dbID]++
+		collectionTasks[collectionID]++
+		s.enqueue(item.task)
+	}
+
+	s.positionMu.Lock()
+	defer s.positionMu.Unlock()
+	s.queuePositions = positions
+}
+
+func (s *importInspector) enqueue(task ImportTask) {
+	switch task.GetType() {
+	case PreImportTaskType:
+		s.processPendingPreImport(task)
+	case ImportTaskType:
+		s.processPendingImport(task)
+	}
+}
+
+// refreshLimiters applies collection.bulkLoadRate.max.mb of the collections to the throughput limiters.
+func (s *importInspector) refreshLimiters(waiting []*importQueueItem) {
+	limits := make(map[int64]ratelimitutil.Limit)
+	for _, item := range waiting {
+		collectionID := item.job.GetCollectionID()
+		if _, ok := limits[collectionID]; ok {
+			continue
+		}
+		limits[collectionID] = 0
+		collection := s.meta.GetCollection(collectionID)
+		if collection == nil {
+			continue
+		}
+		rateStr, ok := collection.Properties[common.CollectionBulkLoadRateMaxKey]
+		if !ok {
+			continue
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || rate <= 0 {
+			continue
+		}
+		limits[collectionID] = ratelimitutil.Limit(rate * 1024 * 1024)
+	}
+	for collectionID, limit := range limits {
+		limiter, ok := s.limiters[collectionID]
+		switch {
+		case limit == 0:
+			delete(s.limiters, collectionID)
+		case !ok:
+			s.limiters[collectionID] = ratelimitutil.NewLimiter(limit, float64(limit))
+		case limiter.Limit() != limit:
+			limiter.SetLimit(limit)
+		}
+	}
+	// keep the limiters of the collections without waiting tasks, to throttle their next imports
+	for collectionID := range s.limiters {
+		if _, ok := limits[collectionID]; !ok && s.meta.GetCollection(collectionID) == nil {
+			delete(s.limiters, collectionID)
+		}
+	}
+}
+
+// allowThroughput consumes the data size of the import task from the limiter of the collection,
+// the preimport tasks are not throttled since they don't write any data.
+func (s *importInspector) allowThroughput(item *importQueueItem) bool {
+	if item.task.GetType() != ImportTaskType {
+		return true
+	}
+	limiter, ok := s.limiters[item.job.GetCollectionID()]
+	if !ok {
+		return true
+	}
+	size := lo.SumBy(item.task.GetFileStats(), func(stat *datapb.ImportFileStats) int64 {
+		return stat.GetTotalMemorySize()
+	})
+	return limiter.AllowN(time.Now(), int(size))
+}
+
+func getImportPriority(job ImportJob) int {
+	priority, err := importutilv2.GetPriority(job.GetOptions())
+	if err != nil {
+		return importutilv2.DefaultPriority
+	}
+	return priority
 }
 
 func (s *importInspector) processPendingPreImport(task ImportTask) {
@@ -31,6 +31,8 @@ import (
 	"github.com/milvus-io/milvus/internal/datacoord/session"
 	task2 "github.com/milvus-io/milvus/internal/datacoord/task"
 	"github.com/milvus-io/milvus/internal/metastore/mocks"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/timerecord"
 )
@@ -54,6 +56,7 @@ func (s *ImportInspectorSuite) SetupTest() {
 
 	s.catalog = mocks.NewDataCoordCatalog(s.T())
 	s.catalog.EXPECT().ListImportJobs(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListContinuousImports(mock.Anything).Return(nil, nil)
 	s.catalog.EXPECT().ListPreImportTasks(mock.Anything).Return(nil, nil)
 	s.catalog.EXPECT().ListImportTasks(mock.Anything).Return(nil, nil)
 	s.catalog.EXPECT().ListChannelCheckpoint(mock.Anything).Return(nil, nil)
@@ -262,6 +265,7 @@ func (s *ImportInspectorSuite) TestProcessFailed() {
 func (s *ImportInspectorSuite) TestReloadFromMeta() {
 	// Test case 1: No jobs and tasks
 	s.catalog.EXPECT().ListImportJobs(mock.Anything).Return(nil, nil)
+	s.catalog.EXPECT().ListContinuousImports(mock.Anything).Return(nil, nil)
 	s.catalog.EXPECT().ListPreImportTasks(mock.Anything).Return(nil, nil)
 	s.catalog.EXPECT().ListImportTasks(mock.Anything).Return(nil, nil)
 	s.inspector.reloadFromMeta()
@@ -330,6 +334,115 @@ func (s *ImportInspectorSuite) TestReloadFromMeta() {
 	s.inspector.reloadFromMeta()
 }
 
+func (s *ImportInspectorSuite) addPendingJob(jobID, dbID, collectionID int64, priority string, tasks ...ImportTask) {
+	job := &importJob{
+		ImportJob: &datapb.ImportJob{
+			JobID:        jobID,
+			DbID:         dbID,
+			CollectionID: collectionID,
+			TimeoutTs:    math.MaxUint64,
+			Schema:       &schemapb.CollectionSchema{},
+		},
+		tr: timerecord.NewTimeRecorder("import job"),
+	}
+	if priority != "" {
+		job.Options = []*commonpb.KeyValuePair{{Key: importutilv2.Priority, Value: priority}}
+	}
+	s.NoError(s.importMeta.AddJob(context.TODO(), job))
+	for _, task := range tasks {
+		s.NoError(s.importMeta.AddTask(context.TODO(), task))
+	}
+}
+
+func (s *ImportInspectorSuite) newPendingPreImportTask(jobID, taskID, collectionID int64) ImportTask {
+	task := &preImportTask{
+		importMeta: s.importMeta,
+		tr:         timerecord.NewTimeRecorder("preimport task"),
+	}
+	task.task.Store(&datapb.PreImportTask{
+		JobID:        jobID,
+		TaskID:       taskID,
+		CollectionID: collectionID,
+		State:        datapb.ImportTaskStateV2_Pending,
+	})
+	return task
+}
+
+func (s *ImportInspectorSuite) TestFairScheduling() {
+	s.catalog.EXPECT().SaveImportJob(mock.Anything, mock.Anything).Return(nil)
+	s.catalog.EXPECT().SavePreImportTask(mock.Anything, mock.Anything).Return(nil)
+	Params.Save(Params.DataCoordCfg.ImportMaxTasksPerDB.Key, "2")
+	defer Params.Reset(Params.DataCoordCfg.ImportMaxTasksPerDB.Key)
+
+	// a big job and a high priority job in db 1, a small job in db 2
+	s.addPendingJob(1, 1, s.collectionID, "",
+		s.newPendingPreImportTask(1, 10, s.collectionID),
+		s.newPendingPreImportTask(1, 11, s.collectionID),
+		s.newPendingPreImportTask(1, 12, s.collectionID))
+	s.addPendingJob(2, 2, 2, "", s.newPendingPreImportTask(2, 20, 2))
+	s.addPendingJob(3, 1, 3, "9", s.newPendingPreImportTask(3, 30, 3))
+
+	enqueued := make([]int64, 0)
+	s.inspector.scheduler.(*task2.MockGlobalScheduler).EXPECT().Enqueue(mock.Anything).Run(func(task task2.Task) {
+		enqueued = append(enqueued, task.GetTaskID())
+	})
+	s.inspector.inspect()
+	// the high priority job goes first, then the db with fewer running tasks,
+	// the rest tasks of the big job exceed the limit of db 1 and wait in the queue.
+	s.Equal([]int64{30, 20, 10}, enqueued)
+	s.Equal(1, s.inspector.GetQueuePosition(1))
+	s.Equal(0, s.inspector.GetQueuePosition(2))
+	s.Equal(0, s.inspector.GetQueuePosition(3))
+
+	// the admitted tasks are enqueued again, no more task is admitted
+	enqueued = enqueued[:0]
+	s.inspector.inspect()
+	s.ElementsMatch([]int64{10, 20, 30}, enqueued)
+
+	// the completed task releases the quota of db 1
+	s.NoError(s.importMeta.UpdateTask(context.TODO(), 30, UpdateState(datapb.ImportTaskStateV2_Completed)))
+	enqueued = enqueued[:0]
+	s.inspector.inspect()
+	s.ElementsMatch([]int64{10, 11, 20}, enqueued)
+	s.Equal(1, s.inspector.GetQueuePosition(1))
+}
+
+func (s *ImportInspectorSuite) TestThroughputLimit() {
+	s.catalog.EXPECT().SaveImportJob(mock.Anything, mock.Anything).Return(nil)
+	s.catalog.EXPECT().SaveImportTask(mock.Anything, mock.Anything).Return(nil)
+	const collectionID = 2
+	s.meta.AddCollection(&collectionInfo{
+		ID:         collectionID,
+		Schema:     newTestSchema(),
+		Properties: map[string]string{common.CollectionBulkLoadRateMaxKey: "1"},
+	})
+
+	newImportTask := func(taskID int64) ImportTask {
+		task := &importTask{
+			importMeta: s.importMeta,
+			tr:         timerecord.NewTimeRecorder("import task"),
+		}
+		task.task.Store(&datapb.ImportTaskV2{
+			JobID:        1,
+			TaskID:       taskID,
+			CollectionID: collectionID,
+			State:        datapb.ImportTaskStateV2_Pending,
+			FileStats:    []*datapb.ImportFileStats{{TotalMemorySize: 10 * 1024 * 1024}},
+		})
+		return task
+	}
+	s.addPendingJob(1, 1, collectionID, "", newImportTask(10), newImportTask(11))
+
+	enqueued := make([]int64, 0)
+	s.inspector.scheduler.(*task2.MockGlobalScheduler).EXPECT().Enqueue(mock.Anything).Run(func(task task2.Task) {
+		enqueued = append(enqueued, task.GetTaskID())
+	})
+	// the first task overdraws the limiter of 1MB/s, the second one has to wait
+	s.inspector.inspect()
+	s.Equal([]int64{10}, enqueued)
+	s.Equal(1, s.inspector.GetQueuePosition(1))
+}
+
 func TestImportInspector(t *testing.T) {
 	suite.Run(t, new(ImportInspectorSuite))
 }
@@ -49,6 +49,12 @@ type ImportMeta interface {
 	GetTaskBy(ctx context.Context, filters ...ImportTaskFilter) []ImportTask
 	RemoveTask(ctx context.Context, taskID int64) error
 	TaskStatsJSON(ctx context.Context) string
+
+	AddContinuousImport(ctx context.Context, ci ContinuousImport) error
+	UpdateContinuousImport(ctx context.Context, importID int64, actions ...UpdateContinuousImportAction) error
+	GetContinuousImport(ctx context.Context, importID int64) ContinuousImport
+	ListContinuousImports(ctx context.Context) []ContinuousImport
+	RemoveContinuousImport(ctx context.Context, importID int64) error
 }
 
 type importTasks struct {
@@ -93,10 +99,11 @@ func (t *importTasks) listTaskStats() []ImportTask {
 }
 
 type importMeta struct {
-	mu      lock.RWMutex // guards jobs and tasks
-	jobs    map[int64]ImportJob
-	tasks   *importTasks
-	catalog metastore.DataCoordCatalog
+	mu                lock.RWMutex // guards jobs, tasks and continuousImports
+	jobs              map[int64]ImportJob
+	tasks             *importTasks
+	continuousImports map[int64]ContinuousImport
+	catalog           metastore.DataCoordCatalog
 }
 
 func NewImportMeta(ctx context.Context, catalog metastore.DataCoordCatalog, alloc allocator.Allocator, meta *meta) (ImportMeta, error) {
@@ -112,6 +119,10 @@ func NewImportMeta(ctx context.Context, catalog metastore.DataCoordCatalog, allo
 	if err != nil {
 		return nil, err
 	}
+	restoredContinuousImports, err := catalog.ListContinuousImports(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	tasks := newImportTasks()
 	importMeta := &importMeta{}
@@ -145,8 +156,14 @@ func NewImportMeta(ctx context.Context, catalog metastore.DataCoordCatalog, allo
 		}
 	}
 
+	continuousImports := make(map[int64]ContinuousImport)
+	for _, ci := range restoredContinuousImports {
+		continuousImports[ci.GetJobID()] = newContinuousImport(ci)
+	}
+
 	importMeta.jobs = jobs
 	importMeta.tasks = tasks
+	importMeta.continuousImports = continuousImports
 	importMeta.catalog = catalog
 	return importMeta, nil
 }
@@ -401,3 +418,56 @@ func (m *importMeta) HandleCommitVchannel(ctx context.Context, jobID int64, vcha
 	m.jobs[jobID] = updatedJob
 	return nil
 }
+
+func (m *importMeta) AddContinuousImport(ctx context.Context, ci ContinuousImport) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err := m.catalog.SaveContinuousImport(ctx, ci.(*continuousImport).ImportJob)
+	if err != nil {
+		return err
+	}
+	m.continuousImports[ci.GetJobID()] = ci
+	return nil
+}
+
+func (m *importMeta) UpdateContinuousImport(ctx context.Context, importID int64, actions ...UpdateContinuousImportAction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if ci, ok := m.continuousImports[importID]; ok {
+		updated := ci.Clone()
+		for _, action := range actions {
+			action(updated)
+		}
+		err := m.catalog.SaveContinuousImport(ctx, updated.(*continuousImport).ImportJob)
+		if err != nil {
+			return err
+		}
+		m.continuousImports[importID] = updated
+	}
+	return nil
+}
+
+func (m *importMeta) GetContinuousImport(ctx context.Context, importID int64) ContinuousImport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.continuousImports[importID]
+}
+
+func (m *importMeta) ListContinuousImports(ctx context.Context) []ContinuousImport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Values(m.continuousImports)
+}
+
+func (m *importMeta) RemoveContinuousImport(ctx context.Context, importID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.continuousImports[importID]; ok {
+		err := m.catalog.DropContinuousImport(ctx, importID)
+		if err != nil {
+			return err
+		}
+		delete(m.continuousImports, importID)
+	}
+	return nil
+}
@@ -123,6 +123,15 @@ func UpdateFileStats(fileStats []*datapb.ImportFileStats) UpdateAction {
 	}
 }
 
+// UpdateDryRunResults records the dry-run results of the files of the preimport task.
+func UpdateDryRunResults(results string) UpdateAction {
+	return func(t ImportTask) {
+		if task, ok := t.(*preImportTask); ok {
+			task.task.Load().DryRunResults = results
+		}
+	}
+}
+
 func UpdateSegmentIDs(segmentIDs []UniqueID) UpdateAction {
 	return func(t ImportTask) {
 		if task, ok := t.(*importTask); ok {
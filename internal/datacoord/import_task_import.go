@@ -29,7 +29,6 @@ import (
 	"github.com/milvus-io/milvus/internal/datacoord/session"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/metastore/kv/binlog"
-	"github.com/milvus-io/milvus/internal/util/importutilv2"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
@@ -231,8 +230,8 @@ func (t *importTask) QueryTaskOnWorker(cluster session.Cluster) {
 
 			// Extract actual timestamps from binlogs for segment positions
 			var minTs, maxTs uint64
-			isL0Import := importutilv2.IsL0Import(job.GetOptions())
-			if isL0Import {
+			segment := t.meta.GetSegment(context.TODO(), info.GetSegmentID())
+			if isL0OnlyImport(job.GetOptions()) || (segment != nil && segment.GetLevel() == datapb.SegmentLevel_L0) {
 				minTs, maxTs = extractTimestampFromBinlogs(info.GetDeltalogs())
 			} else {
 				minTs, maxTs = extractTimestampFromBinlogs(info.GetBinlogs())
@@ -68,6 +68,10 @@ func (p *preImportTask) GetReason() string {
 	return p.task.Load().GetReason()
 }
 
+func (p *preImportTask) GetDryRunResults() string {
+	return p.task.Load().GetDryRunResults()
+}
+
 func (p *preImportTask) GetFileStats() []*datapb.ImportFileStats {
 	return p.task.Load().GetFileStats()
 }
@@ -159,6 +163,7 @@ func (p *preImportTask) QueryTaskOnWorker(cluster session.Cluster) {
 	}
 	if resp.GetState() == datapb.ImportTaskStateV2_Completed {
 		actions = append(actions, UpdateFileStats(resp.GetFileStats()))
+		actions = append(actions, UpdateDryRunResults(resp.GetDryRunResults()))
 		actions = append(actions, UpdateState(datapb.ImportTaskStateV2_Completed))
 	}
 	if len(actions) > 0 {
@@ -22,6 +22,7 @@ import (
 	"math"
 	"path"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -137,8 +138,14 @@ func NewImportTasks(fileGroups [][]*datapb.ImportFileStats,
 	return tasks, nil
 }
 
+// isL0OnlyImport indicates whether the import job only generates L0 segments,
+// which is the case for l0 import and for import in delete mode.
+func isL0OnlyImport(options []*commonpb.KeyValuePair) bool {
+	return importutilv2.IsL0Import(options) || importutilv2.IsDeleteMode(options)
+}
+
 func GetSegmentMaxSize(job ImportJob, meta *meta) int {
-	if importutilv2.IsL0Import(job.GetOptions()) {
+	if isL0OnlyImport(job.GetOptions()) {
 		return paramtable.Get().DataNodeCfg.FlushDeleteBufferBytes.GetAsInt()
 	}
 
@@ -178,7 +185,7 @@ func AssignSegments(job ImportJob, task ImportTask, alloc allocator.Allocator, m
 		}
 	}
 
-	isL0Import := importutilv2.IsL0Import(job.GetOptions())
+	isL0Import := isL0OnlyImport(job.GetOptions())
 	segmentLevel := datapb.SegmentLevel_L1
 	if isL0Import {
 		segmentLevel = datapb.SegmentLevel_L0
@@ -188,13 +195,13 @@ func AssignSegments(job ImportJob, task ImportTask, alloc allocator.Allocator, m
 
 	// alloc new segments
 	segments := make([]int64, 0)
-	addSegment := func(vchannel string, partitionID int64, size int64) error {
+	allocSegments := func(vchannel string, partitionID int64, size int64, level datapb.SegmentLevel, storageVersion int64) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		for size > 0 {
 			segmentInfo, err := AllocImportSegment(ctx, alloc, meta,
 				task.GetJobID(), task.GetTaskID(), task.GetCollectionID(),
-				partitionID, vchannel, job.GetDataTs(), segmentLevel, storageVersion)
+				partitionID, vchannel, job.GetDataTs(), level, storageVersion)
 			if err != nil {
 				return err
 			}
@@ -203,6 +210,9 @@ func AssignSegments(job ImportJob, task ImportTask, alloc allocator.Allocator, m
 		}
 		return nil
 	}
+	addSegment := func(vchannel string, partitionID int64, size int64) error {
+		return allocSegments(vchannel, partitionID, size, segmentLevel, storageVersion)
+	}
 
 	for vchannel, partitionSizes := range hashedDataSize {
 		for partitionID, size := range partitionSizes {
@@ -230,6 +240,19 @@ func AssignSegments(job ImportJob, task ImportTask, alloc allocator.Allocator, m
 			}
 		}
 	}
+
+	// Upsert import also deletes the old entities of the imported primary keys,
+	// so an extra L0 segment is allocated for each vchannel to hold the deletions.
+	// The deletions are not bound to a partition since the old entities may be
+	// located in any partition.
+	if importutilv2.IsUpsertMode(job.GetOptions()) {
+		for _, vchannel := range job.GetVchannels() {
+			err := allocSegments(vchannel, common.AllPartitionsID, 1, datapb.SegmentLevel_L0, importStorageVersion(true))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	return segments, nil
 }
 
@@ -361,7 +384,7 @@ func AssembleImportRequest(task ImportTask, job ImportJob, meta *meta, alloc all
 		return fileStat.GetImportFile()
 	})
 
-	isL0Import := importutilv2.IsL0Import(job.GetOptions())
+	isL0Import := isL0OnlyImport(job.GetOptions())
 	storageVersion := importStorageVersion(isL0Import)
 	useLoonFFI := importUseLoonFFI(isL0Import)
 
@@ -622,6 +645,15 @@ func GetJobProgress(ctx context.Context, jobID int64,
 		return 99, internalpb.ImportJobState_Committing, totalRows, totalRows, ""
 
 	case internalpb.ImportJobState_Completed:
+		if importutilv2.IsDryRun(job.GetOptions()) {
+			// nothing is imported in dry-run, the results of the files are returned by GetTaskProgresses
+			totalRows := lo.SumBy(importMeta.GetTaskBy(ctx, WithJob(jobID), WithType(PreImportTaskType)), func(task ImportTask) int64 {
+				return lo.SumBy(getDryRunFileResults(task), func(result *importutilv2.DryRunFileResult) int64 {
+					return result.Rows
+				})
+			})
+			return 100, internalpb.ImportJobState_Completed, 0, totalRows, ""
+		}
 		_, totalRows := getImportRowsInfo(ctx, jobID, importMeta, meta)
 		return 100, internalpb.ImportJobState_Completed, totalRows, totalRows, ""
 
@@ -633,6 +665,9 @@ func GetJobProgress(ctx context.Context, jobID int64,
 
 func GetTaskProgresses(ctx context.Context, jobID int64, importMeta ImportMeta, meta *meta) []*internalpb.ImportTaskProgress {
 	progresses := make([]*internalpb.ImportTaskProgress, 0)
+	if job := importMeta.GetJob(ctx, jobID); job != nil && importutilv2.IsDryRun(job.GetOptions()) {
+		return getDryRunTaskProgresses(ctx, jobID, importMeta)
+	}
 	tasks := importMeta.GetTaskBy(ctx, WithJob(jobID), WithType(ImportTaskType))
 	for _, task := range tasks {
 		totalRows := lo.SumBy(task.GetFileStats(), func(file *datapb.ImportFileStats) int64 {
@@ -659,6 +694,49 @@ func GetTaskProgresses(ctx context.Context, jobID int64, importMeta ImportMeta,
 	return progresses
 }
 
+// getDryRunTaskProgresses returns the validation results of the files as the task progresses of the dry-run job.
+func getDryRunTaskProgresses(ctx context.Context, jobID int64, importMeta ImportMeta) []*internalpb.ImportTaskProgress {
+	progresses := make([]*internalpb.ImportTaskProgress, 0)
+	tasks := importMeta.GetTaskBy(ctx, WithJob(jobID), WithType(PreImportTaskType))
+	for _, task := range tasks {
+		progress := int64(0)
+		if task.GetState() == datapb.ImportTaskStateV2_Completed {
+			progress = 100
+		}
+		for i, result := range getDryRunFileResults(task) {
+			progresses = append(progresses, &internalpb.ImportTaskProgress{
+				FileName:     fmt.Sprintf("%v", result.Paths),
+				FileSize:     task.GetFileStats()[i].GetFileSize(),
+				Reason:       strings.Join(result.AllErrors(), "; "),
+				Progress:     progress,
+				CompleteTime: task.(*preImportTask).GetCompleteTime(),
+				State:        task.GetState().String(),
+				TotalRows:    result.Rows,
+			})
+		}
+	}
+	return progresses
+}
+
+// getDryRunFileResults returns the dry-run results of the files of the preimport task,
+// the row counts and the estimated sizes are taken from the file stats.
+func getDryRunFileResults(task ImportTask) []*importutilv2.DryRunFileResult {
+	results, err := importutilv2.UnmarshalDryRunResults(task.(*preImportTask).GetDryRunResults())
+	if err != nil {
+		mlog.Warn(context.TODO(), "failed to parse dry-run results", WrapTaskLog(task, mlog.Err(err))...)
+	}
+	return lo.Map(task.GetFileStats(), func(stat *datapb.ImportFileStats, i int) *importutilv2.DryRunFileResult {
+		result := &importutilv2.DryRunFileResult{}
+		if i < len(results) && results[i] != nil {
+			result = results[i]
+		}
+		result.Paths = stat.GetImportFile().GetPaths()
+		result.Rows = stat.GetTotalRows()
+		result.EstimatedSize = stat.GetTotalMemorySize()
+		return result
+	})
+}
+
 func DropImportTask(task ImportTask, cluster session.Cluster, tm ImportMeta) error {
 	if task.GetNodeID() == NullNodeID {
 		return nil
@@ -853,7 +931,7 @@ func CalculateTaskSlot(task ImportTask, importMeta ImportMeta) int {
 		// PreImportTask use fixed buffer size
 		taskBufferSize = baseBufferSize
 	}
-	isL0Import := importutilv2.IsL0Import(job.GetOptions())
+	isL0Import := isL0OnlyImport(job.GetOptions())
 	if isL0Import {
 		// L0 import use fixed buffer size
 		taskBufferSize = paramtable.Get().DataNodeCfg.ImportDeleteBufferSize.GetAsInt()
@@ -21,6 +21,53 @@ func (_m *MockImportMeta) EXPECT() *MockImportMeta_Expecter {
 	return &MockImportMeta_Expecter{mock: &_m.Mock}
 }
 
+// AddContinuousImport provides a mock function with given fields: ctx, ci
+func (_m *MockImportMeta) AddContinuousImport(ctx context.Context, ci ContinuousImport) error {
+	ret := _m.Called(ctx, ci)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddContinuousImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ContinuousImport) error); ok {
+		r0 = rf(ctx, ci)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockImportMeta_AddContinuousImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddContinuousImport'
+type MockImportMeta_AddContinuousImport_Call struct {
+	*mock.Call
+}
+
+// AddContinuousImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ci ContinuousImport
+func (_e *MockImportMeta_Expecter) AddContinuousImport(ctx interface{}, ci interface{}) *MockImportMeta_AddContinuousImport_Call {
+	return &MockImportMeta_AddContinuousImport_Call{Call: _e.mock.On("AddContinuousImport", ctx, ci)}
+}
+
+func (_c *MockImportMeta_AddContinuousImport_Call) Run(run func(ctx context.Context, ci ContinuousImport)) *MockImportMeta_AddContinuousImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ContinuousImport))
+	})
+	return _c
+}
+
+func (_c *MockImportMeta_AddContinuousImport_Call) Return(_a0 error) *MockImportMeta_AddContinuousImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockImportMeta_AddContinuousImport_Call) RunAndReturn(run func(context.Context, ContinuousImport) error) *MockImportMeta_AddContinuousImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddJob provides a mock function with given fields: ctx, job
 func (_m *MockImportMeta) AddJob(ctx context.Context, job ImportJob) error {
 	ret := _m.Called(ctx, job)
@@ -176,6 +223,55 @@ func (_c *MockImportMeta_CountJobBy_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetContinuousImport provides a mock function with given fields: ctx, importID
+func (_m *MockImportMeta) GetContinuousImport(ctx context.Context, importID int64) ContinuousImport {
+	ret := _m.Called(ctx, importID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContinuousImport")
+	}
+
+	var r0 ContinuousImport
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ContinuousImport); ok {
+		r0 = rf(ctx, importID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ContinuousImport)
+		}
+	}
+
+	return r0
+}
+
+// MockImportMeta_GetContinuousImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContinuousImport'
+type MockImportMeta_GetContinuousImport_Call struct {
+	*mock.Call
+}
+
+// GetContinuousImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - importID int64
+func (_e *MockImportMeta_Expecter) GetContinuousImport(ctx interface{}, importID interface{}) *MockImportMeta_GetContinuousImport_Call {
+	return &MockImportMeta_GetContinuousImport_Call{Call: _e.mock.On("GetContinuousImport", ctx, importID)}
+}
+
+func (_c *MockImportMeta_GetContinuousImport_Call) Run(run func(ctx context.Context, importID int64)) *MockImportMeta_GetContinuousImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockImportMeta_GetContinuousImport_Call) Return(_a0 ContinuousImport) *MockImportMeta_GetContinuousImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockImportMeta_GetContinuousImport_Call) RunAndReturn(run func(context.Context, int64) ContinuousImport) *MockImportMeta_GetContinuousImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetJob provides a mock function with given fields: ctx, jobID
 func (_m *MockImportMeta) GetJob(ctx context.Context, jobID int64) ImportJob {
 	ret := _m.Called(ctx, jobID)
@@ -449,6 +545,101 @@ func (_c *MockImportMeta_HandleCommitVchannel_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// ListContinuousImports provides a mock function with given fields: ctx
+func (_m *MockImportMeta) ListContinuousImports(ctx context.Context) []ContinuousImport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListContinuousImports")
+	}
+
+	var r0 []ContinuousImport
+	if rf, ok := ret.Get(0).(func(context.Context) []ContinuousImport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ContinuousImport)
+		}
+	}
+
+	return r0
+}
+
+// MockImportMeta_ListContinuousImports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListContinuousImports'
+type MockImportMeta_ListContinuousImports_Call struct {
+	*mock.Call
+}
+
+// ListContinuousImports is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockImportMeta_Expecter) ListContinuousImports(ctx interface{}) *MockImportMeta_ListContinuousImports_Call {
+	return &MockImportMeta_ListContinuousImports_Call{Call: _e.mock.On("ListContinuousImports", ctx)}
+}
+
+func (_c *MockImportMeta_ListContinuousImports_Call) Run(run func(ctx context.Context)) *MockImportMeta_ListContinuousImports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockImportMeta_ListContinuousImports_Call) Return(_a0 []ContinuousImport) *MockImportMeta_ListContinuousImports_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockImportMeta_ListContinuousImports_Call) RunAndReturn(run func(context.Context) []ContinuousImport) *MockImportMeta_ListContinuousImports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveContinuousImport provides a mock function with given fields: ctx, importID
+func (_m *MockImportMeta) RemoveContinuousImport(ctx context.Context, importID int64) error {
+	ret := _m.Called(ctx, importID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveContinuousImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, importID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockImportMeta_RemoveContinuousImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveContinuousImport'
+type MockImportMeta_RemoveContinuousImport_Call struct {
+	*mock.Call
+}
+
+// RemoveContinuousImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - importID int64
+func (_e *MockImportMeta_Expecter) RemoveContinuousImport(ctx interface{}, importID interface{}) *MockImportMeta_RemoveContinuousImport_Call {
+	return &MockImportMeta_RemoveContinuousImport_Call{Call: _e.mock.On("RemoveContinuousImport", ctx, importID)}
+}
+
+func (_c *MockImportMeta_RemoveContinuousImport_Call) Run(run func(ctx context.Context, importID int64)) *MockImportMeta_RemoveContinuousImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockImportMeta_RemoveContinuousImport_Call) Return(_a0 error) *MockImportMeta_RemoveContinuousImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockImportMeta_RemoveContinuousImport_Call) RunAndReturn(run func(context.Context, int64) error) *MockImportMeta_RemoveContinuousImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveJob provides a mock function with given fields: ctx, jobID
 func (_m *MockImportMeta) RemoveJob(ctx context.Context, jobID int64) error {
 	ret := _m.Called(ctx, jobID)
@@ -589,6 +780,68 @@ func (_c *MockImportMeta_TaskStatsJSON_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// UpdateContinuousImport provides a mock function with given fields: ctx, importID, actions
+func (_m *MockImportMeta) UpdateContinuousImport(ctx context.Context, importID int64, actions ...UpdateContinuousImportAction) error {
+	_va := make([]interface{}, len(actions))
+	for _i := range actions {
+		_va[_i] = actions[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, importID)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContinuousImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...UpdateContinuousImportAction) error); ok {
+		r0 = rf(ctx, importID, actions...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockImportMeta_UpdateContinuousImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContinuousImport'
+type MockImportMeta_UpdateContinuousImport_Call struct {
+	*mock.Call
+}
+
+// UpdateContinuousImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - importID int64
+//   - actions ...UpdateContinuousImportAction
+func (_e *MockImportMeta_Expecter) UpdateContinuousImport(ctx interface{}, importID interface{}, actions ...interface{}) *MockImportMeta_UpdateContinuousImport_Call {
+	return &MockImportMeta_UpdateContinuousImport_Call{Call: _e.mock.On("UpdateContinuousImport",
+		append([]interface{}{ctx, importID}, actions...)...)}
+}
+
+func (_c *MockImportMeta_UpdateContinuousImport_Call) Run(run func(ctx context.Context, importID int64, actions ...UpdateContinuousImportAction)) *MockImportMeta_UpdateContinuousImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]UpdateContinuousImportAction, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(UpdateContinuousImportAction)
+			}
+		}
+		run(args[0].(context.Context), args[1].(int64), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockImportMeta_UpdateContinuousImport_Call) Return(_a0 error) *MockImportMeta_UpdateContinuousImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockImportMeta_UpdateContinuousImport_Call) RunAndReturn(run func(context.Context, int64, ...UpdateContinuousImportAction) error) *MockImportMeta_UpdateContinuousImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateJob provides a mock function with given fields: ctx, jobID, actions
 func (_m *MockImportMeta) UpdateJob(ctx context.Context, jobID int64, actions ...UpdateJobAction) error {
 	_va := make([]interface{}, len(actions))
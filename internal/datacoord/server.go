@@ -121,6 +121,7 @@ type Server struct {
 	importMeta       ImportMeta
 	importInspector  ImportInspector
 	importChecker    ImportChecker
+	importWatcher    ContinuousImportWatcher
 	importJobLock    *lock.KeyLock[int64]
 
 	copySegmentMeta      CopySegmentMeta
@@ -345,6 +346,8 @@ func (s *Server) initDataCoord() error {
 
 	s.importChecker = NewImportChecker(s.ctx, s.meta, s.broker, s.allocator, s.importMeta, s.compactionInspector, s.handler, s.broadcastCommitImportMessage)
 
+	s.importWatcher = NewContinuousImportWatcher(s.ctx, s.meta.chunkManager, s.handler, s.importMeta, s.ImportV2)
+
 	// init file resource observer
 	if s.fileResourceObserver != nil {
 		s.fileResourceObserver.InitDataCoord(s.nodeManager)
@@ -716,6 +719,7 @@ func (s *Server) startServerLoop() {
 	s.globalScheduler.Start()
 	go s.importInspector.Start()
 	go s.importChecker.Start()
+	go s.importWatcher.Start()
 
 	// Start copy segment inspector and checker
 	go s.copySegmentInspector.Start()
@@ -1059,6 +1063,7 @@ func (s *Server) Stop() error {
 	s.globalScheduler.Stop()
 	s.importInspector.Close()
 	s.importChecker.Close()
+	s.importWatcher.Close()
 
 	// Stop copy segment components
 	s.copySegmentInspector.Close()
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"time"
 
@@ -1890,6 +1891,16 @@ func (s *Server) ImportV2(ctx context.Context, in *internalpb.ImportRequestInter
 		return resp, nil
 	}
 
+	if importutilv2.IsContinuous(in.GetOptions()) {
+		importID, err := s.createContinuousImport(ctx, in)
+		if err != nil {
+			resp.Status = merr.Status(err)
+			return resp, nil
+		}
+		resp.JobID = fmt.Sprint(importID)
+		return resp, nil
+	}
+
 	// Use the incoming JobID if provided (backward compat: old proxy allocates jobID
 	// before sending broadcast RPC, which is forwarded here with the original jobID).
 	// Otherwise allocate a new one.
@@ -1930,6 +1941,143 @@ func (s *Server) ImportV2(ctx context.Context, in *internalpb.ImportRequestInter
 	return resp, nil
 }
 
+// createContinuousImport registers a continuous import which watches the prefix given by the request,
+// the import jobs are created by the continuous import watcher.
+func (s *Server) createContinuousImport(ctx context.Context, in *internalpb.ImportRequestInternal) (int64, error) {
+	if len(in.GetFiles()) != 1 || len(in.GetFiles()[0].GetPaths()) != 1 {
+		return 0, merr.WrapErrImportFailed("continuous import requires exactly one prefix to watch")
+	}
+	if importutilv2.IsBackup(in.GetOptions()) || importutilv2.IsL0Import(in.GetOptions()) {
+		return 0, merr.WrapErrImportFailed("continuous import is not supported for backup or l0 import")
+	}
+	if _, err := importutilv2.GetContinuousInterval(in.GetOptions()); err != nil {
+		return 0, err
+	}
+	if s.allocator == nil {
+		return 0, merr.WrapErrServiceUnavailable("allocator not initialized")
+	}
+	importID, _, err := s.allocator.AllocN(1)
+	if err != nil {
+		return 0, merr.Wrap(err, "failed to allocate continuous import ID")
+	}
+	ci := newContinuousImport(&datapb.ImportJob{
+		JobID:          importID,
+		DbID:           in.GetDbID(),
+		CollectionID:   in.GetCollectionID(),
+		CollectionName: in.GetCollectionName(),
+		PartitionIDs:   in.GetPartitionIDs(),
+		Vchannels:      in.GetChannelNames(),
+		Schema:         in.GetSchema(),
+		Files:          in.GetFiles(),
+		Options:        in.GetOptions(),
+		CreateTime:     time.Now().Format("2006-01-02T15:04:05Z07:00"),
+	})
+	if err = s.importMeta.AddContinuousImport(ctx, ci); err != nil {
+		return 0, merr.Wrap(err, "add continuous import failed")
+	}
+	mlog.Info(ctx, "add continuous import done", mlog.FieldJobID(importID),
+		mlog.String("prefix", ci.GetPrefix()), mlog.Duration("interval", ci.GetInterval()))
+	return importID, nil
+}
+
+// ContinuousImportInfo is the status of continuous import exposed by the management API.
+// LastScanTime, UnimportedFiles and LagSeconds are kept in datacoord memory only, they are empty
+// after datacoord restarts or fails over until the next scan of the prefix.
+type ContinuousImportInfo struct {
+	ID              int64   `json:"id"`
+	CollectionID    int64   `json:"collection_id"`
+	CollectionName  string  `json:"collection_name"`
+	Prefix          string  `json:"prefix"`
+	IntervalSeconds float64 `json:"interval_seconds"`
+	State           string  `json:"state"`
+	Reason          string  `json:"reason,omitempty"`
+	CreateTime      string  `json:"create_time"`
+	Watermark       string  `json:"watermark,omitempty"`
+	LastScanTime    string  `json:"last_scan_time,omitempty"`
+	RunningJobID    int64   `json:"running_job_id,omitempty"`
+	RunningFiles    int     `json:"running_files"`
+	UnimportedFiles int     `json:"unimported_files"`
+	LagSeconds      float64 `json:"lag_seconds"`
+}
+
+func newContinuousImportInfo(ci ContinuousImport) *ContinuousImportInfo {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02T15:04:05Z07:00")
+	}
+	state := "running"
+	if ci.IsPaused() {
+		state = "paused"
+	}
+	return &ContinuousImportInfo{
+		ID:              ci.GetJobID(),
+		CollectionID:    ci.GetCollectionID(),
+		CollectionName:  ci.GetCollectionName(),
+		Prefix:          ci.GetPrefix(),
+		IntervalSeconds: ci.GetInterval().Seconds(),
+		State:           state,
+		Reason:          ci.GetReason(),
+		CreateTime:      ci.GetCreateTime(),
+		Watermark:       formatTime(ci.GetWatermark()),
+		LastScanTime:    formatTime(ci.GetLastScanTime()),
+		RunningJobID:    ci.GetRunningJobID(),
+		RunningFiles:    len(ci.GetRunningFiles()),
+		UnimportedFiles: ci.GetUnimportedFiles(),
+		LagSeconds:      ci.GetLag().Seconds(),
+	}
+}
+
+// ListContinuousImports returns the status of continuous imports, including the import lag.
+func (s *Server) ListContinuousImports(ctx context.Context, collectionID int64) []*ContinuousImportInfo {
+	infos := make([]*ContinuousImportInfo, 0)
+	for _, ci := range s.importMeta.ListContinuousImports(ctx) {
+		if collectionID != 0 && ci.GetCollectionID() != collectionID {
+			continue
+		}
+		infos = append(infos, newContinuousImportInfo(ci))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// DescribeContinuousImport returns the status of the continuous import.
+func (s *Server) DescribeContinuousImport(ctx context.Context, importID int64) (*ContinuousImportInfo, error) {
+	ci := s.importMeta.GetContinuousImport(ctx, importID)
+	if ci == nil {
+		return nil, merr.WrapErrImportFailedMsg("continuous import does not exist, id=%d", importID)
+	}
+	return newContinuousImportInfo(ci), nil
+}
+
+// PauseContinuousImport stops creating new import jobs for the continuous import,
+// the running import job is not affected.
+func (s *Server) PauseContinuousImport(ctx context.Context, importID int64) error {
+	if s.importMeta.GetContinuousImport(ctx, importID) == nil {
+		return merr.WrapErrImportFailedMsg("continuous import does not exist, id=%d", importID)
+	}
+	return s.importMeta.UpdateContinuousImport(ctx, importID, UpdateContinuousImportPaused(true))
+}
+
+// ResumeContinuousImport resumes the continuous import, the files of the failed import job are retried.
+func (s *Server) ResumeContinuousImport(ctx context.Context, importID int64) error {
+	if s.importMeta.GetContinuousImport(ctx, importID) == nil {
+		return merr.WrapErrImportFailedMsg("continuous import does not exist, id=%d", importID)
+	}
+	return s.importMeta.UpdateContinuousImport(ctx, importID, UpdateContinuousImportPaused(false), UpdateContinuousImportReason(""))
+}
+
+// DropContinuousImport removes the continuous import, the imported data is not affected.
+func (s *Server) DropContinuousImport(ctx context.Context, importID int64) error {
+	if s.importMeta.GetContinuousImport(ctx, importID) == nil {
+		return merr.WrapErrImportFailedMsg("continuous import does not exist, id=%d", importID)
+	}
+	return s.importMeta.RemoveContinuousImport(ctx, importID)
+}
+
 // createImportJobFromAck creates an import job from ack callback.
 // This is called internally when broadcast ack is received.
 func (s *Server) createImportJobFromAck(ctx context.Context, in *internalpb.ImportRequestInternal) (*internalpb.ImportResponse, error) {
@@ -2047,10 +2195,18 @@ func (s *Server) GetImportProgress(ctx context.Context, in *internalpb.GetImport
 
 	job := s.importMeta.GetJob(ctx, jobID)
 	if job == nil {
+		if ci := s.importMeta.GetContinuousImport(ctx, jobID); ci != nil {
+			return s.getContinuousImportProgress(ctx, ci), nil
+		}
 		resp.Status = merr.Status(merr.WrapErrImportSysFailedMsg("import job does not exist, jobID=%d", jobID))
 		return resp, nil
 	}
 	progress, state, importedRows, totalRows, reason := GetJobProgress(ctx, jobID, s.importMeta, s.meta)
+	if s.importInspector != nil && reason == "" {
+		if position := s.importInspector.GetQueuePosition(jobID); position > 0 {
+			reason = fmt.Sprintf("waiting to be scheduled, queue position: %d", position)
+		}
+	}
 	resp.State = state
 	resp.Reason = reason
 	resp.Progress = progress
@@ -2064,6 +2220,39 @@ func (s *Server) GetImportProgress(ctx context.Context, in *internalpb.GetImport
 	return resp, nil
 }
 
+// getContinuousImportProgress returns the progress of the continuous import,
+// which is importing while watching, pending if paused, or failed if paused by a failure.
+// The progress and the rows are those of the running import job.
+func (s *Server) getContinuousImportProgress(ctx context.Context, ci ContinuousImport) *internalpb.GetImportProgressResponse {
+	resp := &internalpb.GetImportProgressResponse{
+		Status:         merr.Success(),
+		State:          internalpb.ImportJobState_Importing,
+		Reason:         ci.GetReason(),
+		Progress:       100,
+		CollectionName: ci.GetCollectionName(),
+		CreateTime:     ci.GetCreateTime(),
+	}
+	if ci.IsPaused() {
+		resp.State = internalpb.ImportJobState_Pending
+		if ci.GetReason() != "" {
+			resp.State = internalpb.ImportJobState_Failed
+		}
+	}
+	if jobID := ci.GetRunningJobID(); jobID != 0 && s.importMeta.GetJob(ctx, jobID) != nil {
+		progress, _, importedRows, totalRows, reason := GetJobProgress(ctx, jobID, s.importMeta, s.meta)
+		resp.Progress = progress
+		resp.ImportedRows = importedRows
+		resp.TotalRows = totalRows
+		resp.TaskProgresses = GetTaskProgresses(ctx, jobID, s.importMeta, s.meta)
+		if resp.Reason == "" {
+			resp.Reason = reason
+		}
+	} else if ci.GetUnimportedFiles() > 0 {
+		resp.Progress = 0
+	}
+	return resp
+}
+
 func (s *Server) ListImports(ctx context.Context, req *internalpb.ListImportsRequestInternal) (*internalpb.ListImportsResponse, error) {
 	if err := merr.CheckHealthy(s.GetStateCode()); err != nil {
 		return &internalpb.ListImportsResponse{
@@ -24,6 +24,8 @@ import (
 
 	"github.com/samber/lo"
 
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/conc"
@@ -74,7 +76,12 @@ func (s *scheduler) Start() {
 
 func (s *scheduler) scheduleTasks() {
 	tasks := s.manager.GetBy(WithStates(datapb.ImportTaskStateV2_Pending))
+	// the files of the tasks with higher priority are submitted to the execution pool first
 	sort.Slice(tasks, func(i, j int) bool {
+		pi, pj := getTaskPriority(tasks[i]), getTaskPriority(tasks[j])
+		if pi != pj {
+			return pi > pj
+		}
 		return tasks[i].GetTaskID() < tasks[j].GetTaskID()
 	})
 
@@ -120,6 +127,22 @@ func (s *scheduler) Close() {
 	})
 }
 
+// getTaskPriority returns the priority of the import job which the task belongs to.
+func getTaskPriority(task Task) int {
+	var options []*commonpb.KeyValuePair
+	switch t := task.(type) {
+	case *PreImportTask:
+		options = t.options
+	case *ImportTask:
+		options = t.req.GetOptions()
+	}
+	priority, err := importutilv2.GetPriority(options)
+	if err != nil {
+		return importutilv2.DefaultPriority
+	}
+	return priority
+}
+
 func tryFreeFutures(futures map[int64][]*conc.Future[any]) {
 	for k, fs := range futures {
 		fs = lo.Filter(fs, func(f *conc.Future[any], _ int) bool {
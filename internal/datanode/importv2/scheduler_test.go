@@ -136,13 +136,52 @@ func (s *SchedulerSuite) TestScheduler_Slots() {
 		ImportFiles:  []*internalpb.ImportFile{{Paths: []string{"dummy.json"}}},
 		TaskSlot:     10,
 	}
-	preimportTask := NewPreImportTask(preimportReq, s.manager, s.cm)
+	preimportTask, err := NewPreImportTask(preimportReq, s.manager, s.cm)
+	s.NoError(err)
 	s.manager.Add(preimportTask)
 
 	slots := s.scheduler.Slots()
 	s.Equal(int64(10), slots)
 }
 
+func (s *SchedulerSuite) TestScheduler_TaskPriority() {
+	newTask := func(taskID int64, priority string) Task {
+		req := &datapb.PreImportRequest{
+			JobID:       taskID,
+			TaskID:      taskID,
+			Schema:      s.schema,
+			ImportFiles: []*internalpb.ImportFile{{Paths: []string{"dummy.json"}}},
+		}
+		if priority != "" {
+			req.Options = []*commonpb.KeyValuePair{{Key: importutilv2.Priority, Value: priority}}
+		}
+		task, err := NewPreImportTask(req, s.manager, s.cm)
+		s.NoError(err)
+		return task
+	}
+	s.Equal(importutilv2.DefaultPriority, getTaskPriority(newTask(1, "")))
+	s.Equal(9, getTaskPriority(newTask(2, "9")))
+	s.Equal(importutilv2.DefaultPriority, getTaskPriority(newTask(3, "invalid")))
+}
+
+func (s *SchedulerSuite) TestScheduler_NewPreimportTask_DeleteMode() {
+	req := &datapb.PreImportRequest{
+		JobID:       1,
+		TaskID:      2,
+		Schema:      s.schema,
+		ImportFiles: []*internalpb.ImportFile{{Paths: []string{"dummy.json"}}},
+		Options:     []*commonpb.KeyValuePair{{Key: importutilv2.Mode, Value: importutilv2.ModeDelete}},
+	}
+	task, err := NewPreImportTask(req, s.manager, s.cm)
+	s.NoError(err)
+	s.Equal(1, len(task.GetSchema().GetFields()))
+
+	// the schema without primary key can't be imported in delete mode
+	req.Schema = &schemapb.CollectionSchema{}
+	_, err = NewPreImportTask(req, s.manager, s.cm)
+	s.Error(err)
+}
+
 func (s *SchedulerSuite) TestScheduler_Start_Preimport() {
 	content := &sampleContent{
 		Rows: make([]sampleRow, 0),
@@ -173,7 +212,8 @@ func (s *SchedulerSuite) TestScheduler_Start_Preimport() {
 		Schema:       s.schema,
 		ImportFiles:  []*internalpb.ImportFile{{Paths: []string{"dummy.json"}}},
 	}
-	preimportTask := NewPreImportTask(preimportReq, s.manager, s.cm)
+	preimportTask, err := NewPreImportTask(preimportReq, s.manager, s.cm)
+	s.NoError(err)
 	s.manager.Add(preimportTask)
 
 	go s.scheduler.Start()
@@ -221,7 +261,8 @@ func (s *SchedulerSuite) TestScheduler_Start_Preimport_Failed() {
 		Schema:       s.schema,
 		ImportFiles:  []*internalpb.ImportFile{{Paths: []string{"dummy.json"}}},
 	}
-	preimportTask := NewPreImportTask(preimportReq, s.manager, s.cm)
+	preimportTask, err := NewPreImportTask(preimportReq, s.manager, s.cm)
+	s.NoError(err)
 	s.manager.Add(preimportTask)
 
 	go s.scheduler.Start()
@@ -382,7 +423,8 @@ func (s *SchedulerSuite) TestScheduler_ReadFileStat() {
 		Schema:       s.schema,
 		ImportFiles:  []*internalpb.ImportFile{importFile},
 	}
-	preimportTask := NewPreImportTask(preimportReq, s.manager, s.cm)
+	preimportTask, err := NewPreImportTask(preimportReq, s.manager, s.cm)
+	s.NoError(err)
 	s.manager.Add(preimportTask)
 	err = preimportTask.(*PreImportTask).readFileStat(s.reader, 0)
 	s.NoError(err)
@@ -20,6 +20,7 @@ import (
 	"github.com/samber/lo"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/util/importutilv2"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/util/conc"
@@ -120,6 +121,27 @@ func UpdateFileStat(idx int, fileStat *datapb.ImportFileStats) UpdateAction {
 	}
 }
 
+// UpdateDryRunResult records the dry-run result of the file,
+// the results of all files are reported separately from the reason of the preimport task.
+func UpdateDryRunResult(idx int, result *importutilv2.DryRunFileResult) UpdateAction {
+	return func(task Task) {
+		t, ok := task.(*PreImportTask)
+		if !ok {
+			return
+		}
+		results := make([]*importutilv2.DryRunFileResult, len(t.dryRunResults))
+		copy(results, t.dryRunResults)
+		results[idx] = result
+		t.dryRunResults = results
+		encoded, err := importutilv2.MarshalDryRunResults(results)
+		if err != nil {
+			mlog.Warn(t.ctx, "failed to marshal dry-run results", WrapLogFields(t, mlog.Err(err))...)
+			return
+		}
+		t.DryRunResults = encoded
+	}
+}
+
 func UpdateSegmentInfo(info *datapb.ImportSegmentInfo) UpdateAction {
 	mergeFn := func(current []*datapb.FieldBinlog, new []*datapb.FieldBinlog) []*datapb.FieldBinlog {
 		for _, binlog := range new {
@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/internal/flushcommon/syncmgr"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/importutilv2"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
@@ -173,7 +174,17 @@ func (t *ImportTask) Execute() []*conc.Future[any] {
 	req := t.req
 
 	fn := func(file *internalpb.ImportFile) error {
-		reader, err := importutilv2.NewReader(t.ctx, t.cm, t.GetSchema(), file, req.GetOptions(), int(bufferSize), t.req.GetStorageConfig())
+		// Only the primary keys are required for delete mode import.
+		readerSchema := t.GetSchema()
+		if importutilv2.IsDeleteMode(req.GetOptions()) {
+			pkSchema, err := GetPrimaryKeyOnlySchema(readerSchema)
+			if err != nil {
+				t.manager.Update(t.GetTaskID(), UpdateState(datapb.ImportTaskStateV2_Failed), UpdateReason(err.Error()))
+				return err
+			}
+			readerSchema = pkSchema
+		}
+		reader, err := importutilv2.NewReader(t.ctx, t.cm, readerSchema, file, req.GetOptions(), int(bufferSize), t.req.GetStorageConfig())
 		if err != nil {
 			mlog.Warn(t.ctx, "new reader failed", WrapLogFields(t, mlog.String("file", file.String()), mlog.Err(err))...)
 			reason := fmt.Sprintf("error: %v, file: %s", err, file.String())
@@ -213,6 +224,10 @@ func (t *ImportTask) Execute() []*conc.Future[any] {
 }
 
 func (t *ImportTask) importFile(reader importutilv2.Reader) error {
+	mode, err := importutilv2.GetImportMode(t.req.GetOptions())
+	if err != nil {
+		return err
+	}
 	syncFutures := make([]*conc.Future[struct{}], 0)
 	syncTasks := make([]syncmgr.Task, 0)
 	for {
@@ -228,6 +243,17 @@ func (t *ImportTask) importFile(reader importutilv2.Reader) error {
 			mlog.Info(t.ctx, "0 row was imported, the data may have been deleted", WrapLogFields(t)...)
 			continue
 		}
+		if mode == importutilv2.ModeUpsert || mode == importutilv2.ModeDelete {
+			fs, sts, err := t.deleteByPrimaryKeys(data, mode)
+			if err != nil {
+				return err
+			}
+			syncFutures = append(syncFutures, fs...)
+			syncTasks = append(syncTasks, sts...)
+			if mode == importutilv2.ModeDelete {
+				continue
+			}
+		}
 		err = AppendSystemFieldsData(t, data, rowNum)
 		if err != nil {
 			return err
@@ -258,7 +284,7 @@ func (t *ImportTask) importFile(reader importutilv2.Reader) error {
 		syncFutures = append(syncFutures, fs...)
 		syncTasks = append(syncTasks, sts...)
 	}
-	err := conc.AwaitAll(syncFutures...)
+	err = conc.AwaitAll(syncFutures...)
 	if err != nil {
 		return err
 	}
@@ -314,3 +340,48 @@ func (t *ImportTask) sync(hashedData HashedData) ([]*conc.Future[struct{}], []sy
 	}
 	return futures, syncTasks, nil
 }
+
+// deleteByPrimaryKeys generates the deletions for the primary keys of the imported rows
+// and syncs them into the L0 segments. For upsert mode, the deletions are not bound to
+// a partition since the old entities may be located in any partition.
+func (t *ImportTask) deleteByPrimaryKeys(data *storage.InsertData, mode string) ([]*conc.Future[struct{}], []syncmgr.Task, error) {
+	delData, err := NewDeleteDataByPrimaryKeys(t.GetSchema(), data, t.req.GetTs())
+	if err != nil {
+		return nil, nil, err
+	}
+	hashedDelData, err := HashDeleteData(t, delData)
+	if err != nil {
+		return nil, nil, err
+	}
+	partitionID := int64(common.AllPartitionsID)
+	if mode == importutilv2.ModeDelete {
+		partitionID = t.GetPartitionIDs()[0]
+	}
+	mlog.Info(t.ctx, "start to sync import delete data", WrapLogFields(t, mlog.Int64("deleteRows", delData.RowCount))...)
+	futures := make([]*conc.Future[struct{}], 0)
+	syncTasks := make([]syncmgr.Task, 0)
+	for channelIdx, data := range hashedDelData {
+		if data.RowCount == 0 {
+			continue
+		}
+		channel := t.GetVchannels()[channelIdx]
+		segmentID, err := PickSegment(t.req.GetRequestSegments(), channel, partitionID)
+		if err != nil {
+			return nil, nil, err
+		}
+		syncTask, err := NewSyncTask(t.ctx, t.allocator, t.metaCaches, t.req.GetTs(),
+			segmentID, partitionID, t.GetCollectionID(), channel, nil, data,
+			nil, storage.StorageV2, false, t.req.GetStorageConfig())
+		if err != nil {
+			return nil, nil, err
+		}
+		future, err := t.syncMgr.SyncDataWithChunkManager(t.ctx, syncTask, t.cm)
+		if err != nil {
+			mlog.Error(t.ctx, "failed to sync import delete data", WrapLogFields(t, mlog.Err(err))...)
+			return nil, nil, err
+		}
+		futures = append(futures, future)
+		syncTasks = append(syncTasks, syncTask)
+	}
+	return futures, syncTasks, nil
+}
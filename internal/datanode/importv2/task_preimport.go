@@ -49,6 +49,9 @@ type PreImportTask struct {
 	options      []*commonpb.KeyValuePair
 	req          *datapb.PreImportRequest
 
+	// dryRunResults are the validation results of the files in dry-run.
+	dryRunResults []*importutilv2.DryRunFileResult
+
 	manager TaskManager
 	cm      storage.ChunkManager
 }
@@ -56,17 +59,32 @@ type PreImportTask struct {
 func NewPreImportTask(req *datapb.PreImportRequest,
 	manager TaskManager,
 	cm storage.ChunkManager,
-) Task {
+) (Task, error) {
+	// During binlog import, even if the primary key's autoID is set to true,
+	// the primary key from the binlog should be used instead of being reassigned.
+	if importutilv2.IsBackup(req.GetOptions()) {
+		UnsetAutoID(req.GetSchema())
+	}
+	// Only the primary keys are required for delete mode import.
+	schema := req.GetSchema()
+	if importutilv2.IsDeleteMode(req.GetOptions()) {
+		pkSchema, err := GetPrimaryKeyOnlySchema(schema)
+		if err != nil {
+			return nil, err
+		}
+		schema = pkSchema
+	}
 	fileStats := lo.Map(req.GetImportFiles(), func(file *internalpb.ImportFile, _ int) *datapb.ImportFileStats {
 		return &datapb.ImportFileStats{
 			ImportFile: file,
 		}
 	})
 	ctx, cancel := context.WithCancel(context.Background())
-	// During binlog import, even if the primary key's autoID is set to true,
-	// the primary key from the binlog should be used instead of being reassigned.
-	if importutilv2.IsBackup(req.GetOptions()) {
-		UnsetAutoID(req.GetSchema())
+	var dryRunResults []*importutilv2.DryRunFileResult
+	if importutilv2.IsDryRun(req.GetOptions()) {
+		dryRunResults = lo.Map(req.GetImportFiles(), func(file *internalpb.ImportFile, _ int) *importutilv2.DryRunFileResult {
+			return &importutilv2.DryRunFileResult{Paths: file.GetPaths()}
+		})
 	}
 	return &PreImportTask{
 		PreImportTask: &datapb.PreImportTask{
@@ -76,16 +94,17 @@ func NewPreImportTask(req *datapb.PreImportRequest,
 			State:        datapb.ImportTaskStateV2_Pending,
 			FileStats:    fileStats,
 		},
-		ctx:          ctx,
-		cancel:       cancel,
-		partitionIDs: req.GetPartitionIDs(),
-		vchannels:    req.GetVchannels(),
-		schema:       req.GetSchema(),
-		options:      req.GetOptions(),
-		req:          req,
-		manager:      manager,
-		cm:           cm,
-	}
+		ctx:           ctx,
+		cancel:        cancel,
+		partitionIDs:  req.GetPartitionIDs(),
+		vchannels:     req.GetVchannels(),
+		schema:        schema,
+		options:       req.GetOptions(),
+		req:           req,
+		dryRunResults: dryRunResults,
+		manager:       manager,
+		cm:            cm,
+	}, nil
 }
 
 func (t *PreImportTask) GetPartitionIDs() []int64 {
@@ -128,6 +147,7 @@ func (t *PreImportTask) Clone() Task {
 		schema:        t.GetSchema(),
 		options:       t.options,
 		req:           t.req,
+		dryRunResults: t.dryRunResults,
 		manager:       t.manager,
 		cm:            t.cm,
 	}
@@ -147,8 +167,19 @@ func (t *PreImportTask) Execute() []*conc.Future[any] {
 			return fileStat.GetImportFile()
 		})
 
+	dryRun := importutilv2.IsDryRun(t.options)
 	fn := func(i int, file *internalpb.ImportFile) error {
 		reader, err := importutilv2.NewReader(t.ctx, t.cm, t.GetSchema(), file, t.options, bufferSize, t.req.GetStorageConfig())
+		if err != nil && dryRun {
+			// The reader checks the columns of the file against the schema when it's created,
+			// so the error is reported as the schema mismatch in dry-run.
+			mlog.Info(t.ctx, "dry-run detected schema mismatch", WrapLogFields(t, mlog.String("file", file.String()), mlog.Err(err))...)
+			t.manager.Update(t.GetTaskID(), UpdateDryRunResult(i, &importutilv2.DryRunFileResult{
+				Paths:            file.GetPaths(),
+				SchemaMismatches: []string{err.Error()},
+			}))
+			return nil
+		}
 		if err != nil {
 			mlog.Warn(t.ctx, "new reader failed", WrapLogFields(t, mlog.String("file", file.String()), mlog.Err(err))...)
 			reason := fmt.Sprintf("error: %v, file: %s", err, file.String())
@@ -157,7 +188,11 @@ func (t *PreImportTask) Execute() []*conc.Future[any] {
 		}
 		defer reader.Close()
 		start := time.Now()
-		err = t.readFileStat(reader, i)
+		if dryRun {
+			err = t.validateFile(reader, i, file)
+		} else {
+			err = t.readFileStat(reader, i)
+		}
 		if err != nil {
 			mlog.Warn(t.ctx, "preimport failed", WrapLogFields(t, mlog.String("file", file.String()), mlog.Err(err))...)
 			reason := fmt.Sprintf("error: %v, file: %s", err, file.String())
@@ -233,3 +268,76 @@ func (t *PreImportTask) readFileStat(reader importutilv2.Reader, fileIdx int) er
 	t.manager.Update(t.GetTaskID(), UpdateFileStat(fileIdx, stat))
 	return nil
 }
+
+// validateFile reads and validates all rows of the file in dry-run, the errors are recorded in the
+// dry-run result instead of failing the task, so that all the files can be checked in one run.
+func (t *PreImportTask) validateFile(reader importutilv2.Reader, fileIdx int, file *internalpb.ImportFile) error {
+	maxErrors, err := importutilv2.GetDryRunMaxErrors(t.options)
+	if err != nil {
+		return err
+	}
+	result := &importutilv2.DryRunFileResult{Paths: file.GetPaths()}
+	defer func() {
+		t.manager.Update(t.GetTaskID(), UpdateDryRunResult(fileIdx, result))
+	}()
+
+	fileSize, err := reader.Size()
+	if err != nil {
+		return err
+	}
+	maxSize := paramtable.Get().DataNodeCfg.MaxImportFileSizeInGB.GetAsFloat() * 1024 * 1024 * 1024
+	if fileSize > int64(maxSize) {
+		result.AddError(merr.WrapErrParameterInvalidMsg(
+			"The import file size has reached the maximum limit allowed for importing, "+
+				"fileSize=%d, maxSize=%d", fileSize, int64(maxSize)), maxErrors)
+		return nil
+	}
+
+	totalRows := 0
+	totalSize := 0
+	hashedStats := make(map[string]*datapb.PartitionImportStats)
+	for {
+		data, err := reader.Read()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				// the reader can't continue after failing to parse the file
+				result.AddError(err, maxErrors)
+			}
+			break
+		}
+		// validate the rows just like the import does, before hashing them
+		rowNum, _ := GetInsertDataRowCount(data, t.GetSchema())
+		err = CheckRowsEqual(t.GetSchema(), data)
+		if err == nil {
+			err = AppendNullableDefaultFieldsData(t.GetSchema(), data, rowNum)
+		}
+		if err == nil {
+			err = FillDynamicData(t.GetSchema(), data, rowNum)
+		}
+		if err != nil {
+			result.AddError(err, maxErrors)
+			continue
+		}
+		rowsCount, err := GetRowsStats(t, data)
+		if err != nil {
+			result.AddError(err, maxErrors)
+			continue
+		}
+		MergeHashedStats(rowsCount, hashedStats)
+		totalRows += data.GetRowNum()
+		totalSize += data.GetMemorySize()
+	}
+	result.Rows = int64(totalRows)
+	result.EstimatedSize = int64(totalSize)
+
+	stat := &datapb.ImportFileStats{
+		FileSize:        fileSize,
+		TotalRows:       int64(totalRows),
+		TotalMemorySize: int64(totalSize),
+		HashedStats:     hashedStats,
+	}
+	t.manager.Update(t.GetTaskID(), UpdateFileStat(fileIdx, stat))
+	mlog.Info(t.ctx, "dry-run validated file", WrapLogFields(t, mlog.Strings("file", file.GetPaths()),
+		mlog.Int("rows", totalRows), mlog.Int("errors", len(result.Errors)))...)
+	return nil
+}
@@ -522,6 +522,46 @@ func UnsetAutoID(schema *schemapb.CollectionSchema) {
 	}
 }
 
+// GetPrimaryKeyOnlySchema returns a schema which only contains the primary key field.
+// It is used to read the files of delete mode import, in which only the primary keys are required.
+func GetPrimaryKeyOnlySchema(schema *schemapb.CollectionSchema) (*schemapb.CollectionSchema, error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	pkField = typeutil.Clone(pkField)
+	pkField.AutoID = false
+	return &schemapb.CollectionSchema{
+		Name:        schema.GetName(),
+		Description: schema.GetDescription(),
+		Fields:      []*schemapb.FieldSchema{pkField},
+		DbName:      schema.GetDbName(),
+	}, nil
+}
+
+// NewDeleteDataByPrimaryKeys generates the deletions for the primary keys of the imported rows.
+// All deletions use the timestamp of the import task, since a deletion only takes effect on
+// the entities inserted before it, the rows imported along with the deletions are kept.
+func NewDeleteDataByPrimaryKeys(schema *schemapb.CollectionSchema, data *storage.InsertData, ts uint64) (*storage.DeleteData, error) {
+	pkField, err := typeutil.GetPrimaryFieldSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	pkData, ok := data.Data[pkField.GetFieldID()]
+	if !ok || pkData == nil {
+		return nil, merr.WrapErrImportFailedMsg("primary key field '%s' is not provided", pkField.GetName())
+	}
+	delData := storage.NewDeleteData(nil, nil)
+	for i := 0; i < pkData.RowNum(); i++ {
+		pk, err := storage.GenPrimaryKeyByRawData(pkData.GetRow(i), pkField.GetDataType())
+		if err != nil {
+			return nil, err
+		}
+		delData.Append(pk, ts)
+	}
+	return delData, nil
+}
+
 func NewMetaCache(req *datapb.ImportRequest) map[string]metacache.MetaCache {
 	metaCaches := make(map[string]metacache.MetaCache)
 	schema := typeutil.AppendSystemFields(req.GetSchema())
@@ -167,6 +167,68 @@ func Test_UnsetAutoID(t *testing.T) {
 	}
 }
 
+func Test_GetPrimaryKeyOnlySchema(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				DataType:     schemapb.DataType_Int64,
+				IsPrimaryKey: true,
+				AutoID:       true,
+			},
+			{
+				FieldID:  101,
+				Name:     "vec",
+				DataType: schemapb.DataType_FloatVector,
+			},
+		},
+		EnableDynamicField: true,
+	}
+	pkSchema, err := GetPrimaryKeyOnlySchema(schema)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pkSchema.GetFields()))
+	assert.Equal(t, "pk", pkSchema.GetFields()[0].GetName())
+	assert.False(t, pkSchema.GetFields()[0].GetAutoID())
+	assert.False(t, pkSchema.GetEnableDynamicField())
+	// the original schema should not be changed
+	assert.True(t, schema.GetFields()[0].GetAutoID())
+
+	_, err = GetPrimaryKeyOnlySchema(&schemapb.CollectionSchema{})
+	assert.Error(t, err)
+}
+
+func Test_NewDeleteDataByPrimaryKeys(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				DataType:     schemapb.DataType_VarChar,
+				IsPrimaryKey: true,
+			},
+		},
+	}
+	data, err := storage.NewInsertData(schema)
+	assert.NoError(t, err)
+	for _, pk := range []string{"a", "b", "c"} {
+		err = data.Append(map[int64]interface{}{100: pk})
+		assert.NoError(t, err)
+	}
+
+	delData, err := NewDeleteDataByPrimaryKeys(schema, data, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), delData.RowCount)
+	assert.Equal(t, "b", delData.Pks[1].GetValue())
+	for _, ts := range delData.Tss {
+		assert.Equal(t, uint64(1000), ts)
+	}
+
+	_, err = NewDeleteDataByPrimaryKeys(schema, &storage.InsertData{Data: map[int64]storage.FieldData{}}, 1000)
+	assert.Error(t, err)
+}
+
 func Test_PickSegment(t *testing.T) {
 	const (
 		vchannel    = "ch-0"
@@ -381,7 +381,12 @@ func (node *DataNode) PreImport(ctx context.Context, req *datapb.PreImportReques
 	if importutilv2.IsL0Import(req.GetOptions()) {
 		task = importv2.NewL0PreImportTask(req, node.importTaskMgr, cm)
 	} else {
-		task = importv2.NewPreImportTask(req, node.importTaskMgr, cm)
+		task, err = importv2.NewPreImportTask(req, node.importTaskMgr, cm)
+		if err != nil {
+			mlog.Warn(context.TODO(), "create preimport task failed", mlog.Int64("jobID", req.GetJobID()),
+				mlog.Int64("taskID", req.GetTaskID()), mlog.Err(err))
+			return merr.Status(err), nil
+		}
 	}
 	node.importTaskMgr.Add(task)
 
@@ -443,12 +448,17 @@ func (node *DataNode) QueryPreImport(ctx context.Context, req *datapb.QueryPreIm
 		mlog.Info(context.TODO(), "datanode query preimport", logFields...)
 	}
 
+	dryRunResults := task.(interface {
+		GetDryRunResults() string
+	}).GetDryRunResults()
+
 	return &datapb.QueryPreImportResponse{
-		Status:    merr.Success(),
-		TaskID:    task.GetTaskID(),
-		State:     task.GetState(),
-		Reason:    task.GetReason(),
-		FileStats: fileStats,
+		Status:        merr.Success(),
+		TaskID:        task.GetTaskID(),
+		State:         task.GetState(),
+		Reason:        task.GetReason(),
+		FileStats:     fileStats,
+		DryRunResults: dryRunResults,
 	}, nil
 }
 
@@ -44,25 +44,28 @@ const (
 	QuotaCenterCategory           = "/quotacenter/"
 	CommonCategory                = "/common/"
 
-	ListAction           = "list"
-	HasAction            = "has"
-	DescribeAction       = "describe"
-	CreateAction         = "create"
-	DropAction           = "drop"
-	StatsAction          = "get_stats"
-	LoadStateAction      = "get_load_state"
-	RenameAction         = "rename"
-	LoadAction           = "load"
-	RefreshLoadAction    = "refresh_load"
-	ReleaseAction        = "release"
-	QueryAction          = "query"
-	GetAction            = "get"
-	DeleteAction         = "delete"
-	InsertAction         = "insert"
-	UpsertAction         = "upsert"
-	SearchAction         = "search"
-	AdvancedSearchAction = "advanced_search"
-	HybridSearchAction   = "hybrid_search"
+	ListAction            = "list"
+	HasAction             = "has"
+	DescribeAction        = "describe"
+	CreateAction          = "create"
+	DropAction            = "drop"
+	StatsAction           = "get_stats"
+	LoadStateAction       = "get_load_state"
+	RenameAction          = "rename"
+	LoadAction            = "load"
+	RefreshLoadAction     = "refresh_load"
+	ReleaseAction         = "release"
+	QueryAction           = "query"
+	GetAction             = "get"
+	DeleteAction          = "delete"
+	InsertAction          = "insert"
+	UpsertAction          = "upsert"
+	SearchAction          = "search"
+	AdvancedSearchAction  = "advanced_search"
+	HybridSearchAction    = "hybrid_search"
+	QueryIteratorAction   = "query_iterator"
+	SearchIteratorAction  = "search_iterator"
+	FederatedSearchAction = "federated_search"
 
 	UpdatePasswordAction            = "update_password"
 	GrantRoleAction                 = "grant_role"
@@ -146,6 +149,7 @@ const (
 	HTTPReturnMessage        = "message"
 	HTTPReturnData           = "data"
 	HTTPReturnCost           = "cost"
+	HTTPReturnCursor         = "cursor"
 	HTTPReturnRecalls        = "recalls"
 	HTTPReturnLoadState      = "loadState"
 	HTTPReturnLoadProgress   = "loadProgress"
@@ -0,0 +1,254 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metric"
+)
+
+// federatedSearch searches the collections or aliases sharing a compatible vector field, the hits are merged
+// by score or reranked by the rrf or weighted reranker, and each hit carries the name of its source collection.
+func (h *HandlersV2) federatedSearch(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*FederatedSearchReqV2)
+	if httpReq.Limit <= 0 || httpReq.Offset < 0 || len(httpReq.Data) == 0 {
+		err := merr.WrapErrParameterInvalidMsg("limit must be greater than 0, offset must not be negative, and data must not be empty")
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+	// the pagination is applied to the merged hits
+	if searchParamsContainAny(httpReq.SearchParams, common.TopKKey, proxy.OffsetKey, proxy.GroupByFieldKey, proxy.GroupByFieldsKey, proxy.IteratorField) {
+		err := merr.WrapErrParameterInvalidMsg("searchParams of federated search must not contain the pagination, grouping or iterator parameters")
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+
+	template := &milvuspb.SearchRequest{
+		DbName:             dbName,
+		Dsl:                httpReq.Filter,
+		DslType:            commonpb.DslType_BoolExprV1,
+		OutputFields:       httpReq.OutputFields,
+		ExprTemplateValues: generateExpressionTemplate(httpReq.ExprParams),
+	}
+	var err error
+	template.ConsistencyLevel, template.UseDefaultConsistency, err = convertConsistencyLevel(httpReq.ConsistencyLevel)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, federated search with consistency_level invalid", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(err),
+			HTTPReturnMessage: "consistencyLevel can only be [Strong, Session, Bounded, Eventually, Customized], default: Bounded, err:" + err.Error(),
+		})
+		return nil, err
+	}
+	c.Set(ContextRequest, template)
+
+	// the privilege and the rate limit are checked for each collection before searching any of them
+	reqs := make([]*milvuspb.SearchRequest, 0, len(httpReq.CollectionNames))
+	for _, collectionName := range httpReq.CollectionNames {
+		req := &milvuspb.SearchRequest{DbName: dbName, CollectionName: collectionName}
+		if h.checkAuth {
+			if err := checkAuthorizationV2(ctx, c, false, req); err != nil {
+				return nil, err
+			}
+			ctx = c.Request.Context()
+		}
+		reqs = append(reqs, req)
+	}
+
+	annsField, err := proxy.CheckFederatedSearchTargets(ctx, dbName, httpReq.CollectionNames, httpReq.AnnsField)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, federated search with incompatible collections", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+	collSchemas := make([]*schemapb.CollectionSchema, 0, len(httpReq.CollectionNames))
+	for _, collectionName := range httpReq.CollectionNames {
+		collSchema, err := h.GetCollectionSchema(ctx, c, dbName, collectionName)
+		if err != nil {
+			return nil, err
+		}
+		collSchemas = append(collSchemas, collSchema)
+	}
+
+	searchParams, err := generateSearchParams(httpReq.SearchParams)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, generate SearchParams failed", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+	// each collection returns the hits before the offset as well, since any of them could be skipped by the offset
+	searchParams = append(searchParams,
+		&commonpb.KeyValuePair{Key: common.TopKKey, Value: strconv.FormatInt(int64(httpReq.Limit+httpReq.Offset), 10)},
+		&commonpb.KeyValuePair{Key: proxy.OffsetKey, Value: "0"},
+		&commonpb.KeyValuePair{Key: proxy.AnnsFieldKey, Value: annsField},
+	)
+	template.SearchParams = searchParams
+	body, _ := c.Get(gin.BodyBytesKey)
+	placeholderGroup, err := generatePlaceholderGroup(ctx, string(body.([]byte)), collSchemas[0], annsField)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, federated search with vector invalid", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrIncorrectParameterFormat),
+			HTTPReturnMessage: merr.ErrIncorrectParameterFormat.Error() + ", error: " + err.Error(),
+		})
+		return nil, err
+	}
+	template.SearchInput = &milvuspb.SearchRequest_PlaceholderGroup{PlaceholderGroup: placeholderGroup}
+
+	// the quota taken for the collections passed is given back if any of the collections is rejected
+	cancels := make([]func(), 0, len(reqs))
+	for i, req := range reqs {
+		collectionName := req.GetCollectionName()
+		reqs[i] = proto.Clone(template).(*milvuspb.SearchRequest)
+		reqs[i].CollectionName = collectionName
+		cancel, _, err := checkLimiterWithCancel(ctx, reqs[i], h.proxy)
+		if err != nil {
+			for _, cancel := range cancels {
+				cancel()
+			}
+			mlog.Warn(ctx, "high level restful api, fail to check limiter", mlog.Err(err), mlog.String("collection", collectionName))
+			HTTPAbortReturn(c, http.StatusOK, gin.H{
+				HTTPReturnCode:    merr.Code(merr.ErrHTTPRateLimit),
+				HTTPReturnMessage: merr.ErrHTTPRateLimit.Error() + ", error: " + err.Error(),
+			})
+			return nil, RestRequestInterceptorErr
+		}
+		cancels = append(cancels, cancel)
+	}
+
+	var rankParams []*commonpb.KeyValuePair
+	if httpReq.Rerank != nil && httpReq.Rerank.Strategy != "" {
+		bs, _ := json.Marshal(httpReq.Rerank.Params)
+		rankParams = []*commonpb.KeyValuePair{
+			{Key: proxy.RankTypeKey, Value: httpReq.Rerank.Strategy},
+			{Key: proxy.ParamsKey, Value: string(bs)},
+		}
+	}
+	// the metric types are not needed by the rrf reranker
+	requestMetricType := ""
+	for _, kv := range searchParams {
+		if kv.GetKey() == common.MetricTypeKey {
+			requestMetricType = kv.GetValue()
+		}
+	}
+	resolveMetricType := requestMetricType == "" && (len(rankParams) == 0 || httpReq.Rerank.Strategy != "rrf")
+
+	results := make([]*milvuspb.SearchResults, len(reqs))
+	metricTypes := make([]string, len(reqs))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, req := range reqs {
+		g.Go(func() error {
+			resp, err := wrapperProxy(gctx, c, req, false, true, "/milvus.proto.milvus.MilvusService/Search", func(reqCtx context.Context, req any) (interface{}, error) {
+				return h.proxy.Search(reqCtx, req.(*milvuspb.SearchRequest))
+			})
+			if err != nil {
+				return merr.Wrap(err, fmt.Sprintf("failed to search collection %s", req.GetCollectionName()))
+			}
+			results[i] = resp.(*milvuspb.SearchResults)
+			metricTypes[i] = requestMetricType
+			if resolveMetricType {
+				metricTypes[i], err = h.getFederatedMetricType(gctx, req, annsField)
+				if err != nil {
+					return merr.Wrap(err, fmt.Sprintf("failed to describe the index of collection %s", req.GetCollectionName()))
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		mlog.Warn(ctx, "high level restful api, federated search failed", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+
+	merged, err := proxy.MergeFederatedSearchResults(ctx, results, metricTypes, int64(len(httpReq.Data)), int64(httpReq.Limit), int64(httpReq.Offset), rankParams)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, fail to merge federated search results", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+
+	allowJS, _ := strconv.ParseBool(c.Request.Header.Get(HTTPHeaderAllowInt64))
+	rows := make([][]map[string]interface{}, len(results))
+	outputData := make([]map[string]interface{}, 0, len(merged.Hits))
+	var cost int
+	for _, result := range results {
+		cost += proxy.GetCostValue(result.GetStatus())
+	}
+	for _, hit := range merged.Hits {
+		if rows[hit.Source] == nil {
+			result := results[hit.Source].GetResults()
+			rows[hit.Source], err = buildQueryResp(0, result.GetOutputFields(), result.GetFieldsData(), result.GetIds(), result.GetScores(), allowJS, collSchemas[hit.Source])
+			if err == nil && hit.Offset >= len(rows[hit.Source]) {
+				err = merr.WrapErrServiceInternalMsg("hit offset %d out of range %d", hit.Offset, len(rows[hit.Source]))
+			}
+			if err != nil {
+				mlog.Warn(ctx, "high level restful api, fail to deal with federated search result", mlog.Err(err))
+				HTTPReturn(c, http.StatusOK, gin.H{
+					HTTPReturnCode:    merr.Code(merr.ErrInvalidSearchResult),
+					HTTPReturnMessage: merr.ErrInvalidSearchResult.Error() + ", error: " + err.Error(),
+				})
+				return nil, err
+			}
+		}
+		row := rows[hit.Source][hit.Offset]
+		row[HTTPReturnDistance] = hit.Score
+		row[HTTPCollectionName] = httpReq.CollectionNames[hit.Source]
+		outputData = append(outputData, row)
+	}
+	HTTPReturnStream(c, http.StatusOK, gin.H{
+		HTTPReturnCode:  merr.Code(nil),
+		HTTPReturnData:  outputData,
+		HTTPReturnCost:  cost,
+		HTTPReturnTopks: merged.Topks,
+	})
+	return &milvuspb.SearchResults{Status: merr.Success()}, nil
+}
+
+// getFederatedMetricType returns the metric type of the index on the vector field.
+func (h *HandlersV2) getFederatedMetricType(ctx context.Context, req *milvuspb.SearchRequest, annsField string) (string, error) {
+	resp, err := h.proxy.DescribeIndex(ctx, &milvuspb.DescribeIndexRequest{
+		DbName:         req.GetDbName(),
+		CollectionName: req.GetCollectionName(),
+		FieldName:      annsField,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return "", err
+	}
+	for _, index := range resp.GetIndexDescriptions() {
+		if index.GetFieldName() == annsField {
+			return getMetricType(index.GetParams()), nil
+		}
+	}
+	return metric.L2, nil
+}
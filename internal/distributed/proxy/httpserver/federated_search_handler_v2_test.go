@@ -0,0 +1,88 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestFederatedSearchV2(t *testing.T) {
+	paramtable.Init()
+	// the proxy is not called when the request is rejected
+	mp := mocks.NewMockProxy(t)
+	testEngine := initHTTPServerV2(mp, false)
+
+	type testCase struct {
+		name string
+		body string
+		code int32
+	}
+	cases := []testCase{
+		{
+			name: "no collections",
+			body: `{"collectionNames": [], "data": [[0.1, 0.2]]}`,
+			code: merr.Code(merr.ErrParameterMissing),
+		},
+		{
+			name: "no data",
+			body: `{"collectionNames": ["book"], "data": []}`,
+			code: merr.Code(merr.ErrParameterInvalid),
+		},
+		{
+			name: "invalid limit",
+			body: `{"collectionNames": ["book"], "data": [[0.1, 0.2]], "limit": -1}`,
+			code: merr.Code(merr.ErrParameterInvalid),
+		},
+		{
+			name: "pagination in search params",
+			body: `{"collectionNames": ["book"], "data": [[0.1, 0.2]], "searchParams": {"offset": 10}}`,
+			code: merr.Code(merr.ErrParameterInvalid),
+		},
+		{
+			name: "iterator in search params",
+			body: `{"collectionNames": ["book"], "data": [[0.1, 0.2]], "searchParams": {"iterator": true}}`,
+			code: merr.Code(merr.ErrParameterInvalid),
+		},
+		{
+			name: "collection not found",
+			body: `{"collectionNames": ["book", "movie"], "data": [[0.1, 0.2]]}`,
+			code: merr.Code(merr.ErrParameterInvalid),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, versionalV2(EntityCategory, FederatedSearchAction), bytes.NewReader([]byte(tc.body)))
+			w := httptest.NewRecorder()
+			testEngine.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+			ret := &iteratorTestReturn{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), ret))
+			assert.Equal(t, tc.code, ret.Code, ret.Message)
+		})
+	}
+}
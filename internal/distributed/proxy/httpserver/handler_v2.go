@@ -109,14 +109,15 @@ var routeToMethod = map[string]string{ //nolint:gosec // not credentials, just a
 	"/v2/vectordb/databases/alter":            "AlterDatabase",
 	"/v2/vectordb/databases/alter_properties": "AlterDatabase",
 
-	"/v2/vectordb/entities/query":           "Query",
-	"/v2/vectordb/entities/get":             "Query",
-	"/v2/vectordb/entities/delete":          "Delete",
-	"/v2/vectordb/entities/insert":          "Insert",
-	"/v2/vectordb/entities/upsert":          "Upsert",
-	"/v2/vectordb/entities/search":          "Search",
-	"/v2/vectordb/entities/advanced_search": "HybridSearch",
-	"/v2/vectordb/entities/hybrid_search":   "HybridSearch",
+	"/v2/vectordb/entities/query":            "Query",
+	"/v2/vectordb/entities/get":              "Query",
+	"/v2/vectordb/entities/delete":           "Delete",
+	"/v2/vectordb/entities/insert":           "Insert",
+	"/v2/vectordb/entities/upsert":           "Upsert",
+	"/v2/vectordb/entities/search":           "Search",
+	"/v2/vectordb/entities/advanced_search":  "HybridSearch",
+	"/v2/vectordb/entities/hybrid_search":    "HybridSearch",
+	"/v2/vectordb/entities/federated_search": "Search",
 
 	"/v2/vectordb/partitions/list":      "ShowPartitions",
 	"/v2/vectordb/partitions/has":       "HasPartition",
@@ -190,168 +191,194 @@ var routeToMethod = map[string]string{ //nolint:gosec // not credentials, just a
 	"/v2/vectordb/common/run_analyzer": "RunAnalyzer",
 }
 
-func (h *HandlersV2) RegisterRoutesToV2(router gin.IRouter) {
-	router.POST(CollectionCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listCollections))))
-	router.POST(CollectionCategory+HasAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.hasCollection))))
+func (h *HandlersV2) RegisterRoutesToV2(ginRouter gin.IRouter) {
+	// the registered routes are described by the OpenAPI document
+	router := &routeRecorder{IRouter: ginRouter}
+	h.registerRoutesToV2(router)
+	ginRouter.GET(OpenAPIPath, openAPIHandler(router.routes))
+}
+
+func (h *HandlersV2) registerRoutesToV2(router *routeRecorder) {
+	router.POST(CollectionCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listCollections))))
+	router.POST(CollectionCategory+HasAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.hasCollection))))
 	// todo review the return data
-	router.POST(CollectionCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionDetails))))
-	router.POST(CollectionCategory+StatsAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionStats))))
-	router.POST(CollectionCategory+LoadStateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionLoadState))))
-	router.POST(CollectionCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionReq{AutoID: DisableAutoID} }, wrapperTraceLog(h.createCollection))))
-	router.POST(CollectionCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.dropCollection))))
-	router.POST(CollectionCategory+TruncateAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.truncateCollection))))
-	router.POST(CollectionCategory+RenameAction, timeoutMiddleware(wrapperPost(func() any { return &RenameCollectionReq{} }, wrapperTraceLog(h.renameCollection))))
-	router.POST(CollectionCategory+LoadAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.loadCollection))))
-	router.POST(CollectionCategory+RefreshLoadAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.refreshLoadCollection))))
-	router.POST(CollectionCategory+ReleaseAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.releaseCollection))))
-	router.POST(CollectionCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionReqWithProperties{} }, wrapperTraceLog(h.alterCollectionProperties))))
-	router.POST(CollectionCategory+AddFunctionAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionAddFunction{} }, wrapperTraceLog(h.addCollectionFunction))))
-	router.POST(CollectionCategory+AlterFunctionAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionAlterFunction{} }, wrapperTraceLog(h.alterCollectionFunction))))
-	router.POST(CollectionCategory+DropFunctionAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionDropFunction{} }, wrapperTraceLog(h.dropCollectionFunction))))
-	router.POST(CollectionCategory+AddFunctionFieldAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionAddFunctionField{} }, wrapperTraceLog(h.addCollectionFunctionField))))
-	router.POST(CollectionCategory+DropFunctionFieldAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionDropFunctionField{} }, wrapperTraceLog(h.dropCollectionFunctionField))))
-	router.POST(CollectionCategory+DropPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DropCollectionPropertiesReq{} }, wrapperTraceLog(h.dropCollectionProperties))))
-	router.POST(CollectionCategory+CompactAction, timeoutMiddleware(wrapperPost(func() any { return &CompactReq{} }, wrapperTraceLog(h.compact))))
-	router.POST(CollectionCategory+CompactionStateAction, timeoutMiddleware(wrapperPost(func() any { return &GetCompactionStateReq{} }, wrapperTraceLog(h.getcompactionState))))
-	router.POST(CollectionCategory+FlushAction, timeoutMiddleware(wrapperPost(func() any { return &FlushReq{} }, wrapperTraceLog(h.flush))))
-
-	router.POST(CollectionFieldCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionFieldReqWithParams{} }, wrapperTraceLog(h.alterCollectionFieldProperties))))
+	router.POST(CollectionCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionDetails))))
+	router.POST(CollectionCategory+StatsAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionStats))))
+	router.POST(CollectionCategory+LoadStateAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.getCollectionLoadState))))
+	router.POST(CollectionCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionReq{AutoID: DisableAutoID} }, wrapperTraceLog(h.createCollection))))
+	router.POST(CollectionCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.dropCollection))))
+	router.POST(CollectionCategory+TruncateAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.truncateCollection))))
+	router.POST(CollectionCategory+RenameAction, timeoutMiddleware(router.wrapperPost(func() any { return &RenameCollectionReq{} }, wrapperTraceLog(h.renameCollection))))
+	router.POST(CollectionCategory+LoadAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.loadCollection))))
+	router.POST(CollectionCategory+RefreshLoadAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.refreshLoadCollection))))
+	router.POST(CollectionCategory+ReleaseAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.releaseCollection))))
+	router.POST(CollectionCategory+AlterPropertiesAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionReqWithProperties{} }, wrapperTraceLog(h.alterCollectionProperties))))
+	router.POST(CollectionCategory+AddFunctionAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionAddFunction{} }, wrapperTraceLog(h.addCollectionFunction))))
+	router.POST(CollectionCategory+AlterFunctionAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionAlterFunction{} }, wrapperTraceLog(h.alterCollectionFunction))))
+	router.POST(CollectionCategory+DropFunctionAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionDropFunction{} }, wrapperTraceLog(h.dropCollectionFunction))))
+	router.POST(CollectionCategory+AddFunctionFieldAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionAddFunctionField{} }, wrapperTraceLog(h.addCollectionFunctionField))))
+	router.POST(CollectionCategory+DropFunctionFieldAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionDropFunctionField{} }, wrapperTraceLog(h.dropCollectionFunctionField))))
+	router.POST(CollectionCategory+DropPropertiesAction, timeoutMiddleware(router.wrapperPost(func() any { return &DropCollectionPropertiesReq{} }, wrapperTraceLog(h.dropCollectionProperties))))
+	router.POST(CollectionCategory+CompactAction, timeoutMiddleware(router.wrapperPost(func() any { return &CompactReq{} }, wrapperTraceLog(h.compact))))
+	router.POST(CollectionCategory+CompactionStateAction, timeoutMiddleware(router.wrapperPost(func() any { return &GetCompactionStateReq{} }, wrapperTraceLog(h.getcompactionState))))
+	router.POST(CollectionCategory+FlushAction, timeoutMiddleware(router.wrapperPost(func() any { return &FlushReq{} }, wrapperTraceLog(h.flush))))
+
+	router.POST(CollectionFieldCategory+AlterPropertiesAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionFieldReqWithParams{} }, wrapperTraceLog(h.alterCollectionFieldProperties))))
 
 	// /collections/fields/add
-	router.POST(CollectionFieldCategory+AddAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionFieldReqWithSchema{} }, wrapperTraceLog(h.addCollectionField))))
-	router.POST(CollectionStructFieldCategory+AddAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionFieldReqWithSchema{} }, wrapperTraceLog(h.addCollectionStructField))))
-
-	router.POST(DataBaseCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.createDatabase))))
-	router.POST(DataBaseCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqRequiredName{} }, wrapperTraceLog(h.dropDatabase))))
-	router.POST(DataBaseCategory+DropPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DropDatabasePropertiesReq{} }, wrapperTraceLog(h.dropDatabaseProperties))))
-	router.POST(DataBaseCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &EmptyReq{} }, wrapperTraceLog(h.listDatabases))))
-	router.POST(DataBaseCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqRequiredName{} }, wrapperTraceLog(h.describeDatabase))))
-	router.POST(DataBaseCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.alterDatabase))))
-	router.POST(DataBaseCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.alterDatabase))))
+	router.POST(CollectionFieldCategory+AddAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionFieldReqWithSchema{} }, wrapperTraceLog(h.addCollectionField))))
+	router.POST(CollectionStructFieldCategory+AddAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionFieldReqWithSchema{} }, wrapperTraceLog(h.addCollectionStructField))))
+
+	router.POST(DataBaseCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.createDatabase))))
+	router.POST(DataBaseCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReqRequiredName{} }, wrapperTraceLog(h.dropDatabase))))
+	router.POST(DataBaseCategory+DropPropertiesAction, timeoutMiddleware(router.wrapperPost(func() any { return &DropDatabasePropertiesReq{} }, wrapperTraceLog(h.dropDatabaseProperties))))
+	router.POST(DataBaseCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &EmptyReq{} }, wrapperTraceLog(h.listDatabases))))
+	router.POST(DataBaseCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReqRequiredName{} }, wrapperTraceLog(h.describeDatabase))))
+	router.POST(DataBaseCategory+AlterAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.alterDatabase))))
+	router.POST(DataBaseCategory+AlterPropertiesAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReqWithProperties{} }, wrapperTraceLog(h.alterDatabase))))
 	// Query
-	router.POST(EntityCategory+QueryAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+QueryAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &QueryReqV2{
 			Limit:        100,
 			OutputFields: []string{DefaultOutputFields},
 		}
 	}, wrapperTraceLog(h.query))), true))
 	// Get
-	router.POST(EntityCategory+GetAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+GetAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &CollectionIDReq{
 			OutputFields: []string{DefaultOutputFields},
 		}
 	}, wrapperTraceLog(h.get))), true))
 	// Delete
-	router.POST(EntityCategory+DeleteAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+DeleteAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &CollectionFilterReq{}
 	}, wrapperTraceLog(h.delete))), false))
 	// Insert
-	router.POST(EntityCategory+InsertAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+InsertAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &CollectionDataReq{}
 	}, wrapperTraceLog(h.insert))), false))
 	// Upsert
-	router.POST(EntityCategory+UpsertAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+UpsertAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &CollectionDataReq{}
 	}, wrapperTraceLog(h.upsert))), false))
 	// Search
-	router.POST(EntityCategory+SearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+SearchAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &SearchReqV2{
 			Limit: 100,
 		}
 	}, wrapperTraceLog(h.search))), true))
 	// advanced_search, backward compatible uri
-	router.POST(EntityCategory+AdvancedSearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+AdvancedSearchAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &HybridSearchReq{
 			Limit: 100,
 		}
 	}, wrapperTraceLog(h.advancedSearch))), true))
 	// HybridSearch
-	router.POST(EntityCategory+HybridSearchAction, restfulSizeMiddleware(timeoutMiddleware(wrapperPost(func() any {
+	router.POST(EntityCategory+HybridSearchAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
 		return &HybridSearchReq{
 			Limit: 100,
 		}
 	}, wrapperTraceLog(h.advancedSearch))), true))
+	// QueryIterator
+	router.POST(EntityCategory+QueryIteratorAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
+		return &QueryIteratorReqV2{
+			BatchSize:    defaultIteratorBatchSize,
+			OutputFields: []string{DefaultOutputFields},
+		}
+	}, wrapperTraceLog(h.queryIterator))), true))
+	// SearchIterator
+	router.POST(EntityCategory+SearchIteratorAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
+		return &SearchIteratorReqV2{
+			BatchSize: defaultIteratorBatchSize,
+		}
+	}, wrapperTraceLog(h.searchIterator))), true))
+	// FederatedSearch
+	router.POST(EntityCategory+FederatedSearchAction, restfulSizeMiddleware(timeoutMiddleware(router.wrapperPost(func() any {
+		return &FederatedSearchReqV2{
+			Limit: 100,
+		}
+	}, wrapperTraceLog(h.federatedSearch))), true))
 
-	router.POST(PartitionCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.listPartitions))))
-	router.POST(PartitionCategory+HasAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.hasPartitions))))
-	router.POST(PartitionCategory+StatsAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.statsPartition))))
+	router.POST(PartitionCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.listPartitions))))
+	router.POST(PartitionCategory+HasAction, timeoutMiddleware(router.wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.hasPartitions))))
+	router.POST(PartitionCategory+StatsAction, timeoutMiddleware(router.wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.statsPartition))))
 
-	router.POST(PartitionCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.createPartition))))
-	router.POST(PartitionCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.dropPartition))))
-	router.POST(PartitionCategory+LoadAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.loadPartitions))))
-	router.POST(PartitionCategory+ReleaseAction, timeoutMiddleware(wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.releasePartitions))))
+	router.POST(PartitionCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.createPartition))))
+	router.POST(PartitionCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &PartitionReq{} }, wrapperTraceLog(h.dropPartition))))
+	router.POST(PartitionCategory+LoadAction, timeoutMiddleware(router.wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.loadPartitions))))
+	router.POST(PartitionCategory+ReleaseAction, timeoutMiddleware(router.wrapperPost(func() any { return &PartitionsReq{} }, wrapperTraceLog(h.releasePartitions))))
 
-	router.POST(UserCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listUsers))))
-	router.POST(UserCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &UserReq{} }, wrapperTraceLog(h.describeUser))))
+	router.POST(UserCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listUsers))))
+	router.POST(UserCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &UserReq{} }, wrapperTraceLog(h.describeUser))))
 
-	router.POST(UserCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &PasswordReq{} }, wrapperTraceLog(h.createUser))))
-	router.POST(UserCategory+UpdatePasswordAction, timeoutMiddleware(wrapperPost(func() any { return &NewPasswordReq{} }, wrapperTraceLog(h.updateUser))))
-	router.POST(UserCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &UserReq{} }, wrapperTraceLog(h.dropUser))))
-	router.POST(UserCategory+GrantRoleAction, timeoutMiddleware(wrapperPost(func() any { return &UserRoleReq{} }, wrapperTraceLog(h.addRoleToUser))))
-	router.POST(UserCategory+RevokeRoleAction, timeoutMiddleware(wrapperPost(func() any { return &UserRoleReq{} }, wrapperTraceLog(h.removeRoleFromUser))))
+	router.POST(UserCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &PasswordReq{} }, wrapperTraceLog(h.createUser))))
+	router.POST(UserCategory+UpdatePasswordAction, timeoutMiddleware(router.wrapperPost(func() any { return &NewPasswordReq{} }, wrapperTraceLog(h.updateUser))))
+	router.POST(UserCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &UserReq{} }, wrapperTraceLog(h.dropUser))))
+	router.POST(UserCategory+GrantRoleAction, timeoutMiddleware(router.wrapperPost(func() any { return &UserRoleReq{} }, wrapperTraceLog(h.addRoleToUser))))
+	router.POST(UserCategory+RevokeRoleAction, timeoutMiddleware(router.wrapperPost(func() any { return &UserRoleReq{} }, wrapperTraceLog(h.removeRoleFromUser))))
 
-	router.POST(RoleCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listRoles))))
-	router.POST(RoleCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.describeRole))))
+	router.POST(RoleCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listRoles))))
+	router.POST(RoleCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.describeRole))))
 
-	router.POST(RoleCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.createRole))))
-	router.POST(RoleCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.alterRole))))
-	router.POST(RoleCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.dropRole))))
-	router.POST(RoleCategory+GrantPrivilegeAction, timeoutMiddleware(wrapperPost(func() any { return &GrantReq{} }, wrapperTraceLog(h.addPrivilegeToRole))))
-	router.POST(RoleCategory+RevokePrivilegeAction, timeoutMiddleware(wrapperPost(func() any { return &GrantReq{} }, wrapperTraceLog(h.removePrivilegeFromRole))))
-	router.POST(RoleCategory+GrantPrivilegeActionV2, timeoutMiddleware(wrapperPost(func() any { return &GrantV2Req{} }, wrapperTraceLog(h.grantV2))))
-	router.POST(RoleCategory+RevokePrivilegeActionV2, timeoutMiddleware(wrapperPost(func() any { return &GrantV2Req{} }, wrapperTraceLog(h.revokeV2))))
+	router.POST(RoleCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.createRole))))
+	router.POST(RoleCategory+AlterAction, timeoutMiddleware(router.wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.alterRole))))
+	router.POST(RoleCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &RoleReq{} }, wrapperTraceLog(h.dropRole))))
+	router.POST(RoleCategory+GrantPrivilegeAction, timeoutMiddleware(router.wrapperPost(func() any { return &GrantReq{} }, wrapperTraceLog(h.addPrivilegeToRole))))
+	router.POST(RoleCategory+RevokePrivilegeAction, timeoutMiddleware(router.wrapperPost(func() any { return &GrantReq{} }, wrapperTraceLog(h.removePrivilegeFromRole))))
+	router.POST(RoleCategory+GrantPrivilegeActionV2, timeoutMiddleware(router.wrapperPost(func() any { return &GrantV2Req{} }, wrapperTraceLog(h.grantV2))))
+	router.POST(RoleCategory+RevokePrivilegeActionV2, timeoutMiddleware(router.wrapperPost(func() any { return &GrantV2Req{} }, wrapperTraceLog(h.revokeV2))))
 
 	// privilege group
-	router.POST(PrivilegeGroupCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.createPrivilegeGroup))))
-	router.POST(PrivilegeGroupCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.dropPrivilegeGroup))))
-	router.POST(PrivilegeGroupCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listPrivilegeGroups))))
-	router.POST(PrivilegeGroupCategory+AddPrivilegesToGroupAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.addPrivilegesToGroup))))
-	router.POST(PrivilegeGroupCategory+RemovePrivilegesFromGroupAction, timeoutMiddleware(wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.removePrivilegesFromGroup))))
+	router.POST(PrivilegeGroupCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.createPrivilegeGroup))))
+	router.POST(PrivilegeGroupCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.dropPrivilegeGroup))))
+	router.POST(PrivilegeGroupCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &DatabaseReq{} }, wrapperTraceLog(h.listPrivilegeGroups))))
+	router.POST(PrivilegeGroupCategory+AddPrivilegesToGroupAction, timeoutMiddleware(router.wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.addPrivilegesToGroup))))
+	router.POST(PrivilegeGroupCategory+RemovePrivilegesFromGroupAction, timeoutMiddleware(router.wrapperPost(func() any { return &PrivilegeGroupReq{} }, wrapperTraceLog(h.removePrivilegesFromGroup))))
 
-	router.POST(IndexCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.listIndexes))))
-	router.POST(IndexCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &IndexReq{} }, wrapperTraceLog(h.describeIndex))))
+	router.POST(IndexCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &CollectionNameReq{} }, wrapperTraceLog(h.listIndexes))))
+	router.POST(IndexCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &IndexReq{} }, wrapperTraceLog(h.describeIndex))))
 
-	router.POST(IndexCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &IndexParamReq{} }, wrapperTraceLog(h.createIndex))))
+	router.POST(IndexCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &IndexParamReq{} }, wrapperTraceLog(h.createIndex))))
 	// todo cannot drop index before release it ?
-	router.POST(IndexCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &IndexReq{} }, wrapperTraceLog(h.dropIndex))))
-	router.POST(IndexCategory+AlterPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &IndexReqWithProperties{} }, wrapperTraceLog(h.alterIndexProperties))))
-	router.POST(IndexCategory+DropPropertiesAction, timeoutMiddleware(wrapperPost(func() any { return &DropIndexPropertiesReq{} }, wrapperTraceLog(h.dropIndexProperties))))
-
-	router.POST(AliasCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listAlias))))
-	router.POST(AliasCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &AliasReq{} }, wrapperTraceLog(h.describeAlias))))
-
-	router.POST(AliasCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &AliasCollectionReq{} }, wrapperTraceLog(h.createAlias))))
-	router.POST(AliasCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &AliasReq{} }, wrapperTraceLog(h.dropAlias))))
-	router.POST(AliasCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &AliasCollectionReq{} }, wrapperTraceLog(h.alterAlias))))
-
-	router.POST(ImportJobCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listImportJob))))
-	router.POST(ImportJobCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &ImportReq{} }, wrapperTraceLog(h.createImportJob))))
-	router.POST(ImportJobCategory+GetProgressAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
-	router.POST(ImportJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
-	router.POST(ImportJobCategory+CommitAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.commitImportJob))))
-	router.POST(ImportJobCategory+AbortAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.abortImportJob))))
-	router.POST(SnapshotJobCategory+RestoreExternalAction, timeoutMiddleware(wrapperPost(func() any { return &RestoreExternalSnapshotReq{} }, wrapperTraceLog(h.restoreExternalSnapshot))))
-	router.POST(SnapshotJobCategory+ExportAction, timeoutMiddleware(wrapperPost(func() any { return &ExportSnapshotReq{} }, wrapperTraceLog(h.exportSnapshot))))
-	router.POST(SnapshotJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getRestoreSnapshotState))))
-	router.POST(SnapshotJobCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listRestoreSnapshotJobs))))
-	router.POST(ExternalCollectionJobCategory+RefreshAction, timeoutMiddleware(wrapperPost(func() any { return &RefreshExternalCollectionReq{} }, wrapperTraceLog(h.refreshExternalCollection))))
-	router.POST(ExternalCollectionJobCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &RefreshExternalCollectionProgressReq{} }, wrapperTraceLog(h.getRefreshExternalCollectionProgress))))
-	router.POST(ExternalCollectionJobCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listRefreshExternalCollectionJobs))))
+	router.POST(IndexCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &IndexReq{} }, wrapperTraceLog(h.dropIndex))))
+	router.POST(IndexCategory+AlterPropertiesAction, timeoutMiddleware(router.wrapperPost(func() any { return &IndexReqWithProperties{} }, wrapperTraceLog(h.alterIndexProperties))))
+	router.POST(IndexCategory+DropPropertiesAction, timeoutMiddleware(router.wrapperPost(func() any { return &DropIndexPropertiesReq{} }, wrapperTraceLog(h.dropIndexProperties))))
+
+	router.POST(AliasCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listAlias))))
+	router.POST(AliasCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &AliasReq{} }, wrapperTraceLog(h.describeAlias))))
+
+	router.POST(AliasCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &AliasCollectionReq{} }, wrapperTraceLog(h.createAlias))))
+	router.POST(AliasCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &AliasReq{} }, wrapperTraceLog(h.dropAlias))))
+	router.POST(AliasCategory+AlterAction, timeoutMiddleware(router.wrapperPost(func() any { return &AliasCollectionReq{} }, wrapperTraceLog(h.alterAlias))))
+
+	router.POST(ImportJobCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listImportJob))))
+	router.POST(ImportJobCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &ImportReq{} }, wrapperTraceLog(h.createImportJob))))
+	router.POST(ImportJobCategory+GetProgressAction, timeoutMiddleware(router.wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
+	router.POST(ImportJobCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getImportJobProcess))))
+	router.POST(ImportJobCategory+CommitAction, timeoutMiddleware(router.wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.commitImportJob))))
+	router.POST(ImportJobCategory+AbortAction, timeoutMiddleware(router.wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.abortImportJob))))
+	router.POST(SnapshotJobCategory+RestoreExternalAction, timeoutMiddleware(router.wrapperPost(func() any { return &RestoreExternalSnapshotReq{} }, wrapperTraceLog(h.restoreExternalSnapshot))))
+	router.POST(SnapshotJobCategory+ExportAction, timeoutMiddleware(router.wrapperPost(func() any { return &ExportSnapshotReq{} }, wrapperTraceLog(h.exportSnapshot))))
+	router.POST(SnapshotJobCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &JobIDReq{} }, wrapperTraceLog(h.getRestoreSnapshotState))))
+	router.POST(SnapshotJobCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listRestoreSnapshotJobs))))
+	router.POST(ExternalCollectionJobCategory+RefreshAction, timeoutMiddleware(router.wrapperPost(func() any { return &RefreshExternalCollectionReq{} }, wrapperTraceLog(h.refreshExternalCollection))))
+	router.POST(ExternalCollectionJobCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &RefreshExternalCollectionProgressReq{} }, wrapperTraceLog(h.getRefreshExternalCollectionProgress))))
+	router.POST(ExternalCollectionJobCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &OptionalCollectionNameReq{} }, wrapperTraceLog(h.listRefreshExternalCollectionJobs))))
 
 	// resource group
-	router.POST(ResourceGroupCategory+CreateAction, timeoutMiddleware(wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.createResourceGroup))))
-	router.POST(ResourceGroupCategory+DropAction, timeoutMiddleware(wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.dropResourceGroup))))
-	router.POST(ResourceGroupCategory+AlterAction, timeoutMiddleware(wrapperPost(func() any { return &UpdateResourceGroupReq{} }, wrapperTraceLog(h.updateResourceGroup))))
-	router.POST(ResourceGroupCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.describeResourceGroup))))
-	router.POST(ResourceGroupCategory+ListAction, timeoutMiddleware(wrapperPost(func() any { return &EmptyReq{} }, wrapperTraceLog(h.listResourceGroups))))
-	router.POST(ResourceGroupCategory+TransferReplicaAction, timeoutMiddleware(wrapperPost(func() any { return &TransferReplicaReq{} }, wrapperTraceLog(h.transferReplica))))
+	router.POST(ResourceGroupCategory+CreateAction, timeoutMiddleware(router.wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.createResourceGroup))))
+	router.POST(ResourceGroupCategory+DropAction, timeoutMiddleware(router.wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.dropResourceGroup))))
+	router.POST(ResourceGroupCategory+AlterAction, timeoutMiddleware(router.wrapperPost(func() any { return &UpdateResourceGroupReq{} }, wrapperTraceLog(h.updateResourceGroup))))
+	router.POST(ResourceGroupCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &ResourceGroupReq{} }, wrapperTraceLog(h.describeResourceGroup))))
+	router.POST(ResourceGroupCategory+ListAction, timeoutMiddleware(router.wrapperPost(func() any { return &EmptyReq{} }, wrapperTraceLog(h.listResourceGroups))))
+	router.POST(ResourceGroupCategory+TransferReplicaAction, timeoutMiddleware(router.wrapperPost(func() any { return &TransferReplicaReq{} }, wrapperTraceLog(h.transferReplica))))
 
 	// segment group
-	router.POST(SegmentCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &GetSegmentsInfoReq{} }, wrapperTraceLog(h.getSegmentsInfo))))
-	router.POST(QuotaCenterCategory+DescribeAction, timeoutMiddleware(wrapperPost(func() any { return &GetQuotaMetricsReq{} }, wrapperTraceLog(h.getQuotaMetrics))))
+	router.POST(SegmentCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &GetSegmentsInfoReq{} }, wrapperTraceLog(h.getSegmentsInfo))))
+	router.POST(QuotaCenterCategory+DescribeAction, timeoutMiddleware(router.wrapperPost(func() any { return &GetQuotaMetricsReq{} }, wrapperTraceLog(h.getQuotaMetrics))))
 
 	// common
-	router.POST(CommonCategory+RunAnalyzerAction, timeoutMiddleware(wrapperPost(func() any { return &RunAnalyzerReq{} }, wrapperTraceLog(h.runAnalyzer))))
+	router.POST(CommonCategory+RunAnalyzerAction, timeoutMiddleware(router.wrapperPost(func() any { return &RunAnalyzerReq{} }, wrapperTraceLog(h.runAnalyzer))))
 }
 
 type (
@@ -568,6 +595,23 @@ func wrapperProxyWithLimit(ctx context.Context, ginCtx *gin.Context, req any, ch
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent(baseGetter.GetBase().GetMsgType().String())
 	}
+	username, ok := ginCtx.Get(ContextUsername)
+	if !ok {
+		username = ""
+	}
+	// the audit runs before the authorization, so that the denied requests are recorded as well
+	executed := false
+	response, err := proxy.AuditInterceptor(context.WithValue(ctx, hook.GinParamsKey, ginCtx), req, username.(string), fullMethod, func(ctx context.Context, req any) (any, error) {
+		executed = true
+		return callProxyWithLimit(ctx, ginCtx, req, checkAuth, ignoreErr, fullMethod, checkLimit, pxy, handler)
+	})
+	if err != nil && !executed && !ignoreErr {
+		HTTPAbortReturn(ginCtx, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+	}
+	return response, err
+}
+
+func callProxyWithLimit(ctx context.Context, ginCtx *gin.Context, req any, checkAuth bool, ignoreErr bool, fullMethod string, checkLimit bool, pxy types.ProxyComponent, handler func(reqCtx context.Context, req any) (any, error)) (interface{}, error) {
 	if checkAuth {
 		err := checkAuthorizationV2(ctx, ginCtx, ignoreErr, req)
 		if err != nil {
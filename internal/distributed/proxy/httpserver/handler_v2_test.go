@@ -81,6 +81,8 @@ func (req *DefaultReq) GetDbName() string { return req.DbName }
 func init() {
 	paramtable.Init()
 	streaming.SetupNoopWALForTest()
+	cursorKey := []byte("cluster-key")
+	clusterCursorKey.Store(&cursorKey)
 }
 
 func sendReqAndVerify(t *testing.T, testEngine *gin.Engine, testName, method string, testcase requestBodyTestCase) {
@@ -0,0 +1,245 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cockroachdb/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// iteratorCursor is the state of a query or search iterator, which is handed to the client
+// as an opaque signed token, so the proxies stay stateless between the pages.
+type iteratorCursor struct {
+	// Binding is the fingerprint of the request, the cursor is only valid for the same request.
+	Binding string `json:"binding"`
+	// SessionTs is the mvcc timestamp returned by the first page, all the pages read the same snapshot.
+	SessionTs uint64 `json:"sessionTs,omitempty"`
+	// Remaining is the number of entities to return before reaching the limit, -1 for unlimited.
+	Remaining int64 `json:"remaining"`
+
+	// LastIntPK or LastStrPK is the primary key of the last entity returned by the query iterator.
+	LastIntPK *int64  `json:"lastIntPk,omitempty"`
+	LastStrPK *string `json:"lastStrPk,omitempty"`
+
+	// Token is the id of the search iterator, LastBound is the distance bound of the last page.
+	Token     string   `json:"token,omitempty"`
+	LastBound *float32 `json:"lastBound,omitempty"`
+}
+
+// iteratorCursorKeyPath is the etcd path of the cluster-wide key to sign the cursors,
+// which is used if the secret is not configured.
+const iteratorCursorKeyPath = "proxy/iterator-cursor-key"
+
+var clusterCursorKey atomic.Pointer[[]byte]
+
+// InitIteratorCursorKey loads the cluster-wide key to sign the cursors from etcd, or creates it if it doesn't exist,
+// so that the cursors issued by a proxy are accepted by the others.
+func InitIteratorCursorKey(ctx context.Context, cli *clientv3.Client) error {
+	key := path.Join(paramtable.Get().EtcdCfg.MetaRootPath.GetValue(), iteratorCursorKeyPath)
+	secret := make([]byte, sha256.Size)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	resp, err := cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, hex.EncodeToString(secret))).
+		Else(clientv3.OpGet(key)).
+		Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to init the iterator cursor key")
+	}
+	if !resp.Succeeded {
+		kvs := resp.Responses[0].GetResponseRange().GetKvs()
+		if len(kvs) == 0 {
+			return errors.New("the iterator cursor key is removed concurrently")
+		}
+		if secret, err = hex.DecodeString(string(kvs[0].Value)); err != nil {
+			return errors.Wrap(err, "invalid iterator cursor key")
+		}
+	}
+	clusterCursorKey.Store(&secret)
+	return nil
+}
+
+// getIteratorCursorKey returns the key to sign the cursors, the configured secret takes precedence over the cluster-wide key.
+func getIteratorCursorKey() ([]byte, error) {
+	if secret := paramtable.Get().HTTPCfg.IteratorCursorSecret.GetValue(); secret != "" {
+		return []byte(secret), nil
+	}
+	if key := clusterCursorKey.Load(); key != nil {
+		return *key, nil
+	}
+	return nil, merr.WrapErrServiceUnavailable("the iterator cursor key is not initialized",
+		"set proxy.http.iteratorCursorSecret to sign the iterator cursors")
+}
+
+func signIteratorCursor(payload []byte) ([]byte, error) {
+	key, err := getIteratorCursorKey()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil), nil
+}
+
+// encodeIteratorCursor encodes the cursor as base64(payload).base64(signature).
+func encodeIteratorCursor(cursor *iteratorCursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	signature, err := signIteratorCursor(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// decodeIteratorCursor verifies the signature of the token and decodes the cursor,
+// the binding must match the one of the current request.
+func decodeIteratorCursor(token string, binding string) (*iteratorCursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid iterator cursor")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid iterator cursor")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid iterator cursor")
+	}
+	expected, err := signIteratorCursor(payload)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(signature, expected) {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid iterator cursor, the signature does not match")
+	}
+	cursor := &iteratorCursor{}
+	if err := json.Unmarshal(payload, cursor); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid iterator cursor")
+	}
+	if cursor.Binding != binding {
+		return nil, merr.WrapErrParameterInvalidMsg("the iterator cursor was issued for another request, " +
+			"the collection, filter and search parameters must not change during the iteration")
+	}
+	return cursor, nil
+}
+
+// iteratorBinding returns the fingerprint of the parts of a request which must not change during the iteration.
+func iteratorBinding(parts ...any) (string, error) {
+	bs, err := json.Marshal(parts)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// newIteratorCursor returns the cursor of the first page, the limit not greater than 0 means unlimited.
+func newIteratorCursor(binding string, limit int64) *iteratorCursor {
+	if limit <= 0 {
+		limit = -1
+	}
+	return &iteratorCursor{Binding: binding, Remaining: limit}
+}
+
+// pageSize returns the number of entities to fetch for the next page.
+func (cursor *iteratorCursor) pageSize(batchSize int64) int64 {
+	if cursor.Remaining >= 0 && cursor.Remaining < batchSize {
+		return cursor.Remaining
+	}
+	return batchSize
+}
+
+// consume counts the entities returned by the page, returns false if the iteration is done.
+func (cursor *iteratorCursor) consume(count int64) bool {
+	if count == 0 {
+		return false
+	}
+	if cursor.Remaining >= 0 {
+		cursor.Remaining -= count
+		return cursor.Remaining > 0
+	}
+	return true
+}
+
+// composeExpr combines the filter with the range of the primary key after the last entity,
+// the same way the query iterator of the go sdk does.
+func (cursor *iteratorCursor) composeExpr(filter string, pkField *schemapb.FieldSchema) string {
+	var pkFilter string
+	switch {
+	case cursor.LastIntPK != nil:
+		pkFilter = fmt.Sprintf("%s > %d", pkField.GetName(), *cursor.LastIntPK)
+	case cursor.LastStrPK != nil:
+		pkFilter = fmt.Sprintf("%s > %s", pkField.GetName(), strconv.Quote(*cursor.LastStrPK))
+	default:
+		return filter
+	}
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return pkFilter
+	}
+	return fmt.Sprintf("(%s) and %s", filter, pkFilter)
+}
+
+// setLastPK records the last primary key of the page from the query results.
+func (cursor *iteratorCursor) setLastPK(fieldsData []*schemapb.FieldData, pkField *schemapb.FieldSchema) error {
+	for _, fieldData := range fieldsData {
+		if fieldData.GetFieldName() != pkField.GetName() {
+			continue
+		}
+		switch pkField.GetDataType() {
+		case schemapb.DataType_Int64:
+			data := fieldData.GetScalars().GetLongData().GetData()
+			if len(data) > 0 {
+				cursor.LastIntPK = &data[len(data)-1]
+			}
+			return nil
+		case schemapb.DataType_VarChar:
+			data := fieldData.GetScalars().GetStringData().GetData()
+			if len(data) > 0 {
+				cursor.LastStrPK = &data[len(data)-1]
+			}
+			return nil
+		default:
+			return merr.WrapErrParameterInvalidMsg("unsupported primary key type %s for query iterator", pkField.GetDataType().String())
+		}
+	}
+	return merr.WrapErrServiceInternal("primary key field " + pkField.GetName() + " is missing in the query results")
+}
@@ -0,0 +1,279 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const defaultIteratorBatchSize = 1000
+
+// iteratorReturn returns the page of an iterator, the cursor is empty if the iteration is done.
+func iteratorReturn(ctx context.Context, c *gin.Context, cursor *iteratorCursor, hasNext bool, outputData any, status *commonpb.Status) error {
+	token := ""
+	if hasNext {
+		var err error
+		token, err = encodeIteratorCursor(cursor)
+		if err != nil {
+			mlog.Warn(ctx, "high level restful api, fail to encode iterator cursor", mlog.Err(err))
+			HTTPReturn(c, http.StatusOK, gin.H{
+				HTTPReturnCode:    merr.Code(err),
+				HTTPReturnMessage: err.Error(),
+			})
+			return err
+		}
+	}
+	HTTPReturnStream(c, http.StatusOK, gin.H{
+		HTTPReturnCode:   merr.Code(nil),
+		HTTPReturnData:   outputData,
+		HTTPReturnCost:   proxy.GetCostValue(status),
+		HTTPReturnCursor: token,
+	})
+	return nil
+}
+
+// prepareIteratorCursor decodes the cursor of the request, or creates the one of the first page.
+func prepareIteratorCursor(ctx context.Context, c *gin.Context, token string, limit int64, bindingParts ...any) (*iteratorCursor, error) {
+	binding, err := iteratorBinding(bindingParts...)
+	if err == nil {
+		if token == "" {
+			return newIteratorCursor(binding, limit), nil
+		}
+		var cursor *iteratorCursor
+		if cursor, err = decodeIteratorCursor(token, binding); err == nil {
+			return cursor, nil
+		}
+	}
+	mlog.Warn(ctx, "high level restful api, iterator cursor invalid", mlog.Err(err))
+	HTTPAbortReturn(c, http.StatusOK, gin.H{
+		HTTPReturnCode:    merr.Code(err),
+		HTTPReturnMessage: err.Error(),
+	})
+	return nil, err
+}
+
+func (h *HandlersV2) queryIterator(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*QueryIteratorReqV2)
+	if httpReq.BatchSize <= 0 || matchCountRule(httpReq.OutputFields) {
+		err := merr.WrapErrParameterInvalidMsg("batchSize must be greater than 0, and count(*) is not supported by query iterator")
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+	cursor, err := prepareIteratorCursor(ctx, c, httpReq.Cursor, httpReq.Limit,
+		QueryIteratorAction, dbName, httpReq.CollectionName, httpReq.PartitionNames, httpReq.Filter, httpReq.ExprParams)
+	if err != nil {
+		return nil, err
+	}
+	collSchema, err := h.GetCollectionSchema(ctx, c, dbName, httpReq.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+	pkField, ok := getPrimaryField(collSchema)
+	if !ok {
+		err := merr.WrapErrParameterInvalidMsg("collection has no primary key field")
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+
+	// the primary key is required to continue from the last entity
+	outputFields := httpReq.OutputFields
+	if len(outputFields) > 0 && !containsString(outputFields, pkField.GetName()) && !containsString(outputFields, DefaultOutputFields) {
+		outputFields = append(outputFields, pkField.GetName())
+	}
+	pageSize := cursor.pageSize(httpReq.BatchSize)
+	req := &milvuspb.QueryRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		Expr:           cursor.composeExpr(httpReq.Filter, pkField),
+		OutputFields:   outputFields,
+		PartitionNames: httpReq.PartitionNames,
+		QueryParams: []*commonpb.KeyValuePair{
+			{Key: proxy.LimitKey, Value: strconv.FormatInt(pageSize, 10)},
+			{Key: proxy.IteratorField, Value: "true"},
+		},
+		// all the pages after the first one read the snapshot of the first page
+		GuaranteeTimestamp: cursor.SessionTs,
+	}
+	req.ConsistencyLevel, req.UseDefaultConsistency, err = convertConsistencyLevel(httpReq.ConsistencyLevel)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, query iterator with consistency_level invalid", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(err),
+			HTTPReturnMessage: "consistencyLevel can only be [Strong, Session, Bounded, Eventually, Customized], default: Bounded, err:" + err.Error(),
+		})
+		return nil, err
+	}
+	req.ExprTemplateValues = generateExpressionTemplate(httpReq.ExprParams)
+	c.Set(ContextRequest, req)
+	resp, err := wrapperProxyWithLimit(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/Query", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.Query(reqCtx, req.(*milvuspb.QueryRequest))
+	})
+	if err != nil {
+		return resp, err
+	}
+	queryResp := resp.(*milvuspb.QueryResults)
+	allowJS, _ := strconv.ParseBool(c.Request.Header.Get(HTTPHeaderAllowInt64))
+	outputData, err := buildQueryResp(int64(0), queryResp.OutputFields, queryResp.FieldsData, nil, nil, allowJS, collSchema)
+	if err == nil && len(outputData) > 0 {
+		err = cursor.setLastPK(queryResp.FieldsData, pkField)
+	}
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, fail to deal with query iterator result", mlog.Any("response", resp), mlog.Err(err))
+		HTTPReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrInvalidSearchResult),
+			HTTPReturnMessage: merr.ErrInvalidSearchResult.Error() + ", error: " + err.Error(),
+		})
+		return resp, err
+	}
+	if cursor.SessionTs == 0 {
+		cursor.SessionTs = queryResp.GetSessionTs()
+	}
+	return resp, iteratorReturn(ctx, c, cursor, cursor.consume(int64(len(outputData))), outputData, queryResp.GetStatus())
+}
+
+func (h *HandlersV2) searchIterator(ctx context.Context, c *gin.Context, anyReq any, dbName string) (interface{}, error) {
+	httpReq := anyReq.(*SearchIteratorReqV2)
+	if httpReq.BatchSize <= 0 || len(httpReq.Data) != 1 {
+		err := merr.WrapErrParameterInvalidMsg("batchSize must be greater than 0, and search iterator requires exactly one vector in data")
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+	body, _ := c.Get(gin.BodyBytesKey)
+	cursor, err := prepareIteratorCursor(ctx, c, httpReq.Cursor, httpReq.Limit,
+		SearchIteratorAction, dbName, httpReq.CollectionName, httpReq.PartitionNames, httpReq.Filter, httpReq.ExprParams,
+		httpReq.AnnsField, httpReq.SearchParams, gjson.Get(string(body.([]byte)), HTTPRequestData).Raw)
+	if err != nil {
+		return nil, err
+	}
+	req := &milvuspb.SearchRequest{
+		DbName:         dbName,
+		CollectionName: httpReq.CollectionName,
+		Dsl:            httpReq.Filter,
+		DslType:        commonpb.DslType_BoolExprV1,
+		OutputFields:   httpReq.OutputFields,
+		PartitionNames: httpReq.PartitionNames,
+		// all the pages after the first one read the snapshot of the first page
+		GuaranteeTimestamp: cursor.SessionTs,
+	}
+	req.ConsistencyLevel, req.UseDefaultConsistency, err = convertConsistencyLevel(httpReq.ConsistencyLevel)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, search iterator with consistency_level invalid", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(err),
+			HTTPReturnMessage: "consistencyLevel can only be [Strong, Session, Bounded, Eventually, Customized], default: Bounded, err:" + err.Error(),
+		})
+		return nil, err
+	}
+	c.Set(ContextRequest, req)
+
+	collSchema, err := h.GetCollectionSchema(ctx, c, dbName, httpReq.CollectionName)
+	if err != nil {
+		return nil, err
+	}
+	// the pagination is controlled by the cursor only
+	if searchParamsContainAny(httpReq.SearchParams, common.TopKKey, proxy.OffsetKey, proxy.GroupByFieldKey, proxy.IteratorField,
+		proxy.SearchIterV2Key, proxy.SearchIterBatchSizeKey, proxy.SearchIterIdKey, proxy.SearchIterLastBoundKey) {
+		err := merr.WrapErrParameterInvalidMsg("searchParams of search iterator must not contain the pagination or grouping parameters")
+		HTTPAbortReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return nil, err
+	}
+	searchParams, err := generateSearchParams(httpReq.SearchParams)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, generate SearchParams failed", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(err),
+			HTTPReturnMessage: err.Error(),
+		})
+		return nil, err
+	}
+	// the same parameters as the search iterator v2 of the go sdk
+	pageSize := strconv.FormatInt(cursor.pageSize(httpReq.BatchSize), 10)
+	searchParams = append(searchParams,
+		&commonpb.KeyValuePair{Key: common.TopKKey, Value: pageSize},
+		&commonpb.KeyValuePair{Key: proxy.AnnsFieldKey, Value: httpReq.AnnsField},
+		&commonpb.KeyValuePair{Key: proxy.IteratorField, Value: "true"},
+		&commonpb.KeyValuePair{Key: proxy.SearchIterV2Key, Value: "true"},
+		&commonpb.KeyValuePair{Key: proxy.SearchIterBatchSizeKey, Value: pageSize},
+	)
+	if cursor.Token != "" {
+		searchParams = append(searchParams, &commonpb.KeyValuePair{Key: proxy.SearchIterIdKey, Value: cursor.Token})
+	}
+	if cursor.LastBound != nil {
+		searchParams = append(searchParams, &commonpb.KeyValuePair{Key: proxy.SearchIterLastBoundKey, Value: fmt.Sprintf("%v", *cursor.LastBound)})
+	}
+	placeholderGroup, err := generatePlaceholderGroup(ctx, string(body.([]byte)), collSchema, httpReq.AnnsField)
+	if err != nil {
+		mlog.Warn(ctx, "high level restful api, search iterator with vector invalid", mlog.Err(err))
+		HTTPAbortReturn(c, http.StatusOK, gin.H{
+			HTTPReturnCode:    merr.Code(merr.ErrIncorrectParameterFormat),
+			HTTPReturnMessage: merr.ErrIncorrectParameterFormat.Error() + ", error: " + err.Error(),
+		})
+		return nil, err
+	}
+	req.SearchInput = &milvuspb.SearchRequest_PlaceholderGroup{PlaceholderGroup: placeholderGroup}
+	req.SearchParams = searchParams
+	req.ExprTemplateValues = generateExpressionTemplate(httpReq.ExprParams)
+	resp, err := wrapperProxyWithLimit(ctx, c, req, h.checkAuth, false, "/milvus.proto.milvus.MilvusService/Search", true, h.proxy, func(reqCtx context.Context, req any) (interface{}, error) {
+		return h.proxy.Search(reqCtx, req.(*milvuspb.SearchRequest))
+	})
+	if err != nil {
+		return resp, err
+	}
+	searchResp := resp.(*milvuspb.SearchResults)
+	iteratorInfo := searchResp.GetResults().GetSearchIteratorV2Results()
+	if iteratorInfo.GetToken() == "" {
+		err := merr.WrapErrServiceInternal("search iterator info is missing in the search results")
+		mlog.Warn(ctx, "high level restful api, fail to deal with search iterator result", mlog.Err(err))
+		HTTPReturn(c, http.StatusOK, gin.H{HTTPReturnCode: merr.Code(err), HTTPReturnMessage: err.Error()})
+		return resp, err
+	}
+	var outputData []map[string]interface{}
+	if searchResp.GetResults().GetTopK() > 0 {
+		allowJS, _ := strconv.ParseBool(c.Request.Header.Get(HTTPHeaderAllowInt64))
+		outputData, err = buildQueryResp(0, searchResp.Results.OutputFields, searchResp.Results.FieldsData, searchResp.Results.Ids, searchResp.Results.Scores, allowJS, collSchema)
+		if err != nil {
+			mlog.Warn(ctx, "high level restful api, fail to deal with search iterator result", mlog.Any("result", searchResp.Results), mlog.Err(err))
+			HTTPReturn(c, http.StatusOK, gin.H{
+				HTTPReturnCode:    merr.Code(merr.ErrInvalidSearchResult),
+				HTTPReturnMessage: merr.ErrInvalidSearchResult.Error() + ", error: " + err.Error(),
+			})
+			return resp, err
+		}
+	}
+	if outputData == nil {
+		outputData = []map[string]interface{}{}
+	}
+	lastBound := iteratorInfo.GetLastBound()
+	cursor.Token, cursor.LastBound = iteratorInfo.GetToken(), &lastBound
+	if cursor.SessionTs == 0 {
+		cursor.SessionTs = searchResp.GetSessionTs()
+	}
+	return resp, iteratorReturn(ctx, c, cursor, cursor.consume(int64(len(outputData))), outputData, searchResp.GetStatus())
+}
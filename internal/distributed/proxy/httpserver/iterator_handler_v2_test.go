@@ -0,0 +1,342 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proxy"
+	"github.com/milvus-io/milvus/pkg/v3/util/etcd"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+type iteratorTestReturn struct {
+	Code    int32                    `json:"code"`
+	Message string                   `json:"message"`
+	Data    []map[string]interface{} `json:"data"`
+	Cursor  string                   `json:"cursor"`
+}
+
+func sendIteratorRequest(t *testing.T, testEngine *gin.Engine, action string, body string) *iteratorTestReturn {
+	req := httptest.NewRequest(http.MethodPost, versionalV2(EntityCategory, action), bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+	testEngine.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	ret := &iteratorTestReturn{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), ret))
+	return ret
+}
+
+func int64PKFieldData(pks ...int64) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_Int64,
+		FieldName: FieldBookID,
+		Field: &schemapb.FieldData_Scalars{
+			Scalars: &schemapb.ScalarField{
+				Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: pks}},
+			},
+		},
+	}
+}
+
+func TestIteratorCursor(t *testing.T) {
+	paramtable.Init()
+
+	binding, err := iteratorBinding(QueryIteratorAction, "db", "book", "word_count > 0")
+	require.NoError(t, err)
+	lastPK := int64(10)
+	cursor := &iteratorCursor{Binding: binding, SessionTs: 100, Remaining: 5, LastIntPK: &lastPK}
+	token, err := encodeIteratorCursor(cursor)
+	require.NoError(t, err)
+
+	decoded, err := decodeIteratorCursor(token, binding)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	// the cursor can't be used with another request
+	otherBinding, err := iteratorBinding(QueryIteratorAction, "db", "book", "word_count > 1")
+	require.NoError(t, err)
+	_, err = decodeIteratorCursor(token, otherBinding)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// the cursor can't be modified by the client
+	forged, err := json.Marshal(&iteratorCursor{Binding: binding, Remaining: -1})
+	require.NoError(t, err)
+	_, signature, _ := bytes.Cut([]byte(token), []byte("."))
+	_, err = decodeIteratorCursor(string(forged)+"."+string(signature), binding)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	_, err = decodeIteratorCursor("garbage", binding)
+	assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+	// the configured secret takes precedence over the cluster-wide key
+	paramtable.Get().Save(paramtable.Get().HTTPCfg.IteratorCursorSecret.Key, "secret")
+	defer paramtable.Get().Reset(paramtable.Get().HTTPCfg.IteratorCursorSecret.Key)
+	_, err = decodeIteratorCursor(token, binding)
+	assert.Error(t, err)
+	token, err = encodeIteratorCursor(cursor)
+	require.NoError(t, err)
+	_, err = decodeIteratorCursor(token, binding)
+	assert.NoError(t, err)
+
+	// the cursors can't be signed without the secret or the cluster-wide key
+	paramtable.Get().Reset(paramtable.Get().HTTPCfg.IteratorCursorSecret.Key)
+	key := clusterCursorKey.Swap(nil)
+	defer clusterCursorKey.Store(key)
+	_, err = encodeIteratorCursor(cursor)
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+	_, err = decodeIteratorCursor(token, binding)
+	assert.ErrorIs(t, err, merr.ErrServiceUnavailable)
+}
+
+func TestInitIteratorCursorKey(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	etcdCli, err := etcd.GetEtcdClient(
+		params.EtcdCfg.UseEmbedEtcd.GetAsBool(),
+		params.EtcdCfg.EtcdUseSSL.GetAsBool(),
+		params.EtcdCfg.Endpoints.GetAsStrings(),
+		params.EtcdCfg.EtcdTLSCert.GetValue(),
+		params.EtcdCfg.EtcdTLSKey.GetValue(),
+		params.EtcdCfg.EtcdTLSCACert.GetValue(),
+		params.EtcdCfg.EtcdTLSMinVersion.GetValue())
+	require.NoError(t, err)
+	defer etcdCli.Close()
+
+	params.Save(params.EtcdCfg.RootPath.Key, fmt.Sprintf("test-iterator-cursor-key/%d", time.Now().UnixNano()))
+	defer params.Reset(params.EtcdCfg.RootPath.Key)
+	key := clusterCursorKey.Load()
+	defer clusterCursorKey.Store(key)
+
+	// the first proxy creates the key, the others load the same one
+	err = InitIteratorCursorKey(context.Background(), etcdCli)
+	require.NoError(t, err)
+	created := *clusterCursorKey.Load()
+	assert.Len(t, created, 32)
+	clusterCursorKey.Store(nil)
+	err = InitIteratorCursorKey(context.Background(), etcdCli)
+	require.NoError(t, err)
+	assert.Equal(t, created, *clusterCursorKey.Load())
+}
+
+func TestIteratorCursorPagination(t *testing.T) {
+	int64PK := &schemapb.FieldSchema{Name: "id", DataType: schemapb.DataType_Int64}
+	varcharPK := &schemapb.FieldSchema{Name: "id", DataType: schemapb.DataType_VarChar}
+
+	cursor := newIteratorCursor("", 0)
+	assert.Equal(t, int64(-1), cursor.Remaining)
+	assert.Equal(t, int64(10), cursor.pageSize(10))
+	assert.Equal(t, "a > 1", cursor.composeExpr("a > 1", int64PK))
+	assert.True(t, cursor.consume(10))
+	assert.False(t, cursor.consume(0))
+
+	lastIntPK := int64(7)
+	cursor.LastIntPK = &lastIntPK
+	assert.Equal(t, "id > 7", cursor.composeExpr(" ", int64PK))
+	assert.Equal(t, "(a > 1) and id > 7", cursor.composeExpr("a > 1", int64PK))
+
+	lastStrPK := `x"y`
+	cursor = &iteratorCursor{Remaining: -1, LastStrPK: &lastStrPK}
+	assert.Equal(t, `(a > 1) and id > "x\"y"`, cursor.composeExpr("a > 1", varcharPK))
+
+	cursor = newIteratorCursor("", 15)
+	assert.Equal(t, int64(10), cursor.pageSize(10))
+	assert.True(t, cursor.consume(10))
+	assert.Equal(t, int64(5), cursor.pageSize(10))
+	assert.False(t, cursor.consume(5))
+}
+
+func TestQueryIteratorV2(t *testing.T) {
+	paramtable.Init()
+	// disable rate limit
+	paramtable.Get().Save(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key)
+	mp := mocks.NewMockProxy(t)
+	mp.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+		CollectionName: DefaultCollectionName,
+		Schema:         generateCollectionSchema(schemapb.DataType_Int64, false, false),
+		ShardsNum:      ShardNumDefault,
+		Status:         &StatusSuccess,
+	}, nil)
+	pages := [][]int64{{1, 2}, {3, 4}, {5}}
+	var requests []*milvuspb.QueryRequest
+	mp.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		page := pages[len(requests)]
+		requests = append(requests, req)
+		resp := &milvuspb.QueryResults{
+			Status:       commonSuccessStatus,
+			OutputFields: []string{FieldBookID},
+			FieldsData:   []*schemapb.FieldData{int64PKFieldData(page...)},
+		}
+		if req.GetGuaranteeTimestamp() == 0 {
+			resp.SessionTs = 100
+		}
+		return resp, nil
+	}).Times(3)
+	testEngine := initHTTPServerV2(mp, false)
+
+	body := `{"collectionName": "book", "filter": "word_count > 0", "batchSize": 2, "limit": 5, "outputFields": ["word_count"]}`
+	ret := sendIteratorRequest(t, testEngine, QueryIteratorAction, body)
+	require.Equal(t, int32(0), ret.Code, ret.Message)
+	assert.Len(t, ret.Data, 2)
+	require.NotEmpty(t, ret.Cursor)
+
+	var pks []string
+	for ret.Cursor != "" {
+		for _, row := range ret.Data {
+			pks = append(pks, fmt.Sprint(row[FieldBookID]))
+		}
+		cursorBody := `{"collectionName": "book", "filter": "word_count > 0", "batchSize": 2, "outputFields": ["word_count"], "cursor": "` + ret.Cursor + `"}`
+		ret = sendIteratorRequest(t, testEngine, QueryIteratorAction, cursorBody)
+		require.Equal(t, int32(0), ret.Code, ret.Message)
+	}
+	for _, row := range ret.Data {
+		pks = append(pks, fmt.Sprint(row[FieldBookID]))
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, pks)
+
+	require.Len(t, requests, 3)
+	assert.Equal(t, "word_count > 0", requests[0].GetExpr())
+	assert.Equal(t, []string{"word_count", FieldBookID}, requests[0].GetOutputFields())
+	assert.Equal(t, uint64(0), requests[0].GetGuaranteeTimestamp())
+	assert.Equal(t, "(word_count > 0) and book_id > 2", requests[1].GetExpr())
+	assert.Equal(t, uint64(100), requests[1].GetGuaranteeTimestamp())
+	assert.Equal(t, "(word_count > 0) and book_id > 4", requests[2].GetExpr())
+	// the last page is cut by the limit
+	limit, _ := funcutil.GetAttrByKeyFromRepeatedKV(proxy.LimitKey, requests[2].GetQueryParams())
+	assert.Equal(t, "1", limit)
+	iterator, _ := funcutil.GetAttrByKeyFromRepeatedKV(proxy.IteratorField, requests[2].GetQueryParams())
+	assert.Equal(t, "true", iterator)
+
+	// the cursor is bound to the filter
+	lastPK := int64(2)
+	binding, err := iteratorBinding(QueryIteratorAction, "default", "book", []string(nil), "word_count > 0", map[string]interface{}(nil))
+	require.NoError(t, err)
+	token, err := encodeIteratorCursor(&iteratorCursor{Binding: binding, Remaining: -1, LastIntPK: &lastPK})
+	require.NoError(t, err)
+	ret = sendIteratorRequest(t, testEngine, QueryIteratorAction, `{"collectionName": "book", "filter": "word_count > 1", "cursor": "`+token+`"}`)
+	assert.Equal(t, merr.Code(merr.ErrParameterInvalid), ret.Code)
+
+	ret = sendIteratorRequest(t, testEngine, QueryIteratorAction, `{"collectionName": "book", "outputFields": ["count(*)"]}`)
+	assert.Equal(t, merr.Code(merr.ErrParameterInvalid), ret.Code)
+}
+
+func TestSearchIteratorV2(t *testing.T) {
+	paramtable.Init()
+	// disable rate limit
+	paramtable.Get().Save(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key)
+	mp := mocks.NewMockProxy(t)
+	mp.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(&milvuspb.DescribeCollectionResponse{
+		CollectionName: DefaultCollectionName,
+		Schema:         generateCollectionSchema(schemapb.DataType_Int64, false, false),
+		ShardsNum:      ShardNumDefault,
+		Status:         &StatusSuccess,
+	}, nil)
+	pages := [][]int64{{1, 2}, {3, 4}, {}, {1, 2}}
+	var requests []*milvuspb.SearchRequest
+	mp.EXPECT().Search(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+		page := pages[len(requests)]
+		requests = append(requests, req)
+		scores := make([]float32, len(page))
+		for i := range page {
+			scores[i] = float32(page[i]) / 10
+		}
+		resp := &milvuspb.SearchResults{
+			Status: commonSuccessStatus,
+			Results: &schemapb.SearchResultData{
+				NumQueries: 1,
+				TopK:       int64(len(page)),
+				Topks:      []int64{int64(len(page))},
+				Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: page}}},
+				Scores:     scores,
+				SearchIteratorV2Results: &schemapb.SearchIteratorV2Results{
+					Token:     "9f0b6a1e-58a2-4a4e-9b0b-5c2d0b6f1f6b",
+					LastBound: float32(len(requests)) / 2,
+				},
+			},
+		}
+		if req.GetGuaranteeTimestamp() == 0 {
+			resp.SessionTs = 100
+		}
+		return resp, nil
+	}).Times(4)
+	testEngine := initHTTPServerV2(mp, false)
+
+	body := `{"collectionName": "book", "data": [[0.1, 0.2]], "annsField": "book_intro", "batchSize": 2, "searchParams": {"params": {"ef": 64}}%s}`
+	ret := sendIteratorRequest(t, testEngine, SearchIteratorAction, fmtIteratorBody(body, ""))
+	require.Equal(t, int32(0), ret.Code, ret.Message)
+	assert.Len(t, ret.Data, 2)
+	require.NotEmpty(t, ret.Cursor)
+	ret = sendIteratorRequest(t, testEngine, SearchIteratorAction, fmtIteratorBody(body, ret.Cursor))
+	require.Equal(t, int32(0), ret.Code, ret.Message)
+	assert.Len(t, ret.Data, 2)
+	ret = sendIteratorRequest(t, testEngine, SearchIteratorAction, fmtIteratorBody(body, ret.Cursor))
+	require.Equal(t, int32(0), ret.Code, ret.Message)
+	assert.Empty(t, ret.Data)
+	assert.Empty(t, ret.Cursor)
+
+	require.Len(t, requests, 3)
+	for i, req := range requests {
+		iteratorV2, _ := funcutil.GetAttrByKeyFromRepeatedKV(proxy.SearchIterV2Key, req.GetSearchParams())
+		assert.Equal(t, "true", iteratorV2)
+		batchSize, _ := funcutil.GetAttrByKeyFromRepeatedKV(proxy.SearchIterBatchSizeKey, req.GetSearchParams())
+		assert.Equal(t, "2", batchSize)
+		_, err := funcutil.GetAttrByKeyFromRepeatedKV(proxy.SearchIterIdKey, req.GetSearchParams())
+		assert.Equal(t, i > 0, err == nil)
+	}
+	assert.Equal(t, uint64(0), requests[0].GetGuaranteeTimestamp())
+	assert.Equal(t, uint64(100), requests[2].GetGuaranteeTimestamp())
+	lastBound, _ := funcutil.GetAttrByKeyFromRepeatedKV(proxy.SearchIterLastBoundKey, requests[2].GetSearchParams())
+	assert.Equal(t, "1", lastBound)
+
+	// the cursor is bound to the query vector
+	ret = sendIteratorRequest(t, testEngine, SearchIteratorAction, fmtIteratorBody(body, ""))
+	require.NotEmpty(t, ret.Cursor)
+	otherVector := `{"collectionName": "book", "data": [[0.3, 0.4]], "annsField": "book_intro", "batchSize": 2, "searchParams": {"params": {"ef": 64}}, "cursor": "` + ret.Cursor + `"}`
+	ret = sendIteratorRequest(t, testEngine, SearchIteratorAction, otherVector)
+	assert.Equal(t, merr.Code(merr.ErrParameterInvalid), ret.Code)
+
+	ret = sendIteratorRequest(t, testEngine, SearchIteratorAction, `{"collectionName": "book", "data": [[0.1, 0.2], [0.3, 0.4]]}`)
+	assert.Equal(t, merr.Code(merr.ErrParameterInvalid), ret.Code)
+	ret = sendIteratorRequest(t, testEngine, SearchIteratorAction, `{"collectionName": "book", "data": [[0.1, 0.2]], "searchParams": {"offset": 10}}`)
+	assert.Equal(t, merr.Code(merr.ErrParameterInvalid), ret.Code)
+}
+
+func fmtIteratorBody(body string, cursor string) string {
+	if cursor == "" {
+		return strings.Replace(body, "%s", "", 1)
+	}
+	return strings.Replace(body, "%s", `, "cursor": "`+cursor+`"`, 1)
+}
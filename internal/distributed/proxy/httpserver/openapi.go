@@ -0,0 +1,311 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+)
+
+// OpenAPIPath is the path of the OpenAPI 3 document of the restful api v2.
+const OpenAPIPath = "/openapi.json"
+
+// openAPIResponse is the body returned by all the restful api v2.
+type openAPIResponse struct {
+	Code    int32       `json:"code" binding:"required"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+	Cost    int64       `json:"cost"`
+}
+
+// openAPIIteratorResponse is the body returned by the iterators, the cursor is empty if the iteration is done.
+type openAPIIteratorResponse struct {
+	openAPIResponse
+	Cursor string `json:"cursor"`
+}
+
+// openAPIOperation overrides the description of a route, the request body is taken from its registration.
+type openAPIOperation struct {
+	response   any
+	deprecated bool
+}
+
+// openAPIOperations are the routes registered by RegisterRoutesToV2 whose responses or states
+// differ from the default ones.
+var openAPIOperations = map[string]openAPIOperation{
+	EntityCategory + AdvancedSearchAction: {deprecated: true},
+	EntityCategory + QueryIteratorAction:  {response: &openAPIIteratorResponse{}},
+	EntityCategory + SearchIteratorAction: {response: &openAPIIteratorResponse{}},
+}
+
+// openAPIRoute is a route registered to the router, request is nil if the route has no request body.
+type openAPIRoute struct {
+	path    string
+	request any
+}
+
+// routeRecorder records the routes registered to the router and the request bodies bound by their handlers.
+type routeRecorder struct {
+	gin.IRouter
+	routes []openAPIRoute
+	// newReq is the request factory of the handler being registered, see wrapperPost
+	newReq newReqFunc
+}
+
+// wrapperPost is the same as the package level wrapperPost, and records the request factory for the POST
+// registering the returned handler, which is always called after the handler is built.
+func (r *routeRecorder) wrapperPost(newReq newReqFunc, v2 handlerFuncV2) gin.HandlerFunc {
+	r.newReq = newReq
+	return wrapperPost(newReq, v2)
+}
+
+func (r *routeRecorder) POST(path string, handlers ...gin.HandlerFunc) gin.IRoutes {
+	route := openAPIRoute{path: path}
+	if r.newReq != nil {
+		route.request = r.newReq()
+		r.newReq = nil
+	}
+	r.routes = append(r.routes, route)
+	return r.IRouter.POST(path, handlers...)
+}
+
+// openAPIHandler serves the OpenAPI document of the routes, which is built at the first request.
+func openAPIHandler(routes []openAPIRoute) gin.HandlerFunc {
+	var (
+		once sync.Once
+		doc  []byte
+		err  error
+	)
+	return func(c *gin.Context) {
+		once.Do(func() {
+			doc, err = json.Marshal(buildOpenAPISpec(routes))
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{HTTPReturnMessage: err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, "application/json", doc)
+	}
+}
+
+// buildOpenAPISpec builds the OpenAPI 3 document of the routes, the schemas are generated from the request structs.
+func buildOpenAPISpec(routes []openAPIRoute) map[string]any {
+	builder := newOpenAPISchemaBuilder()
+	defaultResponse := builder.schemaOf(reflect.TypeOf(openAPIResponse{}))
+	pathItems := make(map[string]any, len(routes))
+	for _, route := range routes {
+		path := route.path
+		// the path is /category/action, the operations are grouped by the category
+		trimmed := strings.Trim(path, "/")
+		tag := trimmed
+		if i := strings.LastIndex(trimmed, "/"); i > 0 {
+			tag = trimmed[:i]
+		}
+		operation := map[string]any{
+			"operationId": strings.ReplaceAll(trimmed, "/", "_"),
+			"tags":        []string{tag},
+			"summary":     strings.ReplaceAll(trimmed, "_", " "),
+		}
+		if route.request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": builder.schemaOf(reflect.TypeOf(route.request))},
+				},
+			}
+		}
+		response := defaultResponse
+		if op, ok := openAPIOperations[path]; ok {
+			if op.response != nil {
+				response = builder.schemaOf(reflect.TypeOf(op.response))
+			}
+			if op.deprecated {
+				operation["deprecated"] = true
+			}
+		}
+		operation["responses"] = map[string]any{
+			"200": map[string]any{
+				"description": "the result, a non-zero code means the request failed",
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": response},
+				},
+			},
+		}
+		pathItems[path] = map[string]any{"post": operation}
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Milvus RESTful API v2",
+			"version": common.Version.String(),
+		},
+		"servers": []any{map[string]any{"url": "/v2/vectordb"}},
+		"paths":   pathItems,
+		"components": map[string]any{
+			"schemas": builder.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "the token, or username:password if the authorization is enabled",
+				},
+			},
+		},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+	}
+}
+
+// openAPISchemaBuilder generates the schemas of the go types the same way encoding/json marshals them,
+// the named structs are put in the components and referenced.
+type openAPISchemaBuilder struct {
+	schemas map[string]any
+	// names are the component names of the named structs, keyed by the package path and the name
+	names map[string]string
+}
+
+func newOpenAPISchemaBuilder() *openAPISchemaBuilder {
+	return &openAPISchemaBuilder{schemas: map[string]any{}, names: map[string]string{}}
+}
+
+// componentName returns the name of the struct in the components, the name is qualified by the package path
+// if it is taken by a struct of another package.
+func (b *openAPISchemaBuilder) componentName(t reflect.Type) (string, bool) {
+	key := t.PkgPath() + "." + t.Name()
+	if name, ok := b.names[key]; ok {
+		return name, true
+	}
+	name := strings.TrimPrefix(t.Name(), "openAPI")
+	if _, ok := b.schemas[name]; ok {
+		name = strings.ReplaceAll(t.PkgPath(), "/", ".") + "." + name
+	}
+	b.names[key] = name
+	return name, false
+}
+
+func (b *openAPISchemaBuilder) schemaOf(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return b.schemaOf(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return map[string]any{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name, ok := b.componentName(t)
+		if !ok {
+			// placeholder for the recursive structs
+			b.schemas[name] = nil
+			b.schemas[name] = b.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	default:
+		// interface{}, any value
+		return map[string]any{}
+	}
+}
+
+func (b *openAPISchemaBuilder) structSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	b.collectFields(t, properties, &required)
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (b *openAPISchemaBuilder) collectFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		// the fields of the embedded struct are promoted, as encoding/json does
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			b.collectFields(fieldType, properties, required)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema := b.schemaOf(field.Type)
+		for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+			key, value, _ := strings.Cut(rule, "=")
+			switch key {
+			case "required":
+				*required = append(*required, name)
+			case "oneof":
+				schema["enum"] = strings.Fields(value)
+			case "min", "max":
+				if bound, err := strconv.ParseFloat(value, 64); err == nil {
+					schema[openAPIBoundKeyword(schema, key)] = bound
+				}
+			}
+		}
+		properties[name] = schema
+	}
+}
+
+// openAPIBoundKeyword returns the keyword of the min or max validation of the schema.
+func openAPIBoundKeyword(schema map[string]any, key string) string {
+	switch schema["type"] {
+	case "string":
+		return key + "Length"
+	case "array":
+		return key + "Items"
+	case "object":
+		return key + "Properties"
+	default:
+		return key + "imum"
+	}
+}
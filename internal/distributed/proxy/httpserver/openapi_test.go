@@ -0,0 +1,151 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/mocks"
+)
+
+// TestOpenAPICoverage fails if a route is registered without the request body, or openAPIOperations describes
+// a route not registered.
+func TestOpenAPICoverage(t *testing.T) {
+	recorder := &routeRecorder{IRouter: gin.New().Group("/v2/vectordb")}
+	NewHandlersV2(mocks.NewMockProxy(t)).registerRoutesToV2(recorder)
+	registered := map[string]bool{}
+	for _, route := range recorder.routes {
+		registered[route.path] = true
+		assert.NotNil(t, route.request, "the request body of route %s is not recorded", route.path)
+	}
+	for path := range openAPIOperations {
+		assert.True(t, registered[path], "%s in openAPIOperations is not registered", path)
+	}
+
+	// the request bodies are the ones bound by the handlers
+	for path, request := range map[string]any{
+		CollectionCategory + CreateAction: &CollectionReq{},
+		EntityCategory + QueryAction:      &QueryReqV2{},
+		EntityCategory + DeleteAction:     &CollectionFilterReq{},
+		ImportJobCategory + AbortAction:   &JobIDReq{},
+	} {
+		route, ok := lo.Find(recorder.routes, func(route openAPIRoute) bool { return route.path == path })
+		assert.True(t, ok)
+		assert.IsType(t, request, route.request, path)
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	testEngine := initHTTPServerV2(mocks.NewMockProxy(t), false)
+	req := httptest.NewRequest(http.MethodGet, "/v2/vectordb"+OpenAPIPath, nil)
+	w := httptest.NewRecorder()
+	testEngine.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]struct {
+			Post struct {
+				OperationID string   `json:"operationId"`
+				Tags        []string `json:"tags"`
+				Deprecated  bool     `json:"deprecated"`
+				RequestBody struct {
+					Content map[string]struct {
+						Schema map[string]any `json:"schema"`
+					} `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Content map[string]struct {
+						Schema map[string]any `json:"schema"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"post"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	for path, item := range doc.Paths {
+		assert.NotEmpty(t, item.Post.RequestBody.Content["application/json"].Schema, path)
+	}
+
+	query := doc.Paths[EntityCategory+QueryAction].Post
+	assert.Equal(t, "entities_query", query.OperationID)
+	assert.Equal(t, []string{"entities"}, query.Tags)
+	assert.Equal(t, "#/components/schemas/QueryReqV2", query.RequestBody.Content["application/json"].Schema["$ref"])
+	assert.Equal(t, "#/components/schemas/Response", query.Responses["200"].Content["application/json"].Schema["$ref"])
+	assert.True(t, doc.Paths[EntityCategory+AdvancedSearchAction].Post.Deprecated)
+
+	queryReq := doc.Components.Schemas["QueryReqV2"]
+	assert.Equal(t, []string{"collectionName"}, queryReq.Required)
+	assert.Equal(t, map[string]any{"type": "integer", "format": "int32"}, queryReq.Properties["limit"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}}, queryReq.Properties["outputFields"])
+
+	// the fields of the embedded struct are promoted
+	iteratorResp := doc.Components.Schemas["IteratorResponse"]
+	assert.Contains(t, iteratorResp.Properties, "cursor")
+	assert.Contains(t, iteratorResp.Properties, "code")
+
+	// the recursive struct is referenced
+	aggregation := doc.Components.Schemas["SearchAggregationReq"]
+	assert.Equal(t, "#/components/schemas/SearchAggregationReq", aggregation.Properties["subAggregation"]["$ref"])
+}
+
+func TestOpenAPISchemaOf(t *testing.T) {
+	type validated struct {
+		Name  string   `json:"name" binding:"required,max=10"`
+		Kind  string   `json:"kind" binding:"oneof=a b"`
+		Size  int64    `json:"size,omitempty" binding:"min=1"`
+		Items []string `binding:"min=1"`
+		Skip  string   `json:"-"`
+		Raw   []byte   `json:"raw"`
+	}
+	builder := newOpenAPISchemaBuilder()
+	schema := builder.schemaOf(reflect.TypeOf(struct{ validated }{}))
+	assert.Equal(t, []string{"name"}, schema["required"])
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "maxLength": float64(10)}, properties["name"])
+	assert.Equal(t, map[string]any{"type": "string", "enum": []string{"a", "b"}}, properties["kind"])
+	assert.Equal(t, map[string]any{"type": "integer", "format": "int64", "minimum": float64(1)}, properties["size"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "minItems": float64(1)}, properties["Items"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "byte"}, properties["raw"])
+	assert.NotContains(t, properties, "Skip")
+}
+
+func TestOpenAPISchemaOfSameName(t *testing.T) {
+	builder := newOpenAPISchemaBuilder()
+	// the structs of the same name in different packages are different components
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Response"}, builder.schemaOf(reflect.TypeOf(openAPIResponse{})))
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/net.http.Response"}, builder.schemaOf(reflect.TypeOf(http.Response{})))
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Response"}, builder.schemaOf(reflect.TypeOf(&openAPIResponse{})))
+	assert.Contains(t, builder.schemas["Response"].(map[string]any)["properties"], "code")
+	assert.Contains(t, builder.schemas["net.http.Response"].(map[string]any)["properties"], "StatusCode")
+}
@@ -392,6 +392,25 @@ type QueryReqV2 struct {
 func (req *QueryReqV2) GetDbName() string         { return req.DbName }
 func (req *QueryReqV2) GetCollectionName() string { return req.CollectionName }
 
+// QueryIteratorReqV2 scans the entities matching the filter in the order of the primary key,
+// the cursor returned by a page is passed to get the next one.
+type QueryIteratorReqV2 struct {
+	DbName         string   `json:"dbName"`
+	CollectionName string   `json:"collectionName" binding:"required"`
+	PartitionNames []string `json:"partitionNames"`
+	OutputFields   []string `json:"outputFields"`
+	Filter         string   `json:"filter"`
+	BatchSize      int64    `json:"batchSize"`
+	// Limit is the total number of entities to return, not greater than 0 means unlimited.
+	Limit            int64                  `json:"limit"`
+	ExprParams       map[string]interface{} `json:"exprParams"`
+	ConsistencyLevel string                 `json:"consistencyLevel"`
+	Cursor           string                 `json:"cursor"`
+}
+
+func (req *QueryIteratorReqV2) GetDbName() string         { return req.DbName }
+func (req *QueryIteratorReqV2) GetCollectionName() string { return req.CollectionName }
+
 type CollectionIDReq struct {
 	DbName           string      `json:"dbName"`
 	CollectionName   string      `json:"collectionName" binding:"required"`
@@ -493,6 +512,47 @@ type SearchReqV2 struct {
 func (req *SearchReqV2) GetDbName() string         { return req.DbName }
 func (req *SearchReqV2) GetCollectionName() string { return req.CollectionName }
 
+// SearchIteratorReqV2 scans the nearest entities of a vector in the order of the distance,
+// the cursor returned by a page is passed to get the next one.
+type SearchIteratorReqV2 struct {
+	DbName         string        `json:"dbName"`
+	CollectionName string        `json:"collectionName" binding:"required"`
+	Data           []interface{} `json:"data"`
+	AnnsField      string        `json:"annsField"`
+	PartitionNames []string      `json:"partitionNames"`
+	Filter         string        `json:"filter"`
+	BatchSize      int64         `json:"batchSize"`
+	// Limit is the total number of entities to return, not greater than 0 means unlimited.
+	Limit            int64                  `json:"limit"`
+	OutputFields     []string               `json:"outputFields"`
+	SearchParams     map[string]interface{} `json:"searchParams"`
+	ConsistencyLevel string                 `json:"consistencyLevel"`
+	ExprParams       map[string]interface{} `json:"exprParams"`
+	Cursor           string                 `json:"cursor"`
+}
+
+func (req *SearchIteratorReqV2) GetDbName() string         { return req.DbName }
+func (req *SearchIteratorReqV2) GetCollectionName() string { return req.CollectionName }
+
+// FederatedSearchReqV2 searches several collections or aliases with the same vectors, the hits are merged by
+// the distance, or by the reranker if any.
+type FederatedSearchReqV2 struct {
+	DbName           string                 `json:"dbName"`
+	CollectionNames  []string               `json:"collectionNames" binding:"required"`
+	Data             []interface{}          `json:"data" binding:"required"`
+	AnnsField        string                 `json:"annsField"`
+	Filter           string                 `json:"filter"`
+	Limit            int32                  `json:"limit"`
+	Offset           int32                  `json:"offset"`
+	OutputFields     []string               `json:"outputFields"`
+	SearchParams     map[string]interface{} `json:"searchParams"`
+	ConsistencyLevel string                 `json:"consistencyLevel"`
+	ExprParams       map[string]interface{} `json:"exprParams"`
+	Rerank           *Rand                  `json:"rerank"`
+}
+
+func (req *FederatedSearchReqV2) GetDbName() string { return req.DbName }
+
 type SearchAggregationReq struct {
 	Fields         []string                        `json:"fields"`
 	Size           int64                           `json:"size"`
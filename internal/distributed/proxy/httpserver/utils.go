@@ -2953,34 +2953,51 @@ func formatInt64(intArray []int64) []string {
 }
 
 func CheckLimiter(ctx context.Context, req interface{}, pxy types.ProxyComponent) (any, error) {
+	_, resp, err := checkLimiterWithCancel(ctx, req, pxy)
+	return resp, err
+}
+
+// checkLimiterWithCancel checks the rate limits of the request like CheckLimiter,
+// the returned cancel gives back the quota taken by the passed request.
+func checkLimiterWithCancel(ctx context.Context, req interface{}, pxy types.ProxyComponent) (func(), any, error) {
+	cancel := func() {}
 	if !paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() {
-		return nil, nil
+		return cancel, nil, nil
 	}
 	// apply limiter for http/http2 server
 	limiter, err := pxy.GetRateLimiter()
 	if err != nil {
 		mlog.Error(ctx, "Get proxy rate limiter for httpV1/V2 server failed", mlog.Err(err))
-		return nil, err
+		return cancel, nil, err
 	}
 
 	request, ok := req.(proto.Message)
 	if !ok {
-		return nil, merr.WrapErrParameterInvalidMsg("wrong req format when check limiter")
+		return cancel, nil, merr.WrapErrParameterInvalidMsg("wrong req format when check limiter")
 	}
 
 	dbID, collectionIDToPartIDs, rt, n, err := proxy.GetRequestInfo(ctx, request)
 	if err != nil {
-		return nil, err
+		return cancel, nil, err
+	}
+	cancelPrincipal, err := limiter.CheckPrincipal(ctx, proxy.GetCurDBNameFromRequestOrContext(ctx, req), rt, n)
+	if err == nil {
+		err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+		if err != nil {
+			cancelPrincipal()
+		}
 	}
-	err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
 	nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
 	metrics.ProxyRateLimitReqCount.WithLabelValues(nodeID, rt.String(), metrics.TotalLabel).Inc()
 	if err != nil {
 		metrics.ProxyRateLimitReqCount.WithLabelValues(nodeID, rt.String(), metrics.FailLabel).Inc()
-		return proxy.GetFailedResponse(req, err), err
+		return cancel, proxy.GetFailedResponse(req, err), err
 	}
 	metrics.ProxyRateLimitReqCount.WithLabelValues(nodeID, rt.String(), metrics.SuccessLabel).Inc()
-	return nil, nil
+	return func() {
+		limiter.Cancel(dbID, collectionIDToPartIDs, rt, n)
+		cancelPrincipal()
+	}, nil, nil
 }
 
 func convertConsistencyLevel(reqConsistencyLevel string) (commonpb.ConsistencyLevel, bool, error) {
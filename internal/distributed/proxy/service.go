@@ -137,7 +137,16 @@ func authenticate(c *gin.Context) {
 		}
 	}
 	rawToken := httpserver.GetAuthorization(c)
-	if rawToken != "" && !strings.Contains(rawToken, util.CredentialSeparator) {
+	if proxy.IsJWTAuthentication(rawToken) {
+		user, roles, err := proxy.VerifyJWT(rawToken)
+		if err == nil {
+			c.Set(httpserver.ContextUsername, user)
+			c.Set(httpserver.ContextToken, rawToken)
+			// the roles are carried by the request context, which is propagated to the proxy by the v2 handlers
+			c.Request = c.Request.WithContext(proxy.SetExternalRolesToContext(c.Request.Context(), roles))
+			return
+		}
+	} else if rawToken != "" && !strings.Contains(rawToken, util.CredentialSeparator) {
 		user, err := proxy.VerifyAPIKey(rawToken)
 		if err == nil {
 			c.Set(httpserver.ContextUsername, user)
@@ -298,6 +307,7 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 			UnaryRequestStatsInterceptor,
 			accesslog.UnaryAccessLogInterceptor,
 			proxy.GrpcAuthInterceptor(proxy.AuthenticationInterceptor),
+			proxy.UnaryServerAuditInterceptor(),
 			proxy.UnaryServerInterceptor(proxy.PrivilegeInterceptor),
 			proxy.UnaryServerHookInterceptor(),
 			mlog.UnaryServerInterceptor(typeutil.ProxyRole),
@@ -551,6 +561,10 @@ func (s *Server) init() error {
 	mlog.Debug(context.TODO(), "set MixCoord client for Proxy done")
 
 	if HTTPParams.Enabled.GetAsBool() {
+		if err := httpserver.InitIteratorCursorKey(s.ctx, etcdCli); err != nil {
+			mlog.Warn(context.TODO(), "failed to init the iterator cursor key", mlog.Err(err))
+			return err
+		}
 		registerHTTPHandlerOnce.Do(func() {
 			mlog.Info(context.TODO(), "register Proxy http server")
 			s.registerHTTPServer()
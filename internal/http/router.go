@@ -70,6 +70,8 @@ const (
 	RouteGetQueryNodeDistribution   = "/management/querycoord/distribution/get"
 	RouteCheckQueryNodeDistribution = "/management/querycoord/distribution/check"
 	RouteClearReadTaskQueue         = "/management/query/task_queue/clear"
+	RouteListRunningRequests        = "/management/query/running_requests/list"
+	RouteCancelRunningRequest       = "/management/query/running_requests/cancel"
 )
 
 const (
@@ -92,6 +94,8 @@ const (
 
 	DataGCPath = "/management/data_gc"
 
+	ContinuousImportPath = "/management/continuous_import"
+
 	ReplicaLoadConfigCompliancePath = "/management/replica/loadconfig/compliance"
 )
 
@@ -107,6 +107,9 @@ type DataCoordCatalog interface {
 	SaveImportTask(ctx context.Context, task *datapb.ImportTaskV2) error
 	ListImportTasks(ctx context.Context) ([]*datapb.ImportTaskV2, error)
 	DropImportTask(ctx context.Context, taskID int64) error
+	SaveContinuousImport(ctx context.Context, job *datapb.ImportJob) error
+	ListContinuousImports(ctx context.Context) ([]*datapb.ImportJob, error)
+	DropContinuousImport(ctx context.Context, importID int64) error
 
 	SaveCopySegmentJob(ctx context.Context, job *datapb.CopySegmentJob) error
 	ListCopySegmentJobs(ctx context.Context) ([]*datapb.CopySegmentJob, error)
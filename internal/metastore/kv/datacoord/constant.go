@@ -28,6 +28,7 @@ const (
 	ImportJobPrefix                     = MetaPrefix + "/import-job"
 	ImportTaskPrefix                    = MetaPrefix + "/import-task"
 	PreImportTaskPrefix                 = MetaPrefix + "/preimport-task"
+	ContinuousImportPrefix              = MetaPrefix + "/continuous-import"
 	CopySegmentJobPrefix                = MetaPrefix + "/copy-segment-job"
 	CopySegmentTaskPrefix               = MetaPrefix + "/copy-segment-task"
 	CompactionTaskPrefix                = MetaPrefix + "/compaction-task"
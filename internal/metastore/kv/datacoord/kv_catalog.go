@@ -813,6 +813,39 @@ func (kc *Catalog) DropImportTask(ctx context.Context, taskID int64) error {
 	return kc.MetaKv.Remove(ctx, key)
 }
 
+func (kc *Catalog) SaveContinuousImport(ctx context.Context, job *datapb.ImportJob) error {
+	key := buildContinuousImportKey(job.GetJobID())
+	value, err := proto.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return kc.MetaKv.Save(ctx, key, string(value))
+}
+
+func (kc *Catalog) ListContinuousImports(ctx context.Context) ([]*datapb.ImportJob, error) {
+	jobs := make([]*datapb.ImportJob, 0)
+	applyFn := func(key []byte, value []byte) error {
+		job := &datapb.ImportJob{}
+		err := proto.Unmarshal(value, job)
+		if err != nil {
+			return err
+		}
+		jobs = append(jobs, job)
+		return nil
+	}
+
+	err := kc.MetaKv.WalkWithPrefix(ctx, ContinuousImportPrefix+"/", kc.paginationSize, applyFn)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (kc *Catalog) DropContinuousImport(ctx context.Context, importID int64) error {
+	key := buildContinuousImportKey(importID)
+	return kc.MetaKv.Remove(ctx, key)
+}
+
 func (kc *Catalog) SaveCopySegmentJob(ctx context.Context, job *datapb.CopySegmentJob) error {
 	key := buildCopySegmentJobKey(job.GetJobId())
 	value, err := proto.Marshal(job)
@@ -1409,6 +1409,37 @@ func TestCatalog_Import(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("ContinuousImport", func(t *testing.T) {
+		txn := mocks.NewMetaKv(t)
+		txn.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		kc.MetaKv = txn
+		err := kc.SaveContinuousImport(context.TODO(), job)
+		assert.NoError(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		value, err := proto.Marshal(job)
+		assert.NoError(t, err)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, ContinuousImportPrefix+"/", mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ int, f func([]byte, []byte) error) error {
+			return f(nil, value)
+		})
+		kc.MetaKv = txn
+		jobs, err := kc.ListContinuousImports(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(jobs))
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().WalkWithPrefix(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockErr)
+		kc.MetaKv = txn
+		_, err = kc.ListContinuousImports(context.TODO())
+		assert.Error(t, err)
+
+		txn = mocks.NewMetaKv(t)
+		txn.EXPECT().Remove(mock.Anything, buildContinuousImportKey(job.GetJobID())).Return(nil)
+		kc.MetaKv = txn
+		err = kc.DropContinuousImport(context.TODO(), job.GetJobID())
+		assert.NoError(t, err)
+	})
+
 	t.Run("SavePreImportTask", func(t *testing.T) {
 		txn := mocks.NewMetaKv(t)
 		txn.EXPECT().Save(mock.Anything, mock.Anything, mock.Anything).Return(nil)
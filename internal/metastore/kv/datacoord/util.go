@@ -347,6 +347,10 @@ func buildImportJobKey(jobID int64) string {
 	return fmt.Sprintf("%s/%d", ImportJobPrefix, jobID)
 }
 
+func buildContinuousImportKey(importID int64) string {
+	return fmt.Sprintf("%s/%d", ContinuousImportPrefix, importID)
+}
+
 func buildImportTaskKey(taskID int64) string {
 	return fmt.Sprintf("%s/%d", ImportTaskPrefix, taskID)
 }
@@ -559,6 +559,53 @@ func (_c *DataCoordCatalog_DropCompactionTask_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// DropContinuousImport provides a mock function with given fields: ctx, importID
+func (_m *DataCoordCatalog) DropContinuousImport(ctx context.Context, importID int64) error {
+	ret := _m.Called(ctx, importID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DropContinuousImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, importID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_DropContinuousImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropContinuousImport'
+type DataCoordCatalog_DropContinuousImport_Call struct {
+	*mock.Call
+}
+
+// DropContinuousImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - importID int64
+func (_e *DataCoordCatalog_Expecter) DropContinuousImport(ctx interface{}, importID interface{}) *DataCoordCatalog_DropContinuousImport_Call {
+	return &DataCoordCatalog_DropContinuousImport_Call{Call: _e.mock.On("DropContinuousImport", ctx, importID)}
+}
+
+func (_c *DataCoordCatalog_DropContinuousImport_Call) Run(run func(ctx context.Context, importID int64)) *DataCoordCatalog_DropContinuousImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropContinuousImport_Call) Return(_a0 error) *DataCoordCatalog_DropContinuousImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_DropContinuousImport_Call) RunAndReturn(run func(context.Context, int64) error) *DataCoordCatalog_DropContinuousImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DropCopySegmentJob provides a mock function with given fields: ctx, jobID
 func (_m *DataCoordCatalog) DropCopySegmentJob(ctx context.Context, jobID int64) error {
 	ret := _m.Called(ctx, jobID)
@@ -1505,6 +1552,64 @@ func (_c *DataCoordCatalog_ListCompactionTask_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// ListContinuousImports provides a mock function with given fields: ctx
+func (_m *DataCoordCatalog) ListContinuousImports(ctx context.Context) ([]*datapb.ImportJob, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListContinuousImports")
+	}
+
+	var r0 []*datapb.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*datapb.ImportJob, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*datapb.ImportJob); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*datapb.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DataCoordCatalog_ListContinuousImports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListContinuousImports'
+type DataCoordCatalog_ListContinuousImports_Call struct {
+	*mock.Call
+}
+
+// ListContinuousImports is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DataCoordCatalog_Expecter) ListContinuousImports(ctx interface{}) *DataCoordCatalog_ListContinuousImports_Call {
+	return &DataCoordCatalog_ListContinuousImports_Call{Call: _e.mock.On("ListContinuousImports", ctx)}
+}
+
+func (_c *DataCoordCatalog_ListContinuousImports_Call) Run(run func(ctx context.Context)) *DataCoordCatalog_ListContinuousImports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListContinuousImports_Call) Return(_a0 []*datapb.ImportJob, _a1 error) *DataCoordCatalog_ListContinuousImports_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DataCoordCatalog_ListContinuousImports_Call) RunAndReturn(run func(context.Context) ([]*datapb.ImportJob, error)) *DataCoordCatalog_ListContinuousImports_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListCopySegmentJobs provides a mock function with given fields: ctx
 func (_m *DataCoordCatalog) ListCopySegmentJobs(ctx context.Context) ([]*datapb.CopySegmentJob, error) {
 	ret := _m.Called(ctx)
@@ -2657,6 +2762,53 @@ func (_c *DataCoordCatalog_SaveCompactionTask_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// SaveContinuousImport provides a mock function with given fields: ctx, job
+func (_m *DataCoordCatalog) SaveContinuousImport(ctx context.Context, job *datapb.ImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveContinuousImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *datapb.ImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DataCoordCatalog_SaveContinuousImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveContinuousImport'
+type DataCoordCatalog_SaveContinuousImport_Call struct {
+	*mock.Call
+}
+
+// SaveContinuousImport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *datapb.ImportJob
+func (_e *DataCoordCatalog_Expecter) SaveContinuousImport(ctx interface{}, job interface{}) *DataCoordCatalog_SaveContinuousImport_Call {
+	return &DataCoordCatalog_SaveContinuousImport_Call{Call: _e.mock.On("SaveContinuousImport", ctx, job)}
+}
+
+func (_c *DataCoordCatalog_SaveContinuousImport_Call) Run(run func(ctx context.Context, job *datapb.ImportJob)) *DataCoordCatalog_SaveContinuousImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*datapb.ImportJob))
+	})
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveContinuousImport_Call) Return(_a0 error) *DataCoordCatalog_SaveContinuousImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DataCoordCatalog_SaveContinuousImport_Call) RunAndReturn(run func(context.Context, *datapb.ImportJob) error) *DataCoordCatalog_SaveContinuousImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SaveCopySegmentJob provides a mock function with given fields: ctx, job
 func (_m *DataCoordCatalog) SaveCopySegmentJob(ctx context.Context, job *datapb.CopySegmentJob) error {
 	ret := _m.Called(ctx, job)
@@ -0,0 +1,123 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// Filter decides whether the access info should be written,
+// by the method and database filters, the error only and min time cost modes and the sampling rates.
+type Filter struct {
+	includeMethods typeutil.Set[string]
+	excludeMethods typeutil.Set[string]
+	includeDbs     typeutil.Set[string]
+	excludeDbs     typeutil.Set[string]
+	samplingRates  map[string]float64
+	errorOnly      bool
+	minTimeCost    time.Duration
+}
+
+func NewFilter(logCfg *paramtable.AccessLogConfig) (*Filter, error) {
+	samplingRates, err := parseSamplingRates(logCfg.SamplingRates.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{
+		includeMethods: typeutil.NewSet(paramtable.ParseAsStings(logCfg.IncludeMethods.GetValue())...),
+		excludeMethods: typeutil.NewSet(paramtable.ParseAsStings(logCfg.ExcludeMethods.GetValue())...),
+		includeDbs:     typeutil.NewSet(paramtable.ParseAsStings(logCfg.IncludeDbs.GetValue())...),
+		excludeDbs:     typeutil.NewSet(paramtable.ParseAsStings(logCfg.ExcludeDbs.GetValue())...),
+		samplingRates:  samplingRates,
+		errorOnly:      logCfg.ErrorOnly.GetAsBool(),
+		minTimeCost:    logCfg.MinTimeCost.GetAsDuration(time.Millisecond),
+	}, nil
+}
+
+// parseSamplingRates parses the sampling rates in method:rate format, e.g. Search:0.1,HybridSearch:0.1.
+func parseSamplingRates(value string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, item := range paramtable.ParseAsStings(value) {
+		if item == "" {
+			continue
+		}
+		idx := strings.LastIndex(item, ":")
+		if idx <= 0 {
+			return nil, merr.WrapErrParameterInvalid("<method>:<rate>", item, "parse access log sampling rate failed")
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(item[idx+1:]), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, merr.WrapErrParameterInvalid("sampling rate in [0, 1]", item, "parse access log sampling rate failed")
+		}
+		rates[strings.TrimSpace(item[:idx])] = rate
+	}
+	return rates, nil
+}
+
+// Filter returns whether the access info should be written.
+func (f *Filter) Filter(i info.AccessInfo) bool {
+	if f == nil {
+		return true
+	}
+
+	method := i.MethodName()
+	if (f.includeMethods.Len() > 0 && !f.includeMethods.Contain(method)) || f.excludeMethods.Contain(method) {
+		return false
+	}
+	if f.includeDbs.Len() > 0 || f.excludeDbs.Len() > 0 {
+		db := i.DbName()
+		if (f.includeDbs.Len() > 0 && !f.includeDbs.Contain(db)) || f.excludeDbs.Contain(db) {
+			return false
+		}
+	}
+
+	failed := isFailed(i)
+	if f.errorOnly || f.minTimeCost > 0 {
+		slow := f.minTimeCost > 0 && timeCost(i) >= f.minTimeCost
+		if !(f.errorOnly && failed) && !slow {
+			return false
+		}
+	}
+
+	// the failed requests are never dropped by sampling
+	if rate, ok := f.samplingRates[method]; ok && !failed {
+		return rand.Float64() < rate
+	}
+	return true
+}
+
+func isFailed(i info.AccessInfo) bool {
+	status := i.MethodStatus()
+	return status != "Successful" && status != info.Unknown
+}
+
+// timeCost returns the time cost of the request, or 0 if it is unknown.
+func timeCost(i info.AccessInfo) time.Duration {
+	cost, err := time.ParseDuration(i.TimeCost())
+	if err != nil {
+		return 0
+	}
+	return cost
+}
@@ -0,0 +1,151 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+type timeCostAccessInfo struct {
+	*info.GrpcAccessInfo
+	timeCost string
+}
+
+func (i *timeCostAccessInfo) TimeCost() string {
+	return i.timeCost
+}
+
+func newFilterTestAccessInfo(method string, dbName string, timeCost string, err error) info.AccessInfo {
+	rpcInfo := &grpc.UnaryServerInfo{FullMethod: "/milvus.proto.milvus.MilvusService/" + method}
+	accessInfo := info.NewGrpcAccessInfo(context.Background(), rpcInfo, &milvuspb.SearchRequest{DbName: dbName})
+	accessInfo.SetResult(&milvuspb.SearchResults{Status: merr.Status(err)}, nil)
+	return &timeCostAccessInfo{GrpcAccessInfo: accessInfo, timeCost: timeCost}
+}
+
+func newTestFilter(t *testing.T, kvs map[string]string) *Filter {
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	for k, v := range kvs {
+		params.Save(k, v)
+	}
+	filter, err := NewFilter(&params.ProxyCfg.AccessLog)
+	require.NoError(t, err)
+	return filter
+}
+
+func TestFilter(t *testing.T) {
+	logCfg := &paramtable.Get().ProxyCfg.AccessLog
+
+	t.Run("nil filter", func(t *testing.T) {
+		var filter *Filter
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", nil)))
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		filter := newTestFilter(t, map[string]string{
+			logCfg.IncludeMethods.Key: "Search, Query",
+			logCfg.ExcludeMethods.Key: "Query",
+		})
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", nil)))
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Query", "db1", "1ms", nil)))
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Insert", "db1", "1ms", nil)))
+	})
+
+	t.Run("databases", func(t *testing.T) {
+		filter := newTestFilter(t, map[string]string{
+			logCfg.ExcludeDbs.Key: "db2",
+		})
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", nil)))
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Search", "db2", "1ms", nil)))
+
+		filter = newTestFilter(t, map[string]string{
+			logCfg.IncludeDbs.Key: "db1",
+		})
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", nil)))
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Search", "db2", "1ms", nil)))
+	})
+
+	t.Run("error only and min time cost", func(t *testing.T) {
+		filter := newTestFilter(t, map[string]string{
+			logCfg.ErrorOnly.Key: "true",
+		})
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1s", nil)))
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", merr.ErrCollectionNotFound)))
+
+		filter = newTestFilter(t, map[string]string{
+			logCfg.MinTimeCost.Key: "100",
+		})
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", merr.ErrCollectionNotFound)))
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", info.Unknown, nil)))
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "100ms", nil)))
+
+		// the failed or slow requests are written if both are set
+		filter = newTestFilter(t, map[string]string{
+			logCfg.ErrorOnly.Key:   "true",
+			logCfg.MinTimeCost.Key: "100",
+		})
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", merr.ErrCollectionNotFound)))
+		assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1s", nil)))
+		assert.False(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", nil)))
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		filter := newTestFilter(t, map[string]string{
+			logCfg.SamplingRates.Key: "Search:0, Query:1, HybridSearch:0.5",
+		})
+		for i := 0; i < 10; i++ {
+			assert.False(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", nil)))
+			assert.True(t, filter.Filter(newFilterTestAccessInfo("Query", "db1", "1ms", nil)))
+			assert.True(t, filter.Filter(newFilterTestAccessInfo("Insert", "db1", "1ms", nil)))
+			// the failed requests are never dropped
+			assert.True(t, filter.Filter(newFilterTestAccessInfo("Search", "db1", "1ms", merr.ErrCollectionNotFound)))
+		}
+
+		written := 0
+		for i := 0; i < 1000; i++ {
+			if filter.Filter(newFilterTestAccessInfo("HybridSearch", "db1", "1ms", nil)) {
+				written++
+			}
+		}
+		assert.InDelta(t, 500, written, 150)
+	})
+}
+
+func TestParseSamplingRates(t *testing.T) {
+	rates, err := parseSamplingRates("")
+	assert.NoError(t, err)
+	assert.Empty(t, rates)
+
+	rates, err = parseSamplingRates("Search:0.1, /v2/vectordb/entities/search:0.2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"Search": 0.1, "/v2/vectordb/entities/search": 0.2}, rates)
+
+	for _, invalid := range []string{"Search", ":0.1", "Search:abc", "Search:1.5", "Search:-1"} {
+		_, err = parseSamplingRates(invalid)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid, invalid)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	configEvent "github.com/milvus-io/milvus/pkg/v3/config"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
@@ -37,28 +39,51 @@ var (
 )
 
 type AccessLogger struct {
-	enable     atomic.Bool
-	writer     io.Writer
-	formatters *FormatterManger
-	mu         sync.RWMutex
+	enable       atomic.Bool
+	writer       io.Writer
+	formatters   *FormatterManger
+	filter       *Filter
+	outputFormat string
+	// writerConfig is the settings the writer is built from
+	writerConfig string
+	mu           sync.RWMutex
 }
 
 func NewAccessLogger() *AccessLogger {
 	return &AccessLogger{}
 }
 
+// init builds the formatters, the filter and the writer from the params, and swaps them in only if all succeed,
+// the writer is kept if the settings it's built from are not changed.
 func (l *AccessLogger) init(params *paramtable.ComponentParam) error {
-	formatters, err := initFormatter(&params.ProxyCfg.AccessLog)
+	outputFormat := params.ProxyCfg.AccessLog.OutputFormat.GetValue()
+	if outputFormat != TextOutputFormat && outputFormat != JSONOutputFormat {
+		return merr.WrapErrParameterInvalid("text or json", outputFormat, "invalid access log output format")
+	}
+
+	filter, err := NewFilter(&params.ProxyCfg.AccessLog)
 	if err != nil {
 		return err
 	}
-	l.formatters = formatters
 
-	writer, err := initWriter(&params.ProxyCfg.AccessLog, &params.MinioCfg)
+	formatters, err := initFormatter(&params.ProxyCfg.AccessLog)
 	if err != nil {
 		return err
 	}
-	l.writer = writer
+
+	writerConfig := getWriterConfig(params)
+	if l.writer == nil || l.writerConfig != writerConfig {
+		writer, err := initWriter(&params.ProxyCfg.AccessLog, &params.MinioCfg)
+		if err != nil {
+			return err
+		}
+		closeWriter(l.writer)
+		l.writer = writer
+		l.writerConfig = writerConfig
+	}
+	l.formatters = formatters
+	l.filter = filter
+	l.outputFormat = outputFormat
 	return nil
 }
 
@@ -84,23 +109,14 @@ func (l *AccessLogger) Update(enable bool) error {
 	if !enable {
 		if l.enable.Load() != enable {
 			mlog.Info(context.TODO(), "start close access log")
-			if write, ok := l.writer.(*RotateWriter); ok {
-				write.Close()
-				l.writer = nil
-			}
+			closeWriter(l.writer)
+			l.writer = nil
 			l.enable.Store(enable)
 		}
 		return nil
 	}
 
-	// close old writer before re-init
-	if l.writer != nil {
-		if rw, ok := l.writer.(*RotateWriter); ok {
-			rw.Close()
-		}
-	}
-
-	// update access log params
+	// update access log params, the old writer keeps working if the new params are invalid
 	mlog.Info(context.TODO(), "start update access log params")
 	params := paramtable.Get()
 	err := l.init(params)
@@ -121,12 +137,26 @@ func (l *AccessLogger) Write(info info.AccessInfo) bool {
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
-	method := info.MethodName()
-	formatter, ok := l.formatters.GetByMethod(method)
-	if !ok {
+	if !l.filter.Filter(info) {
 		return false
 	}
-	_, err := l.writer.Write([]byte(formatter.Format(info)))
+
+	var line []byte
+	if l.outputFormat == JSONOutputFormat {
+		var err error
+		line, err = FormatJSON(info)
+		if err != nil {
+			mlog.Warn(context.TODO(), "format json access log failed", mlog.Err(err))
+			return false
+		}
+	} else {
+		formatter, ok := l.formatters.GetByMethod(info.MethodName())
+		if !ok {
+			return false
+		}
+		line = []byte(formatter.Format(info))
+	}
+	_, err := l.writer.Write(line)
 	if err != nil {
 		mlog.Warn(context.TODO(), "write access log failed", mlog.Err(err))
 		return false
@@ -183,6 +213,32 @@ func initFormatter(logCfg *paramtable.AccessLogConfig) (*FormatterManger, error)
 	return formatterManger, nil
 }
 
+// getWriterConfig returns the settings the writer is built from.
+func getWriterConfig(params *paramtable.ComponentParam) string {
+	logCfg := &params.ProxyCfg.AccessLog
+	return strings.Join([]string{
+		logCfg.Filename.GetValue(),
+		logCfg.LocalPath.GetValue(),
+		logCfg.MaxSize.GetValue(),
+		logCfg.RotatedTime.GetValue(),
+		logCfg.MaxBackups.GetValue(),
+		logCfg.MinioEnable.GetValue(),
+		logCfg.RemotePath.GetValue(),
+		logCfg.RemoteMaxTime.GetValue(),
+		logCfg.CacheSize.GetValue(),
+		logCfg.CacheFlushInterval.GetValue(),
+	}, ",")
+}
+
+func closeWriter(writer io.Writer) {
+	switch w := writer.(type) {
+	case *RotateWriter:
+		w.Close()
+	case *CacheWriter:
+		w.Close()
+	}
+}
+
 // initAccessLogger initializes a zap access logger for proxy
 func initWriter(logCfg *paramtable.AccessLogConfig, minioCfg *paramtable.MinioConfig) (io.Writer, error) {
 	if len(logCfg.Filename.GetValue()) > 0 {
@@ -17,7 +17,9 @@
 package accesslog
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"net"
 	"os"
 	"sync"
@@ -33,6 +35,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
 	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
 )
 
@@ -302,3 +305,88 @@ func TestAccessLogger_WithMinio(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(logfiles))
 }
+
+func TestAccessLogger_JSONWithFilter(t *testing.T) {
+	params := paramtable.Get()
+	logCfg := &params.ProxyCfg.AccessLog
+	for key, value := range map[string]string{
+		logCfg.Enable.Key:         "true",
+		logCfg.Filename.Key:       "",
+		logCfg.OutputFormat.Key:   JSONOutputFormat,
+		logCfg.ExcludeMethods.Key: "Insert",
+	} {
+		params.Save(key, value)
+		defer params.Reset(key)
+	}
+
+	logger := NewAccessLogger()
+	require.NoError(t, logger.Init(params))
+	buf := &bytes.Buffer{}
+	logger.writer = buf
+
+	newAccessInfo := func(method string) info.AccessInfo {
+		rpcInfo := &grpc.UnaryServerInfo{FullMethod: "/milvus.proto.milvus.MilvusService/" + method}
+		accessInfo := info.NewGrpcAccessInfo(context.Background(), rpcInfo, &milvuspb.QueryRequest{DbName: "db1"})
+		accessInfo.SetResult(&milvuspb.QueryResults{Status: merr.Success()}, nil)
+		return accessInfo
+	}
+	assert.False(t, logger.Write(newAccessInfo("Insert")))
+	assert.True(t, logger.Write(newAccessInfo("Query")))
+	record := make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "Query", record["method_name"])
+	assert.Equal(t, "db1", record["database_name"])
+
+	// the filters are reloaded with the access logger
+	params.Save(logCfg.ExcludeMethods.Key, "Query")
+	require.NoError(t, logger.Update(true))
+	logger.writer = buf
+	assert.False(t, logger.Write(newAccessInfo("Query")))
+	assert.True(t, logger.Write(newAccessInfo("Insert")))
+
+	// invalid output format, the logger keeps working with the old params
+	params.Save(logCfg.OutputFormat.Key, "xml")
+	assert.ErrorIs(t, logger.Update(true), merr.ErrParameterInvalid)
+	assert.True(t, logger.Write(newAccessInfo("Insert")))
+}
+
+func TestAccessLogger_UpdateKeepsWriter(t *testing.T) {
+	params := paramtable.Get()
+	logCfg := &params.ProxyCfg.AccessLog
+	for key, value := range map[string]string{
+		logCfg.Enable.Key:    "true",
+		logCfg.Filename.Key:  "test_access",
+		logCfg.LocalPath.Key: t.TempDir(),
+		logCfg.CacheSize.Key: "0",
+	} {
+		params.Save(key, value)
+		defer params.Reset(key)
+	}
+	defer params.Reset(logCfg.ExcludeMethods.Key)
+	defer params.Reset(logCfg.SamplingRates.Key)
+
+	logger := NewAccessLogger()
+	require.NoError(t, logger.Init(params))
+	writer, ok := logger.writer.(*RotateWriter)
+	require.True(t, ok)
+
+	// the writer is not reopened if only the filters are changed
+	params.Save(logCfg.ExcludeMethods.Key, "Query")
+	require.NoError(t, logger.Update(true))
+	assert.Same(t, writer, logger.writer)
+	assert.False(t, writer.closed)
+
+	// the writer is not closed if the new params are invalid
+	params.Save(logCfg.SamplingRates.Key, "invalid")
+	assert.Error(t, logger.Update(true))
+	assert.Same(t, writer, logger.writer)
+	assert.False(t, writer.closed)
+	params.Reset(logCfg.SamplingRates.Key)
+
+	// the writer is reopened if its settings are changed
+	params.Save(logCfg.Filename.Key, "test_access_new")
+	require.NoError(t, logger.Update(true))
+	assert.NotSame(t, writer, logger.writer)
+	assert.True(t, writer.closed)
+	closeWriter(logger.writer)
+}
@@ -0,0 +1,73 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const (
+	TextOutputFormat = "text"
+	JSONOutputFormat = "json"
+)
+
+const timeCostField = "time_cost"
+
+// integer fields of the json access log, the other fields are strings except time_cost_ms and partial_update
+var jsonIntFields = typeutil.NewSet("error_code", "response_size", "nq")
+
+// FormatJSON formats the access info as a json line, the field names are the metrics without the $ prefix,
+// the unknown fields are omitted and the time cost is converted to time_cost_ms.
+func FormatJSON(i info.AccessInfo) ([]byte, error) {
+	record := make(map[string]any, len(info.MetricFuncMap))
+	for metric, getFunc := range info.MetricFuncMap {
+		value := getFunc(i)
+		if value == "" || value == info.Unknown || value == info.NotAny {
+			continue
+		}
+		name := strings.TrimPrefix(metric, "$")
+		record[name] = value
+
+		switch {
+		case name == timeCostField:
+			delete(record, name)
+			if cost, err := time.ParseDuration(value); err == nil {
+				record["time_cost_ms"] = float64(cost.Microseconds()) / 1000
+			}
+		case name == "partial_update":
+			if v, err := strconv.ParseBool(value); err == nil {
+				record[name] = v
+			}
+		case jsonIntFields.Contain(name):
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				record[name] = v
+			}
+		}
+	}
+
+	bytes, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
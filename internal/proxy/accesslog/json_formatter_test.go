@@ -0,0 +1,58 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+func TestFormatJSON(t *testing.T) {
+	rpcInfo := &grpc.UnaryServerInfo{FullMethod: "/milvus.proto.milvus.MilvusService/Search"}
+	accessInfo := info.NewGrpcAccessInfo(context.Background(), rpcInfo, &milvuspb.SearchRequest{
+		DbName:         "db1",
+		CollectionName: "coll1",
+		Nq:             10,
+	})
+	accessInfo.SetResult(&milvuspb.SearchResults{Status: merr.Status(merr.ErrCollectionNotFound)}, nil)
+
+	line, err := FormatJSON(accessInfo)
+	require.NoError(t, err)
+	assert.Equal(t, byte('\n'), line[len(line)-1])
+
+	record := make(map[string]any)
+	require.NoError(t, json.Unmarshal(line, &record))
+	assert.Equal(t, "Search", record["method_name"])
+	assert.Equal(t, "Failed", record["method_status"])
+	assert.Equal(t, "db1", record["database_name"])
+	assert.Equal(t, "coll1", record["collection_name"])
+	assert.Equal(t, float64(10), record["nq"])
+	assert.Equal(t, float64(merr.Code(merr.ErrCollectionNotFound)), record["error_code"])
+	assert.IsType(t, float64(0), record["time_cost_ms"])
+	assert.NotContains(t, record, "time_cost")
+	// the unknown fields are omitted
+	assert.NotContains(t, record, "partial_update")
+}
@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/hook"
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/requestutil"
+)
+
+// UnaryServerAuditInterceptor records the audited requests, it runs before the privilege check
+// so that the denied requests are recorded as well.
+func UnaryServerAuditInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return AuditInterceptor(ctx, req, GetCurUserFromContextOrDefault(ctx), info.FullMethod, handler)
+	}
+}
+
+// AuditInterceptor records the audited request in the audit log before executing it, and records the result after.
+// The audited request is rejected without being executed if the audit log can't record it.
+func AuditInterceptor(ctx context.Context, req any, userName string, fullMethod string, handler grpc.UnaryHandler) (any, error) {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	category, ok := auditlog.GetCategory(method)
+	if !ok || !auditlog.Enabled() {
+		return handler(ctx, req)
+	}
+
+	dbName := GetCurDBNameFromRequestOrContext(ctx, req)
+	collectionName := ""
+	if name, ok := requestutil.GetCollectionNameFromRequest(req); ok {
+		collectionName, _ = name.(string)
+	}
+	entry := &auditlog.Entry{
+		Category:       category,
+		Method:         method,
+		Principal:      userName,
+		SourceIP:       auditSourceIP(ctx),
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Before:         describeAuditTarget(ctx, method, dbName, collectionName),
+		After:          auditlog.SummarizeRequest(req),
+	}
+	if traceID := trace.SpanContextFromContext(ctx).TraceID(); traceID.IsValid() {
+		entry.TraceID = traceID.String()
+	}
+	if err := auditlog.Begin(entry); err != nil {
+		mlog.Warn(ctx, "reject the request since it can't be audited", mlog.String("method", method), mlog.Err(err))
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+
+	resultErr := err
+	if resultErr == nil {
+		if status, ok := requestutil.GetStatusFromResponse(resp); ok {
+			resultErr = merr.Error(status)
+		}
+	}
+	auditlog.Complete(entry, resultErr)
+	return resp, err
+}
+
+// auditSourceIP returns the client ip of the restful request or the peer address of the grpc request.
+func auditSourceIP(ctx context.Context) string {
+	if ginCtx, ok := ctx.Value(hook.GinParamsKey).(*gin.Context); ok && ginCtx != nil {
+		return ginCtx.ClientIP()
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// describeAuditTarget summarizes the cached state of the collection or database before it is changed.
+func describeAuditTarget(ctx context.Context, method string, dbName string, collectionName string) string {
+	if globalMetaCache == nil || strings.HasPrefix(method, "Create") {
+		return ""
+	}
+
+	var summary any
+	if collectionName != "" {
+		info, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, 0)
+		if err != nil {
+			return ""
+		}
+		fields := make([]string, 0, len(info.schema.GetFields()))
+		for _, field := range info.schema.GetFields() {
+			fields = append(fields, field.GetName())
+		}
+		summary = map[string]any{
+			"collection_id":  info.collID,
+			"fields":         fields,
+			"num_partitions": info.numPartitions,
+			"aliases":        info.aliases,
+			"properties":     auditProperties(info.properties),
+		}
+	} else if strings.HasSuffix(method, "Database") {
+		info, err := globalMetaCache.GetDatabaseInfo(ctx, dbName)
+		if err != nil {
+			return ""
+		}
+		summary = map[string]any{
+			"database_id": info.dbID,
+			"properties":  auditProperties(info.properties),
+		}
+	} else {
+		return ""
+	}
+
+	bytes, err := json.Marshal(summary)
+	if err != nil {
+		return ""
+	}
+	return auditlog.Truncate(string(bytes))
+}
+
+func auditProperties(kvs []*commonpb.KeyValuePair) map[string]string {
+	properties := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		properties[kv.GetKey()] = kv.GetValue()
+	}
+	return properties
+}
@@ -0,0 +1,143 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestAuditInterceptor(t *testing.T) {
+	paramtable.Init()
+	oldCache := globalMetaCache
+	defer func() { globalMetaCache = oldCache }()
+	cache := NewMockCache(t)
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db1", "coll1", int64(0)).Return(&collectionInfo{
+		collID: 100,
+		schema: newSchemaInfo(&schemapb.CollectionSchema{
+			Fields: []*schemapb.FieldSchema{{Name: "pk"}, {Name: "vec"}},
+		}),
+		properties: []*commonpb.KeyValuePair{{Key: "key", Value: "value"}},
+	}, nil).Maybe()
+	globalMetaCache = cache
+
+	params := &paramtable.ComponentParam{}
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AuditLog.Enable.Key, "true")
+	params.Save(params.ProxyCfg.AuditLog.SealKey.Key, "key")
+	cm := storage.NewLocalChunkManager(objectstorage.RootPath(t.TempDir()))
+	require.NoError(t, auditlog.InitAuditLogger(cm, &params.ProxyCfg.AuditLog, 1))
+	defer auditlog.Close()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+
+	// not audited
+	resp, err := AuditInterceptor(ctx, &milvuspb.SearchRequest{DbName: "db1", CollectionName: "coll1"}, "root",
+		"/milvus.proto.milvus.MilvusService/Search", func(ctx context.Context, req any) (any, error) {
+			return merr.Success(), nil
+		})
+	assert.NoError(t, err)
+	assert.True(t, merr.Ok(resp.(*commonpb.Status)))
+
+	// the failed status is recorded and the response is returned as is
+	resp, err = AuditInterceptor(ctx, &milvuspb.DropCollectionRequest{DbName: "db1", CollectionName: "coll1"}, "root",
+		"/milvus.proto.milvus.MilvusService/DropCollection", func(ctx context.Context, req any) (any, error) {
+			return merr.Status(merr.WrapErrCollectionNotFound("coll1")), nil
+		})
+	assert.NoError(t, err)
+	assert.False(t, merr.Ok(resp.(*commonpb.Status)))
+
+	_, err = AuditInterceptor(ctx, &milvuspb.CreateCredentialRequest{Username: "user", Password: "pwd"}, "root",
+		"/milvus.proto.milvus.MilvusService/CreateCredential", func(ctx context.Context, req any) (any, error) {
+			return merr.Success(), nil
+		})
+	assert.NoError(t, err)
+
+	// the request denied by the privilege interceptor is recorded
+	_, err = AuditInterceptor(ctx, &milvuspb.DropRoleRequest{RoleName: "role"}, "user",
+		"/milvus.proto.milvus.MilvusService/DropRole", func(ctx context.Context, req any) (any, error) {
+			return nil, merr.WrapErrPrivilegeNotPermitted("DropRole")
+		})
+	assert.ErrorIs(t, err, merr.ErrPrivilegeNotPermitted)
+	auditlog.Close()
+
+	report, err := auditlog.Verify(context.Background(), cm, params.ProxyCfg.AuditLog.RootPath.GetValue(), []byte("key"))
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Problems)
+	assert.Equal(t, 6, report.Entries)
+
+	content, err := cm.Read(context.Background(), path.Join(cm.RootPath(), "audit_log", "1", "00000000000000000000.jsonl"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 7)
+
+	// each request is recorded as a started entry before execution, and a result entry after
+	records := make([]struct {
+		Entry *auditlog.Entry `json:"entry"`
+	}, 6)
+	for i := range records {
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &records[i]))
+	}
+	drop := records[0].Entry
+	assert.Equal(t, auditlog.CategoryDDL, drop.Category)
+	assert.Equal(t, "DropCollection", drop.Method)
+	assert.Equal(t, "root", drop.Principal)
+	assert.Equal(t, "10.0.0.1:1234", drop.SourceIP)
+	assert.Equal(t, "db1", drop.DbName)
+	assert.Equal(t, "coll1", drop.CollectionName)
+	assert.Contains(t, drop.Before, `"collection_id":100`)
+	assert.Contains(t, drop.Before, `"fields":["pk","vec"]`)
+	assert.Equal(t, auditlog.StatusStarted, drop.Status)
+	dropResult := records[1].Entry
+	assert.Equal(t, "DropCollection", dropResult.Method)
+	assert.Equal(t, drop.Seq, dropResult.StartSeq)
+	assert.Equal(t, auditlog.StatusFailed, dropResult.Status)
+	assert.NotEmpty(t, dropResult.Error)
+
+	create := records[2].Entry
+	assert.Equal(t, auditlog.CategoryRBAC, create.Category)
+	assert.Equal(t, auditlog.StatusStarted, create.Status)
+	assert.Empty(t, create.Before)
+	assert.Contains(t, create.After, "user")
+	assert.NotContains(t, create.After, "pwd")
+	assert.Equal(t, auditlog.StatusSuccessful, records[3].Entry.Status)
+
+	denied := records[5].Entry
+	assert.Equal(t, "DropRole", denied.Method)
+	assert.Equal(t, "user", denied.Principal)
+	assert.Equal(t, auditlog.StatusFailed, denied.Status)
+	assert.Contains(t, denied.Error, "privilege")
+
+	// the request is not executed if it can't be recorded
+	params.Save(params.ProxyCfg.AuditLog.MaxPendingEntries.Key, "1")
+	require.NoError(t, auditlog.InitAuditLogger(storage.NewLocalChunkManager(objectstorage.RootPath(t.TempDir())),
+		&params.ProxyCfg.AuditLog, 1))
+	executed := 0
+	dropCollection := func() error {
+		_, err := AuditInterceptor(ctx, &milvuspb.DropCollectionRequest{DbName: "db1", CollectionName: "coll1"}, "root",
+			"/milvus.proto.milvus.MilvusService/DropCollection", func(ctx context.Context, req any) (any, error) {
+				executed++
+				return merr.Success(), nil
+			})
+		return err
+	}
+	assert.NoError(t, dropCollection())
+	assert.ErrorIs(t, dropCollection(), merr.ErrServiceUnavailable)
+	assert.Equal(t, 1, executed)
+}
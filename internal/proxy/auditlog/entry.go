@@ -0,0 +1,224 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	CategoryDDL  = "ddl"
+	CategoryRBAC = "rbac"
+	CategoryData = "data"
+	CategoryGC   = "gc"
+
+	// StatusStarted is the status of the entry recorded before the request is executed,
+	// the result is recorded by another entry referring to it by StartSeq.
+	StatusStarted    = "Started"
+	StatusSuccessful = "Successful"
+	StatusFailed     = "Failed"
+
+	// maxSummaryLength limits the size of the summaries, a delete expression could be huge
+	maxSummaryLength = 4096
+)
+
+// auditedMethods maps the audited methods to their categories.
+var auditedMethods = map[string]string{
+	"CreateDatabase":           CategoryDDL,
+	"DropDatabase":             CategoryDDL,
+	"AlterDatabase":            CategoryDDL,
+	"CreateCollection":         CategoryDDL,
+	"DropCollection":           CategoryDDL,
+	"AlterCollection":          CategoryDDL,
+	"AlterCollectionField":     CategoryDDL,
+	"AlterCollectionSchema":    CategoryDDL,
+	"AddCollectionField":       CategoryDDL,
+	"AddCollectionStructField": CategoryDDL,
+	"AddCollectionFunction":    CategoryDDL,
+	"AlterCollectionFunction":  CategoryDDL,
+	"DropCollectionFunction":   CategoryDDL,
+	"RenameCollection":         CategoryDDL,
+	"CreatePartition":          CategoryDDL,
+	"DropPartition":            CategoryDDL,
+	"CreateIndex":              CategoryDDL,
+	"DropIndex":                CategoryDDL,
+	"AlterIndex":               CategoryDDL,
+	"CreateAlias":              CategoryDDL,
+	"DropAlias":                CategoryDDL,
+	"AlterAlias":               CategoryDDL,
+	"CreateResourceGroup":      CategoryDDL,
+	"DropResourceGroup":        CategoryDDL,
+	"UpdateResourceGroups":     CategoryDDL,
+	"TransferNode":             CategoryDDL,
+	"TransferReplica":          CategoryDDL,
+	"CreateSnapshot":           CategoryDDL,
+	"DropSnapshot":             CategoryDDL,
+
+	"CreateCredential":      CategoryRBAC,
+	"UpdateCredential":      CategoryRBAC,
+	"DeleteCredential":      CategoryRBAC,
+	"CreateRole":            CategoryRBAC,
+	"DropRole":              CategoryRBAC,
+	"AlterRole":             CategoryRBAC,
+	"OperateUserRole":       CategoryRBAC,
+	"OperatePrivilege":      CategoryRBAC,
+	"OperatePrivilegeV2":    CategoryRBAC,
+	"CreatePrivilegeGroup":  CategoryRBAC,
+	"DropPrivilegeGroup":    CategoryRBAC,
+	"OperatePrivilegeGroup": CategoryRBAC,
+	"RestoreRBAC":           CategoryRBAC,
+
+	"Delete":                  CategoryData,
+	"TruncateCollection":      CategoryData,
+	"RestoreSnapshot":         CategoryData,
+	"RestoreExternalSnapshot": CategoryData,
+
+	"PauseDatacoordGC":  CategoryGC,
+	"ResumeDatacoordGC": CategoryGC,
+}
+
+// GetCategory returns the category of the method, false if the method is not audited.
+func GetCategory(method string) (string, bool) {
+	category, ok := auditedMethods[method]
+	return category, ok
+}
+
+// Entry is an audit log entry, the entries are chained by the hash of the previous entry.
+type Entry struct {
+	Seq            int64  `json:"seq"`
+	NodeID         int64  `json:"node_id"`
+	Time           string `json:"time"`
+	Category       string `json:"category"`
+	Method         string `json:"method"`
+	Principal      string `json:"principal"`
+	SourceIP       string `json:"source_ip"`
+	TraceID        string `json:"trace_id,omitempty"`
+	DbName         string `json:"db_name,omitempty"`
+	CollectionName string `json:"collection_name,omitempty"`
+	Before         string `json:"before,omitempty"`
+	After          string `json:"after,omitempty"`
+	Status         string `json:"status"`
+	Error          string `json:"error,omitempty"`
+	StartSeq       int64  `json:"start_seq,omitempty"`
+	PrevHash       string `json:"prev_hash"`
+	Hash           string `json:"hash"`
+}
+
+// computeHash returns the hash of the entry content and the hash of the previous entry.
+func (e *Entry) computeHash() string {
+	content := *e
+	content.Hash = ""
+	bytes, _ := json.Marshal(&content)
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// Seal is the last record of a sealed segment, it links the segment to the previous one.
+type Seal struct {
+	Segment         int64  `json:"segment"`
+	NodeID          int64  `json:"node_id"`
+	FirstSeq        int64  `json:"first_seq"`
+	LastSeq         int64  `json:"last_seq"`
+	PrevSegmentHash string `json:"prev_segment_hash"`
+	LastHash        string `json:"last_hash"`
+	SealedAt        string `json:"sealed_at"`
+	Signature       string `json:"signature,omitempty"`
+}
+
+// sign returns the HMAC-SHA256 of the seal content with the key.
+func (s *Seal) sign(key []byte) string {
+	content := *s
+	content.Signature = ""
+	bytes, _ := json.Marshal(&content)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(bytes)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Anchor registers the chain of a node at the cluster level, it is written before the first segment of the chain.
+// The anchor records the heads of the chains existing when the node starts, e.g. the chain of the proxy before
+// restart, so that the removal of a whole chain or of its latest segments along with its head could be detected.
+type Anchor struct {
+	NodeID    int64        `json:"node_id"`
+	CreatedAt string       `json:"created_at"`
+	PrevHeads []*ChainHead `json:"prev_heads"`
+	Signature string       `json:"signature,omitempty"`
+}
+
+// ChainHead is the latest sealed segment of a chain, the segment is -1 if the chain has no segment yet.
+type ChainHead struct {
+	NodeID   int64  `json:"node_id"`
+	Segment  int64  `json:"segment"`
+	LastHash string `json:"last_hash,omitempty"`
+}
+
+// sign returns the HMAC-SHA256 of the anchor content with the key.
+func (a *Anchor) sign(key []byte) string {
+	content := *a
+	content.Signature = ""
+	bytes, _ := json.Marshal(&content)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(bytes)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// record is a line of the segment file, which is either an entry or the seal.
+type record struct {
+	Entry *Entry `json:"entry,omitempty"`
+	Seal  *Seal  `json:"seal,omitempty"`
+}
+
+// SummarizeRequest returns the json of the request with the password fields cleared.
+func SummarizeRequest(req any) string {
+	msg, ok := req.(proto.Message)
+	if !ok || msg == nil {
+		return ""
+	}
+	msg = proto.Clone(msg)
+	m := msg.ProtoReflect()
+	sensitiveFields := make([]protoreflect.FieldDescriptor, 0)
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if strings.Contains(strings.ToLower(string(fd.Name())), "password") {
+			sensitiveFields = append(sensitiveFields, fd)
+		}
+		return true
+	})
+	for _, fd := range sensitiveFields {
+		m.Clear(fd)
+	}
+	bytes, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	return Truncate(string(bytes))
+}
+
+// Truncate truncates the summary to the max summary length.
+func Truncate(summary string) string {
+	if len(summary) <= maxSummaryLength {
+		return summary
+	}
+	return summary[:maxSummaryLength] + "...(truncated)"
+}
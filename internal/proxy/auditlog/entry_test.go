@@ -0,0 +1,99 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+)
+
+func TestGetCategory(t *testing.T) {
+	category, ok := GetCategory("DropCollection")
+	assert.True(t, ok)
+	assert.Equal(t, CategoryDDL, category)
+
+	category, ok = GetCategory("OperatePrivilege")
+	assert.True(t, ok)
+	assert.Equal(t, CategoryRBAC, category)
+
+	category, ok = GetCategory("Delete")
+	assert.True(t, ok)
+	assert.Equal(t, CategoryData, category)
+
+	_, ok = GetCategory("Search")
+	assert.False(t, ok)
+}
+
+func TestEntryHash(t *testing.T) {
+	e := &Entry{Seq: 1, Method: "DropCollection", CollectionName: "coll"}
+	e.Hash = e.computeHash()
+	assert.NotEmpty(t, e.Hash)
+	assert.Equal(t, e.Hash, e.computeHash())
+
+	e.CollectionName = "other"
+	assert.NotEqual(t, e.Hash, e.computeHash())
+}
+
+func TestSealSign(t *testing.T) {
+	seal := &Seal{Segment: 1, FirstSeq: 1, LastSeq: 2, LastHash: "hash"}
+	signature := seal.sign([]byte("key"))
+	assert.Equal(t, signature, seal.sign([]byte("key")))
+	assert.NotEqual(t, signature, seal.sign([]byte("other")))
+
+	seal.Signature = signature
+	assert.Equal(t, signature, seal.sign([]byte("key")))
+}
+
+func TestSummarizeRequest(t *testing.T) {
+	t.Run("clear password", func(t *testing.T) {
+		summary := SummarizeRequest(&milvuspb.UpdateCredentialRequest{
+			Username:    "user",
+			OldPassword: "old",
+			NewPassword: "new",
+		})
+		assert.Contains(t, summary, "user")
+		assert.NotContains(t, summary, "old")
+		assert.NotContains(t, summary, "new")
+
+		m := make(map[string]any)
+		assert.NoError(t, json.Unmarshal([]byte(summary), &m))
+		assert.Equal(t, "user", m["username"])
+	})
+
+	t.Run("request not modified", func(t *testing.T) {
+		req := &milvuspb.CreateCredentialRequest{Username: "user", Password: "pwd"}
+		summary := SummarizeRequest(req)
+		assert.NotContains(t, summary, "pwd")
+		assert.Equal(t, "pwd", req.GetPassword())
+	})
+
+	t.Run("not proto", func(t *testing.T) {
+		assert.Empty(t, SummarizeRequest("req"))
+		assert.Empty(t, SummarizeRequest(nil))
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		summary := SummarizeRequest(&milvuspb.DropCollectionRequest{CollectionName: strings.Repeat("a", maxSummaryLength)})
+		assert.True(t, strings.HasSuffix(summary, "...(truncated)"))
+		assert.Equal(t, maxSummaryLength+len("...(truncated)"), len(summary))
+	})
+}
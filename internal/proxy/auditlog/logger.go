@@ -0,0 +1,497 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	segmentExt = ".jsonl"
+	// headFile keeps the seal of the latest segment, so that the removal of the latest segments could be detected
+	headFile = "head.json"
+	// anchorsDir keeps the anchors of the chains, which link the chains of the cluster together
+	anchorsDir = "anchors"
+	anchorExt  = ".json"
+)
+
+var _globalL atomic.Pointer[AuditLogger]
+
+// AuditLogger chains the audit log entries by hash and periodically seals the pending entries
+// into a segment of the object storage. The segments of a proxy are stored under
+// <root path>/<node id>/<segment>.jsonl, each segment ends with a seal linking it to the previous segment,
+// and the seal of the latest segment is copied to <root path>/<node id>/head.json.
+// Since the proxy gets a new node id after restart, the chain of each node is anchored at
+// <root path>/anchors/<node id>.json, which records the heads of the chains existing when the node starts.
+type AuditLogger struct {
+	cm         storage.ChunkManager
+	root       string
+	dir        string
+	nodeID     int64
+	sealKey    []byte
+	interval   time.Duration
+	maxEntries int
+	maxPending int
+
+	mu       sync.Mutex
+	seq      int64
+	lastHash string
+	pending  []*Entry
+
+	// sealMu serializes the sealing, the fields below are protected by it
+	sealMu     sync.Mutex
+	segment    int64
+	sealedHash string
+
+	sealCh    chan struct{}
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewAuditLogger creates an audit logger writing the segments under the root path of the node,
+// the seal key is required, since the unsigned segments could be rewritten along with their hashes.
+func NewAuditLogger(cm storage.ChunkManager, cfg *paramtable.AuditLogConfig, nodeID int64) (*AuditLogger, error) {
+	if cfg.SealKey.GetValue() == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("%s is required when the audit log is enabled", cfg.SealKey.Key)
+	}
+	root := path.Join(cm.RootPath(), cfg.RootPath.GetValue())
+	return &AuditLogger{
+		cm:         cm,
+		root:       root,
+		dir:        path.Join(root, strconv.FormatInt(nodeID, 10)),
+		nodeID:     nodeID,
+		sealKey:    []byte(cfg.SealKey.GetValue()),
+		interval:   cfg.SealInterval.GetAsDuration(time.Second),
+		maxEntries: cfg.SealMaxEntries.GetAsInt(),
+		maxPending: cfg.MaxPendingEntries.GetAsInt(),
+		sealCh:     make(chan struct{}, 1),
+		closeCh:    make(chan struct{}),
+	}, nil
+}
+
+// Start resumes and anchors the chain of the node, and starts sealing the pending entries periodically.
+func (l *AuditLogger) Start() error {
+	if err := l.resume(context.Background()); err != nil {
+		return err
+	}
+	if err := l.anchor(context.Background()); err != nil {
+		return err
+	}
+	l.wg.Add(1)
+	go l.sealLoop()
+	return nil
+}
+
+// resume continues the chain after the latest segment of the node, so that the sequence numbers and the segments
+// are not reused after restart. The head could be one segment behind if the proxy crashed after writing the segment.
+func (l *AuditLogger) resume(ctx context.Context) error {
+	headPath := path.Join(l.dir, headFile)
+	headExist, err := l.cm.Exist(ctx, headPath)
+	if err != nil {
+		return err
+	}
+	if headExist {
+		content, err := l.cm.Read(ctx, headPath)
+		if err != nil {
+			return err
+		}
+		head := &Seal{}
+		if err := json.Unmarshal(content, head); err != nil || head.sign(l.sealKey) != head.Signature {
+			return merr.WrapErrServiceInternalMsg("invalid audit log head %s", headPath)
+		}
+		l.advance(head)
+	}
+
+	for {
+		filePath := segmentPath(l.dir, l.segment)
+		exist, err := l.cm.Exist(ctx, filePath)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return nil
+		}
+		// the segments without the head are probably tampered, the chain can't be continued safely
+		if !headExist {
+			return merr.WrapErrServiceInternalMsg("audit log head %s is missing but segment %s exists", headPath, filePath)
+		}
+		content, err := l.cm.Read(ctx, filePath)
+		if err != nil {
+			return err
+		}
+		seal := lastSeal(content)
+		if seal == nil || seal.sign(l.sealKey) != seal.Signature || seal.Segment != l.segment || seal.PrevSegmentHash != l.sealedHash {
+			return merr.WrapErrServiceInternalMsg("audit log segment %s does not follow the head", filePath)
+		}
+		l.advance(seal)
+		if err := l.writeHead(ctx, seal); err != nil {
+			return err
+		}
+	}
+}
+
+// anchor writes the anchor of the chain before any segment is sealed, the anchor is kept if the chain is resumed.
+func (l *AuditLogger) anchor(ctx context.Context) error {
+	anchorFile := anchorPath(l.root, l.nodeID)
+	exist, err := l.cm.Exist(ctx, anchorFile)
+	if err != nil {
+		return err
+	}
+	if exist {
+		_, err := readAnchor(ctx, l.cm, anchorFile, l.sealKey)
+		return err
+	}
+	heads, err := l.listHeads(ctx)
+	if err != nil {
+		return err
+	}
+	anchor := &Anchor{
+		NodeID:    l.nodeID,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		PrevHeads: heads,
+	}
+	anchor.Signature = anchor.sign(l.sealKey)
+	content, err := json.Marshal(anchor)
+	if err != nil {
+		return err
+	}
+	if err := l.cm.Write(ctx, anchorFile, content); err != nil {
+		return err
+	}
+	mlog.Info(ctx, "audit log chain anchored", mlog.String("anchor", anchorFile), mlog.Int("prevChains", len(heads)))
+	return nil
+}
+
+// listHeads returns the heads of the other chains, including the anchored chains without any segment.
+func (l *AuditLogger) listHeads(ctx context.Context) ([]*ChainHead, error) {
+	heads := make(map[int64]*ChainHead)
+	var headPaths []string
+	err := l.cm.WalkWithPrefix(ctx, l.root+"/", true, func(info *storage.ChunkObjectInfo) bool {
+		dir, name := path.Split(info.FilePath)
+		if path.Base(dir) == anchorsDir {
+			if nodeID, err := strconv.ParseInt(strings.TrimSuffix(name, anchorExt), 10, 64); err == nil {
+				heads[nodeID] = &ChainHead{NodeID: nodeID, Segment: -1}
+			}
+		} else if name == headFile {
+			headPaths = append(headPaths, info.FilePath)
+		}
+		return true
+	})
+	// the root path doesn't exist in the local storage before the first chain is anchored
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, headPath := range headPaths {
+		nodeID, err := strconv.ParseInt(path.Base(path.Dir(headPath)), 10, 64)
+		if err != nil {
+			continue
+		}
+		content, err := l.cm.Read(ctx, headPath)
+		if err != nil {
+			return nil, err
+		}
+		head := &Seal{}
+		if err := json.Unmarshal(content, head); err != nil || head.sign(l.sealKey) != head.Signature {
+			// the invalid head is reported by the verifier, the chain is still required to be anchored
+			mlog.Warn(ctx, "invalid audit log head of other chain", mlog.String("head", headPath))
+			heads[nodeID] = &ChainHead{NodeID: nodeID, Segment: -1}
+			continue
+		}
+		heads[nodeID] = &ChainHead{NodeID: nodeID, Segment: head.Segment, LastHash: head.LastHash}
+	}
+	delete(heads, l.nodeID)
+
+	result := make([]*ChainHead, 0, len(heads))
+	for _, head := range heads {
+		result = append(result, head)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeID < result[j].NodeID
+	})
+	return result, nil
+}
+
+// advance moves the chain to the end of the sealed segment.
+func (l *AuditLogger) advance(seal *Seal) {
+	l.segment = seal.Segment + 1
+	l.seq = seal.LastSeq
+	l.lastHash = seal.LastHash
+	l.sealedHash = seal.LastHash
+}
+
+func (l *AuditLogger) writeHead(ctx context.Context, seal *Seal) error {
+	content, err := json.Marshal(seal)
+	if err != nil {
+		return err
+	}
+	return l.cm.Write(ctx, path.Join(l.dir, headFile), content)
+}
+
+// Close seals the pending entries and stops the logger.
+func (l *AuditLogger) Close() {
+	l.closeOnce.Do(func() {
+		close(l.closeCh)
+		l.wg.Wait()
+		if err := l.Seal(context.Background()); err != nil {
+			mlog.Warn(context.TODO(), "failed to seal audit log on close", mlog.Err(err))
+		}
+	})
+}
+
+// Full returns an error if the pending entries reach the limit, since sealing keeps failing.
+func (l *AuditLogger) Full() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.checkPendingLocked()
+}
+
+func (l *AuditLogger) checkPendingLocked() error {
+	if l.maxPending > 0 && len(l.pending) >= l.maxPending {
+		return merr.WrapErrServiceUnavailable("audit log is full", fmt.Sprintf("%d entries are not sealed", len(l.pending)))
+	}
+	return nil
+}
+
+// Append chains the entry to the previous one and adds it to the pending entries,
+// the entry is dropped and an error is returned if the pending entries reach the limit.
+func (l *AuditLogger) Append(e *Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.checkPendingLocked(); err != nil {
+		mlog.RatedError(context.TODO(), 1, "audit log entry dropped", mlog.String("method", e.Method),
+			mlog.String("principal", e.Principal), mlog.Err(err))
+		return err
+	}
+	l.appendLocked(e)
+	return nil
+}
+
+// AppendResult chains the result entry of a started entry, it is not limited by the pending entries,
+// so that the result of an executed request is never dropped.
+func (l *AuditLogger) AppendResult(e *Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.appendLocked(e)
+}
+
+func (l *AuditLogger) appendLocked(e *Entry) {
+	l.seq++
+	e.Seq = l.seq
+	e.NodeID = l.nodeID
+	if e.Time == "" {
+		e.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+	e.PrevHash = l.lastHash
+	e.Hash = e.computeHash()
+	l.lastHash = e.Hash
+	l.pending = append(l.pending, e)
+	metrics.ProxyAuditLogPendingEntries.WithLabelValues(strconv.FormatInt(l.nodeID, 10)).Set(float64(len(l.pending)))
+
+	if l.maxEntries > 0 && len(l.pending) >= l.maxEntries {
+		select {
+		case l.sealCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Seal writes the pending entries as a new segment, the entries are kept pending if writing fails.
+func (l *AuditLogger) Seal(ctx context.Context) error {
+	l.sealMu.Lock()
+	defer l.sealMu.Unlock()
+
+	l.mu.Lock()
+	entries := l.pending
+	l.mu.Unlock()
+	if len(entries) == 0 {
+		return nil
+	}
+
+	seal := &Seal{
+		Segment:         l.segment,
+		NodeID:          l.nodeID,
+		FirstSeq:        entries[0].Seq,
+		LastSeq:         entries[len(entries)-1].Seq,
+		PrevSegmentHash: l.sealedHash,
+		LastHash:        entries[len(entries)-1].Hash,
+		SealedAt:        time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	seal.Signature = seal.sign(l.sealKey)
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(&record{Entry: entry}); err != nil {
+			return err
+		}
+	}
+	if err := encoder.Encode(&record{Seal: seal}); err != nil {
+		return err
+	}
+	if err := l.cm.Write(ctx, segmentPath(l.dir, l.segment), buf.Bytes()); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.pending = l.pending[len(entries):]
+	metrics.ProxyAuditLogPendingEntries.WithLabelValues(strconv.FormatInt(l.nodeID, 10)).Set(float64(len(l.pending)))
+	l.mu.Unlock()
+	l.segment++
+	l.sealedHash = seal.LastHash
+	mlog.Info(ctx, "audit log segment sealed",
+		mlog.Int64("segment", seal.Segment),
+		mlog.Int64("firstSeq", seal.FirstSeq),
+		mlog.Int64("lastSeq", seal.LastSeq),
+		mlog.String("lastHash", seal.LastHash))
+	// the segment is sealed even if the head is not updated, the head is caught up on the next seal or restart
+	return l.writeHead(ctx, seal)
+}
+
+func (l *AuditLogger) sealLoop() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.closeCh:
+			return
+		case <-ticker.C:
+		case <-l.sealCh:
+		}
+		if err := l.Seal(context.Background()); err != nil {
+			mlog.Warn(context.TODO(), "failed to seal audit log, retry later", mlog.Err(err))
+		}
+	}
+}
+
+func segmentPath(dir string, segment int64) string {
+	return path.Join(dir, fmt.Sprintf("%020d%s", segment, segmentExt))
+}
+
+func anchorPath(root string, nodeID int64) string {
+	return path.Join(root, anchorsDir, strconv.FormatInt(nodeID, 10)+anchorExt)
+}
+
+// readAnchor reads the anchor and checks its signature.
+func readAnchor(ctx context.Context, cm storage.ChunkManager, anchorFile string, sealKey []byte) (*Anchor, error) {
+	content, err := cm.Read(ctx, anchorFile)
+	if err != nil {
+		return nil, err
+	}
+	anchor := &Anchor{}
+	if err := json.Unmarshal(content, anchor); err != nil || anchor.sign(sealKey) != anchor.Signature {
+		return nil, merr.WrapErrServiceInternalMsg("invalid audit log anchor %s", anchorFile)
+	}
+	return anchor, nil
+}
+
+// lastSeal returns the seal of the segment content, nil if the last record is not a seal.
+func lastSeal(content []byte) *Seal {
+	content = bytes.TrimSpace(content)
+	r := &record{}
+	if err := json.Unmarshal(content[bytes.LastIndexByte(content, '\n')+1:], r); err != nil {
+		return nil
+	}
+	return r.Seal
+}
+
+// InitAuditLogger initializes the global audit logger if the audit log is enabled.
+func InitAuditLogger(cm storage.ChunkManager, cfg *paramtable.AuditLogConfig, nodeID int64) error {
+	if !cfg.Enable.GetAsBool() {
+		return nil
+	}
+	logger, err := NewAuditLogger(cm, cfg, nodeID)
+	if err != nil {
+		return err
+	}
+	if err := logger.Start(); err != nil {
+		return err
+	}
+	if old := _globalL.Swap(logger); old != nil {
+		old.Close()
+	}
+	mlog.Info(context.TODO(), "init audit logger done", mlog.String("dir", logger.dir))
+	return nil
+}
+
+// Enabled returns whether the global audit logger is initialized.
+func Enabled() bool {
+	return _globalL.Load() != nil
+}
+
+// Begin records the started entry in the global audit logger before the request is executed,
+// the request must be rejected if it returns an error, since the request could not be audited.
+func Begin(e *Entry) error {
+	if logger := _globalL.Load(); logger != nil {
+		e.Status = StatusStarted
+		return logger.Append(e)
+	}
+	return nil
+}
+
+// Complete records the result of the started entry in the global audit logger after the request is executed.
+func Complete(started *Entry, err error) {
+	logger := _globalL.Load()
+	if logger == nil {
+		return
+	}
+	e := &Entry{
+		Category:       started.Category,
+		Method:         started.Method,
+		Principal:      started.Principal,
+		SourceIP:       started.SourceIP,
+		TraceID:        started.TraceID,
+		DbName:         started.DbName,
+		CollectionName: started.CollectionName,
+		Status:         StatusSuccessful,
+		StartSeq:       started.Seq,
+	}
+	if err != nil {
+		e.Status = StatusFailed
+		e.Error = err.Error()
+	}
+	logger.AppendResult(e)
+}
+
+// Close seals the pending entries of the global audit logger and stops it.
+func Close() {
+	if logger := _globalL.Swap(nil); logger != nil {
+		logger.Close()
+	}
+}
@@ -0,0 +1,277 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func newTestParams() *paramtable.ComponentParam {
+	params := &paramtable.ComponentParam{}
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AuditLog.Enable.Key, "true")
+	params.Save(params.ProxyCfg.AuditLog.SealKey.Key, "key")
+	return params
+}
+
+func newTestChunkManager(t *testing.T) storage.ChunkManager {
+	return storage.NewLocalChunkManager(objectstorage.RootPath(t.TempDir()))
+}
+
+func readRecords(t *testing.T, cm storage.ChunkManager, filePath string) []*record {
+	content, err := cm.Read(context.Background(), filePath)
+	require.NoError(t, err)
+	var records []*record
+	for _, line := range bytes.Split(bytes.TrimSpace(content), []byte("\n")) {
+		r := &record{}
+		require.NoError(t, json.Unmarshal(line, r))
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestAuditLogger_AppendAndSeal(t *testing.T) {
+	params := newTestParams()
+	cm := newTestChunkManager(t)
+	logger, err := NewAuditLogger(cm, &params.ProxyCfg.AuditLog, 1)
+	require.NoError(t, err)
+
+	// nothing to seal
+	assert.NoError(t, logger.Seal(context.Background()))
+
+	logger.Append(&Entry{Category: CategoryDDL, Method: "DropCollection", CollectionName: "coll1"})
+	logger.Append(&Entry{Category: CategoryData, Method: "Delete", CollectionName: "coll2"})
+	require.NoError(t, logger.Seal(context.Background()))
+	logger.Append(&Entry{Category: CategoryRBAC, Method: "DropRole"})
+	logger.Close()
+
+	dir := path.Join(cm.RootPath(), "audit_log", "1")
+	first := readRecords(t, cm, segmentPath(dir, 0))
+	require.Len(t, first, 3)
+	assert.Equal(t, int64(1), first[0].Entry.Seq)
+	assert.Equal(t, int64(1), first[0].Entry.NodeID)
+	assert.NotEmpty(t, first[0].Entry.Time)
+	assert.Empty(t, first[0].Entry.PrevHash)
+	assert.Equal(t, first[0].Entry.Hash, first[1].Entry.PrevHash)
+	require.NotNil(t, first[2].Seal)
+	assert.Equal(t, int64(1), first[2].Seal.FirstSeq)
+	assert.Equal(t, int64(2), first[2].Seal.LastSeq)
+	assert.Equal(t, first[1].Entry.Hash, first[2].Seal.LastHash)
+	assert.Equal(t, first[2].Seal.sign([]byte("key")), first[2].Seal.Signature)
+
+	second := readRecords(t, cm, segmentPath(dir, 1))
+	require.Len(t, second, 2)
+	assert.Equal(t, int64(3), second[0].Entry.Seq)
+	assert.Equal(t, first[1].Entry.Hash, second[0].Entry.PrevHash)
+	assert.Equal(t, first[2].Seal.LastHash, second[1].Seal.PrevSegmentHash)
+}
+
+func TestAuditLogger_SealMaxEntries(t *testing.T) {
+	params := newTestParams()
+	params.Save(params.ProxyCfg.AuditLog.SealMaxEntries.Key, "2")
+	cm := newTestChunkManager(t)
+	logger, err := NewAuditLogger(cm, &params.ProxyCfg.AuditLog, 1)
+	require.NoError(t, err)
+	require.NoError(t, logger.Start())
+	defer logger.Close()
+
+	logger.Append(&Entry{Method: "DropCollection"})
+	logger.Append(&Entry{Method: "DropCollection"})
+	assert.Eventually(t, func() bool {
+		exist, err := cm.Exist(context.Background(), segmentPath(logger.dir, 0))
+		return err == nil && exist
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAuditLogger_MaxPendingEntries(t *testing.T) {
+	params := newTestParams()
+	params.Save(params.ProxyCfg.AuditLog.MaxPendingEntries.Key, "2")
+	logger, err := NewAuditLogger(newTestChunkManager(t), &params.ProxyCfg.AuditLog, 1)
+	require.NoError(t, err)
+
+	assert.NoError(t, logger.Append(&Entry{Method: "DropCollection"}))
+	assert.NoError(t, logger.Full())
+	assert.NoError(t, logger.Append(&Entry{Method: "DropCollection"}))
+	assert.ErrorIs(t, logger.Full(), merr.ErrServiceUnavailable)
+	assert.ErrorIs(t, logger.Append(&Entry{Method: "DropCollection"}), merr.ErrServiceUnavailable)
+	// the result of a started entry is not dropped
+	logger.AppendResult(&Entry{Method: "DropCollection", StartSeq: 1})
+	assert.Len(t, logger.pending, 3)
+
+	// the dropped entry doesn't break the chain
+	require.NoError(t, logger.Seal(context.Background()))
+	assert.NoError(t, logger.Append(&Entry{Method: "DropCollection"}))
+	assert.Equal(t, int64(4), logger.pending[0].Seq)
+}
+
+func TestAuditLogger_Resume(t *testing.T) {
+	ctx := context.Background()
+	params := newTestParams()
+	cm := newTestChunkManager(t)
+	newLogger := func() (*AuditLogger, error) {
+		logger, err := NewAuditLogger(cm, &params.ProxyCfg.AuditLog, 1)
+		require.NoError(t, err)
+		return logger, logger.Start()
+	}
+
+	logger, err := newLogger()
+	require.NoError(t, err)
+	logger.Append(&Entry{Method: "DropCollection"})
+	logger.Close()
+	headPath := path.Join(logger.dir, headFile)
+	oldHead, err := cm.Read(ctx, headPath)
+	require.NoError(t, err)
+
+	// the chain is continued after restart
+	logger, err = newLogger()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), logger.segment)
+	logger.Append(&Entry{Method: "DropCollection"})
+	logger.Close()
+	records := readRecords(t, cm, segmentPath(logger.dir, 1))
+	assert.Equal(t, int64(2), records[0].Entry.Seq)
+
+	// the head is one segment behind if the proxy crashed after writing the segment
+	require.NoError(t, cm.Write(ctx, headPath, oldHead))
+	logger, err = newLogger()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), logger.segment)
+	assert.Equal(t, int64(2), logger.seq)
+	logger.Append(&Entry{Method: "DropCollection"})
+	logger.Close()
+
+	report, err := Verify(ctx, cm, "audit_log", []byte("key"))
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Problems)
+	assert.Equal(t, 3, report.Entries)
+
+	// the chain can't be continued without the head or with a forged head
+	require.NoError(t, cm.Write(ctx, headPath, oldHead[1:]))
+	_, err = newLogger()
+	assert.Error(t, err)
+	require.NoError(t, cm.Remove(ctx, headPath))
+	_, err = newLogger()
+	assert.Error(t, err)
+}
+
+func TestAuditLogger_Anchor(t *testing.T) {
+	ctx := context.Background()
+	params := newTestParams()
+	cm := newTestChunkManager(t)
+	newLogger := func(nodeID int64) (*AuditLogger, error) {
+		logger, err := NewAuditLogger(cm, &params.ProxyCfg.AuditLog, nodeID)
+		require.NoError(t, err)
+		return logger, logger.Start()
+	}
+	readAnchorOf := func(logger *AuditLogger) *Anchor {
+		anchor, err := readAnchor(ctx, cm, anchorPath(logger.root, logger.nodeID), []byte("key"))
+		require.NoError(t, err)
+		return anchor
+	}
+
+	first, err := newLogger(1)
+	require.NoError(t, err)
+	assert.Empty(t, readAnchorOf(first).PrevHeads)
+	first.Append(&Entry{Method: "DropCollection"})
+	first.Close()
+	head := readRecords(t, cm, segmentPath(first.dir, 0))[1].Seal
+
+	// the chain of the restarted proxy records the head of the chain before restart
+	second, err := newLogger(2)
+	require.NoError(t, err)
+	defer second.Close()
+	assert.Equal(t, []*ChainHead{{NodeID: 1, Segment: 0, LastHash: head.LastHash}}, readAnchorOf(second).PrevHeads)
+
+	// the anchored chain without any segment is recorded as well
+	third, err := newLogger(3)
+	require.NoError(t, err)
+	defer third.Close()
+	assert.Equal(t, []*ChainHead{
+		{NodeID: 1, Segment: 0, LastHash: head.LastHash},
+		{NodeID: 2, Segment: -1},
+	}, readAnchorOf(third).PrevHeads)
+
+	// the anchor is kept if the chain is resumed, the chain can't be continued with a forged anchor
+	anchorFile := anchorPath(first.root, 1)
+	oldAnchor, err := cm.Read(ctx, anchorFile)
+	require.NoError(t, err)
+	first, err = newLogger(1)
+	require.NoError(t, err)
+	first.Close()
+	newAnchor, err := cm.Read(ctx, anchorFile)
+	require.NoError(t, err)
+	assert.Equal(t, oldAnchor, newAnchor)
+	require.NoError(t, cm.Write(ctx, anchorFile, oldAnchor[1:]))
+	_, err = newLogger(1)
+	assert.Error(t, err)
+}
+
+func TestGlobalAuditLogger(t *testing.T) {
+	cm := newTestChunkManager(t)
+
+	params := newTestParams()
+	params.Save(params.ProxyCfg.AuditLog.Enable.Key, "false")
+	params.Save(params.ProxyCfg.AuditLog.SealKey.Key, "")
+	assert.NoError(t, InitAuditLogger(cm, &params.ProxyCfg.AuditLog, 1))
+	assert.False(t, Enabled())
+	// no-op if disabled
+	entry := &Entry{Method: "DropCollection"}
+	assert.NoError(t, Begin(entry))
+	Complete(entry, nil)
+	Close()
+
+	// the seal key is required if enabled
+	params.Save(params.ProxyCfg.AuditLog.Enable.Key, "true")
+	assert.Error(t, InitAuditLogger(cm, &params.ProxyCfg.AuditLog, 1))
+	assert.False(t, Enabled())
+
+	params = newTestParams()
+	require.NoError(t, InitAuditLogger(cm, &params.ProxyCfg.AuditLog, 1))
+	assert.True(t, Enabled())
+	entry = &Entry{Method: "DropCollection", CollectionName: "coll"}
+	require.NoError(t, Begin(entry))
+	Complete(entry, merr.WrapErrCollectionNotFound("coll"))
+	Close()
+	assert.False(t, Enabled())
+
+	report, err := Verify(context.Background(), cm, "audit_log", []byte("key"))
+	require.NoError(t, err)
+	assert.True(t, report.OK(), report.Problems)
+	assert.Equal(t, 2, report.Entries)
+
+	records := readRecords(t, cm, segmentPath(path.Join(cm.RootPath(), "audit_log", "1"), 0))
+	require.Len(t, records, 3)
+	assert.Equal(t, StatusStarted, records[0].Entry.Status)
+	result := records[1].Entry
+	assert.Equal(t, StatusFailed, result.Status)
+	assert.Equal(t, "coll", result.CollectionName)
+	assert.Equal(t, records[0].Entry.Seq, result.StartSeq)
+	assert.NotEmpty(t, result.Error)
+}
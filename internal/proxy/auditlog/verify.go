@@ -0,0 +1,251 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// Report is the result of verifying the audit log.
+type Report struct {
+	Nodes    int
+	Segments int
+	Entries  int
+	Problems []string
+}
+
+// OK returns whether no gap or modification is found.
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *Report) addProblem(segmentPath string, format string, args ...any) {
+	r.Problems = append(r.Problems, fmt.Sprintf("%s: %s", segmentPath, fmt.Sprintf(format, args...)))
+}
+
+// chainState is the expected state of the next entry and segment of a node.
+type chainState struct {
+	segment  int64
+	seq      int64
+	prevHash string
+	// seals are the last hashes of the validly signed segments
+	seals map[int64]string
+}
+
+// Verify verifies the audit log segments under the root path, it detects the missing segments, the sequence gaps,
+// the broken hash chains, the modified entries and seals, the missing or invalid signatures,
+// and the chains which are not anchored or removed from the cluster.
+func Verify(ctx context.Context, cm storage.ChunkManager, rootPath string, sealKey []byte) (*Report, error) {
+	// without the key, the rewritten entries along with the recomputed hashes could not be detected
+	if len(sealKey) == 0 {
+		return nil, merr.WrapErrParameterMissingMsg("the seal key is required to verify the audit log")
+	}
+	root := path.Join(cm.RootPath(), rootPath)
+	prefix := root + "/"
+	nodeSegments := make(map[string][]string)
+	var anchorPaths []string
+	err := cm.WalkWithPrefix(ctx, prefix, true, func(info *storage.ChunkObjectInfo) bool {
+		dir := path.Dir(info.FilePath)
+		if path.Base(dir) == anchorsDir {
+			anchorPaths = append(anchorPaths, info.FilePath)
+		} else if strings.HasSuffix(info.FilePath, segmentExt) {
+			nodeSegments[dir] = append(nodeSegments[dir], info.FilePath)
+		} else if _, ok := nodeSegments[dir]; !ok && path.Base(info.FilePath) == headFile {
+			// the node is verified even if all the segments are removed
+			nodeSegments[dir] = nil
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Nodes: len(nodeSegments)}
+	chains := make(map[int64]*chainState, len(nodeSegments))
+	for dir, segments := range nodeSegments {
+		sort.Strings(segments)
+		state := &chainState{seq: 1, seals: make(map[int64]string)}
+		for _, segmentPath := range segments {
+			content, err := cm.Read(ctx, segmentPath)
+			if err != nil {
+				return nil, err
+			}
+			verifySegment(report, state, segmentPath, content, sealKey)
+		}
+		if err := verifyHead(ctx, cm, report, state, path.Join(dir, headFile), sealKey); err != nil {
+			return nil, err
+		}
+		nodeID, err := strconv.ParseInt(path.Base(dir), 10, 64)
+		if err != nil {
+			report.addProblem(dir, "invalid node directory")
+			continue
+		}
+		chains[nodeID] = state
+	}
+	if err := verifyAnchors(ctx, cm, report, root, chains, anchorPaths, sealKey); err != nil {
+		return nil, err
+	}
+	sort.Strings(report.Problems)
+	return report, nil
+}
+
+// verifyAnchors checks that every chain is anchored, and that the chains recorded by the anchors still exist
+// up to the recorded heads, otherwise the chains, e.g. the ones of the proxies before restart, are removed or truncated.
+func verifyAnchors(ctx context.Context, cm storage.ChunkManager, report *Report, root string,
+	chains map[int64]*chainState, anchorPaths []string, sealKey []byte,
+) error {
+	anchors := make(map[int64]*Anchor, len(anchorPaths))
+	for _, anchorFile := range anchorPaths {
+		anchor, err := readAnchor(ctx, cm, anchorFile, sealKey)
+		if err != nil {
+			if !errors.Is(err, merr.ErrServiceInternal) {
+				return err
+			}
+			report.addProblem(anchorFile, "invalid anchor")
+			continue
+		}
+		if anchorPath(root, anchor.NodeID) != anchorFile {
+			report.addProblem(anchorFile, "anchor of node %d is moved", anchor.NodeID)
+			continue
+		}
+		anchors[anchor.NodeID] = anchor
+	}
+
+	for nodeID := range chains {
+		if _, ok := anchors[nodeID]; !ok {
+			report.addProblem(anchorPath(root, nodeID), "chain of node %d is not anchored", nodeID)
+		}
+	}
+	for nodeID, anchor := range anchors {
+		anchorFile := anchorPath(root, nodeID)
+		for _, head := range anchor.PrevHeads {
+			if _, ok := anchors[head.NodeID]; !ok {
+				report.addProblem(anchorFile, "anchor of node %d is missing", head.NodeID)
+			}
+			if head.Segment < 0 {
+				continue
+			}
+			if state, ok := chains[head.NodeID]; !ok || state.seals[head.Segment] != head.LastHash {
+				report.addProblem(anchorFile, "chain of node %d is removed or truncated before segment %d",
+					head.NodeID, head.Segment)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyHead checks that the head points to the last segment, otherwise the latest segments are removed.
+func verifyHead(ctx context.Context, cm storage.ChunkManager, report *Report, state *chainState, headPath string, sealKey []byte) error {
+	exist, err := cm.Exist(ctx, headPath)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		report.addProblem(headPath, "head missing")
+		return nil
+	}
+	content, err := cm.Read(ctx, headPath)
+	if err != nil {
+		return err
+	}
+	head := &Seal{}
+	if err := json.Unmarshal(content, head); err != nil || head.sign(sealKey) != head.Signature {
+		report.addProblem(headPath, "invalid head")
+		return nil
+	}
+	if head.Segment != state.segment-1 || head.LastHash != state.prevHash {
+		report.addProblem(headPath, "head at segment %d does not match the last segment %d", head.Segment, state.segment-1)
+	}
+	return nil
+}
+
+func verifySegment(report *Report, state *chainState, segmentPath string, content []byte, sealKey []byte) {
+	report.Segments++
+	segment, err := strconv.ParseInt(strings.TrimSuffix(path.Base(segmentPath), segmentExt), 10, 64)
+	if err != nil {
+		report.addProblem(segmentPath, "invalid segment name")
+		return
+	}
+	if segment != state.segment {
+		report.addProblem(segmentPath, "missing segments from %d to %d", state.segment, segment-1)
+	}
+	state.segment = segment + 1
+
+	segmentPrevHash := state.prevHash
+	var entries []*Entry
+	var seal *Seal
+	for i, line := range bytes.Split(bytes.TrimSpace(content), []byte("\n")) {
+		r := &record{}
+		if err := json.Unmarshal(line, r); err != nil || (r.Entry == nil) == (r.Seal == nil) {
+			report.addProblem(segmentPath, "invalid record at line %d", i+1)
+			continue
+		}
+		if seal != nil {
+			report.addProblem(segmentPath, "record after the seal at line %d", i+1)
+		}
+		if r.Seal != nil {
+			seal = r.Seal
+			continue
+		}
+
+		e := r.Entry
+		report.Entries++
+		if e.Seq != state.seq {
+			report.addProblem(segmentPath, "sequence gap, expect %d but got %d", state.seq, e.Seq)
+		}
+		if e.PrevHash != state.prevHash {
+			report.addProblem(segmentPath, "broken hash chain at seq %d", e.Seq)
+		}
+		if e.computeHash() != e.Hash {
+			report.addProblem(segmentPath, "entry modified at seq %d", e.Seq)
+		}
+		state.seq = e.Seq + 1
+		state.prevHash = e.Hash
+		entries = append(entries, e)
+	}
+
+	if seal == nil {
+		report.addProblem(segmentPath, "segment not sealed")
+		return
+	}
+	if seal.Signature == "" {
+		report.addProblem(segmentPath, "segment not signed")
+	} else if seal.sign(sealKey) != seal.Signature {
+		report.addProblem(segmentPath, "invalid seal signature")
+	} else {
+		state.seals[segment] = seal.LastHash
+	}
+	if seal.Segment != segment || seal.PrevSegmentHash != segmentPrevHash {
+		report.addProblem(segmentPath, "seal does not link to the previous segment")
+	}
+	if len(entries) == 0 || seal.FirstSeq != entries[0].Seq || seal.LastSeq != entries[len(entries)-1].Seq ||
+		seal.LastHash != entries[len(entries)-1].Hash {
+		report.addProblem(segmentPath, "seal does not match the entries")
+	}
+}
@@ -0,0 +1,211 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// prepareSegments writes an anchored chain of three sealed segments with two entries each, signed with "key".
+func prepareSegments(t *testing.T) (storage.ChunkManager, *AuditLogger) {
+	params := newTestParams()
+	cm := newTestChunkManager(t)
+	logger, err := NewAuditLogger(cm, &params.ProxyCfg.AuditLog, 1)
+	require.NoError(t, err)
+	require.NoError(t, logger.anchor(context.Background()))
+	for i := 0; i < 3; i++ {
+		logger.Append(&Entry{Category: CategoryDDL, Method: "DropCollection", CollectionName: "coll"})
+		logger.Append(&Entry{Category: CategoryData, Method: "Delete", CollectionName: "coll"})
+		require.NoError(t, logger.Seal(context.Background()))
+	}
+	return cm, logger
+}
+
+func verifyProblems(t *testing.T, cm storage.ChunkManager, sealKey string) []string {
+	report, err := Verify(context.Background(), cm, "audit_log", []byte(sealKey))
+	require.NoError(t, err)
+	return report.Problems
+}
+
+func assertProblem(t *testing.T, problems []string, problem string) {
+	for _, p := range problems {
+		if strings.Contains(p, problem) {
+			return
+		}
+	}
+	assert.Failf(t, "problem not found", "expect %q in %v", problem, problems)
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		cm, _ := prepareSegments(t)
+		report, err := Verify(ctx, cm, "audit_log", []byte("key"))
+		require.NoError(t, err)
+		assert.True(t, report.OK(), report.Problems)
+		assert.Equal(t, 1, report.Nodes)
+		assert.Equal(t, 3, report.Segments)
+		assert.Equal(t, 6, report.Entries)
+	})
+
+	t.Run("entry modified", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		filePath := segmentPath(logger.dir, 1)
+		content, err := cm.Read(ctx, filePath)
+		require.NoError(t, err)
+		content = []byte(strings.Replace(string(content), `"method":"Delete"`, `"method":"Insert"`, 1))
+		require.NoError(t, cm.Write(ctx, filePath, content))
+
+		problems := verifyProblems(t, cm, "key")
+		assertProblem(t, problems, "entry modified at seq 4")
+	})
+
+	t.Run("entry removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		filePath := segmentPath(logger.dir, 1)
+		content, err := cm.Read(ctx, filePath)
+		require.NoError(t, err)
+		lines := strings.SplitAfter(string(content), "\n")
+		require.NoError(t, cm.Write(ctx, filePath, []byte(strings.Join(lines[1:], ""))))
+
+		problems := verifyProblems(t, cm, "key")
+		assertProblem(t, problems, "sequence gap, expect 3 but got 4")
+		assertProblem(t, problems, "broken hash chain at seq 4")
+		assertProblem(t, problems, "seal does not match the entries")
+	})
+
+	t.Run("segment removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		require.NoError(t, cm.Remove(ctx, segmentPath(logger.dir, 1)))
+
+		problems := verifyProblems(t, cm, "key")
+		assertProblem(t, problems, "missing segments from 1 to 1")
+		assertProblem(t, problems, "seal does not link to the previous segment")
+	})
+
+	t.Run("latest segment removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		require.NoError(t, cm.Remove(ctx, segmentPath(logger.dir, 2)))
+
+		problems := verifyProblems(t, cm, "key")
+		assert.Len(t, problems, 1)
+		assertProblem(t, problems, "head at segment 2 does not match the last segment 1")
+	})
+
+	t.Run("head removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		require.NoError(t, cm.Remove(ctx, path.Join(logger.dir, headFile)))
+
+		problems := verifyProblems(t, cm, "key")
+		assertProblem(t, problems, "head missing")
+	})
+
+	t.Run("seal removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		filePath := segmentPath(logger.dir, 2)
+		content, err := cm.Read(ctx, filePath)
+		require.NoError(t, err)
+		lines := strings.SplitAfter(string(content), "\n")
+		require.NoError(t, cm.Write(ctx, filePath, []byte(strings.Join(lines[:2], ""))))
+
+		problems := verifyProblems(t, cm, "key")
+		assertProblem(t, problems, "segment not sealed")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		cm, _ := prepareSegments(t)
+		assert.Empty(t, verifyProblems(t, cm, "key"))
+		_, err := Verify(ctx, cm, "audit_log", nil)
+		assert.Error(t, err)
+
+		problems := verifyProblems(t, cm, "other")
+		assert.Len(t, problems, 6)
+		assertProblem(t, problems, "invalid seal signature")
+		assertProblem(t, problems, "invalid head")
+		assertProblem(t, problems, "invalid anchor")
+		assertProblem(t, problems, "chain of node 1 is not anchored")
+	})
+
+	t.Run("signature removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		filePath := segmentPath(logger.dir, 1)
+		content, err := cm.Read(ctx, filePath)
+		require.NoError(t, err)
+		content = regexp.MustCompile(`,"signature":"[0-9a-f]+"`).ReplaceAll(content, nil)
+		require.NoError(t, cm.Write(ctx, filePath, content))
+
+		problems := verifyProblems(t, cm, "key")
+		assert.Len(t, problems, 1)
+		assertProblem(t, problems, "segment not signed")
+	})
+
+	t.Run("anchor removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		require.NoError(t, cm.Remove(ctx, anchorPath(logger.root, 1)))
+
+		problems := verifyProblems(t, cm, "key")
+		assert.Len(t, problems, 1)
+		assertProblem(t, problems, "chain of node 1 is not anchored")
+	})
+
+	t.Run("chain removed", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		// the proxy restarts with a new node id
+		params := newTestParams()
+		restarted, err := NewAuditLogger(cm, &params.ProxyCfg.AuditLog, 2)
+		require.NoError(t, err)
+		require.NoError(t, restarted.anchor(ctx))
+		restarted.Append(&Entry{Category: CategoryDDL, Method: "DropCollection", CollectionName: "coll"})
+		require.NoError(t, restarted.Seal(ctx))
+		assert.Empty(t, verifyProblems(t, cm, "key"))
+
+		// the latest segment is removed along with the head
+		require.NoError(t, cm.Remove(ctx, segmentPath(logger.dir, 2)))
+		require.NoError(t, cm.Remove(ctx, path.Join(logger.dir, headFile)))
+		problems := verifyProblems(t, cm, "key")
+		assertProblem(t, problems, "chain of node 1 is removed or truncated before segment 2")
+
+		// the whole chain is removed along with its anchor
+		require.NoError(t, cm.RemoveWithPrefix(ctx, logger.dir+"/"))
+		require.NoError(t, cm.Remove(ctx, anchorPath(logger.root, 1)))
+		problems = verifyProblems(t, cm, "key")
+		assert.Len(t, problems, 2)
+		assertProblem(t, problems, "anchor of node 1 is missing")
+		assertProblem(t, problems, "chain of node 1 is removed or truncated before segment 2")
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		cm, logger := prepareSegments(t)
+		filePath := segmentPath(logger.dir, 0)
+		content, err := cm.Read(ctx, filePath)
+		require.NoError(t, err)
+		require.NoError(t, cm.Write(ctx, filePath, append(content, []byte("{}\n")...)))
+
+		problems := verifyProblems(t, cm, "key")
+		assertProblem(t, problems, "invalid record at line 4")
+	})
+}
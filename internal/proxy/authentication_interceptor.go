@@ -80,7 +80,17 @@ func AuthenticationInterceptor(ctx context.Context) (context.Context, error) {
 			return nil, status.Error(codes.Unauthenticated, "invalid token format")
 		}
 
-		if !strings.Contains(rawToken, util.CredentialSeparator) {
+		if IsJWTAuthentication(rawToken) {
+			user, roles, err := VerifyJWT(rawToken)
+			if err != nil {
+				return nil, status.Error(codes.Unauthenticated, "auth check failure, please check the jwt is valid and not expired")
+			}
+			metrics.UserRPCCounter.WithLabelValues(user).Inc()
+			userToken := fmt.Sprintf("%s%s%s", user, util.CredentialSeparator, util.PasswordHolder)
+			md[strings.ToLower(util.HeaderAuthorize)] = []string{crypto.Base64Encode(userToken)}
+			ctx = metadata.NewIncomingContext(ctx, md)
+			ctx = SetExternalRolesToContext(ctx, roles)
+		} else if !strings.Contains(rawToken, util.CredentialSeparator) {
 			user, err := VerifyAPIKey(rawToken)
 			if err != nil {
 				mlog.Warn(ctx, "fail to verify apikey", mlog.Err(err))
@@ -2,13 +2,22 @@ package proxy
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/metadata"
 
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proxy/privilege"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/pkg/v3/util"
@@ -121,3 +130,70 @@ func TestAuthenticationInterceptor(t *testing.T) {
 	}
 	hookutil.SetTestHook(hookutil.DefaultHook{})
 }
+
+func TestAuthenticationInterceptorJWT(t *testing.T) {
+	ctx := context.Background()
+	err := InitMetaCache(ctx, &MockMixCoordClientInterface{})
+	assert.NoError(t, err)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keySet, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &privateKey.PublicKey, KeyID: "k1", Algorithm: string(jose.RS256), Use: "sig"},
+	}})
+	require.NoError(t, err)
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksPath, keySet, 0o600))
+
+	params := paramtable.Get()
+	params.Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	params.Save(Params.CommonCfg.JWTEnabled.Key, "true")
+	params.Save(Params.CommonCfg.JWKSSource.Key, jwksPath)
+	params.Save(Params.CommonCfg.JWTIssuer.Key, "https://sso.example.com")
+	params.Save(Params.CommonCfg.JWTRolesClaim.Key, "groups")
+	params.Save(Params.CommonCfg.JWTRoleMapping.KeyPrefix+"readers", "reader")
+	defer func() {
+		params.Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+		params.Reset(Params.CommonCfg.JWTEnabled.Key)
+		params.Reset(Params.CommonCfg.JWKSSource.Key)
+		params.Reset(Params.CommonCfg.JWTIssuer.Key)
+		params.Reset(Params.CommonCfg.JWTRolesClaim.Key)
+		params.Reset(Params.CommonCfg.JWTRoleMapping.KeyPrefix + "readers")
+		if jwtVerifier != nil {
+			jwtVerifier.Close()
+			jwtVerifier = nil
+		}
+	}()
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.RS256,
+		Key:       jose.JSONWebKey{Key: privateKey, KeyID: "k1"},
+	}, nil)
+	require.NoError(t, err)
+	sign := func(expiry time.Time) string {
+		token, err := jwt.Signed(signer).Claims(jwt.Claims{
+			Issuer:  "https://sso.example.com",
+			Subject: "service-a",
+			Expiry:  jwt.NewNumericDate(expiry),
+		}).Claims(map[string]any{"groups": []string{"readers", "admin"}}).Serialize()
+		require.NoError(t, err)
+		return token
+	}
+
+	md := metadata.Pairs(util.HeaderAuthorize, crypto.Base64Encode(sign(time.Now().Add(time.Hour))))
+	authCtx, err := AuthenticationInterceptor(metadata.NewIncomingContext(ctx, md))
+	assert.NoError(t, err)
+	md, ok := metadata.FromIncomingContext(authCtx)
+	assert.True(t, ok)
+	rawToken, err := crypto.Base64Decode(md[strings.ToLower(util.HeaderAuthorize)][0])
+	assert.NoError(t, err)
+	user, password := parseMD(rawToken)
+	assert.Equal(t, "service-a", user)
+	assert.Equal(t, util.PasswordHolder, password)
+	assert.Equal(t, []string{"reader"}, GetExternalRolesFromContext(authCtx))
+
+	// expired token
+	md = metadata.Pairs(util.HeaderAuthorize, crypto.Base64Encode(sign(time.Now().Add(-time.Hour))))
+	_, err = AuthenticationInterceptor(metadata.NewIncomingContext(ctx, md))
+	assert.Error(t, err)
+}
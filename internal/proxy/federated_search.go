@@ -0,0 +1,265 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+
+	"github.com/apache/arrow/go/v17/arrow/memory"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/util/function/chain"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metric"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// FederatedHit locates a hit of the federated search in the search results of its source collection.
+type FederatedHit struct {
+	// Source is the index of the source collection in the targets of the federated search.
+	Source int
+	// Offset is the offset of the hit in the search results of the source collection.
+	Offset int
+	Score  float32
+}
+
+// FederatedSearchResults is the merged result of a federated search, the hits of the queries are concatenated.
+type FederatedSearchResults struct {
+	Hits           []FederatedHit
+	Topks          []int64
+	AllSearchCount int64
+}
+
+// CheckFederatedSearchTargets checks the collections or aliases targeted by a federated search are distinct
+// and share a compatible vector field, the name of the vector field is returned.
+// The vector field is resolved from the first collection if annsField is empty.
+func CheckFederatedSearchTargets(ctx context.Context, dbName string, collectionNames []string, annsField string) (string, error) {
+	if len(collectionNames) == 0 {
+		return "", merr.WrapErrParameterMissingMsg("no collection is specified for the federated search")
+	}
+	maxCollections := Params.ProxyCfg.FederatedSearchMaxCollections.GetAsInt()
+	if maxCollections > 0 && len(collectionNames) > maxCollections {
+		return "", merr.WrapErrParameterInvalidMsg("the number of collections (%d) of the federated search exceeds the limit %d", len(collectionNames), maxCollections)
+	}
+
+	var expected *schemapb.FieldSchema
+	collectionIDs := make(map[int64]string, len(collectionNames))
+	for _, collectionName := range collectionNames {
+		info, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, 0)
+		if err != nil {
+			return "", err
+		}
+		if name, ok := collectionIDs[info.collID]; ok {
+			return "", merr.WrapErrParameterInvalidMsg("%s and %s refer to the same collection", name, collectionName)
+		}
+		collectionIDs[info.collID] = collectionName
+
+		if annsField == "" {
+			for _, field := range info.schema.GetFields() {
+				if !typeutil.IsVectorType(field.GetDataType()) {
+					continue
+				}
+				if annsField != "" {
+					return "", merr.WrapErrParameterInvalidMsg("annsField should be specified since collection %s has multiple vector fields", collectionName)
+				}
+				annsField = field.GetName()
+			}
+		}
+		field := getFederatedVectorField(info.schema.CollectionSchema, annsField)
+		if field == nil {
+			return "", merr.WrapErrParameterInvalidMsg("collection %s has no vector field %s", collectionName, annsField)
+		}
+		if expected == nil {
+			expected = field
+			continue
+		}
+		if err := checkFederatedVectorField(expected, field); err != nil {
+			return "", merr.WrapErrParameterInvalidMsg("vector field %s of collection %s is incompatible with collection %s, %s",
+				annsField, collectionName, collectionNames[0], err.Error())
+		}
+	}
+	return annsField, nil
+}
+
+func getFederatedVectorField(schema *schemapb.CollectionSchema, fieldName string) *schemapb.FieldSchema {
+	for _, field := range schema.GetFields() {
+		if field.GetName() == fieldName && typeutil.IsVectorType(field.GetDataType()) {
+			return field
+		}
+	}
+	return nil
+}
+
+func checkFederatedVectorField(expected *schemapb.FieldSchema, field *schemapb.FieldSchema) error {
+	if field.GetDataType() != expected.GetDataType() {
+		return merr.WrapErrParameterInvalid(expected.GetDataType().String(), field.GetDataType().String(), "data type mismatch")
+	}
+	if typeutil.IsSparseFloatVectorType(field.GetDataType()) {
+		return nil
+	}
+	expectedDim, err := typeutil.GetDim(expected)
+	if err != nil {
+		return err
+	}
+	dim, err := typeutil.GetDim(field)
+	if err != nil {
+		return err
+	}
+	if dim != expectedDim {
+		return merr.WrapErrParameterInvalid(expectedDim, dim, "dim mismatch")
+	}
+	return nil
+}
+
+// MergeFederatedSearchResults merges the search results of the collections targeted by a federated search
+// into the hits of each query, the results should be searched with the limit of limit+offset and without offset.
+// The hits are merged by score if no rank params are given, which requires the same metric type of the collections,
+// otherwise the hits are reranked by the rrf or weighted reranker, the weights are applied to the collections in order.
+func MergeFederatedSearchResults(ctx context.Context, results []*milvuspb.SearchResults, metricTypes []string,
+	nq int64, limit int64, offset int64, rankParams []*commonpb.KeyValuePair,
+) (*FederatedSearchResults, error) {
+	if len(results) == 0 || len(results) != len(metricTypes) {
+		return nil, merr.WrapErrParameterInvalidMsg("the number of search results (%d) mismatch with the metric types (%d)", len(results), len(metricTypes))
+	}
+
+	// the primary keys of the collections may overlap, so each hit is identified by its location
+	// in the search results instead, otherwise the hits of different collections would be merged.
+	numSources := int64(len(results))
+	subSearchResultData := make([]*schemapb.SearchResultData, 0, len(results))
+	var allSearchCount int64
+	for i, result := range results {
+		data, err := newFederatedResultData(result.GetResults(), int64(i), numSources, nq, limit+offset)
+		if err != nil {
+			return nil, err
+		}
+		allSearchCount += result.GetResults().GetAllSearchCount()
+		subSearchResultData = append(subSearchResultData, data)
+	}
+
+	var merged *schemapb.SearchResultData
+	if len(rankParams) == 0 {
+		metricType := metricTypes[0]
+		for _, mt := range metricTypes[1:] {
+			if mt != metricType {
+				return nil, merr.WrapErrParameterInvalidMsg("the metric types of the collections mismatch, the hits could only be merged by the rrf or weighted reranker")
+			}
+		}
+		// the reduce expects the larger score is the better one
+		if !metric.PositivelyRelated(metricType) {
+			for _, data := range subSearchResultData {
+				for k := range data.Scores {
+					data.Scores[k] *= -1
+				}
+			}
+		}
+		ret, err := reduceSearchResultDataNoGroupBy(ctx, subSearchResultData, nq, limit+offset, metricType, schemapb.DataType_Int64, offset)
+		if err != nil {
+			return nil, err
+		}
+		merged = ret.GetResults()
+	} else {
+		var err error
+		merged, err = rerankFederatedResultData(ctx, subSearchResultData, metricTypes, nq, limit, offset, rankParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := merged.GetIds().GetIntId().GetData()
+	ret := &FederatedSearchResults{
+		Hits:           make([]FederatedHit, 0, len(ids)),
+		Topks:          merged.GetTopks(),
+		AllSearchCount: allSearchCount,
+	}
+	for i, id := range ids {
+		ret.Hits = append(ret.Hits, FederatedHit{
+			Source: int(id % numSources),
+			Offset: int(id / numSources),
+			Score:  merged.GetScores()[i],
+		})
+	}
+	if len(ret.Topks) == 0 {
+		ret.Topks = make([]int64, nq)
+	}
+	return ret, nil
+}
+
+// newFederatedResultData copies the scores and topks of the search result, the ids are replaced by the location of the hits.
+func newFederatedResultData(data *schemapb.SearchResultData, source int64, numSources int64, nq int64, topK int64) (*schemapb.SearchResultData, error) {
+	topks := data.GetTopks()
+	if len(data.GetScores()) == 0 {
+		topks = make([]int64, nq)
+	}
+	if int64(len(topks)) != nq {
+		return nil, merr.WrapErrServiceInternalMsg("search result's nq(%d) mis-match with %d", len(topks), nq)
+	}
+	ids := make([]int64, len(data.GetScores()))
+	for i := range ids {
+		ids[i] = int64(i)*numSources + source
+	}
+	return &schemapb.SearchResultData{
+		NumQueries: nq,
+		TopK:       topK,
+		Scores:     append([]float32{}, data.GetScores()...),
+		Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		Topks:      topks,
+	}, nil
+}
+
+func rerankFederatedResultData(ctx context.Context, subSearchResultData []*schemapb.SearchResultData, metricTypes []string,
+	nq int64, limit int64, offset int64, rankParams []*commonpb.KeyValuePair,
+) (*schemapb.SearchResultData, error) {
+	empty := true
+	for _, data := range subSearchResultData {
+		if len(data.GetScores()) > 0 {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return &schemapb.SearchResultData{NumQueries: nq, Topks: make([]int64, nq)}, nil
+	}
+
+	alloc := memory.DefaultAllocator
+	fc, err := chain.BuildRerankChainWithLegacy(nil, rankParams, metricTypes, chain.NewSearchParams(nq, limit, offset, -1), alloc)
+	if err != nil {
+		return nil, err
+	}
+
+	dataframes := make([]*chain.DataFrame, 0, len(subSearchResultData))
+	defer func() {
+		for _, df := range dataframes {
+			df.Release()
+		}
+	}()
+	for _, data := range subSearchResultData {
+		df, err := chain.FromSearchResultData(data, alloc, nil)
+		if err != nil {
+			return nil, err
+		}
+		dataframes = append(dataframes, df)
+	}
+
+	resultDF, err := fc.ExecuteWithContext(ctx, dataframes...)
+	if err != nil {
+		return nil, err
+	}
+	defer resultDF.Release()
+	return chain.ToSearchResultData(resultDF)
+}
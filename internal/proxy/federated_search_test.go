@@ -0,0 +1,258 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/metric"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func newFederatedTestCollection(collectionID int64, fields ...*schemapb.FieldSchema) *collectionInfo {
+	return &collectionInfo{
+		collID: collectionID,
+		schema: newSchemaInfo(&schemapb.CollectionSchema{
+			Fields: append([]*schemapb.FieldSchema{
+				{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			}, fields...),
+		}),
+	}
+}
+
+func newFederatedTestVectorField(name string, dataType schemapb.DataType, dim string) *schemapb.FieldSchema {
+	return &schemapb.FieldSchema{
+		FieldID:    101,
+		Name:       name,
+		DataType:   dataType,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: dim}},
+	}
+}
+
+func TestCheckFederatedSearchTargets(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	oldCache := globalMetaCache
+	defer func() { globalMetaCache = oldCache }()
+
+	cache := NewMockCache(t)
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "tenant1", int64(0)).
+		Return(newFederatedTestCollection(1, newFederatedTestVectorField("vec", schemapb.DataType_FloatVector, "8")), nil).Maybe()
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "tenant2", int64(0)).
+		Return(newFederatedTestCollection(2, newFederatedTestVectorField("vec", schemapb.DataType_FloatVector, "8")), nil).Maybe()
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "alias1", int64(0)).
+		Return(newFederatedTestCollection(1, newFederatedTestVectorField("vec", schemapb.DataType_FloatVector, "8")), nil).Maybe()
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "dim16", int64(0)).
+		Return(newFederatedTestCollection(3, newFederatedTestVectorField("vec", schemapb.DataType_FloatVector, "16")), nil).Maybe()
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "binary", int64(0)).
+		Return(newFederatedTestCollection(4, newFederatedTestVectorField("vec", schemapb.DataType_BinaryVector, "8")), nil).Maybe()
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "multi", int64(0)).
+		Return(newFederatedTestCollection(5,
+			newFederatedTestVectorField("vec", schemapb.DataType_FloatVector, "8"),
+			newFederatedTestVectorField("vec2", schemapb.DataType_FloatVector, "8"),
+		), nil).Maybe()
+	cache.EXPECT().GetCollectionInfo(mock.Anything, "db", "missing", int64(0)).
+		Return(nil, merr.WrapErrCollectionNotFound("missing")).Maybe()
+	globalMetaCache = cache
+
+	t.Run("ok", func(t *testing.T) {
+		annsField, err := CheckFederatedSearchTargets(ctx, "db", []string{"tenant1", "tenant2"}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "vec", annsField)
+
+		annsField, err = CheckFederatedSearchTargets(ctx, "db", []string{"multi", "tenant1"}, "vec")
+		assert.NoError(t, err)
+		assert.Equal(t, "vec", annsField)
+	})
+
+	t.Run("no collection", func(t *testing.T) {
+		_, err := CheckFederatedSearchTargets(ctx, "db", nil, "")
+		assert.ErrorIs(t, err, merr.ErrParameterMissing)
+	})
+
+	t.Run("too many collections", func(t *testing.T) {
+		paramtable.Get().Save(Params.ProxyCfg.FederatedSearchMaxCollections.Key, "1")
+		defer paramtable.Get().Reset(Params.ProxyCfg.FederatedSearchMaxCollections.Key)
+		_, err := CheckFederatedSearchTargets(ctx, "db", []string{"tenant1", "tenant2"}, "")
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("same collection", func(t *testing.T) {
+		_, err := CheckFederatedSearchTargets(ctx, "db", []string{"tenant1", "alias1"}, "")
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("incompatible", func(t *testing.T) {
+		_, err := CheckFederatedSearchTargets(ctx, "db", []string{"tenant1", "dim16"}, "")
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+		_, err = CheckFederatedSearchTargets(ctx, "db", []string{"tenant1", "binary"}, "")
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+		_, err = CheckFederatedSearchTargets(ctx, "db", []string{"tenant1", "tenant2"}, "vec2")
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("multiple vector fields", func(t *testing.T) {
+		_, err := CheckFederatedSearchTargets(ctx, "db", []string{"multi", "tenant1"}, "")
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("collection not found", func(t *testing.T) {
+		_, err := CheckFederatedSearchTargets(ctx, "db", []string{"tenant1", "missing"}, "")
+		assert.ErrorIs(t, err, merr.ErrCollectionNotFound)
+	})
+}
+
+func newFederatedTestResults(pks []int64, scores []float32, topks []int64) *milvuspb.SearchResults {
+	return &milvuspb.SearchResults{
+		Status: merr.Success(),
+		Results: &schemapb.SearchResultData{
+			NumQueries: int64(len(topks)),
+			TopK:       int64(len(scores)),
+			Scores:     scores,
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: pks}}},
+			Topks:      topks,
+		},
+	}
+}
+
+func TestMergeFederatedSearchResults(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+
+	t.Run("merge by score", func(t *testing.T) {
+		// the primary keys of the collections overlap
+		results := []*milvuspb.SearchResults{
+			newFederatedTestResults([]int64{1, 2, 3, 1, 2}, []float32{0.9, 0.5, 0.1, 0.8, 0.7}, []int64{3, 2}),
+			newFederatedTestResults([]int64{1, 2, 1}, []float32{0.7, 0.6, 0.9}, []int64{2, 1}),
+		}
+		ret, err := MergeFederatedSearchResults(ctx, results, []string{metric.IP, metric.IP}, 2, 3, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 3}, ret.Topks)
+		assert.Equal(t, []FederatedHit{
+			{Source: 0, Offset: 0, Score: 0.9},
+			{Source: 1, Offset: 0, Score: 0.7},
+			{Source: 1, Offset: 1, Score: 0.6},
+			{Source: 1, Offset: 2, Score: 0.9},
+			{Source: 0, Offset: 3, Score: 0.8},
+			{Source: 0, Offset: 4, Score: 0.7},
+		}, ret.Hits)
+	})
+
+	t.Run("merge by distance with offset", func(t *testing.T) {
+		results := []*milvuspb.SearchResults{
+			newFederatedTestResults([]int64{1, 2, 3}, []float32{0.1, 0.4, 0.6}, []int64{3}),
+			newFederatedTestResults([]int64{1, 2, 3}, []float32{0.2, 0.3, 0.5}, []int64{3}),
+		}
+		ret, err := MergeFederatedSearchResults(ctx, results, []string{metric.L2, metric.L2}, 1, 2, 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, ret.Topks)
+		assert.Equal(t, []FederatedHit{
+			{Source: 1, Offset: 0, Score: 0.2},
+			{Source: 1, Offset: 1, Score: 0.3},
+		}, ret.Hits)
+	})
+
+	t.Run("empty results", func(t *testing.T) {
+		results := []*milvuspb.SearchResults{
+			{Status: merr.Success(), Results: &schemapb.SearchResultData{NumQueries: 1}},
+			newFederatedTestResults([]int64{1}, []float32{0.5}, []int64{1}),
+		}
+		ret, err := MergeFederatedSearchResults(ctx, results, []string{metric.COSINE, metric.COSINE}, 1, 3, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, ret.Topks)
+		assert.Equal(t, []FederatedHit{{Source: 1, Offset: 0, Score: 0.5}}, ret.Hits)
+	})
+
+	t.Run("metric type mismatch", func(t *testing.T) {
+		results := []*milvuspb.SearchResults{
+			newFederatedTestResults([]int64{1}, []float32{0.5}, []int64{1}),
+			newFederatedTestResults([]int64{1}, []float32{0.5}, []int64{1}),
+		}
+		_, err := MergeFederatedSearchResults(ctx, results, []string{metric.IP, metric.L2}, 1, 3, 0, nil)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+
+		_, err = MergeFederatedSearchResults(ctx, results, []string{metric.IP}, 1, 3, 0, nil)
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid)
+	})
+
+	t.Run("rrf", func(t *testing.T) {
+		results := []*milvuspb.SearchResults{
+			newFederatedTestResults([]int64{1, 2}, []float32{0.9, 0.1}, []int64{2}),
+			newFederatedTestResults([]int64{1, 2}, []float32{0.3, 0.2}, []int64{2}),
+		}
+		rankParams := []*commonpb.KeyValuePair{
+			{Key: RankTypeKey, Value: "rrf"},
+			{Key: ParamsKey, Value: `{"k": 60}`},
+		}
+		ret, err := MergeFederatedSearchResults(ctx, results, []string{metric.IP, metric.L2}, 1, 3, 0, rankParams)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, ret.Topks)
+		require.Len(t, ret.Hits, 3)
+		// the hits of the same rank get the same rrf score
+		assert.ElementsMatch(t, []FederatedHit{{Source: 0, Offset: 0}, {Source: 1, Offset: 0}},
+			[]FederatedHit{{Source: ret.Hits[0].Source, Offset: ret.Hits[0].Offset}, {Source: ret.Hits[1].Source, Offset: ret.Hits[1].Offset}})
+		assert.InDelta(t, 1.0/61, ret.Hits[0].Score, 1e-6)
+		assert.Equal(t, 1, ret.Hits[2].Offset)
+		assert.InDelta(t, 1.0/62, ret.Hits[2].Score, 1e-6)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		results := []*milvuspb.SearchResults{
+			newFederatedTestResults([]int64{1, 2}, []float32{0.9, 0.1}, []int64{2}),
+			newFederatedTestResults([]int64{1, 2}, []float32{0.8, 0.7}, []int64{2}),
+		}
+		rankParams := []*commonpb.KeyValuePair{
+			{Key: RankTypeKey, Value: "weighted"},
+			{Key: ParamsKey, Value: `{"weights": [0.1, 1.0], "norm_score": false}`},
+		}
+		ret, err := MergeFederatedSearchResults(ctx, results, []string{metric.IP, metric.IP}, 1, 2, 0, rankParams)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, ret.Topks)
+		require.Len(t, ret.Hits, 2)
+		assert.Equal(t, FederatedHit{Source: 1, Offset: 0, Score: ret.Hits[0].Score}, ret.Hits[0])
+		assert.Equal(t, FederatedHit{Source: 1, Offset: 1, Score: ret.Hits[1].Score}, ret.Hits[1])
+
+		// the number of weights mismatch with the collections
+		rankParams[1].Value = `{"weights": [1.0]}`
+		_, err = MergeFederatedSearchResults(ctx, results, []string{metric.IP, metric.IP}, 1, 2, 0, rankParams)
+		assert.Error(t, err)
+	})
+
+	t.Run("rerank empty results", func(t *testing.T) {
+		results := []*milvuspb.SearchResults{
+			{Status: merr.Success(), Results: &schemapb.SearchResultData{NumQueries: 2}},
+			{Status: merr.Success(), Results: &schemapb.SearchResultData{NumQueries: 2}},
+		}
+		rankParams := []*commonpb.KeyValuePair{{Key: RankTypeKey, Value: "rrf"}}
+		ret, err := MergeFederatedSearchResults(ctx, results, []string{"", ""}, 2, 3, 0, rankParams)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{0, 0}, ret.Topks)
+		assert.Empty(t, ret.Hits)
+	})
+}
@@ -209,6 +209,13 @@ func (node *Proxy) InvalidateCollectionMetaCache(ctx context.Context, request *p
 		}
 	}
 
+	switch msgType {
+	case commonpb.MsgType_DropDatabase, commonpb.MsgType_AlterDatabase:
+		node.searchCache.InvalidateDatabase(dbName)
+	default:
+		node.searchCache.InvalidateCollection(dbName, collectionName, collectionID)
+	}
+
 	switch msgType {
 	case commonpb.MsgType_DropCollection:
 		// no need to handle error, since this Proxy may not create dml stream for the collection.
@@ -2820,6 +2827,9 @@ func GetCollectionRateSubLabel(req any) string {
 
 // Search searches the most similar records of requests.
 func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+	ctx, done := node.runningRequests.Register(ctx, metrics.SearchLabel, request.GetDbName(), request.GetCollectionName())
+	defer done()
+
 	var err error
 	rsp := &milvuspb.SearchResults{
 		Status: merr.Success(),
@@ -2955,6 +2965,8 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		enableMaterializedView: node.enableMaterializedView,
 		mustUsePartitionKey:    Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
 		chMgr:                  node.chMgr,
+		searchCache:            node.searchCache,
+		coalescer:              node.searchCoalescer,
 	}
 
 	succeeded := false
@@ -3090,6 +3102,9 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 }
 
 func (node *Proxy) HybridSearch(ctx context.Context, request *milvuspb.HybridSearchRequest) (*milvuspb.SearchResults, error) {
+	ctx, done := node.runningRequests.Register(ctx, metrics.HybridSearchLabel, request.GetDbName(), request.GetCollectionName())
+	defer done()
+
 	var err error
 	rsp := &milvuspb.SearchResults{
 		Status: merr.Success(),
@@ -3742,6 +3757,9 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask, sp trace.Span) (*mi
 
 // Query get the records by primary keys.
 func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+	ctx, done := node.runningRequests.Register(ctx, metrics.QueryLabel, request.GetDbName(), request.GetCollectionName())
+	defer done()
+
 	qt := &queryTask{
 		ctx:       ctx,
 		Condition: NewTaskCondition(ctx),
@@ -3760,6 +3778,7 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 		shardclientMgr:      node.shardMgr,
 		mustUsePartitionKey: Params.ProxyCfg.MustUsePartitionKey.GetAsBool(),
 		chMgr:               node.chMgr,
+		coalescer:           node.queryCoalescer,
 	}
 
 	subLabel := GetCollectionRateSubLabel(request)
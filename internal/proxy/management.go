@@ -29,6 +29,8 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	management "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/querypb"
@@ -93,6 +95,14 @@ func RegisterMgrRoute(proxy *Proxy) {
 			Path:        management.RouteClearReadTaskQueue,
 			HandlerFunc: proxy.ClearReadTaskQueueManagement,
 		})
+		management.Register(&management.Handler{
+			Path:        management.RouteListRunningRequests,
+			HandlerFunc: proxy.ListRunningRequests,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteCancelRunningRequest,
+			HandlerFunc: proxy.CancelRequest,
+		})
 		management.Register(&management.Handler{
 			Path:        management.RouteQueryCoordBalanceStatus,
 			HandlerFunc: proxy.CheckQueryCoordBalanceStatus,
@@ -148,11 +158,18 @@ func (node *Proxy) PauseDatacoordGC(w http.ResponseWriter, req *http.Request) {
 		})
 	}
 
+	audit, err := beginGcControlAudit(req, "PauseDatacoordGC", params)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, `{"msg": "failed to pause garbage collection, %s"}`, err.Error())
+		return
+	}
 	resp, err := node.mixCoord.GcControl(req.Context(), &datapb.GcControlRequest{
 		Base:    commonpbutil.NewMsgBase(),
 		Command: datapb.GcCommand_Pause,
 		Params:  params,
 	})
+	completeGcControlAudit(audit, resp, err)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, `{"msg": "failed to pause garbage collection, %s"}`, err.Error())
@@ -237,11 +254,18 @@ func (node *Proxy) ResumeDatacoordGC(w http.ResponseWriter, req *http.Request) {
 		{Key: "collection_id", Value: collectionID},
 	}
 
+	audit, err := beginGcControlAudit(req, "ResumeDatacoordGC", params)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, `{"msg": "failed to resume garbage collection, %s"}`, err.Error())
+		return
+	}
 	resp, err := node.mixCoord.GcControl(req.Context(), &datapb.GcControlRequest{
 		Base:    commonpbutil.NewMsgBase(),
 		Command: datapb.GcCommand_Resume,
 		Params:  params,
 	})
+	completeGcControlAudit(audit, resp, err)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, `{"msg": "failed to resume garbage collection, %s"}`, err.Error())
@@ -256,6 +280,36 @@ func (node *Proxy) ResumeDatacoordGC(w http.ResponseWriter, req *http.Request) {
 	w.Write([]byte(`{"msg": "OK"}`))
 }
 
+// beginGcControlAudit records the garbage collection control request in the audit log before it is executed,
+// the request must be rejected if it can't be recorded.
+func beginGcControlAudit(req *http.Request, method string, params []*commonpb.KeyValuePair) (*auditlog.Entry, error) {
+	if !auditlog.Enabled() {
+		return nil, nil
+	}
+	entry := &auditlog.Entry{
+		Category: auditlog.CategoryGC,
+		Method:   method,
+		SourceIP: req.RemoteAddr,
+		After:    auditlog.SummarizeRequest(&datapb.GcControlRequest{Params: params}),
+	}
+	if err := auditlog.Begin(entry); err != nil {
+		mlog.Warn(req.Context(), "reject the request since it can't be audited", mlog.String("method", method), mlog.Err(err))
+		return nil, err
+	}
+	return entry, nil
+}
+
+// completeGcControlAudit records the result of the garbage collection control request in the audit log.
+func completeGcControlAudit(started *auditlog.Entry, resp *commonpb.Status, err error) {
+	if started == nil {
+		return
+	}
+	if err == nil {
+		err = merr.Error(resp)
+	}
+	auditlog.Complete(started, err)
+}
+
 func (node *Proxy) ListQueryNode(w http.ResponseWriter, req *http.Request) {
 	resp, err := node.mixCoord.ListQueryNode(req.Context(), &querypb.ListQueryNodeRequest{
 		Base: commonpbutil.NewMsgBase(),
@@ -422,6 +476,54 @@ func (node *Proxy) ClearReadTaskQueueManagement(w http.ResponseWriter, req *http
 	w.Write(bs)
 }
 
+// ListRunningRequests lists the search and query requests running in this proxy.
+func (node *Proxy) ListRunningRequests(w http.ResponseWriter, req *http.Request) {
+	bs, err := json.Marshal(map[string]interface{}{
+		"msg":      "OK",
+		"requests": node.runningRequests.List(),
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, `{"msg": "failed to list running requests, %s"}`, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
+// CancelRequest cancels the running requests in this proxy matched by the request id,
+// which is either the id listed by ListRunningRequests or the trace id of the request.
+func (node *Proxy) CancelRequest(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm() //nolint:gosec // internal admin endpoint
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"msg": "failed to cancel request, %s"}`, err.Error()) //nolint:gosec // internal admin endpoint
+		return
+	}
+	requestID := req.FormValue("request_id") //nolint:gosec // internal admin endpoint
+	if requestID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"msg": "failed to cancel request, request_id is required"}`))
+		return
+	}
+
+	canceled := node.runningRequests.Cancel(requestID, req.FormValue("reason")) //nolint:gosec // internal admin endpoint
+	if len(canceled) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		bs, _ := json.Marshal(map[string]interface{}{
+			"msg": fmt.Sprintf("failed to cancel request, no running request matches %s", requestID),
+		})
+		w.Write(bs)
+		return
+	}
+	bs, _ := json.Marshal(map[string]interface{}{
+		"msg":      "OK",
+		"canceled": canceled,
+	})
+	w.WriteHeader(http.StatusOK)
+	w.Write(bs)
+}
+
 func (node *Proxy) SuspendQueryNode(w http.ResponseWriter, req *http.Request) {
 	err := req.ParseForm() //nolint:gosec // internal admin endpoint
 	if err != nil {
@@ -865,6 +865,49 @@ func (s *ProxyManagementSuite) TestCommitBackfillResult() {
 	})
 }
 
+func (s *ProxyManagementSuite) TestRunningRequests() {
+	s.SetupTest()
+	defer s.TearDownTest()
+	s.proxy.runningRequests = newRunningRequests()
+
+	ctx, done := s.proxy.runningRequests.Register(context.Background(), "search", "default", "coll")
+	defer done()
+
+	req, err := http.NewRequest(http.MethodGet, management.RouteListRunningRequests, nil)
+	s.Require().NoError(err)
+	recorder := httptest.NewRecorder()
+	s.proxy.ListRunningRequests(recorder, req)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Contains(recorder.Body.String(), `"id":"1"`)
+	s.Contains(recorder.Body.String(), `"collection_name":"coll"`)
+
+	// test miss requested param
+	req, err = http.NewRequest(http.MethodPost, management.RouteCancelRunningRequest, strings.NewReader(""))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder = httptest.NewRecorder()
+	s.proxy.CancelRequest(recorder, req)
+	s.Equal(http.StatusBadRequest, recorder.Code)
+
+	// test no matched request
+	req, err = http.NewRequest(http.MethodPost, management.RouteCancelRunningRequest, strings.NewReader("request_id=2"))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder = httptest.NewRecorder()
+	s.proxy.CancelRequest(recorder, req)
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.NoError(ctx.Err())
+
+	req, err = http.NewRequest(http.MethodPost, management.RouteCancelRunningRequest, strings.NewReader("request_id=1&reason=full+scan"))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder = httptest.NewRecorder()
+	s.proxy.CancelRequest(recorder, req)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.ErrorIs(ctx.Err(), context.Canceled)
+	s.ErrorContains(context.Cause(ctx), "full scan")
+}
+
 func TestProxyManagement(t *testing.T) {
 	suite.Run(t, new(ProxyManagementSuite))
 }
@@ -34,6 +34,7 @@ import (
 	internalhttp "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/proxy/privilege"
 	"github.com/milvus-io/milvus/internal/types"
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
 	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
@@ -134,6 +135,19 @@ type databaseInfo struct {
 	dbID             typeutil.UniqueID
 	properties       []*commonpb.KeyValuePair
 	createdTimestamp uint64
+
+	// the principal rate limits parsed from the properties,
+	// the databaseInfo is replaced when the database meta changes.
+	principalLimitsOnce sync.Once
+	principalLimits     *rlinternal.PrincipalLimits
+}
+
+// getPrincipalLimits returns the rate limits of the users and roles configured by the database properties.
+func (d *databaseInfo) getPrincipalLimits() *rlinternal.PrincipalLimits {
+	d.principalLimitsOnce.Do(func() {
+		d.principalLimits = rlinternal.ParsePrincipalLimits(d.properties)
+	})
+	return d.principalLimits
 }
 
 // schemaInfo is a helper function wraps *schemapb.CollectionSchema
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"sync"
 
+	"github.com/samber/lo"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -25,7 +26,12 @@ import (
 
 type PrivilegeFunc func(ctx context.Context, req interface{}) (context.Context, error)
 
-const RBACRoleContextKey = hook.HookContextKeyType("rbac-role")
+const (
+	RBACRoleContextKey = hook.HookContextKeyType("rbac-role")
+	// ExternalRoleContextKey holds the roles mapped from the claims of the jwt, which are granted
+	// in addition to the roles bound to the user.
+	ExternalRoleContextKey = hook.HookContextKeyType("external-role")
+)
 
 var (
 	initOnce                sync.Once
@@ -39,6 +45,19 @@ func SetRBACRolesToContext(ctx context.Context, roles []string) context.Context
 	return context.WithValue(ctx, RBACRoleContextKey, rolesCopy)
 }
 
+func SetExternalRolesToContext(ctx context.Context, roles []string) context.Context {
+	if len(roles) == 0 {
+		return ctx
+	}
+	rolesCopy := append([]string(nil), roles...)
+	return context.WithValue(ctx, ExternalRoleContextKey, rolesCopy)
+}
+
+func GetExternalRolesFromContext(ctx context.Context) []string {
+	roles, _ := ctx.Value(ExternalRoleContextKey).([]string)
+	return roles
+}
+
 // UnaryServerInterceptor returns a new unary server interceptors that performs per-request privilege access.
 func UnaryServerInterceptor(privilegeFunc PrivilegeFunc) grpc.UnaryServerInterceptor {
 	privilege.InitPrivilegeGroups()
@@ -77,7 +96,7 @@ func PrivilegeInterceptor(ctx context.Context, req interface{}) (context.Context
 		mlog.Warn(ctx, "GetRole fail", mlog.String("username", username), mlog.Err(err))
 		return ctx, err
 	}
-	roleNames = append(roleNames, util.RolePublic)
+	roleNames = lo.Uniq(append(append(roleNames, GetExternalRolesFromContext(ctx)...), util.RolePublic))
 	ctx = SetRBACRolesToContext(ctx, roleNames)
 	objectType := privilegeExt.ObjectType.String()
 	objectNameIndex := privilegeExt.ObjectNameIndex
@@ -278,6 +278,14 @@ func TestPrivilegeInterceptorClusterLevel(t *testing.T) {
 		})
 		assert.Error(t, err)
 	})
+
+	t.Run("cluster grant from the jwt roles authorizes CreateDatabase", func(t *testing.T) {
+		ctx := SetExternalRolesToContext(GetContext(context.Background(), "dave:pwd"), []string{"role_cluster"})
+		_, err := PrivilegeInterceptor(ctx, &milvuspb.CreateDatabaseRequest{
+			DbName: "brand_new_db",
+		})
+		assert.NoError(t, err)
+	})
 }
 
 // TestPrivilegeInterceptorDatabaseLevel covers the database-level half of the
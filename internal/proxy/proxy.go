@@ -29,12 +29,15 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	"github.com/milvus-io/milvus/internal/allocator"
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/internal/proxy/shardclient"
+	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
+	"github.com/milvus-io/milvus/pkg/v3/common"
 	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/mlog"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
@@ -114,6 +117,16 @@ type Proxy struct {
 	enableComplexDeleteLimit bool
 
 	slowQueries *expirable.LRU[Timestamp, *metricsinfo.SlowQuery]
+
+	// search result cache of the non-strong consistency searches
+	searchCache *searchResultCache
+
+	// coalescers of the identical in-flight search and query requests
+	searchCoalescer *requestCoalescer[*milvuspb.SearchResults]
+	queryCoalescer  *requestCoalescer[*milvuspb.QueryResults]
+
+	// search and query requests running in the proxy, which could be canceled by the administrator
+	runningRequests *runningRequests
 }
 
 // NewProxy returns a Proxy struct.
@@ -131,6 +144,10 @@ func NewProxy(ctx context.Context, _ dependency.Factory) (*Proxy, error) {
 		// lbPolicy:        lbPolicy,
 		resourceManager: resourceManager,
 		slowQueries:     expirable.NewLRU[Timestamp, *metricsinfo.SlowQuery](20, nil, time.Minute*15),
+		searchCache:     newSearchResultCache(),
+		searchCoalescer: newRequestCoalescer[*milvuspb.SearchResults](metrics.SearchLabel),
+		queryCoalescer:  newRequestCoalescer[*milvuspb.QueryResults](metrics.QueryLabel),
+		runningRequests: newRunningRequests(),
 	}
 	node.UpdateStateCode(commonpb.StateCode_Abnormal)
 	expr.Register("proxy", node)
@@ -243,10 +260,15 @@ func (node *Proxy) Init() error {
 	mlog.Debug(node.ctx, "init meta cache done", mlog.String("role", typeutil.ProxyRole))
 
 	node.shardMgr = shardclient.NewShardClientMgr(node.mixCoord)
-	node.lbPolicy = shardclient.NewLBPolicyImpl(node.shardMgr)
+	node.lbPolicy = shardclient.NewLBPolicyImpl(node.shardMgr, node.lbPolicyOptions()...)
 
 	node.enableMaterializedView = Params.CommonCfg.EnableMaterializedView.GetAsBool()
 
+	if err := node.initAuditLogger(); err != nil {
+		mlog.Warn(node.ctx, "failed to init audit logger", mlog.String("role", typeutil.ProxyRole), mlog.Err(err))
+		return err
+	}
+
 	// Enable internal rand pool for UUIDv4 generation
 	// This is NOT thread-safe and should only be called before the service starts and
 	// there is no possibility that New or any other UUID V4 generation function will be called concurrently
@@ -258,6 +280,50 @@ func (node *Proxy) Init() error {
 	return nil
 }
 
+// initAuditLogger initializes the audit log on the persistent storage if it is enabled.
+func (node *Proxy) initAuditLogger() error {
+	if !Params.ProxyCfg.AuditLog.Enable.GetAsBool() {
+		return nil
+	}
+	cm, err := storage.NewChunkManagerFactoryWithParam(Params).NewPersistentStorageChunkManager(node.ctx)
+	if err != nil {
+		return err
+	}
+	return auditlog.InitAuditLogger(cm, &Params.ProxyCfg.AuditLog, paramtable.GetNodeID())
+}
+
+// lbPolicyOptions returns the options of the replica selection, the zones come from the session labels
+// and the collection could override the replica selection policy by its properties.
+func (node *Proxy) lbPolicyOptions() []shardclient.LBPolicyOption {
+	opts := []shardclient.LBPolicyOption{
+		shardclient.WithCollectionPolicyResolver(func(ctx context.Context, dbName string, collectionName string, collectionID int64) string {
+			if globalMetaCache == nil {
+				return ""
+			}
+			info, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, collectionID)
+			if err != nil {
+				return ""
+			}
+			return common.GetCollectionReplicaSelectionPolicy(info.properties...)
+		}),
+	}
+	if node.session == nil {
+		return opts
+	}
+	session := node.session
+	return append(opts, shardclient.WithZoneResolver(session.GetZone(), func(ctx context.Context) (map[int64]string, error) {
+		sessions, _, err := session.GetSessions(ctx, typeutil.QueryNodeRole)
+		if err != nil {
+			return nil, err
+		}
+		nodeZones := make(map[int64]string, len(sessions))
+		for _, s := range sessions {
+			nodeZones[s.ServerID] = s.GetZone()
+		}
+		return nodeZones, nil
+	}))
+}
+
 // Start starts a proxy node.
 func (node *Proxy) Start() error {
 	node.shardMgr.Start()
@@ -332,6 +398,8 @@ func (node *Proxy) Stop() error {
 		globalMetaCache.Close()
 	}
 
+	auditlog.Close()
+
 	node.cancel()
 	node.wg.Wait()
 
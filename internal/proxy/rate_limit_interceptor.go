@@ -54,7 +54,13 @@ func RateLimitInterceptor(limiter types.Limiter) grpc.UnaryServerInterceptor {
 				}
 			}
 		}
-		err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+		cancelPrincipal, err := limiter.CheckPrincipal(ctx, GetCurDBNameFromRequestOrContext(ctx, req), rt, n)
+		if err == nil {
+			err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+			if err != nil {
+				cancelPrincipal()
+			}
+		}
 		nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
 		metrics.ProxyRateLimitReqCount.WithLabelValues(nodeID, rt.String(), metrics.TotalLabel).Inc()
 		if err != nil {
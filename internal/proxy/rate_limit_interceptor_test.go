@@ -52,6 +52,13 @@ func (l *limiterMock) Check(dbID int64, collectionIDToPartIDs map[int64][]int64,
 	return nil
 }
 
+func (l *limiterMock) Cancel(dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) {
+}
+
+func (l *limiterMock) CheckPrincipal(ctx context.Context, dbName string, rt internalpb.RateType, n int) (func(), error) {
+	return func() {}, nil
+}
+
 func (l *limiterMock) Alloc(ctx context.Context, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
 	return l.Check(dbID, collectionIDToPartIDs, rt, n)
 }
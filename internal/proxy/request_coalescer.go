@@ -0,0 +1,161 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/atomic"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/conc"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+const (
+	coalesceStateIdle int32 = iota
+	coalesceStateExecuting
+	coalesceStateAbandoned
+)
+
+// requestCoalescer deduplicates the identical in-flight requests, the first request executes
+// and the identical requests arriving before it finishes wait for and share its result.
+type requestCoalescer[T proto.Message] struct {
+	label string
+	sf    conc.Singleflight[T]
+}
+
+func newRequestCoalescer[T proto.Message](label string) *requestCoalescer[T] {
+	return &requestCoalescer[T]{label: label}
+}
+
+// isRequestCoalescable returns whether the request of the consistency level could be coalesced,
+// the strong and session consistency requests must see the writes before them, so they are excluded.
+func isRequestCoalescable(consistencyLevel commonpb.ConsistencyLevel) bool {
+	if !paramtable.Get().ProxyCfg.RequestCoalescingEnabled.GetAsBool() {
+		return false
+	}
+	return consistencyLevel == commonpb.ConsistencyLevel_Bounded ||
+		consistencyLevel == commonpb.ConsistencyLevel_Eventually
+}
+
+// Do executes the request of the key, or waits for the identical in-flight request and shares its result.
+// The shared result is copied for each caller, and coalesced is true if the result comes from another request.
+// If the shared execution fails because of the context of the executing request while the context of
+// the caller is still alive, the caller executes the request by itself.
+func (c *requestCoalescer[T]) Do(ctx context.Context, key string, execute func() (T, error)) (result T, coalesced bool, err error) {
+	if c == nil {
+		result, err = execute()
+		return result, false, err
+	}
+
+	// state is only changed by the execution of this caller, the caller abandons
+	// the execution if it has not started when the context of the caller is done
+	state := atomic.NewInt32(coalesceStateIdle)
+	ch := c.sf.DoChan(key, func() (T, error) {
+		if !state.CompareAndSwap(coalesceStateIdle, coalesceStateExecuting) {
+			var zero T
+			return zero, ctx.Err()
+		}
+		return execute()
+	})
+
+	var res conc.SingleflightResult[T]
+	select {
+	case res = <-ch:
+	case <-ctx.Done():
+		if state.CompareAndSwap(coalesceStateIdle, coalesceStateAbandoned) {
+			return result, false, ctx.Err()
+		}
+		res = <-ch
+	}
+
+	executed := state.Load() == coalesceStateExecuting
+	result, err = res.Val, res.Err
+	if !executed {
+		metrics.ProxyCoalescedRequestCount.WithLabelValues(paramtable.GetStringNodeID(), c.label).Inc()
+		if err != nil && ctx.Err() == nil &&
+			(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+			result, err = execute()
+			return result, false, err
+		}
+	}
+	if err != nil {
+		return result, !executed, err
+	}
+	// the original result is shared by all the callers, everyone owns a copy
+	if res.Shared {
+		result = proto.Clone(result).(T)
+	}
+	return result, !executed, nil
+}
+
+// buildCoalesceKey builds the coalescing key of the normalized requests and the consistency level.
+func buildCoalesceKey(consistencyLevel commonpb.ConsistencyLevel, msgs ...proto.Message) (string, error) {
+	marshaler := proto.MarshalOptions{Deterministic: true}
+	h := sha256.New()
+	h.Write(binary.LittleEndian.AppendUint32(nil, uint32(consistencyLevel)))
+	for _, msg := range msgs {
+		bytes, err := marshaler.Marshal(msg)
+		if err != nil {
+			return "", err
+		}
+		h.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(bytes))))
+		h.Write(bytes)
+	}
+	return string(h.Sum(nil)), nil
+}
+
+// buildSearchCoalesceKey builds the coalescing key of the search, which consists of the consistency level,
+// the collection, the normalized plan and search params and the output fields.
+func buildSearchCoalesceKey(req *internalpb.SearchRequest, request *milvuspb.SearchRequest) (string, error) {
+	internalReq, userReq := normalizeSearchRequests(req, request)
+	return buildCoalesceKey(req.GetConsistencyLevel(), internalReq, userReq)
+}
+
+// buildQueryCoalesceKey builds the coalescing key of the query, which consists of the consistency level,
+// the collection, the normalized plan and query params and the output fields.
+func buildQueryCoalesceKey(req *internalpb.RetrieveRequest, request *milvuspb.QueryRequest) (string, error) {
+	internalReq := proto.Clone(req).(*internalpb.RetrieveRequest)
+	internalReq.Base = nil
+	internalReq.ReqID = 0
+	internalReq.MvccTimestamp = 0
+	internalReq.GuaranteeTimestamp = 0
+	internalReq.TimeoutTimestamp = 0
+	internalReq.Username = ""
+	internalReq.CollectionTtlTimestamps = 0
+	internalReq.EntityTtlPhysicalTime = 0
+
+	userReq := proto.Clone(request).(*milvuspb.QueryRequest)
+	userReq.Base = nil
+	userReq.DbName = ""
+	userReq.CollectionName = ""
+	userReq.Expr = ""
+	userReq.ExprTemplateValues = nil
+	userReq.TravelTimestamp = 0
+	userReq.GuaranteeTimestamp = 0
+	userReq.ConsistencyLevel = commonpb.ConsistencyLevel_Strong
+	userReq.UseDefaultConsistency = false
+	return buildCoalesceKey(req.GetConsistencyLevel(), internalReq, userReq)
+}
@@ -0,0 +1,244 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func TestIsRequestCoalescable(t *testing.T) {
+	params := paramtable.Get()
+	assert.False(t, isRequestCoalescable(commonpb.ConsistencyLevel_Bounded))
+
+	params.Save(params.ProxyCfg.RequestCoalescingEnabled.Key, "true")
+	defer params.Reset(params.ProxyCfg.RequestCoalescingEnabled.Key)
+	assert.True(t, isRequestCoalescable(commonpb.ConsistencyLevel_Bounded))
+	assert.True(t, isRequestCoalescable(commonpb.ConsistencyLevel_Eventually))
+	assert.False(t, isRequestCoalescable(commonpb.ConsistencyLevel_Strong))
+	assert.False(t, isRequestCoalescable(commonpb.ConsistencyLevel_Session))
+}
+
+func TestBuildCoalesceKey(t *testing.T) {
+	t.Run("search", func(t *testing.T) {
+		base := time.UnixMilli(1_700_000_000_000)
+		req, request := newSearchCacheTestRequests(base)
+		req.ConsistencyLevel = commonpb.ConsistencyLevel_Bounded
+		key, err := buildSearchCoalesceKey(req, request)
+		require.NoError(t, err)
+
+		// the fields varying between the identical requests are ignored
+		req2, request2 := newSearchCacheTestRequests(base.Add(time.Minute))
+		req2.ConsistencyLevel = commonpb.ConsistencyLevel_Bounded
+		req2.Username = "bob"
+		request2.Dsl = "a>1"
+		key2, err := buildSearchCoalesceKey(req2, request2)
+		require.NoError(t, err)
+		assert.Equal(t, key, key2)
+
+		req2.ConsistencyLevel = commonpb.ConsistencyLevel_Eventually
+		key2, err = buildSearchCoalesceKey(req2, request2)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, key2)
+
+		req3, request3 := newSearchCacheTestRequests(base)
+		req3.ConsistencyLevel = commonpb.ConsistencyLevel_Bounded
+		req3.CollectionID = 101
+		key3, err := buildSearchCoalesceKey(req3, request3)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, key3)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		newRequests := func() (*internalpb.RetrieveRequest, *milvuspb.QueryRequest) {
+			return &internalpb.RetrieveRequest{
+				Base:               &commonpb.MsgBase{MsgID: 1},
+				ReqID:              1,
+				CollectionID:       100,
+				SerializedExprPlan: []byte("plan"),
+				GuaranteeTimestamp: 1,
+				Username:           "alice",
+				ConsistencyLevel:   commonpb.ConsistencyLevel_Bounded,
+			}, &milvuspb.QueryRequest{
+				DbName:         "default",
+				CollectionName: "coll",
+				Expr:           "a > 1",
+				OutputFields:   []string{"a"},
+			}
+		}
+		req, request := newRequests()
+		key, err := buildQueryCoalesceKey(req, request)
+		require.NoError(t, err)
+
+		req2, request2 := newRequests()
+		req2.ReqID = 2
+		req2.GuaranteeTimestamp = 2
+		req2.Username = "bob"
+		request2.Expr = "a>1"
+		key2, err := buildQueryCoalesceKey(req2, request2)
+		require.NoError(t, err)
+		assert.Equal(t, key, key2)
+
+		req3, request3 := newRequests()
+		request3.OutputFields = []string{"b"}
+		key3, err := buildQueryCoalesceKey(req3, request3)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, key3)
+
+		req4, request4 := newRequests()
+		req4.SerializedExprPlan = []byte("plan2")
+		key4, err := buildQueryCoalesceKey(req4, request4)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, key4)
+	})
+}
+
+func TestRequestCoalescer(t *testing.T) {
+	t.Run("nil coalescer", func(t *testing.T) {
+		var c *requestCoalescer[*milvuspb.SearchResults]
+		result, coalesced, err := c.Do(context.Background(), "key", func() (*milvuspb.SearchResults, error) {
+			return newSearchCacheTestResult(1), nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, coalesced)
+		assert.Equal(t, []int64{1}, result.GetResults().GetIds().GetIntId().GetData())
+	})
+
+	t.Run("coalesce", func(t *testing.T) {
+		c := newRequestCoalescer[*milvuspb.SearchResults](metrics.SearchLabel)
+		executing := make(chan struct{})
+		release := make(chan struct{})
+		executions := atomic.NewInt32(0)
+		execute := func() (*milvuspb.SearchResults, error) {
+			if executions.Inc() == 1 {
+				close(executing)
+			}
+			<-release
+			return newSearchCacheTestResult(1, 2), nil
+		}
+
+		const n = 5
+		results := make([]*milvuspb.SearchResults, n)
+		coalesced := atomic.NewInt32(0)
+		wg := sync.WaitGroup{}
+		run := func(i int) {
+			defer wg.Done()
+			result, shared, err := c.Do(context.Background(), "key", execute)
+			assert.NoError(t, err)
+			if shared {
+				coalesced.Inc()
+			}
+			results[i] = result
+		}
+		wg.Add(1)
+		go run(0)
+		<-executing
+		for i := 1; i < n; i++ {
+			wg.Add(1)
+			go run(i)
+		}
+		// wait for the waiters to join the in-flight execution
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), executions.Load())
+		assert.Equal(t, int32(n-1), coalesced.Load())
+		for i := range results {
+			assert.Equal(t, []int64{1, 2}, results[i].GetResults().GetIds().GetIntId().GetData())
+			for j := i + 1; j < n; j++ {
+				assert.NotSame(t, results[i], results[j])
+			}
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c := newRequestCoalescer[*milvuspb.SearchResults](metrics.SearchLabel)
+		_, coalesced, err := c.Do(context.Background(), "key", func() (*milvuspb.SearchResults, error) {
+			return nil, merr.ErrCollectionNotLoaded
+		})
+		assert.ErrorIs(t, err, merr.ErrCollectionNotLoaded)
+		assert.False(t, coalesced)
+	})
+
+	t.Run("executing request canceled", func(t *testing.T) {
+		c := newRequestCoalescer[*milvuspb.SearchResults](metrics.SearchLabel)
+		leaderCtx, cancel := context.WithCancel(context.Background())
+		executing := make(chan struct{})
+		leaderDone := make(chan struct{})
+		go func() {
+			defer close(leaderDone)
+			_, _, err := c.Do(leaderCtx, "key", func() (*milvuspb.SearchResults, error) {
+				close(executing)
+				<-leaderCtx.Done()
+				return nil, leaderCtx.Err()
+			})
+			assert.ErrorIs(t, err, context.Canceled)
+		}()
+		<-executing
+
+		waiterDone := make(chan struct{})
+		go func() {
+			defer close(waiterDone)
+			// the waiter executes by itself since its context is still alive
+			result, coalesced, err := c.Do(context.Background(), "key", func() (*milvuspb.SearchResults, error) {
+				return newSearchCacheTestResult(3), nil
+			})
+			assert.NoError(t, err)
+			assert.False(t, coalesced)
+			assert.Equal(t, []int64{3}, result.GetResults().GetIds().GetIntId().GetData())
+		}()
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+		<-leaderDone
+		<-waiterDone
+	})
+
+	t.Run("waiter canceled", func(t *testing.T) {
+		c := newRequestCoalescer[*milvuspb.SearchResults](metrics.SearchLabel)
+		executing := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		go c.Do(context.Background(), "key", func() (*milvuspb.SearchResults, error) {
+			close(executing)
+			<-release
+			return newSearchCacheTestResult(1), nil
+		})
+		<-executing
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, coalesced, err := c.Do(ctx, "key", func() (*milvuspb.SearchResults, error) {
+			return newSearchCacheTestResult(2), nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, coalesced)
+	})
+}
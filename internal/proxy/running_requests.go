@@ -0,0 +1,129 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// runningRequest is a read request running in the proxy.
+type runningRequest struct {
+	ID             int64     `json:"id,string"`
+	TraceID        string    `json:"trace_id,omitempty"`
+	Type           string    `json:"type"`
+	DbName         string    `json:"db_name"`
+	CollectionName string    `json:"collection_name"`
+	Username       string    `json:"username,omitempty"`
+	StartTime      time.Time `json:"start_time"`
+	DurationMs     int64     `json:"duration_ms"`
+
+	cancel context.CancelCauseFunc
+}
+
+// runningRequests tracks the read requests running in the proxy, so that the administrator could
+// list and cancel them. Canceling a request cancels its context, the cancellation is propagated to
+// the query nodes by the grpc calls, where the queued tasks are dropped and the segcore futures are canceled.
+type runningRequests struct {
+	idAlloc  atomic.Int64
+	requests *typeutil.ConcurrentMap[int64, *runningRequest]
+}
+
+func newRunningRequests() *runningRequests {
+	return &runningRequests{
+		requests: typeutil.NewConcurrentMap[int64, *runningRequest](),
+	}
+}
+
+// Register registers a running request and returns the context to run the request with,
+// done must be called when the request finishes.
+func (r *runningRequests) Register(ctx context.Context, reqType string, dbName string, collectionName string) (context.Context, func()) {
+	if r == nil {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	req := &runningRequest{
+		ID:             r.idAlloc.Inc(),
+		Type:           reqType,
+		DbName:         dbName,
+		CollectionName: collectionName,
+		Username:       GetCurUserFromContextOrDefault(ctx),
+		StartTime:      time.Now(),
+		cancel:         cancel,
+	}
+	if traceID := trace.SpanContextFromContext(ctx).TraceID(); traceID.IsValid() {
+		req.TraceID = traceID.String()
+	}
+	r.requests.Insert(req.ID, req)
+	return ctx, func() {
+		r.requests.Remove(req.ID)
+		cancel(nil)
+	}
+}
+
+// List returns the running requests ordered by the start time.
+func (r *runningRequests) List() []*runningRequest {
+	if r == nil {
+		return nil
+	}
+	now := time.Now()
+	requests := make([]*runningRequest, 0, r.requests.Len())
+	r.requests.Range(func(_ int64, req *runningRequest) bool {
+		copied := *req
+		copied.cancel = nil
+		copied.DurationMs = now.Sub(req.StartTime).Milliseconds()
+		requests = append(requests, &copied)
+		return true
+	})
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].ID < requests[j].ID
+	})
+	return requests
+}
+
+// Cancel cancels the running requests whose id or trace id equals the request id,
+// the trace id is the client request id if the client specifies it.
+func (r *runningRequests) Cancel(requestID string, reason string) []*runningRequest {
+	if r == nil || requestID == "" {
+		return nil
+	}
+	cause := errors.Wrap(context.Canceled, "request canceled by admin")
+	if reason != "" {
+		cause = errors.Wrap(context.Canceled, fmt.Sprintf("request canceled by admin: %s", reason))
+	}
+	id, _ := strconv.ParseInt(requestID, 10, 64)
+	canceled := make([]*runningRequest, 0)
+	for _, req := range r.List() {
+		if req.ID != id && req.TraceID != requestID {
+			continue
+		}
+		if running, ok := r.requests.Get(req.ID); ok {
+			running.cancel(cause)
+			canceled = append(canceled, req)
+		}
+	}
+	return canceled
+}
@@ -0,0 +1,97 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRunningRequests(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var r *runningRequests
+		ctx := context.Background()
+		registered, done := r.Register(ctx, "search", "default", "coll")
+		done()
+		assert.Equal(t, ctx, registered)
+		assert.Empty(t, r.List())
+		assert.Empty(t, r.Cancel("1", ""))
+	})
+
+	t.Run("list and done", func(t *testing.T) {
+		r := newRunningRequests()
+		ctx1, done1 := r.Register(GetContext(context.Background(), "alice:123456"), "search", "db1", "coll1")
+		_, done2 := r.Register(context.Background(), "query", "db2", "coll2")
+
+		requests := r.List()
+		require.Len(t, requests, 2)
+		assert.Equal(t, int64(1), requests[0].ID)
+		assert.Equal(t, "search", requests[0].Type)
+		assert.Equal(t, "db1", requests[0].DbName)
+		assert.Equal(t, "coll1", requests[0].CollectionName)
+		assert.Equal(t, "alice", requests[0].Username)
+		assert.Equal(t, int64(2), requests[1].ID)
+		assert.Equal(t, "query", requests[1].Type)
+
+		done1()
+		assert.Len(t, r.List(), 1)
+		// the finished request is not canceled by the administrator
+		assert.Empty(t, r.Cancel("1", ""))
+		assert.ErrorIs(t, ctx1.Err(), context.Canceled)
+		assert.Equal(t, context.Canceled, context.Cause(ctx1))
+		done2()
+		assert.Empty(t, r.List())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		r := newRunningRequests()
+		traceID := trace.TraceID{1, 2, 3}
+		traceCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  trace.SpanID{1},
+		}))
+		ctx1, done1 := r.Register(traceCtx, "search", "db1", "coll1")
+		defer done1()
+		ctx2, done2 := r.Register(traceCtx, "query", "db1", "coll1")
+		defer done2()
+		ctx3, done3 := r.Register(context.Background(), "query", "db1", "coll1")
+		defer done3()
+		assert.Equal(t, traceID.String(), r.List()[0].TraceID)
+
+		assert.Empty(t, r.Cancel("", ""))
+		assert.Empty(t, r.Cancel("4", ""))
+
+		// cancel by id
+		canceled := r.Cancel("3", "")
+		require.Len(t, canceled, 1)
+		assert.Equal(t, int64(3), canceled[0].ID)
+		assert.ErrorIs(t, ctx3.Err(), context.Canceled)
+		assert.ErrorContains(t, context.Cause(ctx3), "request canceled by admin")
+		assert.NoError(t, ctx1.Err())
+
+		// cancel by trace id
+		canceled = r.Cancel(traceID.String(), "full scan")
+		assert.Len(t, canceled, 2)
+		assert.ErrorIs(t, ctx1.Err(), context.Canceled)
+		assert.ErrorIs(t, ctx2.Err(), context.Canceled)
+		assert.ErrorContains(t, context.Cause(ctx2), "full scan")
+	})
+}
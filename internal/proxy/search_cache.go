@@ -0,0 +1,236 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+)
+
+const searchResultCacheName = "SearchResult"
+
+type searchCacheKey [sha256.Size]byte
+
+type searchCacheEntry struct {
+	key            searchCacheKey
+	dbName         string
+	collectionName string
+	collectionID   UniqueID
+	result         *milvuspb.SearchResults
+	size           int64
+	expireAt       time.Time
+}
+
+// searchResultCache caches the search results of the non-strong consistency searches,
+// the least recently used results are evicted when the memory size exceeds the limit.
+type searchResultCache struct {
+	mu      sync.Mutex
+	entries map[searchCacheKey]*list.Element
+	lru     *list.List
+	size    int64
+}
+
+func newSearchResultCache() *searchResultCache {
+	return &searchResultCache{
+		entries: make(map[searchCacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// isSearchCacheEnabled returns whether the search of the collection could use the search result cache.
+func isSearchCacheEnabled(collectionInfo *collectionInfo) bool {
+	if !paramtable.Get().ProxyCfg.SearchCacheEnabled.GetAsBool() {
+		return false
+	}
+	enabled, err := common.IsSearchCacheKvEnabled(collectionInfo.properties...)
+	return err == nil && enabled
+}
+
+// buildSearchCacheKey builds the cache key of the search, which consists of the collection,
+// the normalized plan and search params, the output fields and the guarantee timestamp bucket.
+// The raw expression and placeholders of the request are replaced by the plan and placeholders
+// of the internal request, and the fields varying between the identical requests are cleared.
+func buildSearchCacheKey(req *internalpb.SearchRequest, request *milvuspb.SearchRequest) (searchCacheKey, error) {
+	internalReq, userReq := normalizeSearchRequests(req, request)
+	marshaler := proto.MarshalOptions{Deterministic: true}
+	internalBytes, err := marshaler.Marshal(internalReq)
+	if err != nil {
+		return searchCacheKey{}, err
+	}
+	userBytes, err := marshaler.Marshal(userReq)
+	if err != nil {
+		return searchCacheKey{}, err
+	}
+
+	bucketMs := paramtable.Get().ProxyCfg.SearchCacheTimestampBucket.GetAsInt64()
+	physicalMs := tsoutil.PhysicalTime(req.GetGuaranteeTimestamp()).UnixMilli()
+	bucket := physicalMs
+	if bucketMs > 0 {
+		bucket = physicalMs / bucketMs
+	}
+
+	h := sha256.New()
+	h.Write(binary.LittleEndian.AppendUint64(nil, uint64(bucket)))
+	h.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(internalBytes))))
+	h.Write(internalBytes)
+	h.Write(userBytes)
+	var key searchCacheKey
+	copy(key[:], h.Sum(nil))
+	return key, nil
+}
+
+// normalizeSearchRequests returns the copies of the requests with the fields varying between the identical searches cleared,
+// the raw expression and placeholders of the user request are left to the plan and placeholders of the internal request.
+func normalizeSearchRequests(req *internalpb.SearchRequest, request *milvuspb.SearchRequest) (*internalpb.SearchRequest, *milvuspb.SearchRequest) {
+	internalReq := proto.Clone(req).(*internalpb.SearchRequest)
+	internalReq.Base = nil
+	internalReq.ReqID = 0
+	internalReq.MvccTimestamp = 0
+	internalReq.GuaranteeTimestamp = 0
+	internalReq.TimeoutTimestamp = 0
+	internalReq.Username = ""
+	internalReq.CollectionTtlTimestamps = 0
+	internalReq.EntityTtlPhysicalTime = 0
+
+	userReq := proto.Clone(request).(*milvuspb.SearchRequest)
+	userReq.Base = nil
+	userReq.DbName = ""
+	userReq.CollectionName = ""
+	userReq.Dsl = ""
+	userReq.SearchInput = nil
+	userReq.ExprTemplateValues = nil
+	userReq.TravelTimestamp = 0
+	userReq.GuaranteeTimestamp = 0
+	userReq.ConsistencyLevel = commonpb.ConsistencyLevel_Strong
+	userReq.UseDefaultConsistency = false
+	return internalReq, userReq
+}
+
+// Get returns a copy of the cached result of the key.
+func (c *searchResultCache) Get(key searchCacheKey) (*milvuspb.SearchResults, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nodeID := paramtable.GetStringNodeID()
+	elem, ok := c.entries[key]
+	if ok && time.Now().After(elem.Value.(*searchCacheEntry).expireAt) {
+		c.removeElement(elem)
+		c.updateMetrics()
+		ok = false
+	}
+	if !ok {
+		metrics.ProxyCacheStatsCounter.WithLabelValues(nodeID, searchResultCacheName, metrics.CacheMissLabel).Inc()
+		return nil, false
+	}
+	metrics.ProxyCacheStatsCounter.WithLabelValues(nodeID, searchResultCacheName, metrics.CacheHitLabel).Inc()
+	c.lru.MoveToFront(elem)
+	return proto.Clone(elem.Value.(*searchCacheEntry).result).(*milvuspb.SearchResults), true
+}
+
+// Put caches a copy of the successful search result of the key,
+// the result larger than the memory limit is not cached.
+func (c *searchResultCache) Put(key searchCacheKey, dbName string, collectionName string, collectionID UniqueID, result *milvuspb.SearchResults) {
+	if c == nil || !merr.Ok(result.GetStatus()) {
+		return
+	}
+	params := paramtable.Get().ProxyCfg
+	maxSize := params.SearchCacheMaxMemorySize.GetAsInt64() * 1024 * 1024
+	entry := &searchCacheEntry{
+		key:            key,
+		dbName:         dbName,
+		collectionName: collectionName,
+		collectionID:   collectionID,
+		result:         proto.Clone(result).(*milvuspb.SearchResults),
+		expireAt:       time.Now().Add(params.SearchCacheTTL.GetAsDuration(time.Second)),
+	}
+	entry.size = int64(proto.Size(entry.result))
+	if entry.size > maxSize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.removeElement(elem)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += entry.size
+	for c.size > maxSize {
+		c.removeElement(c.lru.Back())
+	}
+	c.updateMetrics()
+}
+
+// InvalidateCollection removes the cached results of the collection matched by the id or the name.
+func (c *searchResultCache) InvalidateCollection(dbName string, collectionName string, collectionID UniqueID) {
+	c.removeIf(func(entry *searchCacheEntry) bool {
+		if collectionID != 0 && entry.collectionID == collectionID {
+			return true
+		}
+		return collectionName != "" && entry.dbName == dbName && entry.collectionName == collectionName
+	})
+}
+
+// InvalidateDatabase removes the cached results of all the collections in the database.
+func (c *searchResultCache) InvalidateDatabase(dbName string) {
+	c.removeIf(func(entry *searchCacheEntry) bool {
+		return entry.dbName == dbName
+	})
+}
+
+func (c *searchResultCache) removeIf(filter func(entry *searchCacheEntry) bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.lru.Front(); e != nil; {
+		next := e.Next()
+		if filter(e.Value.(*searchCacheEntry)) {
+			c.removeElement(e)
+		}
+		e = next
+	}
+	c.updateMetrics()
+}
+
+func (c *searchResultCache) removeElement(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*searchCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+func (c *searchResultCache) updateMetrics() {
+	metrics.ProxySearchResultCacheSize.WithLabelValues(paramtable.GetStringNodeID()).Set(float64(c.size))
+}
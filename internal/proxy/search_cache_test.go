@@ -0,0 +1,264 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+)
+
+func newSearchCacheTestRequests(guaranteeTime time.Time) (*internalpb.SearchRequest, *milvuspb.SearchRequest) {
+	return &internalpb.SearchRequest{
+		Base:               &commonpb.MsgBase{MsgID: 1, Timestamp: tsoutil.ComposeTSByTime(guaranteeTime)},
+		ReqID:              1,
+		CollectionID:       100,
+		SerializedExprPlan: []byte("plan"),
+		PlaceholderGroup:   []byte("placeholder"),
+		Nq:                 1,
+		Topk:               10,
+		GuaranteeTimestamp: tsoutil.ComposeTSByTime(guaranteeTime),
+		Username:           "alice",
+	}, &milvuspb.SearchRequest{
+		DbName:         "default",
+		CollectionName: "coll",
+		Dsl:            "a > 1",
+		OutputFields:   []string{"a"},
+		SearchParams:   []*commonpb.KeyValuePair{{Key: "params", Value: `{"nprobe": 10}`}},
+	}
+}
+
+func newSearchCacheTestResult(ids ...int64) *milvuspb.SearchResults {
+	return &milvuspb.SearchResults{
+		Status: merr.Success(),
+		Results: &schemapb.SearchResultData{
+			NumQueries: 1,
+			TopK:       int64(len(ids)),
+			Ids:        &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: ids}}},
+		},
+		CollectionName: "coll",
+	}
+}
+
+func newSearchCacheTestIDs(n int) []int64 {
+	ids := make([]int64, n)
+	for i := range ids {
+		ids[i] = 1<<62 + int64(i)
+	}
+	return ids
+}
+
+func TestBuildSearchCacheKey(t *testing.T) {
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.SearchCacheTimestampBucket.Key, "5000")
+	defer params.Reset(params.ProxyCfg.SearchCacheTimestampBucket.Key)
+
+	base := time.UnixMilli(1_700_000_000_000)
+	req, request := newSearchCacheTestRequests(base)
+	key, err := buildSearchCacheKey(req, request)
+	require.NoError(t, err)
+
+	// the fields varying between the identical requests are ignored
+	req2, request2 := newSearchCacheTestRequests(base.Add(time.Second))
+	req2.ReqID = 2
+	req2.Username = "bob"
+	request2.Dsl = "a>1"
+	request2.ConsistencyLevel = commonpb.ConsistencyLevel_Bounded
+	key2, err := buildSearchCacheKey(req2, request2)
+	require.NoError(t, err)
+	assert.Equal(t, key, key2)
+
+	// different guarantee timestamp bucket
+	req3, request3 := newSearchCacheTestRequests(base.Add(5 * time.Second))
+	key3, err := buildSearchCacheKey(req3, request3)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, key3)
+
+	// different plan, placeholders, output fields and search params
+	for _, mutate := range []func(*internalpb.SearchRequest, *milvuspb.SearchRequest){
+		func(req *internalpb.SearchRequest, _ *milvuspb.SearchRequest) {
+			req.SerializedExprPlan = []byte("plan2")
+		},
+		func(req *internalpb.SearchRequest, _ *milvuspb.SearchRequest) {
+			req.PlaceholderGroup = []byte("placeholder2")
+		},
+		func(req *internalpb.SearchRequest, _ *milvuspb.SearchRequest) { req.CollectionID = 101 },
+		func(_ *internalpb.SearchRequest, request *milvuspb.SearchRequest) {
+			request.OutputFields = []string{"b"}
+		},
+		func(_ *internalpb.SearchRequest, request *milvuspb.SearchRequest) {
+			request.SearchParams = []*commonpb.KeyValuePair{{Key: "params", Value: `{"nprobe": 20}`}}
+		},
+	} {
+		req, request := newSearchCacheTestRequests(base)
+		mutate(req, request)
+		other, err := buildSearchCacheKey(req, request)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, other)
+	}
+}
+
+func TestIsSearchCacheEnabled(t *testing.T) {
+	info := &collectionInfo{}
+	assert.False(t, isSearchCacheEnabled(info))
+
+	info.properties = []*commonpb.KeyValuePair{{Key: common.CollectionSearchCacheEnabledKey, Value: "true"}}
+	assert.True(t, isSearchCacheEnabled(info))
+
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.SearchCacheEnabled.Key, "false")
+	defer params.Reset(params.ProxyCfg.SearchCacheEnabled.Key)
+	assert.False(t, isSearchCacheEnabled(info))
+}
+
+func TestSearchTaskIsSearchCacheable(t *testing.T) {
+	newTask := func() *searchTask {
+		return &searchTask{
+			SearchRequest: &internalpb.SearchRequest{ConsistencyLevel: commonpb.ConsistencyLevel_Bounded},
+			request:       &milvuspb.SearchRequest{},
+		}
+	}
+	assert.True(t, newTask().isSearchCacheable())
+
+	task := newTask()
+	task.ConsistencyLevel = commonpb.ConsistencyLevel_Strong
+	assert.False(t, task.isSearchCacheable())
+
+	task = newTask()
+	task.isIterator = true
+	assert.False(t, task.isSearchCacheable())
+
+	task = newTask()
+	task.IsAdvanced = true
+	assert.False(t, task.isSearchCacheable())
+
+	task = newTask()
+	task.request.Highlighter = &commonpb.Highlighter{}
+	assert.False(t, task.isSearchCacheable())
+}
+
+func TestSearchResultCache(t *testing.T) {
+	t.Run("nil cache", func(t *testing.T) {
+		var cache *searchResultCache
+		_, ok := cache.Get(searchCacheKey{})
+		assert.False(t, ok)
+		cache.Put(searchCacheKey{}, "default", "coll", 100, newSearchCacheTestResult(1))
+		cache.InvalidateCollection("default", "coll", 100)
+		cache.InvalidateDatabase("default")
+	})
+
+	t.Run("get and put", func(t *testing.T) {
+		cache := newSearchResultCache()
+		key := searchCacheKey{1}
+		_, ok := cache.Get(key)
+		assert.False(t, ok)
+
+		result := newSearchCacheTestResult(1, 2)
+		cache.Put(key, "default", "coll", 100, result)
+		cached, ok := cache.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, []int64{1, 2}, cached.GetResults().GetIds().GetIntId().GetData())
+
+		// the cached result is not affected by the callers
+		result.Results.Ids.GetIntId().Data[0] = 3
+		cached.Results.Ids.GetIntId().Data[1] = 4
+		cached, ok = cache.Get(key)
+		assert.True(t, ok)
+		assert.Equal(t, []int64{1, 2}, cached.GetResults().GetIds().GetIntId().GetData())
+
+		// the failed result is not cached
+		cache.Put(searchCacheKey{2}, "default", "coll", 100, &milvuspb.SearchResults{Status: merr.Status(merr.ErrServiceInternal)})
+		_, ok = cache.Get(searchCacheKey{2})
+		assert.False(t, ok)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		params := paramtable.Get()
+		params.Save(params.ProxyCfg.SearchCacheTTL.Key, "0")
+		defer params.Reset(params.ProxyCfg.SearchCacheTTL.Key)
+
+		cache := newSearchResultCache()
+		cache.Put(searchCacheKey{1}, "default", "coll", 100, newSearchCacheTestResult(1))
+		time.Sleep(time.Millisecond)
+		_, ok := cache.Get(searchCacheKey{1})
+		assert.False(t, ok)
+		assert.Equal(t, 0, cache.lru.Len())
+		assert.Equal(t, int64(0), cache.size)
+	})
+
+	t.Run("evict by memory size", func(t *testing.T) {
+		params := paramtable.Get()
+		params.Save(params.ProxyCfg.SearchCacheMaxMemorySize.Key, "1")
+		defer params.Reset(params.ProxyCfg.SearchCacheMaxMemorySize.Key)
+
+		// about 400KB for each result
+		ids := newSearchCacheTestIDs(45 * 1024)
+		cache := newSearchResultCache()
+		cache.Put(searchCacheKey{1}, "default", "coll", 100, newSearchCacheTestResult(ids...))
+		cache.Put(searchCacheKey{2}, "default", "coll", 100, newSearchCacheTestResult(ids...))
+		// key 1 is recently used, so key 2 is evicted
+		_, ok := cache.Get(searchCacheKey{1})
+		assert.True(t, ok)
+		cache.Put(searchCacheKey{3}, "default", "coll", 100, newSearchCacheTestResult(ids...))
+		_, ok = cache.Get(searchCacheKey{2})
+		assert.False(t, ok)
+		_, ok = cache.Get(searchCacheKey{1})
+		assert.True(t, ok)
+		_, ok = cache.Get(searchCacheKey{3})
+		assert.True(t, ok)
+		assert.LessOrEqual(t, cache.size, int64(1024*1024))
+
+		// the result larger than the memory limit is not cached
+		cache.Put(searchCacheKey{4}, "default", "coll", 100, newSearchCacheTestResult(newSearchCacheTestIDs(1024*1024)...))
+		_, ok = cache.Get(searchCacheKey{4})
+		assert.False(t, ok)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		cache := newSearchResultCache()
+		cache.Put(searchCacheKey{1}, "db1", "coll1", 100, newSearchCacheTestResult(1))
+		cache.Put(searchCacheKey{2}, "db1", "coll2", 101, newSearchCacheTestResult(1))
+		cache.Put(searchCacheKey{3}, "db2", "coll3", 102, newSearchCacheTestResult(1))
+		cache.Put(searchCacheKey{4}, "db2", "coll4", 103, newSearchCacheTestResult(1))
+
+		cache.InvalidateCollection("db1", "", 100)
+		_, ok := cache.Get(searchCacheKey{1})
+		assert.False(t, ok)
+		_, ok = cache.Get(searchCacheKey{2})
+		assert.True(t, ok)
+
+		cache.InvalidateCollection("db1", "coll2", 0)
+		_, ok = cache.Get(searchCacheKey{2})
+		assert.False(t, ok)
+
+		cache.InvalidateDatabase("db2")
+		assert.Equal(t, 0, cache.lru.Len())
+		assert.Empty(t, cache.entries)
+		assert.Equal(t, int64(0), cache.size)
+	})
+}
@@ -67,22 +67,69 @@ type LBPolicy interface {
 }
 
 const (
-	RoundRobin = "round_robin"
-	LookAside  = "look_aside"
+	RoundRobin        = "round_robin"
+	LookAside         = "look_aside"
+	ZoneAware         = "zone_aware"
+	PowerOfTwoChoices = "power_of_two_choices"
 )
 
+// IsValidReplicaSelectionPolicy returns whether the replica selection policy is supported.
+func IsValidReplicaSelectionPolicy(policy string) bool {
+	switch policy {
+	case RoundRobin, LookAside, ZoneAware, PowerOfTwoChoices:
+		return true
+	default:
+		return false
+	}
+}
+
+// CollectionPolicyResolver returns the replica selection policy of the collection, empty means the global policy.
+type CollectionPolicyResolver func(ctx context.Context, dbName string, collectionName string, collectionID int64) string
+
+type lbPolicyOptions struct {
+	localZone      string
+	getNodeZones   NodeZonesGetter
+	policyResolver CollectionPolicyResolver
+}
+
+type LBPolicyOption func(*lbPolicyOptions)
+
+// WithZoneResolver sets the zone of the proxy and the getter of the zones of the query nodes for the zone aware policy.
+func WithZoneResolver(localZone string, getNodeZones NodeZonesGetter) LBPolicyOption {
+	return func(opts *lbPolicyOptions) {
+		opts.localZone = localZone
+		opts.getNodeZones = getNodeZones
+	}
+}
+
+// WithCollectionPolicyResolver sets the resolver of the replica selection policy of each collection.
+func WithCollectionPolicyResolver(resolver CollectionPolicyResolver) LBPolicyOption {
+	return func(opts *lbPolicyOptions) {
+		opts.policyResolver = resolver
+	}
+}
+
 type LBPolicyImpl struct {
 	getBalancer    func() LBBalancer
+	policyResolver CollectionPolicyResolver
 	clientMgr      ShardClientMgr
 	balancerMap    map[string]LBBalancer
 	retryOnReplica int
 	blacklist      *ChannelBlacklist
 }
 
-func NewLBPolicyImpl(clientMgr ShardClientMgr) *LBPolicyImpl {
+func NewLBPolicyImpl(clientMgr ShardClientMgr, opts ...LBPolicyOption) *LBPolicyImpl {
+	options := &lbPolicyOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	lookAside := NewLookAsideBalancer(clientMgr)
 	balancerMap := make(map[string]LBBalancer)
-	balancerMap[LookAside] = NewLookAsideBalancer(clientMgr)
+	balancerMap[LookAside] = lookAside
 	balancerMap[RoundRobin] = NewRoundRobinBalancer()
+	balancerMap[ZoneAware] = NewZoneAwareBalancer(lookAside, options.localZone, options.getNodeZones)
+	balancerMap[PowerOfTwoChoices] = NewPowerOfTwoChoicesBalancer(lookAside)
 
 	balancePolicy := params.Params.ProxyCfg.ReplicaSelectionPolicy.GetValue()
 	getBalancer := func() LBBalancer {
@@ -96,6 +143,7 @@ func NewLBPolicyImpl(clientMgr ShardClientMgr) *LBPolicyImpl {
 
 	return &LBPolicyImpl{
 		getBalancer:    getBalancer,
+		policyResolver: options.policyResolver,
 		clientMgr:      clientMgr,
 		balancerMap:    balancerMap,
 		retryOnReplica: retryOnReplica,
@@ -103,6 +151,18 @@ func NewLBPolicyImpl(clientMgr ShardClientMgr) *LBPolicyImpl {
 	}
 }
 
+// getWorkloadBalancer returns the balancer of the replica selection policy of the collection,
+// or the balancer of the global policy if the collection doesn't specify it.
+func (lb *LBPolicyImpl) getWorkloadBalancer(ctx context.Context, workload ChannelWorkload) LBBalancer {
+	if lb.policyResolver != nil {
+		policy := lb.policyResolver(ctx, workload.Db, workload.CollectionName, workload.CollectionID)
+		if balancer, ok := lb.balancerMap[policy]; ok {
+			return balancer
+		}
+	}
+	return lb.getBalancer()
+}
+
 func (lb *LBPolicyImpl) Start(ctx context.Context) {
 	for _, lb := range lb.balancerMap {
 		lb.Start(ctx)
@@ -294,7 +354,7 @@ func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWo
 		}
 		excludeNodes := typeutil.NewUniqueSet(blacklist...)
 		excludeNodes.Insert(requestExcludedNodes.Collect()...)
-		balancer := lb.getBalancer()
+		balancer := lb.getWorkloadBalancer(ctx, workload)
 		targetNode, selectedByBalancer, err := lb.selectNode(ctx, balancer, workload, &excludeNodes)
 		if err != nil {
 			log.Warn(ctx, "failed to select node for shard",
@@ -437,7 +497,12 @@ func (lb *LBPolicyImpl) ExecuteOneChannel(ctx context.Context, workload Collecti
 }
 
 func (lb *LBPolicyImpl) UpdateCostMetrics(node int64, cost *internalpb.CostAggregation) {
-	lb.getBalancer().UpdateCostMetrics(node, cost)
+	balancer := lb.getBalancer()
+	balancer.UpdateCostMetrics(node, cost)
+	// the look aside balancer tracks the health of nodes for the other policies and collections
+	if lookAside := lb.balancerMap[LookAside]; lookAside != balancer {
+		lookAside.UpdateCostMetrics(node, cost)
+	}
 }
 
 func (lb *LBPolicyImpl) Close() {
@@ -832,10 +832,39 @@ func (s *LBPolicySuite) TestNewLBPolicy() {
 	s.Equal(reflect.TypeOf(policy.getBalancer()).String(), "*shardclient.RoundRobinBalancer")
 	policy.Close()
 
+	params.Save(params.ProxyCfg.ReplicaSelectionPolicy.Key, "zone_aware")
+	policy = NewLBPolicyImpl(mgr)
+	s.Equal(reflect.TypeOf(policy.getBalancer()).String(), "*shardclient.ZoneAwareBalancer")
+	policy.Close()
+
+	params.Save(params.ProxyCfg.ReplicaSelectionPolicy.Key, "power_of_two_choices")
+	policy = NewLBPolicyImpl(mgr)
+	s.Equal(reflect.TypeOf(policy.getBalancer()).String(), "*shardclient.PowerOfTwoChoicesBalancer")
+	policy.Close()
+
 	params.Save(params.ProxyCfg.ReplicaSelectionPolicy.Key, "look_aside")
 	policy = NewLBPolicyImpl(mgr)
 	s.Equal(reflect.TypeOf(policy.getBalancer()).String(), "*shardclient.LookAsideBalancer")
 	policy.Close()
+
+	// the replica selection policy of the collection overrides the global one
+	policy = NewLBPolicyImpl(mgr, WithCollectionPolicyResolver(func(ctx context.Context, dbName string, collectionName string, collectionID int64) string {
+		if collectionID == 1 {
+			return PowerOfTwoChoices
+		}
+		if collectionID == 2 {
+			return "invalid"
+		}
+		return ""
+	}))
+	ctx := context.Background()
+	s.Equal(reflect.TypeOf(policy.getWorkloadBalancer(ctx, ChannelWorkload{CollectionID: 1})).String(), "*shardclient.PowerOfTwoChoicesBalancer")
+	s.Equal(reflect.TypeOf(policy.getWorkloadBalancer(ctx, ChannelWorkload{CollectionID: 2})).String(), "*shardclient.LookAsideBalancer")
+	s.Equal(reflect.TypeOf(policy.getWorkloadBalancer(ctx, ChannelWorkload{CollectionID: 3})).String(), "*shardclient.LookAsideBalancer")
+	policy.Close()
+
+	s.True(IsValidReplicaSelectionPolicy(ZoneAware))
+	s.False(IsValidReplicaSelectionPolicy("invalid"))
 }
 
 func (s *LBPolicySuite) TestGetShard() {
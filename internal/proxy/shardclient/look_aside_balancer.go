@@ -318,10 +318,15 @@ func (b *LookAsideBalancer) trySetQueryNodeUnReachable(node int64, err error) {
 		return
 	}
 
+	// mark the node unavailable even if it has not been selected, the other balancers depend on it
+	metrics, _ := b.metricsMap.GetOrInsert(node, &CostMetrics{})
+	metrics.unavailable.Store(true)
+}
+
+// isUnavailable returns whether the node is marked unavailable by the health check.
+func (b *LookAsideBalancer) isUnavailable(node int64) bool {
 	metrics, ok := b.metricsMap.Get(node)
-	if ok {
-		metrics.unavailable.Store(true)
-	}
+	return ok && metrics.unavailable.Load()
 }
 
 func (b *LookAsideBalancer) trySetQueryNodeReachable(node int64) {
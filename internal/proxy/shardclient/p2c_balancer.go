@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"math/rand"
+
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// outstandingRequests counts the requests assigned to each query node which have not finished yet.
+type outstandingRequests struct {
+	counters *typeutil.ConcurrentMap[int64, *atomic.Int64]
+}
+
+func newOutstandingRequests() *outstandingRequests {
+	return &outstandingRequests{
+		counters: typeutil.NewConcurrentMap[int64, *atomic.Int64](),
+	}
+}
+
+func (o *outstandingRequests) inc(node int64) {
+	counter, _ := o.counters.GetOrInsert(node, atomic.NewInt64(0))
+	counter.Inc()
+}
+
+func (o *outstandingRequests) dec(node int64) {
+	if counter, ok := o.counters.Get(node); ok {
+		counter.Dec()
+	}
+}
+
+func (o *outstandingRequests) get(node int64) int64 {
+	if counter, ok := o.counters.Get(node); ok {
+		return counter.Load()
+	}
+	return 0
+}
+
+// PowerOfTwoChoicesBalancer picks two random available nodes and selects the one with fewer outstanding requests,
+// the health of the nodes is tracked by the look aside balancer.
+type PowerOfTwoChoicesBalancer struct {
+	lookAside   *LookAsideBalancer
+	outstanding *outstandingRequests
+}
+
+func NewPowerOfTwoChoicesBalancer(lookAside *LookAsideBalancer) *PowerOfTwoChoicesBalancer {
+	return &PowerOfTwoChoicesBalancer{
+		lookAside:   lookAside,
+		outstanding: newOutstandingRequests(),
+	}
+}
+
+func (b *PowerOfTwoChoicesBalancer) RegisterNodeInfo(nodeInfos []NodeInfo) {
+	b.lookAside.RegisterNodeInfo(nodeInfos)
+}
+
+func (b *PowerOfTwoChoicesBalancer) SelectNode(ctx context.Context, availableNodes []int64, nq int64) (int64, error) {
+	nodes := make([]int64, 0, len(availableNodes))
+	for _, node := range availableNodes {
+		if !b.lookAside.isUnavailable(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return -1, merr.WrapErrServiceUnavailable("all available nodes are unreachable")
+	}
+
+	targetNode := nodes[0]
+	if len(nodes) > 1 {
+		i := rand.Intn(len(nodes))
+		j := rand.Intn(len(nodes) - 1)
+		if j >= i {
+			j++
+		}
+		targetNode = nodes[i]
+		if b.outstanding.get(nodes[j]) < b.outstanding.get(targetNode) {
+			targetNode = nodes[j]
+		}
+	}
+	b.outstanding.inc(targetNode)
+	return targetNode, nil
+}
+
+func (b *PowerOfTwoChoicesBalancer) CancelWorkload(node int64, nq int64) {
+	b.outstanding.dec(node)
+}
+
+// UpdateCostMetrics is a no-op, the cost metrics are recorded by the look aside balancer.
+func (b *PowerOfTwoChoicesBalancer) UpdateCostMetrics(node int64, cost *internalpb.CostAggregation) {}
+
+// Start is a no-op, the health check is done by the look aside balancer.
+func (b *PowerOfTwoChoicesBalancer) Start(ctx context.Context) {}
+
+func (b *PowerOfTwoChoicesBalancer) Close() {}
@@ -0,0 +1,104 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+type PowerOfTwoChoicesBalancerSuite struct {
+	suite.Suite
+
+	lookAside *LookAsideBalancer
+	balancer  *PowerOfTwoChoicesBalancer
+}
+
+func (s *PowerOfTwoChoicesBalancerSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *PowerOfTwoChoicesBalancerSuite) SetupTest() {
+	s.lookAside = NewLookAsideBalancer(NewMockShardClientManager(s.T()))
+	s.balancer = NewPowerOfTwoChoicesBalancer(s.lookAside)
+	s.balancer.Start(context.Background())
+}
+
+func (s *PowerOfTwoChoicesBalancerSuite) TearDownTest() {
+	s.balancer.Close()
+}
+
+func (s *PowerOfTwoChoicesBalancerSuite) TestSelectNode() {
+	ctx := context.Background()
+	node, err := s.balancer.SelectNode(ctx, []int64{1}, 1)
+	s.NoError(err)
+	s.Equal(int64(1), node)
+	s.Equal(int64(1), s.balancer.outstanding.get(1))
+
+	// node 2 has no outstanding request, so it is always selected among the two nodes
+	for i := 0; i < 10; i++ {
+		node, err = s.balancer.SelectNode(ctx, []int64{1, 2}, 1)
+		s.NoError(err)
+		s.Equal(int64(2), node)
+		s.balancer.CancelWorkload(node, 1)
+	}
+
+	s.balancer.CancelWorkload(1, 1)
+	s.Equal(int64(0), s.balancer.outstanding.get(1))
+}
+
+func (s *PowerOfTwoChoicesBalancerSuite) TestBalance() {
+	ctx := context.Background()
+	nodes := []int64{1, 2, 3, 4}
+	for i := 0; i < 100; i++ {
+		_, err := s.balancer.SelectNode(ctx, nodes, 1)
+		s.NoError(err)
+	}
+	for _, node := range nodes {
+		s.InDelta(25, s.balancer.outstanding.get(node), 10)
+	}
+}
+
+func (s *PowerOfTwoChoicesBalancerSuite) TestUnavailableNodes() {
+	ctx := context.Background()
+	setNodeUnavailable(s.lookAside, 1)
+	for i := 0; i < 10; i++ {
+		node, err := s.balancer.SelectNode(ctx, []int64{1, 2}, 1)
+		s.NoError(err)
+		s.Equal(int64(2), node)
+	}
+
+	setNodeUnavailable(s.lookAside, 2)
+	_, err := s.balancer.SelectNode(ctx, []int64{1, 2}, 1)
+	s.ErrorIs(err, merr.ErrServiceUnavailable)
+	_, err = s.balancer.SelectNode(ctx, []int64{}, 1)
+	s.ErrorIs(err, merr.ErrServiceUnavailable)
+}
+
+func setNodeUnavailable(b *LookAsideBalancer, node int64) {
+	metrics, _ := b.metricsMap.GetOrInsert(node, &CostMetrics{})
+	metrics.unavailable.Store(true)
+}
+
+func TestPowerOfTwoChoicesBalancerSuite(t *testing.T) {
+	suite.Run(t, new(PowerOfTwoChoicesBalancerSuite))
+}
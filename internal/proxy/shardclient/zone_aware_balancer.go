@@ -0,0 +1,161 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+// NodeZonesGetter returns the zone of each query node.
+type NodeZonesGetter func(ctx context.Context) (map[int64]string, error)
+
+// ZoneAwareBalancer prefers the query nodes in the same zone as the proxy, and falls back to the
+// query nodes in the other zones when the nodes in the same zone are unavailable or overloaded.
+// The node is selected by the look aside balancer among the preferred nodes.
+type ZoneAwareBalancer struct {
+	lookAside    *LookAsideBalancer
+	localZone    string
+	getNodeZones NodeZonesGetter
+	nodeZones    atomic.Pointer[map[int64]string]
+	outstanding  *outstandingRequests
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func NewZoneAwareBalancer(lookAside *LookAsideBalancer, localZone string, getNodeZones NodeZonesGetter) *ZoneAwareBalancer {
+	return &ZoneAwareBalancer{
+		lookAside:    lookAside,
+		localZone:    localZone,
+		getNodeZones: getNodeZones,
+		outstanding:  newOutstandingRequests(),
+		closeCh:      make(chan struct{}),
+	}
+}
+
+func (b *ZoneAwareBalancer) Start(ctx context.Context) {
+	if b.localZone == "" || b.getNodeZones == nil {
+		mlog.Info(ctx, "zone of proxy is unknown, zone aware balancer falls back to look aside")
+		return
+	}
+	b.refreshNodeZones(ctx)
+	b.wg.Add(1)
+	go b.refreshNodeZonesLoop(ctx)
+}
+
+func (b *ZoneAwareBalancer) Close() {
+	b.closeOnce.Do(func() {
+		close(b.closeCh)
+		b.wg.Wait()
+	})
+}
+
+func (b *ZoneAwareBalancer) RegisterNodeInfo(nodeInfos []NodeInfo) {
+	b.lookAside.RegisterNodeInfo(nodeInfos)
+}
+
+func (b *ZoneAwareBalancer) SelectNode(ctx context.Context, availableNodes []int64, nq int64) (int64, error) {
+	targetNode, err := b.lookAside.SelectNode(ctx, b.preferredNodes(availableNodes), nq)
+	if err != nil {
+		return targetNode, err
+	}
+	b.outstanding.inc(targetNode)
+	return targetNode, nil
+}
+
+// preferredNodes returns the available nodes in the same zone, or the nodes in the other zones if
+// none of them is available or they are overloaded compared to the nodes in the other zones.
+func (b *ZoneAwareBalancer) preferredNodes(availableNodes []int64) []int64 {
+	nodeZones := b.nodeZones.Load()
+	if nodeZones == nil {
+		return availableNodes
+	}
+
+	localNodes := make([]int64, 0, len(availableNodes))
+	remoteNodes := make([]int64, 0, len(availableNodes))
+	minLocal, minRemote := int64(math.MaxInt64), int64(math.MaxInt64)
+	for _, node := range availableNodes {
+		if b.lookAside.isUnavailable(node) {
+			continue
+		}
+		outstanding := b.outstanding.get(node)
+		if (*nodeZones)[node] == b.localZone {
+			localNodes = append(localNodes, node)
+			minLocal = min(minLocal, outstanding)
+		} else {
+			remoteNodes = append(remoteNodes, node)
+			minRemote = min(minRemote, outstanding)
+		}
+	}
+
+	if len(localNodes) == 0 {
+		metrics.ProxyZoneAwareReplicaSelectionCount.WithLabelValues(metrics.ZoneAwareRemoteLabel).Inc()
+		return availableNodes
+	}
+	overloadFactor := paramtable.Get().ProxyCfg.ZoneAwareOverloadFactor.GetAsFloat()
+	if len(remoteNodes) > 0 && float64(minLocal) > overloadFactor*float64(minRemote+1) {
+		metrics.ProxyZoneAwareReplicaSelectionCount.WithLabelValues(metrics.ZoneAwareRemoteLabel).Inc()
+		return remoteNodes
+	}
+	metrics.ProxyZoneAwareReplicaSelectionCount.WithLabelValues(metrics.ZoneAwareLocalLabel).Inc()
+	return localNodes
+}
+
+func (b *ZoneAwareBalancer) CancelWorkload(node int64, nq int64) {
+	b.lookAside.CancelWorkload(node, nq)
+	b.outstanding.dec(node)
+}
+
+// UpdateCostMetrics is a no-op, the cost metrics are recorded by the look aside balancer.
+func (b *ZoneAwareBalancer) UpdateCostMetrics(node int64, cost *internalpb.CostAggregation) {}
+
+func (b *ZoneAwareBalancer) refreshNodeZones(ctx context.Context) {
+	nodeZones, err := b.getNodeZones(ctx)
+	if err != nil {
+		mlog.Warn(ctx, "failed to refresh zones of query nodes", mlog.Err(err))
+		return
+	}
+	b.nodeZones.Store(&nodeZones)
+}
+
+func (b *ZoneAwareBalancer) refreshNodeZonesLoop(ctx context.Context) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(paramtable.Get().ProxyCfg.ZoneAwareRefreshInterval.GetAsDuration(time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.closeCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.refreshNodeZones(ctx)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shardclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+type ZoneAwareBalancerSuite struct {
+	suite.Suite
+
+	lookAside *LookAsideBalancer
+	balancer  *ZoneAwareBalancer
+}
+
+func (s *ZoneAwareBalancerSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *ZoneAwareBalancerSuite) SetupTest() {
+	s.lookAside = NewLookAsideBalancer(NewMockShardClientManager(s.T()))
+	s.balancer = NewZoneAwareBalancer(s.lookAside, "zone1", func(ctx context.Context) (map[int64]string, error) {
+		return map[int64]string{1: "zone1", 2: "zone1", 3: "zone2", 4: "zone2"}, nil
+	})
+	s.balancer.Start(context.Background())
+}
+
+func (s *ZoneAwareBalancerSuite) TearDownTest() {
+	s.balancer.Close()
+}
+
+func (s *ZoneAwareBalancerSuite) TestPreferLocalZone() {
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		node, err := s.balancer.SelectNode(ctx, []int64{1, 2, 3, 4}, 1)
+		s.NoError(err)
+		s.Contains([]int64{1, 2}, node)
+		s.balancer.CancelWorkload(node, 1)
+	}
+}
+
+func (s *ZoneAwareBalancerSuite) TestFallbackOnUnavailable() {
+	ctx := context.Background()
+	setNodeUnavailable(s.lookAside, 1)
+	setNodeUnavailable(s.lookAside, 2)
+	for i := 0; i < 10; i++ {
+		node, err := s.balancer.SelectNode(ctx, []int64{1, 2, 3, 4}, 1)
+		s.NoError(err)
+		s.Contains([]int64{3, 4}, node)
+		s.balancer.CancelWorkload(node, 1)
+	}
+}
+
+func (s *ZoneAwareBalancerSuite) TestFallbackOnOverload() {
+	ctx := context.Background()
+	// the local nodes are overloaded if they have more than 2 * (0 + 1) outstanding requests
+	for i := 0; i < 3; i++ {
+		s.balancer.outstanding.inc(1)
+		s.balancer.outstanding.inc(2)
+	}
+	node, err := s.balancer.SelectNode(ctx, []int64{1, 2, 3, 4}, 1)
+	s.NoError(err)
+	s.Contains([]int64{3, 4}, node)
+	s.balancer.CancelWorkload(node, 1)
+
+	// the local nodes are preferred again once the workload drops
+	s.balancer.outstanding.dec(1)
+	node, err = s.balancer.SelectNode(ctx, []int64{1, 2, 3, 4}, 1)
+	s.NoError(err)
+	s.Contains([]int64{1, 2}, node)
+}
+
+func (s *ZoneAwareBalancerSuite) TestUnknownZone() {
+	ctx := context.Background()
+	balancer := NewZoneAwareBalancer(s.lookAside, "", nil)
+	balancer.Start(ctx)
+	defer balancer.Close()
+	node, err := balancer.SelectNode(ctx, []int64{3}, 1)
+	s.NoError(err)
+	s.Equal(int64(3), node)
+
+	// keep the zones unknown if refreshing fails
+	balancer = NewZoneAwareBalancer(s.lookAside, "zone1", func(ctx context.Context) (map[int64]string, error) {
+		return nil, errors.New("mock error")
+	})
+	balancer.Start(ctx)
+	defer balancer.Close()
+	s.Nil(balancer.nodeZones.Load())
+	s.Equal([]int64{3, 4}, balancer.preferredNodes([]int64{3, 4}))
+}
+
+func TestZoneAwareBalancerSuite(t *testing.T) {
+	suite.Run(t, new(ZoneAwareBalancerSuite))
+}
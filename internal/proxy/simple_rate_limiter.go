@@ -39,6 +39,9 @@ import (
 	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
 )
 
+// principalRejectedStateTTL is how long a principal is reported in the quota states after it rejected a request.
+const principalRejectedStateTTL = time.Minute
+
 // SimpleLimiter is implemented based on Limiter interface
 type SimpleLimiter struct {
 	quotaStatesMu sync.RWMutex
@@ -144,6 +147,100 @@ func (m *SimpleLimiter) Check(dbID int64, collectionIDToPartIDs map[int64][]int6
 	return ret
 }
 
+// Cancel gives back the quota taken by a passed Check, e.g. when a request checked for several collections
+// is rejected by the limits of a later collection.
+func (m *SimpleLimiter) Cancel(dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) {
+	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() || n <= 0 {
+		return
+	}
+
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+
+	m.rateLimiter.GetRootLimiters().Cancel(rt, n)
+	if dbID == util.InvalidDBID {
+		return
+	}
+	if dbRateLimiters := m.rateLimiter.GetDatabaseLimiters(dbID); dbRateLimiters != nil {
+		dbRateLimiters.Cancel(rt, n)
+	}
+	for collectionID, partitionIDs := range collectionIDToPartIDs {
+		if collectionID == 0 {
+			continue
+		}
+		if !isNotCollectionLevelLimitRequest(rt) {
+			if collectionRateLimiters := m.rateLimiter.GetCollectionLimiters(dbID, collectionID); collectionRateLimiters != nil {
+				collectionRateLimiters.Cancel(rt, n)
+			}
+		}
+		for _, partID := range partitionIDs {
+			if partID == 0 {
+				continue
+			}
+			if partitionRateLimiters := m.rateLimiter.GetPartitionLimiters(dbID, collectionID, partID); partitionRateLimiters != nil {
+				partitionRateLimiters.Cancel(rt, n)
+			}
+		}
+	}
+}
+
+// CheckPrincipal checks the rate limits of the current user and its roles configured by the database properties,
+// e.g. database.user.alice.searchRate.max.vps, so that one user cannot use up the quota of a shared database.
+// It should be called before Check, the requests rejected here don't consume the quota of the database,
+// and the returned cancel should be called if Check rejects the request.
+func (m *SimpleLimiter) CheckPrincipal(ctx context.Context, dbName string, rt internalpb.RateType, n int) (func(), error) {
+	cancel := func() {}
+	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() || n <= 0 || !rlinternal.IsPrincipalRateType(rt) {
+		return cancel, nil
+	}
+	username := GetCurUserFromContextOrDefault(ctx)
+	if username == "" || globalMetaCache == nil {
+		return cancel, nil
+	}
+	db, err := globalMetaCache.GetDatabaseInfo(ctx, dbName)
+	if err != nil {
+		// the database is checked by the request itself
+		return cancel, nil
+	}
+	principalLimits := db.getPrincipalLimits().Resolve(username, getPrincipalRoles(ctx, username), rt)
+	if len(principalLimits) == 0 {
+		return cancel, nil
+	}
+
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+	doneLimiters := make([]*rlinternal.RateLimiterNode, 0, len(principalLimits))
+	cancel = func() {
+		for _, limiter := range doneLimiters {
+			limiter.Cancel(rt, n)
+		}
+	}
+	for _, principalLimit := range principalLimits {
+		principalLimiters := m.rateLimiter.GetOrCreatePrincipalLimiters(db.dbID, principalLimit.Principal, newDatabaseLimiter)
+		principalLimiters.SetPrincipalRate(rt, principalLimit.Rate)
+		if err := principalLimiters.Check(rt, n); err != nil {
+			cancel()
+			metrics.ProxyPrincipalRateLimitRejectCount.WithLabelValues(paramtable.GetStringNodeID(),
+				dbName, principalLimit.Configured, rt.String()).Inc()
+			return func() {}, err
+		}
+		doneLimiters = append(doneLimiters, principalLimiters)
+	}
+	return cancel, nil
+}
+
+// getPrincipalRoles returns the roles of the user, which are resolved by the PrivilegeInterceptor if the authorization is enabled.
+func getPrincipalRoles(ctx context.Context, username string) []string {
+	if roles, ok := ctx.Value(RBACRoleContextKey).([]string); ok {
+		return roles
+	}
+	roles, err := GetRole(username)
+	if err != nil {
+		return GetExternalRolesFromContext(ctx)
+	}
+	return append(append([]string{}, roles...), GetExternalRolesFromContext(ctx)...)
+}
+
 func isNotCollectionLevelLimitRequest(rt internalpb.RateType) bool {
 	// Most ddl is global level, only DDLFlush will be applied at collection
 	switch rt {
@@ -176,6 +273,24 @@ func (m *SimpleLimiter) GetQuotaStates() ([]milvuspb.QuotaState, []string) {
 			return true
 		})
 
+	// the principals which rejected requests recently
+	since := time.Now().Add(-principalRejectedStateTTL)
+	m.rateLimiter.GetRootLimiters().GetChildren().Range(func(dbID int64, dbLimiter *rlinternal.RateLimiterNode) bool {
+		dbLimiter.GetPrincipals().Range(func(principal string, principalLimiter *rlinternal.RateLimiterNode) bool {
+			for _, state := range principalLimiter.GetRejectedStates(since) {
+				if serviceStates[state] == nil {
+					serviceStates[state] = typeutil.NewSet[stateReasonKey]()
+				}
+				serviceStates[state].Insert(stateReasonKey{
+					ErrorCode: commonpb.ErrorCode_RateLimit,
+					Reason:    fmt.Sprintf("%s in database %d", principal, dbID),
+				})
+			}
+			return true
+		})
+		return true
+	})
+
 	states := make([]milvuspb.QuotaState, 0)
 	reasons := make([]string, 0)
 	for state, stateReasonKeys := range serviceStates {
@@ -17,15 +17,19 @@
 package proxy
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
 	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
 	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v3/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/v3/util"
@@ -185,6 +189,36 @@ func TestSimpleRateLimiter(t *testing.T) {
 		run(math.MaxFloat64 / 10000)
 	})
 
+	t.Run("test cancel", func(t *testing.T) {
+		bak := Params.QuotaConfig.QuotaAndLimitsEnabled.GetValue()
+		paramtable.Get().Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+		defer Params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, bak)
+
+		simpleLimiter := NewSimpleLimiter(0, 0)
+		collectionRateLimiters := simpleLimiter.rateLimiter.GetOrCreateCollectionLimiters(1, collectionID, newDatabaseLimiter,
+			func() *rlinternal.RateLimiterNode {
+				collectionRateLimiters := rlinternal.NewRateLimiterNode(internalpb.RateScope_Collection)
+				collectionRateLimiters.GetLimiters().
+					Insert(internalpb.RateType_DQLSearch, ratelimitutil.NewLimiter(ratelimitutil.Limit(0.01), 1))
+				return collectionRateLimiters
+			})
+		assert.NotNil(t, collectionRateLimiters)
+
+		err := simpleLimiter.Check(1, collectionIDToPartIDs, internalpb.RateType_DQLSearch, 5)
+		assert.NoError(t, err)
+		err = simpleLimiter.Check(1, collectionIDToPartIDs, internalpb.RateType_DQLSearch, 5)
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+
+		// the quota taken by the passed check is given back
+		simpleLimiter.Cancel(1, collectionIDToPartIDs, internalpb.RateType_DQLSearch, 5)
+		err = simpleLimiter.Check(1, collectionIDToPartIDs, internalpb.RateType_DQLSearch, 5)
+		assert.NoError(t, err)
+
+		// the missing limiters are skipped
+		simpleLimiter.Cancel(2, map[int64][]int64{3: {4}}, internalpb.RateType_DQLSearch, 5)
+		assert.Nil(t, simpleLimiter.rateLimiter.GetDatabaseLimiters(2))
+	})
+
 	t.Run("test set rates", func(t *testing.T) {
 		simpleLimiter := NewSimpleLimiter(0, 0)
 		zeroRates := getZeroCollectionRates()
@@ -402,3 +436,71 @@ func TestRateLimiter(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestSimpleRateLimiterCheckPrincipal(t *testing.T) {
+	bak := Params.QuotaConfig.QuotaAndLimitsEnabled.GetValue()
+	paramtable.Get().Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+	defer Params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, bak)
+
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().GetDatabaseInfo(mock.Anything, "tenant_db").Return(&databaseInfo{
+		dbID: 1,
+		properties: []*commonpb.KeyValuePair{
+			{Key: "database.user.*.searchRate.max.vps", Value: "0.01"},
+			{Key: "database.role.tenant_a.queryRate.max.qps", Value: "0"},
+		},
+	}, nil).Maybe()
+	mockCache.EXPECT().GetDatabaseInfo(mock.Anything, "default").Return(&databaseInfo{dbID: 2}, nil).Maybe()
+	originCache := globalMetaCache
+	globalMetaCache = mockCache
+	defer func() { globalMetaCache = originCache }()
+
+	simpleLimiter := NewSimpleLimiter(0, 0)
+	alice := SetRBACRolesToContext(GetContext(context.Background(), "alice:pwd"), []string{"public"})
+	bob := SetRBACRolesToContext(GetContext(context.Background(), "bob:pwd"), []string{"public", "tenant_a"})
+	carol := SetRBACRolesToContext(GetContext(context.Background(), "carol:pwd"), []string{"public"})
+	checkPrincipal := func(ctx context.Context, dbName string, rt internalpb.RateType, n int) error {
+		_, err := simpleLimiter.CheckPrincipal(ctx, dbName, rt, n)
+		return err
+	}
+
+	// every user has its own quota
+	assert.NoError(t, checkPrincipal(alice, "tenant_db", internalpb.RateType_DQLSearch, 1))
+	err := checkPrincipal(alice, "tenant_db", internalpb.RateType_DQLSearch, 1)
+	assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+	assert.Contains(t, err.Error(), "user:alice")
+	assert.NoError(t, checkPrincipal(bob, "tenant_db", internalpb.RateType_DQLSearch, 1))
+
+	// the roles are limited as well
+	assert.NoError(t, checkPrincipal(alice, "tenant_db", internalpb.RateType_DQLQuery, 1))
+	err = checkPrincipal(bob, "tenant_db", internalpb.RateType_DQLQuery, 1)
+	assert.ErrorIs(t, err, merr.ErrServiceQuotaExceeded)
+	assert.Contains(t, err.Error(), "role:tenant_a")
+
+	// no principal limit in the database
+	assert.NoError(t, checkPrincipal(alice, "default", internalpb.RateType_DQLSearch, 100))
+	// the ddl is not limited by the principals
+	assert.NoError(t, checkPrincipal(alice, "tenant_db", internalpb.RateType_DDLCollection, 1))
+
+	// the quota is given back if the request is rejected afterwards
+	cancel, err := simpleLimiter.CheckPrincipal(carol, "tenant_db", internalpb.RateType_DQLSearch, 1)
+	assert.NoError(t, err)
+	cancel()
+	assert.NoError(t, checkPrincipal(carol, "tenant_db", internalpb.RateType_DQLSearch, 1))
+	assert.Error(t, checkPrincipal(carol, "tenant_db", internalpb.RateType_DQLSearch, 1))
+
+	// the rejected requests are counted by the configured principals
+	nodeID := paramtable.GetStringNodeID()
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.ProxyPrincipalRateLimitRejectCount.WithLabelValues(
+		nodeID, "tenant_db", "user:*", internalpb.RateType_DQLSearch.String())))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ProxyPrincipalRateLimitRejectCount.WithLabelValues(
+		nodeID, "tenant_db", "role:tenant_a", internalpb.RateType_DQLQuery.String())))
+
+	states, reasons := simpleLimiter.GetQuotaStates()
+	assert.ElementsMatch(t, []milvuspb.QuotaState{milvuspb.QuotaState_ReadLimited, milvuspb.QuotaState_ReadLimited, milvuspb.QuotaState_ReadLimited}, states)
+	assert.ElementsMatch(t, []string{
+		"rate limit exceeded: user:alice in database 1",
+		"rate limit exceeded: user:carol in database 1",
+		"rate limit exceeded: role:tenant_a in database 1",
+	}, reasons)
+}
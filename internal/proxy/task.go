@@ -2253,6 +2253,15 @@ func (t *alterCollectionTask) PreExecute(ctx context.Context) error {
 	if err := common.ValidateNamespaceShardingEnabledNotAltered(t.GetProperties(), t.GetDeleteKeys()); err != nil {
 		return err
 	}
+	if _, err := common.IsSearchCacheKvEnabled(t.GetProperties()...); err != nil {
+		return err
+	}
+	if policy := common.GetCollectionReplicaSelectionPolicy(t.GetProperties()...); policy != "" &&
+		!shardclient.IsValidReplicaSelectionPolicy(policy) {
+		return merr.WrapErrParameterInvalidMsg("invalid %s: %s, supported policies are %s, %s, %s and %s",
+			common.CollectionReplicaSelectionPolicyKey, policy,
+			shardclient.RoundRobin, shardclient.LookAside, shardclient.ZoneAware, shardclient.PowerOfTwoChoices)
+	}
 
 	collSchema, err := globalMetaCache.GetCollectionSchema(ctx, t.GetDbName(), t.CollectionName)
 	if err != nil {
@@ -116,8 +116,45 @@ func (it *importTask) PreExecute(ctx context.Context) error {
 
 	isBackup := importutilv2.IsBackup(req.GetOptions())
 	isL0Import := importutilv2.IsL0Import(req.GetOptions())
+	isContinuous := importutilv2.IsContinuous(req.GetOptions())
 	hasPartitionKey := typeutil.HasPartitionKey(schema.CollectionSchema)
 
+	mode, err := importutilv2.GetImportMode(req.GetOptions())
+	if err != nil {
+		return err
+	}
+	if mode == importutilv2.ModeUpsert {
+		pkField, err := typeutil.GetPrimaryFieldSchema(schema.CollectionSchema)
+		if err != nil {
+			return err
+		}
+		if pkField.GetAutoID() {
+			return merr.WrapErrImportFailed("upsert import is not supported for collection with autoID primary key")
+		}
+	}
+
+	if isContinuous {
+		if isBackup || isL0Import {
+			return merr.WrapErrImportFailed("continuous import is not supported for backup or l0 import")
+		}
+		if _, err = importutilv2.GetContinuousInterval(req.GetOptions()); err != nil {
+			return err
+		}
+	}
+
+	if _, err = importutilv2.GetPriority(req.GetOptions()); err != nil {
+		return err
+	}
+
+	if importutilv2.IsDryRun(req.GetOptions()) {
+		if isContinuous || isL0Import {
+			return merr.WrapErrImportFailed("dry-run is not supported for continuous or l0 import")
+		}
+		if _, err = importutilv2.GetDryRunMaxErrors(req.GetOptions()); err != nil {
+			return err
+		}
+	}
+
 	var partitionIDs []int64
 	if isBackup {
 		if req.GetPartitionName() == "" {
@@ -129,7 +166,7 @@ func (it *importTask) PreExecute(ctx context.Context) error {
 			return err
 		}
 		partitionIDs = []UniqueID{partitionID}
-	} else if isL0Import {
+	} else if isL0Import || mode == importutilv2.ModeDelete {
 		if req.GetPartitionName() == "" {
 			partitionIDs = []UniqueID{common.AllPartitionsID}
 		} else {
@@ -152,6 +189,17 @@ func (it *importTask) PreExecute(ctx context.Context) error {
 			return merr.WrapErrImportFailed("for l0 import, collection cannot be loaded, please release it first")
 		}
 	} else {
+		if mode == importutilv2.ModeUpsert {
+			// Upsert import generates L0 segments for the replaced primary keys,
+			// so the same loading restriction as l0 import applies.
+			loaded, err := isCollectionLoaded(ctx, node.mixCoord, collectionID)
+			if err != nil {
+				return err
+			}
+			if loaded {
+				return merr.WrapErrImportFailed("for upsert import, collection cannot be loaded, please release it first")
+			}
+		}
 		if hasPartitionKey {
 			if req.GetPartitionName() != "" {
 				return merr.WrapErrImportFailed("not allow to set partition name for collection with partition key")
@@ -186,7 +234,12 @@ func (it *importTask) PreExecute(ctx context.Context) error {
 		return merr.WrapErrImportFailedMsg("The max number of import files should not exceed %d, but got %d",
 			Params.DataCoordCfg.MaxFilesPerImportReq.GetAsInt(), len(req.Files))
 	}
-	if !isBackup && !isL0Import {
+	if isContinuous {
+		// the only path is the prefix to watch, the files under it are checked by datacoord
+		if len(req.GetFiles()) != 1 || len(req.GetFiles()[0].GetPaths()) != 1 {
+			return merr.WrapErrImportFailed("continuous import requires exactly one prefix to watch")
+		}
+	} else if !isBackup && !isL0Import {
 		// check file type
 		for _, file := range req.GetFiles() {
 			_, err = importutilv2.GetFileType(file)
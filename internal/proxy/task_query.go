@@ -88,6 +88,12 @@ type queryTask struct {
 	storageCost          segcore.StorageCost
 	aggregationFieldMap  *agg.AggregationFieldMap
 	chMgr                channelsMgr
+
+	// coalesceKey is empty if the query could not be coalesced with the identical in-flight queries
+	coalescer   *requestCoalescer[*milvuspb.QueryResults]
+	coalesceKey string
+	// resultDone is true if the result is done in Execute by the coalesced execution
+	resultDone bool
 }
 
 func (t *queryTask) getQueryLabel() string {
@@ -911,6 +917,15 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	}
 
 	t.DbID = 0 // TODO
+	if t.coalescer != nil && !t.reQuery && !t.queryParams.isIterator && isRequestCoalescable(t.ConsistencyLevel) {
+		key, err := buildQueryCoalesceKey(t.RetrieveRequest, t.request)
+		if err != nil {
+			log.Warn(ctx, "failed to build query coalesce key, skip the request coalescing", mlog.Err(err))
+		} else {
+			t.coalesceKey = key
+		}
+	}
+
 	log.Debug(ctx, "Query PreExecute done.",
 		mlog.Uint64("guarantee_ts", guaranteeTs),
 		mlog.Uint64("mvcc_ts", t.GetMvccTimestamp()),
@@ -920,6 +935,32 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 }
 
 func (t *queryTask) Execute(ctx context.Context) error {
+	if t.coalesceKey != "" {
+		// the coalesced execution reduces the results as well, so PostExecute has nothing to do
+		result, coalesced, err := t.coalescer.Do(ctx, t.coalesceKey, func() (*milvuspb.QueryResults, error) {
+			if err := t.execute(ctx); err != nil {
+				return nil, err
+			}
+			if err := t.postExecute(ctx); err != nil {
+				return nil, err
+			}
+			return t.result, nil
+		})
+		if err != nil {
+			return err
+		}
+		t.result = result
+		t.resultDone = true
+		if coalesced {
+			t.result.CollectionName = t.collectionName
+			mlog.Debug(ctx, "Query Execute done by the identical in-flight query.", mlog.Int64("collection", t.GetCollectionID()))
+		}
+		return nil
+	}
+	return t.execute(ctx)
+}
+
+func (t *queryTask) execute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute query %d", t.ID()))
 	defer tr.CtxElapse(ctx, "done")
 	log := mlog.With(mlog.Int64("collection", t.GetCollectionID()),
@@ -973,6 +1014,13 @@ func (t *queryTask) Execute(ctx context.Context) error {
 }
 
 func (t *queryTask) PostExecute(ctx context.Context) error {
+	if t.resultDone {
+		return nil
+	}
+	return t.postExecute(ctx)
+}
+
+func (t *queryTask) postExecute(ctx context.Context) error {
 	tr := timerecord.NewTimeRecorder("queryTask PostExecute")
 	defer func() {
 		tr.CtxElapse(ctx, "done")
@@ -17,7 +17,6 @@
 package proxy
 
 import (
-	"container/list"
 	"context"
 	"fmt"
 	"math"
@@ -58,7 +57,7 @@ var _ taskQueue = (*baseTaskQueue)(nil)
 
 // baseTaskQueue implements taskQueue.
 type baseTaskQueue struct {
-	unissuedTasks *list.List
+	unissuedTasks unissuedTaskList
 	activeTasks   map[UniqueID]task
 	utLock        sync.RWMutex
 	atLock        sync.RWMutex
@@ -108,42 +107,21 @@ func (queue *baseTaskQueue) FrontUnissuedTask() task {
 	queue.utLock.RLock()
 	defer queue.utLock.RUnlock()
 
-	if queue.unissuedTasks.Len() <= 0 {
-		return nil
-	}
-
-	return queue.unissuedTasks.Front().Value.(task)
+	return queue.unissuedTasks.Front()
 }
 
 func (queue *baseTaskQueue) PopUnissuedTask() task {
 	queue.utLock.Lock()
 	defer queue.utLock.Unlock()
 
-	if queue.unissuedTasks.Len() <= 0 {
-		return nil
-	}
-
-	ft := queue.unissuedTasks.Front()
-	queue.unissuedTasks.Remove(ft)
-
-	return ft.Value.(task)
+	return queue.unissuedTasks.PopFront()
 }
 
 func (queue *baseTaskQueue) popUnissuedTasks(filter func(task) bool) []task {
 	queue.utLock.Lock()
 	defer queue.utLock.Unlock()
 
-	removed := make([]task, 0)
-	for e := queue.unissuedTasks.Front(); e != nil; {
-		next := e.Next()
-		t := e.Value.(task)
-		if filter == nil || filter(t) {
-			queue.unissuedTasks.Remove(e)
-			removed = append(removed, t)
-		}
-		e = next
-	}
-	return removed
+	return queue.unissuedTasks.Remove(filter)
 }
 
 func (queue *baseTaskQueue) AddActiveTask(t task) {
@@ -172,14 +150,19 @@ func (queue *baseTaskQueue) PopActiveTask(taskID UniqueID) task {
 }
 
 func (queue *baseTaskQueue) getTaskByReqID(reqID UniqueID) task {
+	var found task
 	queue.utLock.RLock()
-	for e := queue.unissuedTasks.Front(); e != nil; e = e.Next() {
-		if e.Value.(task).ID() == reqID {
-			queue.utLock.RUnlock()
-			return e.Value.(task)
+	queue.unissuedTasks.Range(func(t task) bool {
+		if t.ID() == reqID {
+			found = t
+			return false
 		}
-	}
+		return true
+	})
 	queue.utLock.RUnlock()
+	if found != nil {
+		return found
+	}
 
 	queue.atLock.RLock()
 	t, ok := queue.activeTasks[reqID]
@@ -245,8 +228,12 @@ func (queue *baseTaskQueue) getMaxTaskNum() int64 {
 }
 
 func newBaseTaskQueue(tsoAllocatorIns tsoAllocator) *baseTaskQueue {
+	return newBaseTaskQueueWithList(tsoAllocatorIns, newFIFOTaskList())
+}
+
+func newBaseTaskQueueWithList(tsoAllocatorIns tsoAllocator, unissuedTasks unissuedTaskList) *baseTaskQueue {
 	return &baseTaskQueue{
-		unissuedTasks:   list.New(),
+		unissuedTasks:   unissuedTasks,
 		activeTasks:     make(map[UniqueID]task),
 		utLock:          sync.RWMutex{},
 		atLock:          sync.RWMutex{},
@@ -414,7 +401,8 @@ func (queue *dmTaskQueue) getPChanStatsInfo() (map[pChan]*pChanStatistics, error
 	return ret, nil
 }
 
-// dqTaskQueue represents queue for DQL task such as search/query
+// dqTaskQueue represents queue for DQL task such as search/query,
+// the order to schedule the tasks is decided by the configured dql schedule policy.
 type dqTaskQueue struct {
 	*baseTaskQueue
 }
@@ -493,7 +481,7 @@ func newDmTaskQueue(tsoAllocatorIns tsoAllocator) *dmTaskQueue {
 
 func newDqTaskQueue(tsoAllocatorIns tsoAllocator) *dqTaskQueue {
 	return &dqTaskQueue{
-		baseTaskQueue: newBaseTaskQueue(tsoAllocatorIns),
+		baseTaskQueue: newBaseTaskQueueWithList(tsoAllocatorIns, newDQLTaskList()),
 	}
 }
 
@@ -684,6 +672,7 @@ func (sched *taskScheduler) queryLoop() {
 		case <-sched.dqQueue.utChan():
 			for t := sched.scheduleDqTask(); t != nil; t = sched.scheduleDqTask() {
 				task := t
+				observeDQLTenantQueueLatency(task)
 				p := pool
 				// if task is sub task spawned by another, use sub task pool in case of deadlock
 				if task.IsSubTask() {
@@ -756,8 +745,7 @@ func (sched *taskScheduler) getTaskQueueMetrics(queue *baseTaskQueue, queueType
 	defer queue.utLock.RUnlock()
 	utNum := queue.unissuedTasks.Len()
 
-	for e := queue.unissuedTasks.Front(); e != nil; e = e.Next() {
-		task := e.Value.(task)
+	queue.unissuedTasks.Range(func(task task) bool {
 		taskType := task.Name()
 		queueTimeMs := task.GetDurationInQueue().Milliseconds()
 
@@ -768,7 +756,8 @@ func (sched *taskScheduler) getTaskQueueMetrics(queue *baseTaskQueue, queueType
 		}
 
 		tracker.AddSample(queueTimeMs)
-	}
+		return true
+	})
 
 	pendingTaskMetrics := make([]metricsinfo.TaskMetrics, 0, len(pendingTaskStats))
 	for _, tracker := range pendingTaskStats {
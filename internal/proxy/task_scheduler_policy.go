@@ -0,0 +1,404 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/pkg/v3/metrics"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v3/util/requestutil"
+)
+
+const (
+	dqlSchedulePolicyFIFO                 = "fifo"
+	dqlSchedulePolicyUserRoundRobin       = "user-round-robin"
+	dqlSchedulePolicyDatabaseWeightedFair = "database-weighted-fair"
+)
+
+// unissuedTaskList holds the unissued tasks of a task queue and decides the order in which they are scheduled.
+// It is not concurrent safe, the callers are protected by the utLock of the task queue.
+type unissuedTaskList interface {
+	// PushBack adds a new task into the list.
+	PushBack(t task)
+	// Front returns the task to be scheduled next without removing it.
+	Front() task
+	// PopFront removes and returns the task to be scheduled next.
+	PopFront() task
+	// Remove removes and returns all the tasks matched by the filter.
+	Remove(filter func(task) bool) []task
+	// Range calls fn on the tasks until fn returns false.
+	Range(fn func(t task) bool)
+	// Len returns the number of the tasks in the list.
+	Len() int
+}
+
+var (
+	_ unissuedTaskList = (*fifoTaskList)(nil)
+	_ unissuedTaskList = (*userRoundRobinTaskList)(nil)
+	_ unissuedTaskList = (*databaseWeightedFairTaskList)(nil)
+)
+
+// newDQLTaskList creates the unissued task list of the dql queue by the configured schedule policy.
+func newDQLTaskList() unissuedTaskList {
+	switch name := paramtable.Get().ProxyCfg.DQLSchedulePolicy.GetValue(); name {
+	case dqlSchedulePolicyFIFO:
+		return newFIFOTaskList()
+	case dqlSchedulePolicyUserRoundRobin:
+		return newUserRoundRobinTaskList()
+	case dqlSchedulePolicyDatabaseWeightedFair:
+		return newDatabaseWeightedFairTaskList()
+	default:
+		mlog.Warn(context.TODO(), "unknown dql schedule policy, fallback to fifo", mlog.String("policy", name))
+		return newFIFOTaskList()
+	}
+}
+
+// getDQLTaskTenant returns the database and the user which the dql task belongs to.
+func getDQLTaskTenant(t task) (dbName string, username string) {
+	switch dt := t.(type) {
+	case *searchTask:
+		dbName = dt.request.GetDbName()
+	case *queryTask:
+		dbName = dt.request.GetDbName()
+	case *HighlightTask:
+		dbName = dt.dbName
+	case requestutil.DBNameGetter:
+		dbName = dt.GetDbName()
+	}
+	ctx := t.TraceCtx()
+	if ctx == nil {
+		if dbName == "" {
+			dbName = util.DefaultDBName
+		}
+		return dbName, ""
+	}
+	if dbName == "" {
+		dbName = GetCurDBNameFromContextOrDefault(ctx)
+	}
+	return dbName, GetCurUserFromContextOrDefault(ctx)
+}
+
+// observeDQLTenantQueueLatency records how long the dql task waited in the queue for its database.
+func observeDQLTenantQueueLatency(t task) {
+	dbName, _ := getDQLTaskTenant(t)
+	metrics.ProxyDQLTenantQueueLatency.
+		WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), dbName, t.Name()).
+		Observe(float64(t.GetDurationInQueue().Microseconds()) / 1000.0)
+}
+
+// fifoTaskList schedules the tasks in the order they arrive.
+type fifoTaskList struct {
+	tasks *list.List
+}
+
+func newFIFOTaskList() *fifoTaskList {
+	return &fifoTaskList{tasks: list.New()}
+}
+
+func (l *fifoTaskList) PushBack(t task) {
+	l.tasks.PushBack(t)
+}
+
+func (l *fifoTaskList) Front() task {
+	if l.tasks.Len() == 0 {
+		return nil
+	}
+	return l.tasks.Front().Value.(task)
+}
+
+func (l *fifoTaskList) PopFront() task {
+	if l.tasks.Len() == 0 {
+		return nil
+	}
+	return l.tasks.Remove(l.tasks.Front()).(task)
+}
+
+func (l *fifoTaskList) Remove(filter func(task) bool) []task {
+	return removeTasksFromList(l.tasks, filter)
+}
+
+func (l *fifoTaskList) Range(fn func(t task) bool) {
+	for e := l.tasks.Front(); e != nil; e = e.Next() {
+		if !fn(e.Value.(task)) {
+			return
+		}
+	}
+}
+
+func (l *fifoTaskList) Len() int {
+	return l.tasks.Len()
+}
+
+// removeTasksFromList removes the tasks matched by the filter from the list, a nil filter matches all.
+func removeTasksFromList(tasks *list.List, filter func(task) bool) []task {
+	removed := make([]task, 0)
+	for e := tasks.Front(); e != nil; {
+		next := e.Next()
+		t := e.Value.(task)
+		if filter == nil || filter(t) {
+			tasks.Remove(e)
+			removed = append(removed, t)
+		}
+		e = next
+	}
+	return removed
+}
+
+// userRoundRobinTaskList polls the tasks of the users one by one,
+// so a burst of tasks from one user does not block the others.
+type userRoundRobinTaskList struct {
+	groups map[string]*list.List
+	// order is the polling order of the users with pending tasks, next is the index of the user to poll.
+	order []string
+	next  int
+	count int
+}
+
+func newUserRoundRobinTaskList() *userRoundRobinTaskList {
+	return &userRoundRobinTaskList{
+		groups: make(map[string]*list.List),
+	}
+}
+
+func (l *userRoundRobinTaskList) PushBack(t task) {
+	_, username := getDQLTaskTenant(t)
+	group, ok := l.groups[username]
+	if !ok {
+		group = list.New()
+		l.groups[username] = group
+		l.order = append(l.order, username)
+	}
+	group.PushBack(t)
+	l.count++
+}
+
+func (l *userRoundRobinTaskList) Front() task {
+	if l.count == 0 {
+		return nil
+	}
+	return l.groups[l.order[l.next]].Front().Value.(task)
+}
+
+func (l *userRoundRobinTaskList) PopFront() task {
+	if l.count == 0 {
+		return nil
+	}
+	username := l.order[l.next]
+	group := l.groups[username]
+	t := group.Remove(group.Front()).(task)
+	l.count--
+	if group.Len() == 0 {
+		l.removeGroup(l.next)
+	} else {
+		l.next++
+	}
+	if l.next >= len(l.order) {
+		l.next = 0
+	}
+	return t
+}
+
+// removeGroup removes the empty group at the index of the polling order,
+// the next group to poll is the one following the removed group.
+func (l *userRoundRobinTaskList) removeGroup(index int) {
+	delete(l.groups, l.order[index])
+	l.order = append(l.order[:index], l.order[index+1:]...)
+	if index < l.next {
+		l.next--
+	}
+}
+
+func (l *userRoundRobinTaskList) Remove(filter func(task) bool) []task {
+	removed := make([]task, 0)
+	for i := 0; i < len(l.order); {
+		group := l.groups[l.order[i]]
+		removed = append(removed, removeTasksFromList(group, filter)...)
+		if group.Len() == 0 {
+			l.removeGroup(i)
+			continue
+		}
+		i++
+	}
+	if l.next >= len(l.order) {
+		l.next = 0
+	}
+	l.count -= len(removed)
+	return removed
+}
+
+func (l *userRoundRobinTaskList) Range(fn func(t task) bool) {
+	for _, username := range l.order {
+		for e := l.groups[username].Front(); e != nil; e = e.Next() {
+			if !fn(e.Value.(task)) {
+				return
+			}
+		}
+	}
+}
+
+func (l *userRoundRobinTaskList) Len() int {
+	return l.count
+}
+
+// weightedTask is a task tagged with its virtual start time in the weighted fair queue.
+type weightedTask struct {
+	task
+	start float64
+	seq   uint64
+}
+
+// weightedTaskGroup holds the pending tasks of a database.
+type weightedTaskGroup struct {
+	tasks *list.List
+	// finish is the virtual finish time of the last task pushed into the group.
+	finish float64
+}
+
+// databaseWeightedFairTaskList schedules the tasks of the databases in proportion to the database weights.
+// It implements the start-time fair queuing: every task costs the same, a task of the database
+// with weight w starts at max(virtual time, finish of the previous task of the database) and finishes 1/w later,
+// the task with the smallest start time is scheduled first and the virtual time advances to its start.
+type databaseWeightedFairTaskList struct {
+	groups      map[string]*weightedTaskGroup
+	virtualTime float64
+	seq         uint64
+	count       int
+}
+
+func newDatabaseWeightedFairTaskList() *databaseWeightedFairTaskList {
+	return &databaseWeightedFairTaskList{
+		groups: make(map[string]*weightedTaskGroup),
+	}
+}
+
+// getDatabaseWeight returns the configured weight of the database, the database names are case-insensitive.
+func getDatabaseWeight(dbName string) float64 {
+	params := paramtable.Get().ProxyCfg
+	if value, ok := params.DQLDatabaseWeights.GetValue()[strings.ToLower(dbName)]; ok {
+		weight, err := strconv.ParseFloat(value, 64)
+		if err == nil && weight > 0 {
+			return weight
+		}
+		mlog.Warn(context.TODO(), "invalid dql database weight, use the default weight",
+			mlog.String("database", dbName), mlog.String("weight", value))
+	}
+	weight := params.DQLDefaultDatabaseWeight.GetAsFloat()
+	if weight <= 0 {
+		return 1
+	}
+	return weight
+}
+
+func (l *databaseWeightedFairTaskList) PushBack(t task) {
+	dbName, _ := getDQLTaskTenant(t)
+	group, ok := l.groups[dbName]
+	if !ok {
+		group = &weightedTaskGroup{tasks: list.New()}
+		l.groups[dbName] = group
+	}
+	start := max(l.virtualTime, group.finish)
+	group.finish = start + 1/getDatabaseWeight(dbName)
+	l.seq++
+	group.tasks.PushBack(&weightedTask{task: t, start: start, seq: l.seq})
+	l.count++
+}
+
+// head returns the group whose first task has the smallest start time, the earlier pushed one wins the tie.
+func (l *databaseWeightedFairTaskList) head() *weightedTaskGroup {
+	var (
+		selected *weightedTaskGroup
+		first    *weightedTask
+	)
+	for _, group := range l.groups {
+		if group.tasks.Len() == 0 {
+			continue
+		}
+		wt := group.tasks.Front().Value.(*weightedTask)
+		if first == nil || wt.start < first.start || (wt.start == first.start && wt.seq < first.seq) {
+			selected, first = group, wt
+		}
+	}
+	return selected
+}
+
+func (l *databaseWeightedFairTaskList) Front() task {
+	group := l.head()
+	if group == nil {
+		return nil
+	}
+	return group.tasks.Front().Value.(*weightedTask).task
+}
+
+func (l *databaseWeightedFairTaskList) PopFront() task {
+	group := l.head()
+	if group == nil {
+		return nil
+	}
+	wt := group.tasks.Remove(group.tasks.Front()).(*weightedTask)
+	l.count--
+	l.virtualTime = wt.start
+	l.gc()
+	return wt.task
+}
+
+// gc removes the empty groups which could not be ahead of the virtual time any more,
+// the finish time of the other empty groups is kept so that they do not gain credits by leaving and rejoining.
+func (l *databaseWeightedFairTaskList) gc() {
+	for dbName, group := range l.groups {
+		if group.tasks.Len() == 0 && (group.finish <= l.virtualTime || l.count == 0) {
+			delete(l.groups, dbName)
+		}
+	}
+}
+
+func (l *databaseWeightedFairTaskList) Remove(filter func(task) bool) []task {
+	removed := make([]task, 0)
+	for _, group := range l.groups {
+		for e := group.tasks.Front(); e != nil; {
+			next := e.Next()
+			wt := e.Value.(*weightedTask)
+			if filter == nil || filter(wt.task) {
+				group.tasks.Remove(e)
+				removed = append(removed, wt.task)
+			}
+			e = next
+		}
+	}
+	l.count -= len(removed)
+	l.gc()
+	return removed
+}
+
+func (l *databaseWeightedFairTaskList) Range(fn func(t task) bool) {
+	for _, group := range l.groups {
+		for e := group.tasks.Front(); e != nil; e = e.Next() {
+			if !fn(e.Value.(*weightedTask).task) {
+				return
+			}
+		}
+	}
+}
+
+func (l *databaseWeightedFairTaskList) Len() int {
+	return l.count
+}
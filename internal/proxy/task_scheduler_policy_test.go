@@ -0,0 +1,235 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v3/util/paramtable"
+)
+
+func newTenantMockDqlTask(dbName string, username string) *mockDqlTask {
+	return newMockDqlTask(GetContextWithDB(context.Background(), username+":123456", dbName))
+}
+
+func popAllTasks(l unissuedTaskList) []task {
+	tasks := make([]task, 0, l.Len())
+	for l.Len() > 0 {
+		front := l.Front()
+		t := l.PopFront()
+		if front != t {
+			panic("front is not the popped task")
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks
+}
+
+func TestGetDQLTaskTenant(t *testing.T) {
+	dbName, username := getDQLTaskTenant(newTenantMockDqlTask("db1", "alice"))
+	assert.Equal(t, "db1", dbName)
+	assert.Equal(t, "alice", username)
+
+	dbName, username = getDQLTaskTenant(newDefaultMockDqlTask())
+	assert.Equal(t, "default", dbName)
+	assert.Equal(t, "", username)
+}
+
+func TestNewDQLTaskList(t *testing.T) {
+	params := paramtable.Get()
+	key := params.ProxyCfg.DQLSchedulePolicy.Key
+	defer params.Reset(key)
+
+	assert.IsType(t, &fifoTaskList{}, newDQLTaskList())
+	params.Save(key, dqlSchedulePolicyUserRoundRobin)
+	assert.IsType(t, &userRoundRobinTaskList{}, newDQLTaskList())
+	params.Save(key, dqlSchedulePolicyDatabaseWeightedFair)
+	assert.IsType(t, &databaseWeightedFairTaskList{}, newDQLTaskList())
+	params.Save(key, "unknown")
+	assert.IsType(t, &fifoTaskList{}, newDQLTaskList())
+}
+
+func TestFIFOTaskList(t *testing.T) {
+	l := newFIFOTaskList()
+	assert.Nil(t, l.Front())
+	assert.Nil(t, l.PopFront())
+
+	t1 := newTenantMockDqlTask("db1", "alice")
+	t2 := newTenantMockDqlTask("db2", "bob")
+	t3 := newTenantMockDqlTask("db1", "alice")
+	l.PushBack(t1)
+	l.PushBack(t2)
+	l.PushBack(t3)
+	assert.Equal(t, 3, l.Len())
+
+	removed := l.Remove(func(t task) bool { return t == t2 })
+	assert.Equal(t, []task{t2}, removed)
+	assert.Equal(t, []task{t1, t3}, popAllTasks(l))
+}
+
+func TestUserRoundRobinTaskList(t *testing.T) {
+	l := newUserRoundRobinTaskList()
+	assert.Nil(t, l.Front())
+	assert.Nil(t, l.PopFront())
+
+	// a burst from alice does not block bob and carol
+	a1 := newTenantMockDqlTask("db1", "alice")
+	a2 := newTenantMockDqlTask("db1", "alice")
+	a3 := newTenantMockDqlTask("db1", "alice")
+	b1 := newTenantMockDqlTask("db1", "bob")
+	b2 := newTenantMockDqlTask("db1", "bob")
+	c1 := newTenantMockDqlTask("db2", "carol")
+	for _, task := range []task{a1, a2, a3, b1, b2, c1} {
+		l.PushBack(task)
+	}
+	assert.Equal(t, 6, l.Len())
+
+	count := 0
+	l.Range(func(task) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 6, count)
+
+	assert.Equal(t, []task{a1, b1, c1, a2, b2, a3}, popAllTasks(l))
+	assert.Empty(t, l.groups)
+
+	t.Run("remove", func(t *testing.T) {
+		l := newUserRoundRobinTaskList()
+		for _, task := range []task{a1, a2, a3, b1, b2, c1} {
+			l.PushBack(task)
+		}
+		assert.Equal(t, a1, l.PopFront())
+		// bob is the next user to poll, removing all his tasks moves on to carol
+		removed := l.Remove(func(t task) bool {
+			_, username := getDQLTaskTenant(t)
+			return username == "bob"
+		})
+		assert.ElementsMatch(t, []task{b1, b2}, removed)
+		assert.Equal(t, 3, l.Len())
+		assert.Equal(t, []task{c1, a2, a3}, popAllTasks(l))
+
+		l.PushBack(a1)
+		assert.Len(t, l.Remove(nil), 1)
+		assert.Equal(t, 0, l.Len())
+		assert.Nil(t, l.Front())
+	})
+}
+
+func TestDatabaseWeightedFairTaskList(t *testing.T) {
+	params := paramtable.Get()
+	weightKey := params.ProxyCfg.DQLDatabaseWeights.KeyPrefix + "db1"
+	params.SaveGroup(map[string]string{weightKey: "3"})
+	defer params.Remove(weightKey)
+
+	l := newDatabaseWeightedFairTaskList()
+	assert.Nil(t, l.Front())
+	assert.Nil(t, l.PopFront())
+
+	// db1 with weight 3 is scheduled three times as often as db2 with the default weight
+	db1Tasks := make([]task, 0)
+	db2Tasks := make([]task, 0)
+	for i := 0; i < 6; i++ {
+		task := newTenantMockDqlTask("db1", "alice")
+		db1Tasks = append(db1Tasks, task)
+		l.PushBack(task)
+	}
+	for i := 0; i < 2; i++ {
+		task := newTenantMockDqlTask("db2", "bob")
+		db2Tasks = append(db2Tasks, task)
+		l.PushBack(task)
+	}
+	assert.Equal(t, 8, l.Len())
+
+	popped := popAllTasks(l)
+	assert.Equal(t, []task{
+		db1Tasks[0], db2Tasks[0], db1Tasks[1], db1Tasks[2],
+		db1Tasks[3], db2Tasks[1], db1Tasks[4], db1Tasks[5],
+	}, popped)
+	assert.Empty(t, l.groups)
+
+	t.Run("late comer", func(t *testing.T) {
+		l := newDatabaseWeightedFairTaskList()
+		for i := 0; i < 10; i++ {
+			l.PushBack(newTenantMockDqlTask("db2", "bob"))
+		}
+		l.PopFront()
+		l.PopFront()
+		// the database arrives later is scheduled next rather than after the whole burst
+		late := newTenantMockDqlTask("db3", "carol")
+		l.PushBack(late)
+		assert.Equal(t, late, l.Front())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		l := newDatabaseWeightedFairTaskList()
+		for _, task := range db1Tasks {
+			l.PushBack(task)
+		}
+		for _, task := range db2Tasks {
+			l.PushBack(task)
+		}
+		removed := l.Remove(func(t task) bool {
+			dbName, _ := getDQLTaskTenant(t)
+			return dbName == "db2"
+		})
+		assert.ElementsMatch(t, db2Tasks, removed)
+		assert.Equal(t, db1Tasks, popAllTasks(l))
+	})
+}
+
+func TestGetDatabaseWeight(t *testing.T) {
+	params := paramtable.Get()
+	weightKey := params.ProxyCfg.DQLDatabaseWeights.KeyPrefix + "db1"
+	invalidKey := params.ProxyCfg.DQLDatabaseWeights.KeyPrefix + "db2"
+	params.SaveGroup(map[string]string{weightKey: "2.5", invalidKey: "-1"})
+	defer params.Remove(weightKey)
+	defer params.Remove(invalidKey)
+
+	assert.Equal(t, 2.5, getDatabaseWeight("db1"))
+	assert.Equal(t, 2.5, getDatabaseWeight("DB1"))
+	assert.Equal(t, 1.0, getDatabaseWeight("db2"))
+	assert.Equal(t, 1.0, getDatabaseWeight("db3"))
+
+	defaultKey := params.ProxyCfg.DQLDefaultDatabaseWeight.Key
+	params.Save(defaultKey, "4")
+	defer params.Reset(defaultKey)
+	assert.Equal(t, 4.0, getDatabaseWeight("db3"))
+}
+
+func TestDqTaskQueueClearWithFairPolicy(t *testing.T) {
+	params := paramtable.Get()
+	key := params.ProxyCfg.DQLSchedulePolicy.Key
+	params.Save(key, dqlSchedulePolicyUserRoundRobin)
+	defer params.Reset(key)
+
+	queue := newDqTaskQueue(newMockTsoAllocator())
+	for _, username := range []string{"alice", "alice", "bob"} {
+		assert.NoError(t, queue.Enqueue(newTenantMockDqlTask("db1", username)))
+	}
+	assert.Equal(t, 3, queue.unissuedTasks.Len())
+	pending := queue.FrontUnissuedTask()
+	assert.Equal(t, pending, queue.getTaskByReqID(pending.ID()))
+
+	result := queue.clearQueuedTasks("", "test")
+	assert.Equal(t, int64(3), result.queuedCleared)
+	assert.True(t, queue.utEmpty())
+	assert.ErrorIs(t, pending.WaitToFinish(), context.Canceled)
+}
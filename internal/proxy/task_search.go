@@ -127,6 +127,16 @@ type searchTask struct {
 	hybridElementLevel   bool
 
 	chMgr channelsMgr
+
+	// searchCacheKey is nil if the search could not use the search result cache
+	searchCache    *searchResultCache
+	searchCacheKey *searchCacheKey
+
+	// coalesceKey is empty if the search could not be coalesced with the identical in-flight searches
+	coalescer   *requestCoalescer[*milvuspb.SearchResults]
+	coalesceKey string
+	// resultDone is true if the result is done in Execute, by the cache or the coalesced execution
+	resultDone bool
 }
 
 func (t *searchTask) CanSkipAllocTimestamp() bool {
@@ -359,6 +369,23 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if t.searchCache != nil && t.isSearchCacheable() && isSearchCacheEnabled(collectionInfo) {
+		key, err := buildSearchCacheKey(t.SearchRequest, t.request)
+		if err != nil {
+			log.Warn(ctx, "failed to build search cache key, skip the search result cache", mlog.Err(err))
+		} else {
+			t.searchCacheKey = &key
+		}
+	}
+	if t.coalescer != nil && t.isSearchCacheable() && isRequestCoalescable(t.ConsistencyLevel) {
+		key, err := buildSearchCoalesceKey(t.SearchRequest, t.request)
+		if err != nil {
+			log.Warn(ctx, "failed to build search coalesce key, skip the request coalescing", mlog.Err(err))
+		} else {
+			t.coalesceKey = key
+		}
+	}
+
 	log.Debug(ctx, "search PreExecute done.",
 		mlog.Uint64("guarantee_ts", guaranteeTs),
 		mlog.Bool("use_default_consistency", useDefaultConsistency),
@@ -368,6 +395,17 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 	return nil
 }
 
+// isSearchCacheable returns whether the result of the search could be served from the search result cache
+// or shared with the identical in-flight searches, the strong consistency searches, iterators, hybrid searches and the searches with highlight or trace are excluded.
+func (t *searchTask) isSearchCacheable() bool {
+	return t.ConsistencyLevel != commonpb.ConsistencyLevel_Strong &&
+		!t.isIterator &&
+		!t.GetIsAdvanced() &&
+		!t.GetIsRecallEvaluation() &&
+		!t.traceEnabled &&
+		t.request.GetHighlighter() == nil
+}
+
 func (t *searchTask) checkNq(ctx context.Context) (int64, error) {
 	var nq int64
 	if t.GetIsAdvanced() {
@@ -1241,6 +1279,44 @@ func (t *searchTask) tryParsePartitionIDsFromPlan(plan *planpb.PlanNode) ([]int6
 func (t *searchTask) Execute(ctx context.Context) error {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Search-Execute")
 	defer sp.End()
+
+	if t.searchCacheKey != nil {
+		if result, ok := t.searchCache.Get(*t.searchCacheKey); ok {
+			t.result = result
+			t.result.CollectionName = t.request.GetCollectionName()
+			t.resultDone = true
+			mlog.Debug(ctx, "Search Execute done by the search result cache.", mlog.Int64("collection", t.GetCollectionID()))
+			return nil
+		}
+	}
+
+	if t.coalesceKey != "" {
+		// the coalesced execution reduces the results as well, so PostExecute has nothing to do
+		result, coalesced, err := t.coalescer.Do(ctx, t.coalesceKey, func() (*milvuspb.SearchResults, error) {
+			if err := t.execute(ctx); err != nil {
+				return nil, err
+			}
+			if err := t.postExecute(ctx); err != nil {
+				return nil, err
+			}
+			return t.result, nil
+		})
+		if err != nil {
+			return err
+		}
+		t.result = result
+		t.resultDone = true
+		if coalesced {
+			t.result.CollectionName = t.request.GetCollectionName()
+			mlog.Debug(ctx, "Search Execute done by the identical in-flight search.", mlog.Int64("collection", t.GetCollectionID()))
+		}
+		return nil
+	}
+
+	return t.execute(ctx)
+}
+
+func (t *searchTask) execute(ctx context.Context) error {
 	log := mlog.WithLazy(mlog.Int64("nq", t.GetNq()))
 
 	tr := timerecord.NewTimeRecorder(fmt.Sprintf("proxy execute search %d", t.ID()))
@@ -1352,6 +1428,13 @@ func validateElementFilterVectorSearch(plan *planpb.PlanNode, schema *schemapb.C
 }
 
 func (t *searchTask) PostExecute(ctx context.Context) error {
+	if t.resultDone {
+		return nil
+	}
+	return t.postExecute(ctx)
+}
+
+func (t *searchTask) postExecute(ctx context.Context) error {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Search-PostExecute")
 	defer sp.End()
 
@@ -1497,6 +1580,10 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 		}
 	}
 
+	if t.searchCacheKey != nil {
+		t.searchCache.Put(*t.searchCacheKey, t.request.GetDbName(), t.collectionName, t.CollectionID, t.result)
+	}
+
 	log.Debug(ctx, "Search post execute done",
 		mlog.Int64("collection", t.GetCollectionID()),
 		mlog.Int64s("partitionIDs", t.GetPartitionIDs()))
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -45,6 +46,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/function/models"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
 	"github.com/milvus-io/milvus/internal/util/indexparamcheck"
+	"github.com/milvus-io/milvus/internal/util/jwtauth"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/milvus-io/milvus/pkg/v3/common"
@@ -1506,6 +1508,55 @@ func VerifyAPIKey(rawToken string) (string, error) {
 	return user, nil
 }
 
+var (
+	jwtVerifierMu sync.Mutex
+	jwtVerifier   *jwtauth.Verifier
+)
+
+// getJWTVerifier creates the jwt verifier at the first use, the creation is retried by the next request if it fails.
+func getJWTVerifier() (*jwtauth.Verifier, error) {
+	jwtVerifierMu.Lock()
+	defer jwtVerifierMu.Unlock()
+	if jwtVerifier != nil {
+		return jwtVerifier, nil
+	}
+	verifier, err := jwtauth.NewVerifier(jwtauth.Config{
+		Issuer:          Params.CommonCfg.JWTIssuer.GetValue(),
+		Audience:        Params.CommonCfg.JWTAudience.GetValue(),
+		Algorithms:      Params.CommonCfg.JWTAlgorithms.GetAsStrings(),
+		JWKSSource:      Params.CommonCfg.JWKSSource.GetValue(),
+		RefreshInterval: Params.CommonCfg.JWKSRefreshInterval.GetAsDuration(time.Second),
+		UsernameClaim:   Params.CommonCfg.JWTUsernameClaim.GetValue(),
+		RolesClaim:      Params.CommonCfg.JWTRolesClaim.GetValue(),
+		RoleMapping:     Params.CommonCfg.JWTRoleMapping.GetValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	jwtVerifier = verifier
+	return jwtVerifier, nil
+}
+
+// IsJWTAuthentication returns whether the token should be verified as a jwt.
+func IsJWTAuthentication(rawToken string) bool {
+	return Params.CommonCfg.JWTEnabled.GetAsBool() && jwtauth.IsJWT(rawToken)
+}
+
+// VerifyJWT verifies the jwt bearer token, returns the user and the roles mapped from the claims.
+func VerifyJWT(rawToken string) (string, []string, error) {
+	verifier, err := getJWTVerifier()
+	if err != nil {
+		mlog.Warn(context.TODO(), "fail to create the jwt verifier", mlog.Err(err))
+		return "", nil, err
+	}
+	identity, err := verifier.Verify(rawToken)
+	if err != nil {
+		mlog.Warn(context.TODO(), "fail to verify jwt", mlog.Err(err))
+		return "", nil, err
+	}
+	return identity.Username, identity.Roles, nil
+}
+
 // PasswordVerify verify password
 func passwordVerify(ctx context.Context, username, rawPwd string, privilegeCache privilege.PrivilegeCache) bool {
 	// it represents the cache miss if Sha256Password is empty within credInfo, which shall be updated first connection.
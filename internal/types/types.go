@@ -48,6 +48,11 @@ type (
 type Limiter interface {
 	Check(dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error
 	Alloc(ctx context.Context, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error
+	// Cancel gives back the quota taken by a passed Check.
+	Cancel(dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int)
+	// CheckPrincipal checks the rate limits of the current user and its roles in the database,
+	// the returned cancel gives back the quota if the request is rejected afterwards.
+	CheckPrincipal(ctx context.Context, dbName string, rt internalpb.RateType, n int) (cancel func(), err error)
 }
 
 // Component is the interface all services implement
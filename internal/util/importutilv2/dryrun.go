@@ -0,0 +1,74 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importutilv2
+
+import (
+	"github.com/milvus-io/milvus/internal/json"
+)
+
+// DryRunFileResult is the validation result of an import file in dry-run.
+type DryRunFileResult struct {
+	Paths         []string `json:"paths,omitempty"`
+	Rows          int64    `json:"rows"`
+	EstimatedSize int64    `json:"estimated_size"`
+	// SchemaMismatches are the errors detected before reading rows,
+	// such as missing fields or mismatched column types.
+	SchemaMismatches []string `json:"schema_mismatches,omitempty"`
+	// Errors are the first errors of reading and validating rows.
+	Errors []string `json:"errors,omitempty"`
+}
+
+func (r *DryRunFileResult) IsValid() bool {
+	return len(r.SchemaMismatches) == 0 && len(r.Errors) == 0
+}
+
+// AllErrors returns the schema mismatches followed by the row errors.
+func (r *DryRunFileResult) AllErrors() []string {
+	errs := make([]string, 0, len(r.SchemaMismatches)+len(r.Errors))
+	errs = append(errs, r.SchemaMismatches...)
+	return append(errs, r.Errors...)
+}
+
+// AddError records the row error, the errors beyond maxErrors are dropped.
+func (r *DryRunFileResult) AddError(err error, maxErrors int) {
+	if len(r.Errors) < maxErrors {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
+
+// MarshalDryRunResults encodes the results of the files of a preimport task,
+// which is passed from datanode to datacoord along with the completed task.
+func MarshalDryRunResults(results []*DryRunFileResult) (string, error) {
+	bytes, err := json.Marshal(results)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// UnmarshalDryRunResults decodes the results encoded by MarshalDryRunResults,
+// it returns nil if the results are empty.
+func UnmarshalDryRunResults(encoded string) ([]*DryRunFileResult, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+	results := make([]*DryRunFileResult, 0)
+	if err := json.Unmarshal([]byte(encoded), &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
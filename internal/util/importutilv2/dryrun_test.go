@@ -0,0 +1,49 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importutilv2
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRunResults(t *testing.T) {
+	results, err := UnmarshalDryRunResults("")
+	assert.NoError(t, err)
+	assert.Nil(t, results)
+
+	valid := &DryRunFileResult{Paths: []string{"a.parquet"}}
+	invalid := &DryRunFileResult{Paths: []string{"b.parquet"}, SchemaMismatches: []string{"mismatch"}}
+	for i := 0; i < 3; i++ {
+		invalid.AddError(errors.New("row error"), 2)
+	}
+	assert.True(t, valid.IsValid())
+	assert.False(t, invalid.IsValid())
+	assert.Equal(t, []string{"mismatch", "row error", "row error"}, invalid.AllErrors())
+
+	reason, err := MarshalDryRunResults([]*DryRunFileResult{valid, invalid})
+	assert.NoError(t, err)
+	results, err = UnmarshalDryRunResults(reason)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(results))
+	assert.Equal(t, invalid, results[1])
+
+	_, err = UnmarshalDryRunResults("invalid")
+	assert.Error(t, err)
+}
@@ -46,6 +46,72 @@ const (
 	CSVNullKey = "nullkey"
 )
 
+// Options for import mode.
+const (
+	// Mode specifies how the imported rows are applied to the collection, default to "insert".
+	Mode = "mode"
+
+	// ModeInsert appends the imported rows to the collection.
+	ModeInsert = "insert"
+
+	// ModeUpsert replaces the existing entities which have the same primary keys as the imported rows.
+	ModeUpsert = "upsert"
+
+	// ModeDelete deletes the existing entities whose primary keys are listed in the import files.
+	ModeDelete = "delete"
+)
+
+// Options for column transforms.
+const (
+	// ColumnMapping maps the column names of the import files to the field names,
+	// the value is a JSON object, such as {"source_column": "field_name"}.
+	ColumnMapping = "column_mapping"
+
+	// ConstantColumns fills the fields with constant values for all rows,
+	// the value is a JSON object, such as {"field_name": "value"}.
+	ConstantColumns = "constant_columns"
+
+	// TypeCasts reads the values of the fields as strings and converts them to the field type,
+	// the value is a JSON object, such as {"field_name": "int64"}.
+	TypeCasts = "type_casts"
+
+	// DropUnknownColumns ignores the columns which are not defined in the schema,
+	// instead of storing them in the dynamic field, default to false.
+	DropUnknownColumns = "drop_unknown_columns"
+)
+
+// Options for continuous import.
+const (
+	// Continuous indicates whether to watch the prefix given by the import file
+	// and import the newly landed files periodically, default to false.
+	Continuous = "continuous"
+
+	// ContinuousInterval specifies the interval of scanning the prefix, such as "60s" or "5m".
+	ContinuousInterval = "continuous_interval"
+)
+
+// Options for dry-run import.
+const (
+	// DryRun indicates whether to only validate the import files against the schema,
+	// the job ends after preimport without writing any data, default to false.
+	DryRun = "dry_run"
+
+	// DryRunMaxErrors limits the number of errors reported by the dry-run, default to 10.
+	DryRunMaxErrors = "dry_run_max_errors"
+
+	DefaultDryRunMaxErrors = 10
+)
+
+// Priority is the scheduling priority of the import job, an integer from MinPriority to MaxPriority,
+// the tasks of the jobs with higher priority are scheduled first, default to DefaultPriority.
+const (
+	Priority = "priority"
+
+	MinPriority     = 0
+	MaxPriority     = 10
+	DefaultPriority = 5
+)
+
 // AutoCommitKey is the option key for enabling/disabling auto-commit of import jobs.
 const AutoCommitKey = "auto_commit"
 
@@ -140,6 +206,71 @@ func IsL0Import(options Options) bool {
 	return true
 }
 
+func IsContinuous(options Options) bool {
+	continuous, err := funcutil.GetAttrByKeyFromRepeatedKV(Continuous, options)
+	if err != nil || strings.ToLower(continuous) != "true" {
+		return false
+	}
+	return true
+}
+
+// GetContinuousInterval returns the scan interval of continuous import,
+// it returns 0 if the interval is not specified.
+func GetContinuousInterval(options Options) (time.Duration, error) {
+	intervalStr, err := funcutil.GetAttrByKeyFromRepeatedKV(ContinuousInterval, options)
+	if err != nil {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return 0, merr.WrapErrImportFailedMsg("parse %s failed, value=%s, err=%s", ContinuousInterval, intervalStr, err)
+	}
+	if interval <= 0 {
+		return 0, merr.WrapErrImportFailedMsg("%s must be positive, value=%s", ContinuousInterval, intervalStr)
+	}
+	return interval, nil
+}
+
+func IsDryRun(options Options) bool {
+	dryRun, err := funcutil.GetAttrByKeyFromRepeatedKV(DryRun, options)
+	if err != nil || strings.ToLower(dryRun) != "true" {
+		return false
+	}
+	return true
+}
+
+// GetDryRunMaxErrors returns the max number of errors reported by the dry-run.
+func GetDryRunMaxErrors(options Options) (int, error) {
+	maxErrorsStr, err := funcutil.GetAttrByKeyFromRepeatedKV(DryRunMaxErrors, options)
+	if err != nil {
+		return DefaultDryRunMaxErrors, nil
+	}
+	maxErrors, err := strconv.Atoi(maxErrorsStr)
+	if err != nil {
+		return 0, merr.WrapErrImportFailedMsg("parse %s failed, value=%s, err=%s", DryRunMaxErrors, maxErrorsStr, err)
+	}
+	if maxErrors <= 0 {
+		return 0, merr.WrapErrImportFailedMsg("%s must be positive, value=%s", DryRunMaxErrors, maxErrorsStr)
+	}
+	return maxErrors, nil
+}
+
+func GetPriority(options Options) (int, error) {
+	priorityStr, err := funcutil.GetAttrByKeyFromRepeatedKV(Priority, options)
+	if err != nil {
+		return DefaultPriority, nil
+	}
+	priority, err := strconv.Atoi(priorityStr)
+	if err != nil {
+		return 0, merr.WrapErrImportFailedMsg("parse %s failed, value=%s, err=%s", Priority, priorityStr, err)
+	}
+	if priority < MinPriority || priority > MaxPriority {
+		return 0, merr.WrapErrImportFailedMsg("%s must be in range [%d, %d], value=%s",
+			Priority, MinPriority, MaxPriority, priorityStr)
+	}
+	return priority, nil
+}
+
 func GetStorageVersion(options Options) (int64, error) {
 	storageVersion, err := funcutil.GetAttrByKeyFromRepeatedKV(StorageVersion, options)
 	if err != nil {
@@ -204,6 +335,38 @@ func GetEZK(options Options) (string, error) {
 	return ezk, nil
 }
 
+// GetImportMode parses the mode option. Defaults to "insert" if absent.
+func GetImportMode(options Options) (string, error) {
+	mode, err := funcutil.GetAttrByKeyFromRepeatedKV(Mode, options)
+	if err != nil || len(mode) == 0 {
+		return ModeInsert, nil
+	}
+	mode = strings.ToLower(mode)
+	switch mode {
+	case ModeInsert:
+		return mode, nil
+	case ModeUpsert, ModeDelete:
+		if IsBackup(options) || IsL0Import(options) {
+			return "", merr.WrapErrImportFailedMsg("import mode %s is not allowed for backup or l0 import", mode)
+		}
+		return mode, nil
+	default:
+		return "", merr.WrapErrImportFailedMsg("unsupported import mode: %s", mode)
+	}
+}
+
+// IsUpsertMode indicates whether the import replaces the existing entities with the same primary keys.
+func IsUpsertMode(options Options) bool {
+	mode, err := GetImportMode(options)
+	return err == nil && mode == ModeUpsert
+}
+
+// IsDeleteMode indicates whether the import deletes the entities whose primary keys are listed in the files.
+func IsDeleteMode(options Options) bool {
+	mode, err := GetImportMode(options)
+	return err == nil && mode == ModeDelete
+}
+
 // IsAutoCommit parses the auto_commit option. Defaults to true if absent.
 func IsAutoCommit(options Options) bool {
 	val, err := funcutil.GetAttrByKeyFromRepeatedKV(AutoCommitKey, options)
@@ -208,6 +208,107 @@ func TestOption_GetStorageVersion(t *testing.T) {
 	assert.Equal(t, int64(2), version) // StorageV2 = 2
 }
 
+func TestOption_GetImportMode(t *testing.T) {
+	mode, err := GetImportMode(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, ModeInsert, mode)
+
+	options := []*commonpb.KeyValuePair{{Key: Mode, Value: "Upsert"}}
+	mode, err = GetImportMode(options)
+	assert.NoError(t, err)
+	assert.Equal(t, ModeUpsert, mode)
+	assert.True(t, IsUpsertMode(options))
+	assert.False(t, IsDeleteMode(options))
+
+	options = []*commonpb.KeyValuePair{{Key: Mode, Value: "delete"}}
+	mode, err = GetImportMode(options)
+	assert.NoError(t, err)
+	assert.Equal(t, ModeDelete, mode)
+	assert.True(t, IsDeleteMode(options))
+
+	options = []*commonpb.KeyValuePair{{Key: Mode, Value: "merge"}}
+	_, err = GetImportMode(options)
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+	assert.False(t, IsUpsertMode(options))
+
+	options = []*commonpb.KeyValuePair{
+		{Key: Mode, Value: "upsert"},
+		{Key: BackupFlag, Value: "true"},
+	}
+	_, err = GetImportMode(options)
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+
+	options = []*commonpb.KeyValuePair{
+		{Key: Mode, Value: "delete"},
+		{Key: L0Import, Value: "true"},
+	}
+	_, err = GetImportMode(options)
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+}
+
+func TestOption_Continuous(t *testing.T) {
+	assert.False(t, IsContinuous(nil))
+	interval, err := GetContinuousInterval(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+
+	options := []*commonpb.KeyValuePair{
+		{Key: Continuous, Value: "True"},
+		{Key: ContinuousInterval, Value: "5m"},
+	}
+	assert.True(t, IsContinuous(options))
+	interval, err = GetContinuousInterval(options)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, interval)
+
+	options = []*commonpb.KeyValuePair{{Key: ContinuousInterval, Value: "abc"}}
+	_, err = GetContinuousInterval(options)
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+
+	options = []*commonpb.KeyValuePair{{Key: ContinuousInterval, Value: "-1s"}}
+	_, err = GetContinuousInterval(options)
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+}
+
+func TestOption_DryRun(t *testing.T) {
+	assert.False(t, IsDryRun(nil))
+	maxErrors, err := GetDryRunMaxErrors(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDryRunMaxErrors, maxErrors)
+
+	options := []*commonpb.KeyValuePair{
+		{Key: DryRun, Value: "true"},
+		{Key: DryRunMaxErrors, Value: "5"},
+	}
+	assert.True(t, IsDryRun(options))
+	maxErrors, err = GetDryRunMaxErrors(options)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, maxErrors)
+
+	options = []*commonpb.KeyValuePair{{Key: DryRunMaxErrors, Value: "abc"}}
+	_, err = GetDryRunMaxErrors(options)
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+
+	options = []*commonpb.KeyValuePair{{Key: DryRunMaxErrors, Value: "0"}}
+	_, err = GetDryRunMaxErrors(options)
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+}
+
+func TestOption_Priority(t *testing.T) {
+	priority, err := GetPriority(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPriority, priority)
+
+	priority, err = GetPriority([]*commonpb.KeyValuePair{{Key: Priority, Value: "9"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 9, priority)
+
+	_, err = GetPriority([]*commonpb.KeyValuePair{{Key: Priority, Value: "high"}})
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+	_, err = GetPriority([]*commonpb.KeyValuePair{{Key: Priority, Value: "11"}})
+	assert.ErrorIs(t, err, merr.ErrImportFailed)
+}
+
 func TestSimple(t *testing.T) {
 	// Simple test to verify the test environment works
 	assert.Equal(t, 1, 1)
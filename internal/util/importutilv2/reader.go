@@ -67,6 +67,27 @@ func NewReader(ctx context.Context,
 		return binlog.NewReader(ctx, cm, schema, storageConfig, storageVersion, paths, tsStart, tsEnd, bufferSize, importEz)
 	}
 
+	transform, err := NewColumnTransform(schema, options)
+	if err != nil {
+		return nil, err
+	}
+	if transform == nil {
+		return newFormatReader(ctx, cm, schema, importFile, options, bufferSize)
+	}
+	reader, err := newFormatReader(ctx, cm, transform.ReaderSchema(schema), importFile, options, bufferSize)
+	if err != nil {
+		return nil, err
+	}
+	return &transformReader{Reader: reader, transform: transform}, nil
+}
+
+func newFormatReader(ctx context.Context,
+	cm storage.ChunkManager,
+	schema *schemapb.CollectionSchema,
+	importFile *internalpb.ImportFile,
+	options Options,
+	bufferSize int,
+) (Reader, error) {
 	fileType, err := GetFileType(importFile)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,479 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importutilv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// castMaxLength is the max length of the string values which are converted by type casts.
+const castMaxLength = 65535
+
+// ColumnTransform adapts the columns of the import files to the collection schema.
+//
+// Column renaming and dropping of unknown columns are done by rewriting the schema
+// used by the format readers, so that they take effect before the rows are validated.
+// Constants and type casts are applied on the data returned by the format readers.
+type ColumnTransform struct {
+	mapping        map[string]string               // field name -> source column name
+	constants      map[int64]any                   // field id -> constant value
+	constantFields map[int64]*schemapb.FieldSchema // field id -> field which has constant value
+	casts          map[int64]*schemapb.FieldSchema // field id -> field which values are read as strings
+	dropUnknown    bool
+}
+
+// NewColumnTransform parses the column transform options,
+// it returns nil if none of these options is specified.
+func NewColumnTransform(schema *schemapb.CollectionSchema, options Options) (*ColumnTransform, error) {
+	mappingStr, _ := funcutil.GetAttrByKeyFromRepeatedKV(ColumnMapping, options)
+	constantsStr, _ := funcutil.GetAttrByKeyFromRepeatedKV(ConstantColumns, options)
+	castsStr, _ := funcutil.GetAttrByKeyFromRepeatedKV(TypeCasts, options)
+	dropUnknownStr, _ := funcutil.GetAttrByKeyFromRepeatedKV(DropUnknownColumns, options)
+	dropUnknown := strings.ToLower(dropUnknownStr) == "true"
+	if len(mappingStr) == 0 && len(constantsStr) == 0 && len(castsStr) == 0 && !dropUnknown {
+		return nil, nil
+	}
+
+	name2Field := lo.KeyBy(schema.GetFields(), func(field *schemapb.FieldSchema) string {
+		return field.GetName()
+	})
+	getField := func(option string, name string) (*schemapb.FieldSchema, error) {
+		field, ok := name2Field[name]
+		if !ok || field.GetIsDynamic() {
+			return nil, merr.WrapErrImportFailedMsg("field '%s' in option '%s' is not found in schema", name, option)
+		}
+		if field.GetIsFunctionOutput() {
+			return nil, merr.WrapErrImportFailedMsg("not allowed to set function output field '%s' in option '%s'", name, option)
+		}
+		return field, nil
+	}
+
+	t := &ColumnTransform{
+		mapping:        make(map[string]string),
+		constants:      make(map[int64]any),
+		constantFields: make(map[int64]*schemapb.FieldSchema),
+		casts:          make(map[int64]*schemapb.FieldSchema),
+		dropUnknown:    dropUnknown,
+	}
+
+	if len(mappingStr) > 0 {
+		mapping := make(map[string]string)
+		if err := json.Unmarshal([]byte(mappingStr), &mapping); err != nil {
+			return nil, merr.WrapErrImportFailedMsg("parse %s failed, value=%s, err=%s", ColumnMapping, mappingStr, err)
+		}
+		for column, fieldName := range mapping {
+			if _, err := getField(ColumnMapping, fieldName); err != nil {
+				return nil, err
+			}
+			if _, ok := t.mapping[fieldName]; ok {
+				return nil, merr.WrapErrImportFailedMsg("field '%s' is mapped by multiple columns", fieldName)
+			}
+			t.mapping[fieldName] = column
+		}
+		// the renamed fields must not conflict with each other
+		columns := make(map[string]string)
+		for _, field := range schema.GetFields() {
+			column := t.columnName(field.GetName())
+			if other, ok := columns[column]; ok {
+				return nil, merr.WrapErrImportFailedMsg("column '%s' is ambiguous for field '%s' and '%s'", column, other, field.GetName())
+			}
+			columns[column] = field.GetName()
+		}
+	}
+
+	if len(constantsStr) > 0 {
+		constants := make(map[string]any)
+		dec := json.NewDecoder(strings.NewReader(constantsStr))
+		dec.UseNumber()
+		if err := dec.Decode(&constants); err != nil {
+			return nil, merr.WrapErrImportFailedMsg("parse %s failed, value=%s, err=%s", ConstantColumns, constantsStr, err)
+		}
+		for fieldName, value := range constants {
+			field, err := getField(ConstantColumns, fieldName)
+			if err != nil {
+				return nil, err
+			}
+			if field.GetIsPrimaryKey() {
+				return nil, merr.WrapErrImportFailedMsg("not allowed to set constant value for primary key '%s'", fieldName)
+			}
+			v, err := parseConstant(field, value)
+			if err != nil {
+				return nil, err
+			}
+			t.constants[field.GetFieldID()] = v
+			t.constantFields[field.GetFieldID()] = field
+		}
+	}
+
+	if len(castsStr) > 0 {
+		casts := make(map[string]string)
+		if err := json.Unmarshal([]byte(castsStr), &casts); err != nil {
+			return nil, merr.WrapErrImportFailedMsg("parse %s failed, value=%s, err=%s", TypeCasts, castsStr, err)
+		}
+		for fieldName, target := range casts {
+			field, err := getField(TypeCasts, fieldName)
+			if err != nil {
+				return nil, err
+			}
+			if !strings.EqualFold(field.GetDataType().String(), target) {
+				return nil, merr.WrapErrImportFailedMsg("cast field '%s' to '%s' is not allowed, the field type is '%s'",
+					fieldName, target, field.GetDataType().String())
+			}
+			if typeutil.IsAutoPKField(field) {
+				return nil, merr.WrapErrImportFailedMsg("not allowed to cast auto-generated primary key '%s'", fieldName)
+			}
+			switch field.GetDataType() {
+			case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32,
+				schemapb.DataType_Int64, schemapb.DataType_Float, schemapb.DataType_Double:
+				t.casts[field.GetFieldID()] = field
+			case schemapb.DataType_VarChar, schemapb.DataType_String, schemapb.DataType_JSON:
+				// string values are natively accepted by all the format readers
+			default:
+				return nil, merr.WrapErrImportFailedMsg("cast to '%s' is not supported, field '%s'", target, fieldName)
+			}
+		}
+	}
+	return t, nil
+}
+
+func (t *ColumnTransform) columnName(fieldName string) string {
+	if column, ok := t.mapping[fieldName]; ok {
+		return column
+	}
+	return fieldName
+}
+
+// ReaderSchema returns the schema used by the format readers.
+func (t *ColumnTransform) ReaderSchema(schema *schemapb.CollectionSchema) *schemapb.CollectionSchema {
+	readerSchema := typeutil.Clone(schema)
+	fields := make([]*schemapb.FieldSchema, 0, len(readerSchema.GetFields()))
+	for _, field := range readerSchema.GetFields() {
+		if field.GetIsDynamic() && t.dropUnknown {
+			continue
+		}
+		field.Name = t.columnName(field.GetName())
+		if _, ok := t.constants[field.GetFieldID()]; ok {
+			// the data of constant fields is replaced after reading, allow it to be absent
+			field.Nullable = true
+			field.DefaultValue = nil
+		}
+		if _, ok := t.casts[field.GetFieldID()]; ok {
+			// read the values as strings, and convert them after reading
+			field.Nullable = field.GetNullable() || field.GetDefaultValue() != nil
+			field.DefaultValue = nil
+			field.DataType = schemapb.DataType_VarChar
+			field.TypeParams = []*commonpb.KeyValuePair{
+				{Key: common.MaxLengthKey, Value: strconv.Itoa(castMaxLength)},
+			}
+		}
+		fields = append(fields, field)
+	}
+	readerSchema.Fields = fields
+	if t.dropUnknown {
+		readerSchema.EnableDynamicField = false
+	}
+	return readerSchema
+}
+
+// Apply converts the casted fields and fills the constant fields of the data read.
+func (t *ColumnTransform) Apply(data *storage.InsertData) error {
+	for fieldID, field := range t.casts {
+		if _, ok := t.constants[fieldID]; ok {
+			continue
+		}
+		fieldData, ok := data.Data[fieldID]
+		if !ok || fieldData == nil {
+			continue
+		}
+		strData, ok := fieldData.(*storage.StringFieldData)
+		if !ok {
+			return merr.WrapErrImportSysFailedMsg("unexpected data type '%T' of casted field '%s'", fieldData, field.GetName())
+		}
+		casted, err := castStringData(field, strData)
+		if err != nil {
+			return err
+		}
+		data.Data[fieldID] = casted
+	}
+
+	if len(t.constants) == 0 {
+		return nil
+	}
+	rowNum := 0
+	for fieldID, fieldData := range data.Data {
+		if _, ok := t.constants[fieldID]; !ok && fieldData != nil && fieldData.RowNum() > rowNum {
+			rowNum = fieldData.RowNum()
+		}
+	}
+	for fieldID, value := range t.constants {
+		fieldData, err := newConstantFieldData(t.constantFields[fieldID], value, rowNum)
+		if err != nil {
+			return err
+		}
+		data.Data[fieldID] = fieldData
+	}
+	return nil
+}
+
+// parseConstant converts the JSON value to the row value of the field.
+func parseConstant(field *schemapb.FieldSchema, value any) (any, error) {
+	wrapErr := func() error {
+		return merr.WrapErrImportFailedMsg("illegal constant value '%v' for field '%s' of type '%s'",
+			value, field.GetName(), field.GetDataType().String())
+	}
+	parseInt := func(bits int) (int64, error) {
+		num, ok := value.(json.Number)
+		if !ok {
+			return 0, wrapErr()
+		}
+		v, err := strconv.ParseInt(num.String(), 10, bits)
+		if err != nil {
+			return 0, wrapErr()
+		}
+		return v, nil
+	}
+	parseFloat := func() (float64, error) {
+		num, ok := value.(json.Number)
+		if !ok {
+			return 0, wrapErr()
+		}
+		v, err := num.Float64()
+		if err != nil {
+			return 0, wrapErr()
+		}
+		return v, nil
+	}
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		v, ok := value.(bool)
+		if !ok {
+			return nil, wrapErr()
+		}
+		return v, nil
+	case schemapb.DataType_Int8:
+		v, err := parseInt(8)
+		return int8(v), err
+	case schemapb.DataType_Int16:
+		v, err := parseInt(16)
+		return int16(v), err
+	case schemapb.DataType_Int32:
+		v, err := parseInt(32)
+		return int32(v), err
+	case schemapb.DataType_Int64:
+		return parseInt(64)
+	case schemapb.DataType_Float:
+		v, err := parseFloat()
+		if err != nil {
+			return nil, err
+		}
+		if math.Abs(v) > math.MaxFloat32 {
+			return nil, wrapErr()
+		}
+		return float32(v), nil
+	case schemapb.DataType_Double:
+		return parseFloat()
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		v, ok := value.(string)
+		if !ok {
+			return nil, wrapErr()
+		}
+		maxLength, err := parameterMaxLength(field)
+		if err != nil {
+			return nil, err
+		}
+		if maxLength > 0 && int64(len(v)) > maxLength {
+			return nil, merr.WrapErrImportFailedMsg("constant value length(%d) for field %s exceeds max_length(%d)",
+				len(v), field.GetName(), maxLength)
+		}
+		return v, nil
+	case schemapb.DataType_JSON:
+		if str, ok := value.(string); ok {
+			var dummy any
+			if err := json.Unmarshal([]byte(str), &dummy); err != nil {
+				return nil, wrapErr()
+			}
+			return []byte(str), nil
+		}
+		bs, err := json.Marshal(value)
+		if err != nil {
+			return nil, wrapErr()
+		}
+		return bs, nil
+	default:
+		return nil, merr.WrapErrImportFailedMsg("constant value is not supported for field '%s' of type '%s'",
+			field.GetName(), field.GetDataType().String())
+	}
+}
+
+func parameterMaxLength(field *schemapb.FieldSchema) (int64, error) {
+	maxLengthStr, err := funcutil.GetAttrByKeyFromRepeatedKV(common.MaxLengthKey, field.GetTypeParams())
+	if err != nil {
+		return 0, nil
+	}
+	maxLength, err := strconv.ParseInt(maxLengthStr, 10, 64)
+	if err != nil {
+		return 0, merr.WrapErrImportFailedMsg("invalid max_length '%s' of field '%s'", maxLengthStr, field.GetName())
+	}
+	return maxLength, nil
+}
+
+func newConstantFieldData(field *schemapb.FieldSchema, value any, rowNum int) (storage.FieldData, error) {
+	fieldData, err := storage.NewFieldData(field.GetDataType(), field, rowNum)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < rowNum; i++ {
+		if err = fieldData.AppendRow(value); err != nil {
+			return nil, err
+		}
+	}
+	return fieldData, nil
+}
+
+// castStringData converts the string values read from the import file to the field type.
+// Null values are replaced by the default value of the field if it has one.
+func castStringData(field *schemapb.FieldSchema, strData *storage.StringFieldData) (storage.FieldData, error) {
+	fieldData, err := storage.NewFieldData(field.GetDataType(), field, len(strData.Data))
+	if err != nil {
+		return nil, err
+	}
+	defaultValue := field.GetDefaultValue()
+	for i, str := range strData.Data {
+		if len(strData.ValidData) > 0 && !strData.ValidData[i] {
+			if defaultValue == nil {
+				if !field.GetNullable() {
+					return nil, merr.WrapErrImportFailedMsg("null value is not allowed for field '%s'", field.GetName())
+				}
+				if err = fieldData.AppendRow(nil); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			str = defaultValueString(field)
+		}
+		row, err := castString(field, strings.TrimSpace(str))
+		if err != nil {
+			return nil, err
+		}
+		if err = fieldData.AppendRow(row); err != nil {
+			return nil, err
+		}
+	}
+	return fieldData, nil
+}
+
+func defaultValueString(field *schemapb.FieldSchema) string {
+	defaultValue := field.GetDefaultValue()
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		return strconv.FormatBool(defaultValue.GetBoolData())
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		return strconv.FormatInt(int64(defaultValue.GetIntData()), 10)
+	case schemapb.DataType_Int64:
+		return strconv.FormatInt(defaultValue.GetLongData(), 10)
+	case schemapb.DataType_Float:
+		return strconv.FormatFloat(float64(defaultValue.GetFloatData()), 'g', -1, 32)
+	case schemapb.DataType_Double:
+		return strconv.FormatFloat(defaultValue.GetDoubleData(), 'g', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func castString(field *schemapb.FieldSchema, str string) (any, error) {
+	wrapErr := func(err error) error {
+		return merr.WrapErrImportFailedMsg("failed to cast '%s' to '%s' for field '%s', err=%v",
+			str, field.GetDataType().String(), field.GetName(), err)
+	}
+	parseInt := func(bits int) (int64, error) {
+		v, err := strconv.ParseInt(str, 10, bits)
+		if err != nil {
+			return 0, wrapErr(err)
+		}
+		return v, nil
+	}
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		return v, nil
+	case schemapb.DataType_Int8:
+		v, err := parseInt(8)
+		return int8(v), err
+	case schemapb.DataType_Int16:
+		v, err := parseInt(16)
+		return int16(v), err
+	case schemapb.DataType_Int32:
+		v, err := parseInt(32)
+		return int32(v), err
+	case schemapb.DataType_Int64:
+		return parseInt(64)
+	case schemapb.DataType_Float:
+		v, err := strconv.ParseFloat(str, 32)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		if err = typeutil.VerifyFloat(v); err != nil {
+			return nil, wrapErr(err)
+		}
+		return float32(v), nil
+	case schemapb.DataType_Double:
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		if err = typeutil.VerifyFloat(v); err != nil {
+			return nil, wrapErr(err)
+		}
+		return v, nil
+	default:
+		return nil, wrapErr(fmt.Errorf("unsupported cast"))
+	}
+}
+
+// transformReader applies the column transform on the data read by the format reader.
+type transformReader struct {
+	Reader
+	transform *ColumnTransform
+}
+
+func (r *transformReader) Read() (*storage.InsertData, error) {
+	data, err := r.Reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.transform.Apply(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,134 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importutilv2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+)
+
+func newTransformTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		EnableDynamicField: true,
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "age", DataType: schemapb.DataType_Int32},
+			{
+				FieldID: 102, Name: "score", DataType: schemapb.DataType_Double,
+				DefaultValue: &schemapb.ValueField{Data: &schemapb.ValueField_DoubleData{DoubleData: 1.5}},
+			},
+			{
+				FieldID: 103, Name: "tag", DataType: schemapb.DataType_VarChar,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "8"}},
+			},
+			{FieldID: 104, Name: "$meta", DataType: schemapb.DataType_JSON, IsDynamic: true},
+		},
+	}
+}
+
+func TestColumnTransform_Options(t *testing.T) {
+	schema := newTransformTestSchema()
+
+	transform, err := NewColumnTransform(schema, Options{})
+	assert.NoError(t, err)
+	assert.Nil(t, transform)
+
+	cases := []struct {
+		key   string
+		value string
+		ok    bool
+	}{
+		{ColumnMapping, `{"id": "pk"}`, true},
+		{ColumnMapping, `{"id": "xxx"}`, false},
+		{ColumnMapping, `{"id": "$meta"}`, false},
+		{ColumnMapping, `{"a": "pk", "b": "pk"}`, false},
+		{ColumnMapping, `{"age": "pk"}`, false},
+		{ColumnMapping, `abc`, false},
+		{ConstantColumns, `{"tag": "abc"}`, true},
+		{ConstantColumns, `{"tag": "abcdefghijk"}`, false},
+		{ConstantColumns, `{"age": 10}`, true},
+		{ConstantColumns, `{"age": 1.5}`, false},
+		{ConstantColumns, `{"age": 10000000000}`, false},
+		{ConstantColumns, `{"pk": 1}`, false},
+		{TypeCasts, `{"age": "Int32"}`, true},
+		{TypeCasts, `{"age": "int64"}`, false},
+		{TypeCasts, `{"tag": "varchar"}`, true},
+		{TypeCasts, `{"xxx": "int64"}`, false},
+	}
+	for _, c := range cases {
+		_, err = NewColumnTransform(schema, Options{{Key: c.key, Value: c.value}})
+		assert.Equal(t, c.ok, err == nil, "%s=%s", c.key, c.value)
+	}
+}
+
+func TestColumnTransform_ReaderSchema(t *testing.T) {
+	schema := newTransformTestSchema()
+	transform, err := NewColumnTransform(schema, Options{
+		{Key: ColumnMapping, Value: `{"id": "pk"}`},
+		{Key: ConstantColumns, Value: `{"tag": "abc"}`},
+		{Key: TypeCasts, Value: `{"age": "int32", "score": "double"}`},
+		{Key: DropUnknownColumns, Value: "true"},
+	})
+	assert.NoError(t, err)
+
+	readerSchema := transform.ReaderSchema(schema)
+	assert.False(t, readerSchema.GetEnableDynamicField())
+	assert.Equal(t, 4, len(readerSchema.GetFields()))
+	assert.Equal(t, "id", readerSchema.GetFields()[0].GetName())
+	assert.Equal(t, schemapb.DataType_VarChar, readerSchema.GetFields()[1].GetDataType())
+	assert.False(t, readerSchema.GetFields()[1].GetNullable())
+	assert.Equal(t, schemapb.DataType_VarChar, readerSchema.GetFields()[2].GetDataType())
+	assert.True(t, readerSchema.GetFields()[2].GetNullable())
+	assert.Nil(t, readerSchema.GetFields()[2].GetDefaultValue())
+	assert.True(t, readerSchema.GetFields()[3].GetNullable())
+
+	// the original schema is untouched
+	assert.Equal(t, "pk", schema.GetFields()[0].GetName())
+	assert.Equal(t, schemapb.DataType_Int32, schema.GetFields()[1].GetDataType())
+}
+
+func TestColumnTransform_Apply(t *testing.T) {
+	schema := newTransformTestSchema()
+	transform, err := NewColumnTransform(schema, Options{
+		{Key: ConstantColumns, Value: `{"tag": "abc"}`},
+		{Key: TypeCasts, Value: `{"age": "int32", "score": "double"}`},
+	})
+	assert.NoError(t, err)
+
+	data := &storage.InsertData{Data: map[int64]storage.FieldData{
+		100: &storage.Int64FieldData{Data: []int64{1, 2}},
+		101: &storage.StringFieldData{Data: []string{"10", " 20 "}},
+		102: &storage.StringFieldData{Data: []string{"", "2.5"}, ValidData: []bool{false, true}, Nullable: true},
+	}}
+	assert.NoError(t, transform.Apply(data))
+	assert.Equal(t, []int32{10, 20}, data.Data[101].(*storage.Int32FieldData).Data)
+	assert.Equal(t, []float64{1.5, 2.5}, data.Data[102].(*storage.DoubleFieldData).Data)
+	assert.Equal(t, []string{"abc", "abc"}, data.Data[103].(*storage.StringFieldData).Data)
+
+	data = &storage.InsertData{Data: map[int64]storage.FieldData{
+		100: &storage.Int64FieldData{Data: []int64{1}},
+		101: &storage.StringFieldData{Data: []string{"abc"}},
+		102: &storage.StringFieldData{Data: []string{"1"}},
+	}}
+	assert.Error(t, transform.Apply(data))
+}
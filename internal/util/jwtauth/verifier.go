@@ -0,0 +1,324 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jwtauth verifies JWT bearer tokens, e.g. the access tokens issued by an OIDC provider,
+// against a JSON Web Key Set and maps their claims to a Milvus user and roles.
+package jwtauth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/util"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const (
+	// minReloadInterval limits how often an unknown key id triggers the reload of the key set.
+	minReloadInterval = 30 * time.Second
+	fetchTimeout      = 10 * time.Second
+	maxKeySetSize     = 1 << 20
+)
+
+// Config is the configuration of the Verifier.
+type Config struct {
+	// Issuer is checked against the iss claim, it is required so that the tokens of other issuers
+	// sharing the key set are rejected. Audience is checked against the aud claim if not empty.
+	Issuer   string
+	Audience string
+	// Algorithms are the accepted signature algorithms.
+	Algorithms []string
+	// JWKSSource is the local file path or the http(s) url of the key set.
+	JWKSSource      string
+	RefreshInterval time.Duration
+	// UsernameClaim and RolesClaim are the claim names, nested claims are separated by dots.
+	UsernameClaim string
+	RolesClaim    string
+	// RoleMapping returns the mapping from the lowercase claim values to the comma separated Milvus roles,
+	// no role is granted from the claim if it is empty.
+	RoleMapping func() map[string]string
+}
+
+// Identity is the Milvus user and roles of a verified token.
+type Identity struct {
+	Username string
+	Roles    []string
+}
+
+// Verifier verifies JWT tokens against a key set which is reloaded periodically.
+type Verifier struct {
+	cfg        Config
+	algorithms []jose.SignatureAlgorithm
+	client     *http.Client
+
+	mu         sync.RWMutex
+	keySet     *jose.JSONWebKeySet
+	lastReload time.Time
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewVerifier creates the verifier and loads the key set, the key set is refreshed in background
+// until Close is called if the refresh interval is positive.
+func NewVerifier(cfg Config) (*Verifier, error) {
+	if cfg.JWKSSource == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("the jwks source of jwt authentication is not configured")
+	}
+	if cfg.Issuer == "" {
+		return nil, merr.WrapErrParameterInvalidMsg("the issuer of jwt authentication is not configured")
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = "sub"
+	}
+	if len(cfg.Algorithms) == 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("no signature algorithm is allowed for jwt authentication")
+	}
+	algorithms := make([]jose.SignatureAlgorithm, 0, len(cfg.Algorithms))
+	for _, alg := range cfg.Algorithms {
+		algorithms = append(algorithms, jose.SignatureAlgorithm(strings.TrimSpace(alg)))
+	}
+	v := &Verifier{
+		cfg:        cfg,
+		algorithms: algorithms,
+		client:     &http.Client{Timeout: fetchTimeout},
+		closeCh:    make(chan struct{}),
+	}
+	if err := v.reload(); err != nil {
+		return nil, err
+	}
+	if cfg.RefreshInterval > 0 {
+		v.wg.Add(1)
+		go v.refreshLoop()
+	}
+	return v, nil
+}
+
+// Close stops refreshing the key set.
+func (v *Verifier) Close() {
+	v.closeOnce.Do(func() {
+		close(v.closeCh)
+	})
+	v.wg.Wait()
+}
+
+func (v *Verifier) refreshLoop() {
+	defer v.wg.Done()
+	ticker := time.NewTicker(v.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-v.closeCh:
+			return
+		case <-ticker.C:
+			if err := v.reload(); err != nil {
+				// keep using the previous key set until the source is available again
+				mlog.Warn(context.TODO(), "failed to refresh the jwks, keep using the previous one",
+					mlog.String("source", v.cfg.JWKSSource), mlog.Err(err))
+			}
+		}
+	}
+}
+
+func (v *Verifier) reload() error {
+	data, err := v.fetch()
+	if err != nil {
+		return err
+	}
+	keySet := &jose.JSONWebKeySet{}
+	if err := json.Unmarshal(data, keySet); err != nil {
+		return merr.WrapErrParameterInvalidMsg("invalid jwks from %s: %s", v.cfg.JWKSSource, err.Error())
+	}
+	if len(keySet.Keys) == 0 {
+		return merr.WrapErrParameterInvalidMsg("no key found in the jwks from %s", v.cfg.JWKSSource)
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.keySet = keySet
+	v.lastReload = time.Now()
+	return nil
+}
+
+func (v *Verifier) fetch() ([]byte, error) {
+	source := v.cfg.JWKSSource
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+	resp, err := v.client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the jwks from %s, status: %s", source, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxKeySetSize))
+}
+
+// keys returns the candidate keys of the key id, the key set is reloaded at most once
+// every minReloadInterval if the key id is unknown, in case the keys are rotated.
+func (v *Verifier) keys(kid string) []jose.JSONWebKey {
+	v.mu.RLock()
+	keySet, lastReload := v.keySet, v.lastReload
+	v.mu.RUnlock()
+	if kid == "" {
+		return keySet.Keys
+	}
+	if keys := keySet.Key(kid); len(keys) > 0 || time.Since(lastReload) < minReloadInterval {
+		return keys
+	}
+	if err := v.reload(); err != nil {
+		mlog.Warn(context.TODO(), "failed to reload the jwks for unknown key id",
+			mlog.String("kid", kid), mlog.Err(err))
+		// avoid reloading on every request while the source is unavailable
+		v.mu.Lock()
+		v.lastReload = time.Now()
+		v.mu.Unlock()
+		return nil
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.keySet.Key(kid)
+}
+
+// Verify checks the signature and the registered claims of the token, and returns the identity mapped from the claims.
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	parsed, err := jwt.ParseSigned(token, v.algorithms)
+	if err != nil {
+		return nil, merr.WrapErrPrivilegeNotAuthenticated("invalid jwt: %s", err.Error())
+	}
+	var kid string
+	if len(parsed.Headers) > 0 {
+		kid = parsed.Headers[0].KeyID
+	}
+	claims := jwt.Claims{}
+	rawClaims := map[string]any{}
+	verified := false
+	for _, key := range v.keys(kid) {
+		if err := parsed.Claims(key, &claims, &rawClaims); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, merr.WrapErrPrivilegeNotAuthenticated("the signature of jwt cannot be verified by the jwks, kid: %s", kid)
+	}
+	if claims.Expiry == nil {
+		return nil, merr.WrapErrPrivilegeNotAuthenticated("the jwt has no exp claim")
+	}
+	expected := jwt.Expected{Issuer: v.cfg.Issuer, Time: time.Now()}
+	if v.cfg.Audience != "" {
+		expected.AnyAudience = jwt.Audience{v.cfg.Audience}
+	}
+	if err := claims.Validate(expected); err != nil {
+		return nil, merr.WrapErrPrivilegeNotAuthenticated("invalid jwt claims: %s", err.Error())
+	}
+
+	username, ok := lookupClaim(rawClaims, v.cfg.UsernameClaim).(string)
+	if !ok || username == "" {
+		return nil, merr.WrapErrPrivilegeNotAuthenticated("the jwt has no string claim %s for the user name", v.cfg.UsernameClaim)
+	}
+	// the root user can only be authenticated by the password
+	if username == util.UserRoot {
+		return nil, merr.WrapErrPrivilegeNotAuthenticated("the root user cannot be authenticated by jwt")
+	}
+	identity := &Identity{Username: username}
+	if v.cfg.RolesClaim != "" {
+		identity.Roles = v.mapRoles(claimStrings(lookupClaim(rawClaims, v.cfg.RolesClaim)))
+	}
+	return identity, nil
+}
+
+func (v *Verifier) mapRoles(values []string) []string {
+	var mapping map[string]string
+	if v.cfg.RoleMapping != nil {
+		mapping = v.cfg.RoleMapping()
+	}
+	// the claim values are not trusted as the role names, only the mapped roles are granted
+	if len(mapping) == 0 {
+		return nil
+	}
+	roles := make([]string, 0, len(values))
+	seen := make(map[string]struct{})
+	for _, value := range values {
+		// the keys of the config group are lowercase
+		mapped, ok := mapping[strings.ToLower(value)]
+		if !ok {
+			continue
+		}
+		for _, role := range strings.Split(mapped, ",") {
+			role = strings.TrimSpace(role)
+			if _, ok := seen[role]; role == "" || ok {
+				continue
+			}
+			seen[role] = struct{}{}
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// lookupClaim returns the claim of the dot separated path, e.g. realm_access.roles.
+func lookupClaim(claims map[string]any, path string) any {
+	// the claim name may contain dots itself, e.g. the namespaced claims of some providers
+	if value, ok := claims[path]; ok {
+		return value
+	}
+	var current any = claims
+	for _, name := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[name]
+	}
+	return current
+}
+
+// claimStrings converts the claim to strings, a string claim is split by spaces like the scope claim.
+func claimStrings(claim any) []string {
+	switch value := claim.(type) {
+	case string:
+		return strings.Fields(value)
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// IsJWT returns whether the token is in the compact serialization of JWS, i.e. header.payload.signature.
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2 && !strings.ContainsAny(token, " :")
+}
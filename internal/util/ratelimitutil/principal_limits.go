@@ -0,0 +1,149 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitutil
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/mlog"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+)
+
+const (
+	UserPrincipalPrefix = "user:"
+	RolePrincipalPrefix = "role:"
+	// AnyUser is the user name of the limits applied to every user without the user specific limits.
+	AnyUser = "*"
+)
+
+// principalRateLimitKeys maps the suffixes of the principal properties to the rate types,
+// the suffixes are the same as the ones of the collection properties.
+var principalRateLimitKeys = map[string]internalpb.RateType{
+	"insertRate.max.mb":  internalpb.RateType_DMLInsert,
+	"deleteRate.max.mb":  internalpb.RateType_DMLDelete,
+	"searchRate.max.vps": internalpb.RateType_DQLSearch,
+	"queryRate.max.qps":  internalpb.RateType_DQLQuery,
+}
+
+// IsPrincipalRateType returns whether the rate type can be limited at the principal level.
+func IsPrincipalRateType(rt internalpb.RateType) bool {
+	switch rt {
+	case internalpb.RateType_DMLInsert, internalpb.RateType_DMLDelete,
+		internalpb.RateType_DQLSearch, internalpb.RateType_DQLQuery:
+		return true
+	default:
+		return false
+	}
+}
+
+// PrincipalLimits are the rate limits of the users and roles configured by the database properties.
+type PrincipalLimits struct {
+	users map[string]map[internalpb.RateType]float64
+	roles map[string]map[internalpb.RateType]float64
+}
+
+// PrincipalLimit is the rate of a principal which limits the request.
+type PrincipalLimit struct {
+	Principal string
+	// Configured is the principal in the database properties, which is user:* for the limit of AnyUser.
+	Configured string
+	Rate       float64
+}
+
+// ParsePrincipalLimits parses the principal rate limits from the database properties,
+// returns nil if there is no principal limit.
+func ParsePrincipalLimits(properties []*commonpb.KeyValuePair) *PrincipalLimits {
+	limits := &PrincipalLimits{
+		users: map[string]map[internalpb.RateType]float64{},
+		roles: map[string]map[internalpb.RateType]float64{},
+	}
+	for _, kv := range properties {
+		var (
+			target map[string]map[internalpb.RateType]float64
+			rest   string
+		)
+		switch {
+		case strings.HasPrefix(kv.GetKey(), common.DatabaseUserRateLimitPrefix):
+			target, rest = limits.users, strings.TrimPrefix(kv.GetKey(), common.DatabaseUserRateLimitPrefix)
+		case strings.HasPrefix(kv.GetKey(), common.DatabaseRoleRateLimitPrefix):
+			target, rest = limits.roles, strings.TrimPrefix(kv.GetKey(), common.DatabaseRoleRateLimitPrefix)
+		default:
+			continue
+		}
+		// the user and role names cannot contain dots
+		name, suffix, ok := strings.Cut(rest, ".")
+		rt, known := principalRateLimitKeys[suffix]
+		if !ok || name == "" || !known {
+			mlog.RatedWarn(context.TODO(), rate.Every(time.Minute), "unknown principal rate limit property", mlog.String("key", kv.GetKey()))
+			continue
+		}
+		value, err := strconv.ParseFloat(kv.GetValue(), 64)
+		if err != nil || value < 0 {
+			mlog.RatedWarn(context.TODO(), rate.Every(time.Minute), "invalid principal rate limit property",
+				mlog.String("key", kv.GetKey()), mlog.String("value", kv.GetValue()))
+			continue
+		}
+		if strings.HasSuffix(suffix, ".mb") {
+			value = value * 1024.0 * 1024.0
+		}
+		if target[name] == nil {
+			target[name] = map[internalpb.RateType]float64{}
+		}
+		target[name][rt] = value
+	}
+	if len(limits.users) == 0 && len(limits.roles) == 0 {
+		return nil
+	}
+	return limits
+}
+
+// Resolve returns the principals which limit the request of the rate type from the user with the roles,
+// the user specific limit takes precedence over the limit of AnyUser.
+func (l *PrincipalLimits) Resolve(user string, roles []string, rt internalpb.RateType) []PrincipalLimit {
+	if l == nil {
+		return nil
+	}
+	result := make([]PrincipalLimit, 0)
+	if user != "" {
+		configured := user
+		userLimits, ok := l.users[user]
+		if !ok {
+			configured = AnyUser
+			userLimits = l.users[AnyUser]
+		}
+		if rate, ok := userLimits[rt]; ok {
+			result = append(result, PrincipalLimit{
+				Principal:  UserPrincipalPrefix + user,
+				Configured: UserPrincipalPrefix + configured,
+				Rate:       rate,
+			})
+		}
+	}
+	for _, role := range roles {
+		if rate, ok := l.roles[role][rt]; ok {
+			result = append(result, PrincipalLimit{Principal: RolePrincipalPrefix + role, Configured: RolePrincipalPrefix + role, Rate: rate})
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus/pkg/v3/proto/internalpb"
+)
+
+func TestParsePrincipalLimits(t *testing.T) {
+	assert.Nil(t, ParsePrincipalLimits(nil))
+	assert.Nil(t, ParsePrincipalLimits([]*commonpb.KeyValuePair{
+		{Key: "database.max.collections", Value: "10"},
+		{Key: "database.user.alice.unknownRate.max.qps", Value: "10"},
+		{Key: "database.user.alice.searchRate.max.vps", Value: "-1"},
+		{Key: "database.user.alice.queryRate.max.qps", Value: "abc"},
+		{Key: "database.user..queryRate.max.qps", Value: "10"},
+	}))
+
+	limits := ParsePrincipalLimits([]*commonpb.KeyValuePair{
+		{Key: "database.user.alice.searchRate.max.vps", Value: "100"},
+		{Key: "database.user.alice.insertRate.max.mb", Value: "2"},
+		{Key: "database.user.*.searchRate.max.vps", Value: "10"},
+		{Key: "database.user.*.queryRate.max.qps", Value: "5"},
+		{Key: "database.role.tenant_a.searchRate.max.vps", Value: "1000"},
+		{Key: "database.role.blocked.deleteRate.max.mb", Value: "0"},
+	})
+
+	// the user specific limits take precedence over the ones of any user
+	assert.Equal(t, []PrincipalLimit{{Principal: "user:alice", Configured: "user:alice", Rate: 100}, {Principal: "role:tenant_a", Configured: "role:tenant_a", Rate: 1000}},
+		limits.Resolve("alice", []string{"public", "tenant_a"}, internalpb.RateType_DQLSearch))
+	assert.Equal(t, []PrincipalLimit{{Principal: "user:alice", Configured: "user:alice", Rate: 2 * 1024 * 1024}},
+		limits.Resolve("alice", nil, internalpb.RateType_DMLInsert))
+	assert.Empty(t, limits.Resolve("alice", nil, internalpb.RateType_DQLQuery))
+
+	assert.Equal(t, []PrincipalLimit{{Principal: "user:bob", Configured: "user:*", Rate: 10}},
+		limits.Resolve("bob", nil, internalpb.RateType_DQLSearch))
+	assert.Equal(t, []PrincipalLimit{{Principal: "user:bob", Configured: "user:*", Rate: 5}},
+		limits.Resolve("bob", nil, internalpb.RateType_DQLQuery))
+	assert.Equal(t, []PrincipalLimit{{Principal: "role:blocked", Configured: "role:blocked", Rate: 0}},
+		limits.Resolve("bob", []string{"blocked"}, internalpb.RateType_DMLDelete))
+
+	var noLimits *PrincipalLimits
+	assert.Empty(t, noLimits.Resolve("alice", nil, internalpb.RateType_DQLSearch))
+
+	assert.True(t, IsPrincipalRateType(internalpb.RateType_DQLSearch))
+	assert.False(t, IsPrincipalRateType(internalpb.RateType_DDLCollection))
+}
@@ -19,6 +19,7 @@ package ratelimitutil
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
@@ -47,6 +48,15 @@ type RateLimiterNode struct {
 	// children will be collections if current level is database
 	// children will be partitions if current level is collection
 	children *typeutil.ConcurrentMap[int64, *RateLimiterNode]
+
+	// principals are the user and role limiters of a database, keyed by the principal, e.g. user:alice
+	principals *typeutil.ConcurrentMap[string, *RateLimiterNode]
+	// principal is the user or role limited by the node, empty for the non-principal nodes
+	principal string
+	// rejectedAt is the last time the principal node rejected a request of the quota state
+	rejectedAt *typeutil.ConcurrentMap[milvuspb.QuotaState, time.Time]
+	// accessedAt is the unix nano of the last time the principal node was got by a request
+	accessedAt atomic.Int64
 }
 
 func NewRateLimiterNode(level internalpb.RateScope) *RateLimiterNode {
@@ -54,11 +64,22 @@ func NewRateLimiterNode(level internalpb.RateScope) *RateLimiterNode {
 		limiters:    typeutil.NewConcurrentMap[internalpb.RateType, *ratelimitutil.Limiter](),
 		quotaStates: typeutil.NewConcurrentMap[milvuspb.QuotaState, *QuotaStateInfo](),
 		children:    typeutil.NewConcurrentMap[int64, *RateLimiterNode](),
+		principals:  typeutil.NewConcurrentMap[string, *RateLimiterNode](),
+		rejectedAt:  typeutil.NewConcurrentMap[milvuspb.QuotaState, time.Time](),
 		level:       level,
 	}
 	return rln
 }
 
+// NewPrincipalRateLimiterNode returns the limiter of the user or role in a database,
+// the limiters of the rate types are registered by SetPrincipalRate.
+func NewPrincipalRateLimiterNode(principal string) *RateLimiterNode {
+	rln := NewRateLimiterNode(internalpb.RateScope_Database)
+	rln.principal = principal
+	rln.accessedAt.Store(time.Now().UnixNano())
+	return rln
+}
+
 func (rln *RateLimiterNode) Level() internalpb.RateScope {
 	return rln.level
 }
@@ -84,15 +105,31 @@ func (rln *RateLimiterNode) Cancel(rt internalpb.RateType, n int) {
 func (rln *RateLimiterNode) Check(rt internalpb.RateType, n int) error {
 	limit, rate := rln.Limit(rt, n)
 	if rate == 0 {
+		rln.markRejected(rt)
 		return rln.GetQuotaExceededError(rt)
 	}
 	if limit {
+		rln.markRejected(rt)
 		return rln.GetRateLimitError(rate)
 	}
 	return nil
 }
 
+func (rln *RateLimiterNode) markRejected(rt internalpb.RateType) {
+	if rln.principal == "" {
+		return
+	}
+	state := milvuspb.QuotaState_WriteLimited
+	if rt == internalpb.RateType_DQLSearch || rt == internalpb.RateType_DQLQuery {
+		state = milvuspb.QuotaState_ReadLimited
+	}
+	rln.rejectedAt.Insert(state, time.Now())
+}
+
 func (rln *RateLimiterNode) GetQuotaExceededError(rt internalpb.RateType) error {
+	if rln.principal != "" {
+		return merr.WrapErrServiceQuotaExceeded(fmt.Sprintf("rate type: %s, %s has no quota in database %d", rt.String(), rln.principal, rln.id))
+	}
 	switch rt {
 	case internalpb.RateType_DMLInsert, internalpb.RateType_DMLDelete, internalpb.RateType_DMLBulkLoad:
 		if stateInfo, ok := rln.quotaStates.Get(milvuspb.QuotaState_DenyToWrite); ok {
@@ -112,6 +149,9 @@ func (rln *RateLimiterNode) GetQuotaExceededError(rt internalpb.RateType) error
 }
 
 func (rln *RateLimiterNode) GetRateLimitError(rate float64) error {
+	if rln.principal != "" {
+		return merr.WrapErrServiceRateLimit(rate, fmt.Sprintf("request is rejected by the rate limit of %s in database %d, please retry later", rln.principal, rln.id))
+	}
 	return merr.WrapErrServiceRateLimit(rate, "request is rejected by grpc RateLimiter middleware, please retry later")
 }
 
@@ -166,7 +206,45 @@ func (rln *RateLimiterNode) GetID() int64 {
 	return rln.id
 }
 
-const clearInvalidNodeInterval = 1 * time.Minute
+func (rln *RateLimiterNode) GetPrincipal() string {
+	return rln.principal
+}
+
+func (rln *RateLimiterNode) GetPrincipals() *typeutil.ConcurrentMap[string, *RateLimiterNode] {
+	return rln.principals
+}
+
+// SetPrincipalRate registers or updates the limiter of the rate type.
+func (rln *RateLimiterNode) SetPrincipalRate(rt internalpb.RateType, rate float64) {
+	newLimit := ratelimitutil.Limit(rate)
+	if old, ok := rln.limiters.Get(rt); ok {
+		if old.Limit() != newLimit {
+			old.SetLimit(newLimit)
+		}
+		return
+	}
+	// use rate as burst, the same as the other levels
+	rln.limiters.Insert(rt, ratelimitutil.NewLimiter(newLimit, rate))
+}
+
+// GetRejectedStates returns the quota states of the requests rejected by the principal node since the time.
+func (rln *RateLimiterNode) GetRejectedStates(since time.Time) []milvuspb.QuotaState {
+	states := make([]milvuspb.QuotaState, 0)
+	rln.rejectedAt.Range(func(state milvuspb.QuotaState, rejectedAt time.Time) bool {
+		if rejectedAt.After(since) {
+			states = append(states, state)
+		}
+		return true
+	})
+	return states
+}
+
+const (
+	clearInvalidNodeInterval = 1 * time.Minute
+	// principalIdleTimeout is the time after which an unused principal node is removed,
+	// e.g. the user is dropped or its requests don't reach this proxy anymore.
+	principalIdleTimeout = 10 * time.Minute
+)
 
 // RateLimiterTree is implemented based on RateLimiterNode to operate multilevel rate limiters
 //
@@ -176,6 +254,7 @@ const clearInvalidNodeInterval = 1 * time.Minute
 //		-> database level
 //			-> collection level
 //				-> partition levelearl
+//			-> principal level, the users and roles in the database
 type RateLimiterTree struct {
 	root *RateLimiterNode
 	mu   sync.RWMutex
@@ -248,6 +327,20 @@ func (m *RateLimiterTree) ClearInvalidLimiterNode(req *proxypb.LimiterNode) {
 		})
 		return true
 	})
+
+	m.GetRootLimiters().GetChildren().Range(func(dbID int64, dbNode *RateLimiterNode) bool {
+		removePrincipals := make([]string, 0)
+		dbNode.GetPrincipals().Range(func(principal string, principalNode *RateLimiterNode) bool {
+			if time.Since(time.Unix(0, principalNode.accessedAt.Load())) > principalIdleTimeout {
+				removePrincipals = append(removePrincipals, principal)
+			}
+			return true
+		})
+		for _, principal := range removePrincipals {
+			dbNode.GetPrincipals().Remove(principal)
+		}
+		return true
+	})
 }
 
 func (m *RateLimiterTree) GetDatabaseLimiters(dbID int64) *RateLimiterNode {
@@ -355,3 +448,26 @@ func (m *RateLimiterTree) GetOrCreatePartitionLimiters(dbID int64, collectionID
 	collectionRateLimiters.AddChild(partitionID, partRateLimiters)
 	return partRateLimiters
 }
+
+// GetOrCreatePrincipalLimiters get limiter of the user or role in the database, or create it if it doesn't exist.
+// create a database rate limiters if db rate limiter does not exist
+// the principal limiters unused for principalIdleTimeout are removed by ClearInvalidLimiterNode
+func (m *RateLimiterTree) GetOrCreatePrincipalLimiters(dbID int64, principal string,
+	newDBRateLimiter func() *RateLimiterNode,
+) *RateLimiterNode {
+	dbRateLimiters := m.GetOrCreateDatabaseLimiters(dbID, newDBRateLimiter)
+	if cur, ok := dbRateLimiters.GetPrincipals().Get(principal); ok {
+		cur.accessedAt.Store(time.Now().UnixNano())
+		return cur
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cur, ok := dbRateLimiters.GetPrincipals().Get(principal); ok {
+		cur.accessedAt.Store(time.Now().UnixNano())
+		return cur
+	}
+	principalRateLimiters := NewPrincipalRateLimiterNode(principal)
+	principalRateLimiters.id = dbID
+	dbRateLimiters.GetPrincipals().Insert(principal, principalRateLimiters)
+	return principalRateLimiters
+}
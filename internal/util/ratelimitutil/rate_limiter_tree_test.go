@@ -214,3 +214,64 @@ func TestRateLimiterTreeClearInvalidLimiterNode(t *testing.T) {
 	assert.Equal(t, 1, root.GetChild(1).GetChildren().Len())
 	assert.Equal(t, 1, root.GetChild(1).GetChild(10).GetChildren().Len())
 }
+
+func TestRateLimiterTreePrincipalLimiters(t *testing.T) {
+	newDBLimiter := func() *RateLimiterNode { return NewRateLimiterNode(internalpb.RateScope_Database) }
+	tree := NewRateLimiterTree(NewRateLimiterNode(internalpb.RateScope_Cluster))
+
+	alice := tree.GetOrCreatePrincipalLimiters(1, "user:alice", newDBLimiter)
+	assert.Same(t, alice, tree.GetOrCreatePrincipalLimiters(1, "user:alice", newDBLimiter))
+	assert.NotSame(t, alice, tree.GetOrCreatePrincipalLimiters(2, "user:alice", newDBLimiter))
+	assert.Equal(t, "user:alice", alice.GetPrincipal())
+	assert.Equal(t, int64(1), alice.GetID())
+	// the principals are not the children of the database
+	assert.Equal(t, 0, tree.GetDatabaseLimiters(1).GetChildren().Len())
+
+	since := time.Now()
+	alice.SetPrincipalRate(internalpb.RateType_DQLSearch, 0.01)
+	assert.NoError(t, alice.Check(internalpb.RateType_DQLSearch, 1))
+	err := alice.Check(internalpb.RateType_DQLSearch, 1)
+	assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+	assert.Contains(t, err.Error(), "user:alice in database 1")
+	assert.Equal(t, []milvuspb.QuotaState{milvuspb.QuotaState_ReadLimited}, alice.GetRejectedStates(since))
+	assert.Empty(t, alice.GetRejectedStates(time.Now()))
+
+	// the rate is updated in place
+	alice.SetPrincipalRate(internalpb.RateType_DQLSearch, 0)
+	limiter, ok := alice.GetLimiters().Get(internalpb.RateType_DQLSearch)
+	assert.True(t, ok)
+	assert.Equal(t, ratelimitutil.Limit(0), limiter.Limit())
+	err = alice.Check(internalpb.RateType_DQLSearch, 1)
+	assert.ErrorIs(t, err, merr.ErrServiceQuotaExceeded)
+	assert.Contains(t, err.Error(), "user:alice has no quota in database 1")
+
+	// the principals are removed with the database
+	tree.lastClearTime = time.Now().Add(-clearInvalidNodeInterval)
+	tree.ClearInvalidLimiterNode(&proxypb.LimiterNode{Children: map[int64]*proxypb.LimiterNode{2: {}}})
+	assert.Nil(t, tree.GetDatabaseLimiters(1))
+	assert.Equal(t, 1, tree.GetDatabaseLimiters(2).GetPrincipals().Len())
+}
+
+func TestRateLimiterTreeClearIdlePrincipalLimiters(t *testing.T) {
+	newDBLimiter := func() *RateLimiterNode { return NewRateLimiterNode(internalpb.RateScope_Database) }
+	tree := NewRateLimiterTree(NewRateLimiterNode(internalpb.RateScope_Cluster))
+
+	alice := tree.GetOrCreatePrincipalLimiters(1, "user:alice", newDBLimiter)
+	bob := tree.GetOrCreatePrincipalLimiters(1, "user:bob", newDBLimiter)
+	alice.accessedAt.Store(time.Now().Add(-2 * principalIdleTimeout).UnixNano())
+	bob.accessedAt.Store(time.Now().Add(-2 * principalIdleTimeout).UnixNano())
+	// bob is accessed again before the clearing
+	assert.Same(t, bob, tree.GetOrCreatePrincipalLimiters(1, "user:bob", newDBLimiter))
+
+	tree.lastClearTime = time.Now().Add(-clearInvalidNodeInterval)
+	tree.ClearInvalidLimiterNode(&proxypb.LimiterNode{Children: map[int64]*proxypb.LimiterNode{1: {}}})
+	principals := tree.GetDatabaseLimiters(1).GetPrincipals()
+	assert.Equal(t, 1, principals.Len())
+	_, ok := principals.Get("user:alice")
+	assert.False(t, ok)
+	_, ok = principals.Get("user:bob")
+	assert.True(t, ok)
+
+	// the removed principal is recreated by the next request
+	assert.NotSame(t, alice, tree.GetOrCreatePrincipalLimiters(1, "user:alice", newDBLimiter))
+}
@@ -53,6 +53,7 @@ const (
 	// All Roles
 	LabelStandalone    = "STANDALONE"
 	LabelResourceGroup = "RESOURCE_GROUP"
+	LabelZone          = "ZONE"
 )
 
 // NewServerLabel creates a new server label with the given role and label.
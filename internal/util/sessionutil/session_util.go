@@ -159,6 +159,14 @@ func (s *SessionRaw) GetResourceGroupName() string {
 	return s.ServerLabels[LabelResourceGroup]
 }
 
+// GetZone returns the zone of the session.
+func (s *SessionRaw) GetZone() string {
+	if s.ServerLabels == nil {
+		return ""
+	}
+	return s.ServerLabels[LabelZone]
+}
+
 // Session is a struct to store service's session, including ServerID, ServerName,
 // Address.
 // Exclusive indicates that this server can only start one.
@@ -300,10 +300,21 @@ const (
 	DatabaseForceDenyFlushDDLKey      = "database.force.deny.flush"
 	DatabaseForceDenyCompactionDDLKey = "database.force.deny.compaction"
 
+	// principal level rate limits of the database, e.g. database.user.alice.searchRate.max.vps,
+	// the user * applies to every user which has no user specific limit, a role limit is shared by all its users.
+	DatabaseUserRateLimitPrefix = "database.user."
+	DatabaseRoleRateLimitPrefix = "database.role."
+
 	// collection level load properties
 	CollectionReplicaNumber  = "collection.replica.number"
 	CollectionResourceGroups = "collection.resource_groups"
 
+	// CollectionSearchCacheEnabledKey enables the proxy search result cache of the collection
+	CollectionSearchCacheEnabledKey = "collection.searchCache.enabled"
+
+	// CollectionReplicaSelectionPolicyKey overrides the proxy replica selection policy for the collection
+	CollectionReplicaSelectionPolicyKey = "collection.replicaSelectionPolicy"
+
 	// CMEK related property keys, used in db and collection properties
 	EncryptionEnabledKey = "cipher.enabled"
 	EncryptionRootKeyKey = "cipher.key"
@@ -539,6 +550,31 @@ func IsPartitionKeyIsolationKvEnabled(kvs ...*commonpb.KeyValuePair) (bool, erro
 	return false, nil
 }
 
+// IsSearchCacheKvEnabled returns whether the search result cache is enabled by the collection properties.
+func IsSearchCacheKvEnabled(kvs ...*commonpb.KeyValuePair) (bool, error) {
+	for _, kv := range kvs {
+		if kv.Key == CollectionSearchCacheEnabledKey {
+			val, err := strconv.ParseBool(strings.ToLower(kv.Value))
+			if err != nil {
+				return false, merr.WrapErrParameterInvalidMsg("failed to parse %s: %v", CollectionSearchCacheEnabledKey, err)
+			}
+			return val, nil
+		}
+	}
+	return false, nil
+}
+
+// GetCollectionReplicaSelectionPolicy returns the replica selection policy of the collection properties,
+// empty means the collection uses the global policy.
+func GetCollectionReplicaSelectionPolicy(kvs ...*commonpb.KeyValuePair) string {
+	for _, kv := range kvs {
+		if kv.Key == CollectionReplicaSelectionPolicyKey {
+			return strings.ToLower(strings.TrimSpace(kv.Value))
+		}
+	}
+	return ""
+}
+
 // IsQueryModeKeyExists checks if the query_mode key exists in the key-value pairs.
 func IsQueryModeKeyExists(kvs ...*commonpb.KeyValuePair) bool {
 	for _, kv := range kvs {
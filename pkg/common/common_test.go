@@ -152,6 +152,32 @@ func TestCommonPartitionKeyIsolation(t *testing.T) {
 	})
 }
 
+func TestSearchCacheKvEnabled(t *testing.T) {
+	res, err := IsSearchCacheKvEnabled()
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	res, err = IsSearchCacheKvEnabled(&commonpb.KeyValuePair{Key: CollectionSearchCacheEnabledKey, Value: "True"})
+	assert.NoError(t, err)
+	assert.True(t, res)
+
+	res, err = IsSearchCacheKvEnabled(&commonpb.KeyValuePair{Key: CollectionSearchCacheEnabledKey, Value: "false"})
+	assert.NoError(t, err)
+	assert.False(t, res)
+
+	res, err = IsSearchCacheKvEnabled(&commonpb.KeyValuePair{Key: CollectionSearchCacheEnabledKey, Value: "invalid"})
+	assert.ErrorContains(t, err, "failed to parse "+CollectionSearchCacheEnabledKey)
+	assert.False(t, res)
+}
+
+func TestGetCollectionReplicaSelectionPolicy(t *testing.T) {
+	assert.Equal(t, "", GetCollectionReplicaSelectionPolicy())
+	assert.Equal(t, "zone_aware", GetCollectionReplicaSelectionPolicy(
+		&commonpb.KeyValuePair{Key: CollectionReplicaNumber, Value: "2"},
+		&commonpb.KeyValuePair{Key: CollectionReplicaSelectionPolicyKey, Value: " Zone_Aware "},
+	))
+}
+
 func TestNamespaceMode(t *testing.T) {
 	t.Run("default mode is partition key", func(t *testing.T) {
 		assert.Equal(t, NamespaceModePartitionKey, GetNamespaceMode())
@@ -71,6 +71,11 @@ const (
 	PreferredNodeRejectedLabel    = "rejected"
 )
 
+const (
+	ZoneAwareLocalLabel  = "local"
+	ZoneAwareRemoteLabel = "remote"
+)
+
 const (
 	UnissuedIndexTaskLabel   = "unissued"
 	InProgressIndexTaskLabel = "in-progress"
@@ -142,6 +147,7 @@ const (
 	segmentFormatLabelName         = "segment_format"
 	usernameLabelName              = "username"
 	roleNameLabelName              = "role_name"
+	principalLabelName             = "principal"
 	cacheNameLabelName             = "cache_name"
 	cacheStateLabelName            = "cache_state"
 	dataSourceLabelName            = "data_source"
@@ -187,6 +187,24 @@ var (
 			Help:      "count of cache hits/miss",
 		}, []string{nodeIDLabelName, cacheNameLabelName, cacheStateLabelName})
 
+	// ProxySearchResultCacheSize records the memory size of the cached search results in Proxy.
+	ProxySearchResultCacheSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "search_result_cache_size",
+			Help:      "memory size in bytes of the cached search results",
+		}, []string{nodeIDLabelName})
+
+	// ProxyCoalescedRequestCount records the number of requests served by the result of an identical in-flight request.
+	ProxyCoalescedRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "coalesced_request_count",
+			Help:      "count of requests served by the result of an identical in-flight request",
+		}, []string{nodeIDLabelName, functionLabelName})
+
 	// ProxyUpdateCacheLatency record the time that proxy update cache when cache miss.
 	ProxyUpdateCacheLatency = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -365,6 +383,17 @@ var (
 			nodeIDLabelName,
 		})
 
+	// ProxyZoneAwareReplicaSelectionCount records whether the zone aware replica selection selects the node in the same zone.
+	ProxyZoneAwareReplicaSelectionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "zone_aware_replica_selection_count",
+			Help:      "counter of zone aware replica selection results",
+		}, []string{
+			statusLabelName,
+		})
+
 	// ProxyShardLeaderPreferredNodeCount records preferred shard leader selection results.
 	ProxyShardLeaderPreferredNodeCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -385,6 +414,16 @@ var (
 			Help:      "count of operation executed",
 		}, []string{nodeIDLabelName, msgTypeLabelName, statusLabelName})
 
+	// ProxyPrincipalRateLimitRejectCount counts the requests rejected by the rate limits of the users and roles,
+	// which are configured by the database properties, the users limited by user:* are counted as user:*.
+	ProxyPrincipalRateLimitRejectCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "principal_rate_limit_reject_count",
+			Help:      "count of requests rejected by the rate limits of the users and roles in databases",
+		}, []string{nodeIDLabelName, databaseLabelName, principalLabelName, msgTypeLabelName})
+
 	ProxySlowQueryCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: milvusNamespace,
@@ -403,6 +442,26 @@ var (
 			Buckets:   subMsBuckets, // unit: ms
 		}, []string{nodeIDLabelName, functionLabelName})
 
+	// ProxyDQLTenantQueueLatency records the latency that the search and query requests of each database wait in the queue,
+	// it is not labeled by the user to keep the cardinality bounded.
+	ProxyDQLTenantQueueLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "dql_tenant_queue_latency",
+			Help:      "latency which search and query requests of each database wait in the queue",
+			Buckets:   subMsBuckets, // unit: ms
+		}, []string{nodeIDLabelName, databaseLabelName, functionLabelName})
+
+	// ProxyAuditLogPendingEntries records the number of the audit log entries waiting to be sealed.
+	ProxyAuditLogPendingEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "audit_log_pending_entries",
+			Help:      "number of the audit log entries waiting to be sealed into the object storage",
+		}, []string{nodeIDLabelName})
+
 	MaxInsertRate = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: milvusNamespace,
@@ -518,6 +577,8 @@ func RegisterProxy(registry *prometheus.Registry) {
 	registry.MustRegister(ProxySyncSegmentRequestLength)
 
 	registry.MustRegister(ProxyCacheStatsCounter)
+	registry.MustRegister(ProxySearchResultCacheSize)
+	registry.MustRegister(ProxyCoalescedRequestCount)
 	registry.MustRegister(ProxyUpdateCacheLatency)
 
 	registry.MustRegister(ProxySyncTimeTickLag)
@@ -544,11 +605,15 @@ func RegisterProxy(registry *prometheus.Registry) {
 	registry.MustRegister(ProxyWorkLoadScore)
 	registry.MustRegister(ProxyExecutingTotalNq)
 	registry.MustRegister(ProxyShardLeaderPreferredNodeCount)
+	registry.MustRegister(ProxyZoneAwareReplicaSelectionCount)
 	registry.MustRegister(ProxyRateLimitReqCount)
+	registry.MustRegister(ProxyPrincipalRateLimitRejectCount)
 
 	registry.MustRegister(ProxySlowQueryCount)
 	registry.MustRegister(ProxyReportValue)
 	registry.MustRegister(ProxyReqInQueueLatency)
+	registry.MustRegister(ProxyDQLTenantQueueLatency)
+	registry.MustRegister(ProxyAuditLogPendingEntries)
 
 	registry.MustRegister(MaxInsertRate)
 	registry.MustRegister(ProxyRetrySearchCount)
@@ -597,6 +662,14 @@ func CleanupProxyDBMetrics(nodeID int64, dbName string) {
 		nodeIDLabelName:   strconv.FormatInt(nodeID, 10),
 		databaseLabelName: dbName,
 	})
+	ProxyDQLTenantQueueLatency.DeletePartialMatch(prometheus.Labels{
+		nodeIDLabelName:   strconv.FormatInt(nodeID, 10),
+		databaseLabelName: dbName,
+	})
+	ProxyPrincipalRateLimitRejectCount.DeletePartialMatch(prometheus.Labels{
+		nodeIDLabelName:   strconv.FormatInt(nodeID, 10),
+		databaseLabelName: dbName,
+	})
 }
 
 func CleanupProxyCollectionMetrics(nodeID int64, dbName string, collection string) {
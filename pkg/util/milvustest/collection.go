@@ -0,0 +1,771 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvustest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const defaultPartitionName = "_default"
+
+type partition struct {
+	id        int64
+	name      string
+	loaded    bool
+	createdAt uint64
+}
+
+type index struct {
+	id        int64
+	name      string
+	fieldName string
+	params    []*commonpb.KeyValuePair
+}
+
+type collection struct {
+	id          int64
+	schema      *schemapb.CollectionSchema
+	shardsNum   int32
+	consistency commonpb.ConsistencyLevel
+	properties  []*commonpb.KeyValuePair
+	createdAt   uint64
+	updatedAt   uint64
+	loaded      bool
+	partitions  []*partition
+	indexes     []*index
+
+	rows       []*row
+	pkIndex    map[any]int
+	nextAutoID int64
+}
+
+func (c *collection) name() string {
+	return c.schema.GetName()
+}
+
+func (c *collection) field(name string) *schemapb.FieldSchema {
+	for _, field := range c.schema.GetFields() {
+		if field.GetName() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+func (c *collection) pkField() *schemapb.FieldSchema {
+	for _, field := range c.schema.GetFields() {
+		if field.GetIsPrimaryKey() {
+			return field
+		}
+	}
+	return nil
+}
+
+func (c *collection) dynamicField() *schemapb.FieldSchema {
+	for _, field := range c.schema.GetFields() {
+		if field.GetIsDynamic() {
+			return field
+		}
+	}
+	return nil
+}
+
+func (c *collection) partition(name string) *partition {
+	for _, p := range c.partitions {
+		if p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (c *collection) isPartitionLoaded(p *partition) bool {
+	return c.loaded || p.loaded
+}
+
+func (c *collection) loadState() commonpb.LoadState {
+	if c.loaded {
+		return commonpb.LoadState_LoadStateLoaded
+	}
+	for _, p := range c.partitions {
+		if p.loaded {
+			return commonpb.LoadState_LoadStateLoaded
+		}
+	}
+	return commonpb.LoadState_LoadStateNotLoad
+}
+
+// rebuildPKIndex rebuilds the primary key index after the rows are removed.
+func (c *collection) rebuildPKIndex() {
+	c.pkIndex = make(map[any]int, len(c.rows))
+	for i, r := range c.rows {
+		c.pkIndex[r.pk] = i
+	}
+}
+
+// getCollection returns the collection by name or alias, the lock shall be held by the caller.
+func (s *Server) getCollection(name string) (*collection, error) {
+	if coll, ok := s.collections[name]; ok {
+		return coll, nil
+	}
+	if target, ok := s.aliases[name]; ok {
+		if coll, ok := s.collections[target]; ok {
+			return coll, nil
+		}
+	}
+	return nil, merr.WrapErrCollectionNotFound(name)
+}
+
+func (s *Server) aliasesOf(collName string) []string {
+	var aliases []string
+	for alias, target := range s.aliases {
+		if target == collName {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+func validateSchema(schema *schemapb.CollectionSchema) error {
+	var pkCount, vectorCount int
+	names := typeutil.NewSet[string]()
+	for _, field := range schema.GetFields() {
+		if field.GetName() == "" {
+			return merr.WrapErrParameterInvalidMsg("field name shall not be empty")
+		}
+		if names.Contain(field.GetName()) {
+			return merr.WrapErrParameterInvalidMsg("duplicated field name %s", field.GetName())
+		}
+		names.Insert(field.GetName())
+		if !isSupportedType(field.GetDataType()) {
+			return merr.WrapErrParameterInvalidMsg("data type %s of field %s is not supported", field.GetDataType(), field.GetName())
+		}
+		if field.GetIsPrimaryKey() {
+			pkCount++
+			if field.GetDataType() != schemapb.DataType_Int64 && field.GetDataType() != schemapb.DataType_VarChar {
+				return merr.WrapErrParameterInvalidMsg("primary key %s shall be int64 or varchar", field.GetName())
+			}
+		}
+		if typeutil.IsVectorType(field.GetDataType()) {
+			vectorCount++
+			if field.GetNullable() {
+				return merr.WrapErrParameterInvalidMsg("nullable vector field %s is not supported", field.GetName())
+			}
+			if field.GetDataType() != schemapb.DataType_SparseFloatVector {
+				if _, err := typeutil.GetDim(field); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if pkCount != 1 {
+		return merr.WrapErrParameterInvalidMsg("schema shall have exactly one primary key, got %d", pkCount)
+	}
+	if vectorCount == 0 {
+		return merr.WrapErrParameterInvalidMsg("schema does not contain vector field")
+	}
+	return nil
+}
+
+func (s *Server) CreateCollection(ctx context.Context, req *milvuspb.CreateCollectionRequest) (*commonpb.Status, error) {
+	schema := &schemapb.CollectionSchema{}
+	if err := proto.Unmarshal(req.GetSchema(), schema); err != nil {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("failed to unmarshal schema: %s", err.Error())), nil
+	}
+	if req.GetCollectionName() == "" {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("collection name shall not be empty")), nil
+	}
+	schema.Name = req.GetCollectionName()
+	if err := validateSchema(schema); err != nil {
+		return merr.Status(err), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.collections[schema.GetName()]; ok {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("collection %s already exists", schema.GetName())), nil
+	}
+	if _, ok := s.aliases[schema.GetName()]; ok {
+		return merr.Status(merr.WrapErrAliasCollectionNameConflict("default", schema.GetName())), nil
+	}
+
+	for i, field := range schema.GetFields() {
+		field.FieldID = common.StartOfUserFieldID + int64(i)
+	}
+	if schema.GetEnableDynamicField() {
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+			FieldID:   common.StartOfUserFieldID + int64(len(schema.GetFields())),
+			Name:      common.MetaFieldName,
+			DataType:  schemapb.DataType_JSON,
+			IsDynamic: true,
+		})
+	}
+	shardsNum := req.GetShardsNum()
+	if shardsNum <= 0 {
+		shardsNum = 1
+	}
+	ts := allocTimestamp()
+	s.collections[schema.GetName()] = &collection{
+		id:          s.allocID(),
+		schema:      schema,
+		shardsNum:   shardsNum,
+		consistency: req.GetConsistencyLevel(),
+		properties:  req.GetProperties(),
+		createdAt:   ts,
+		updatedAt:   ts,
+		partitions:  []*partition{{id: s.allocID(), name: defaultPartitionName, createdAt: ts}},
+		pkIndex:     make(map[any]int),
+		nextAutoID:  1,
+	}
+	return merr.Success(), nil
+}
+
+func (s *Server) DropCollection(ctx context.Context, req *milvuspb.DropCollectionRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, ok := s.collections[req.GetCollectionName()]
+	if !ok {
+		// dropping the non-existing collection is idempotent
+		return merr.Success(), nil
+	}
+	if aliases := s.aliasesOf(coll.name()); len(aliases) > 0 {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("unable to drop the collection %s because it has associated aliases %v", coll.name(), aliases)), nil
+	}
+	delete(s.collections, coll.name())
+	return merr.Success(), nil
+}
+
+func (s *Server) HasCollection(ctx context.Context, req *milvuspb.HasCollectionRequest) (*milvuspb.BoolResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, err := s.getCollection(req.GetCollectionName())
+	return &milvuspb.BoolResponse{
+		Status: merr.Success(),
+		Value:  err == nil,
+	}, nil
+}
+
+func (s *Server) DescribeCollection(ctx context.Context, req *milvuspb.DescribeCollectionRequest) (*milvuspb.DescribeCollectionResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.DescribeCollectionResponse{Status: merr.Status(err)}, nil
+	}
+	var vchannels, pchannels []string
+	for i := int32(0); i < coll.shardsNum; i++ {
+		pchannel := fmt.Sprintf("milvustest-dml_%d", i)
+		pchannels = append(pchannels, pchannel)
+		vchannels = append(vchannels, fmt.Sprintf("%s_%dv%d", pchannel, coll.id, i))
+	}
+	return &milvuspb.DescribeCollectionResponse{
+		Status:               merr.Success(),
+		Schema:               proto.Clone(coll.schema).(*schemapb.CollectionSchema),
+		CollectionID:         coll.id,
+		CollectionName:       coll.name(),
+		VirtualChannelNames:  vchannels,
+		PhysicalChannelNames: pchannels,
+		CreatedTimestamp:     coll.createdAt,
+		ShardsNum:            coll.shardsNum,
+		Aliases:              s.aliasesOf(coll.name()),
+		ConsistencyLevel:     coll.consistency,
+		Properties:           coll.properties,
+		DbName:               "default",
+		NumPartitions:        int64(len(coll.partitions)),
+		UpdateTimestamp:      coll.updatedAt,
+	}, nil
+}
+
+func (s *Server) ShowCollections(ctx context.Context, req *milvuspb.ShowCollectionsRequest) (*milvuspb.ShowCollectionsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := lo.Keys(s.collections)
+	sort.Strings(names)
+	resp := &milvuspb.ShowCollectionsResponse{Status: merr.Success()}
+	for _, name := range names {
+		coll := s.collections[name]
+		if req.GetType() == milvuspb.ShowType_InMemory && coll.loadState() != commonpb.LoadState_LoadStateLoaded {
+			continue
+		}
+		resp.CollectionNames = append(resp.CollectionNames, name)
+		resp.CollectionIds = append(resp.CollectionIds, coll.id)
+		resp.CreatedTimestamps = append(resp.CreatedTimestamps, coll.createdAt)
+		resp.ShardsNum = append(resp.ShardsNum, coll.shardsNum)
+	}
+	return resp, nil
+}
+
+func (s *Server) RenameCollection(ctx context.Context, req *milvuspb.RenameCollectionRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, ok := s.collections[req.GetOldName()]
+	if !ok {
+		return merr.Status(merr.WrapErrCollectionNotFound(req.GetOldName())), nil
+	}
+	if _, err := s.getCollection(req.GetNewName()); err == nil || req.GetNewName() == "" {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("invalid new collection name %q", req.GetNewName())), nil
+	}
+	for alias, target := range s.aliases {
+		if target == coll.name() {
+			s.aliases[alias] = req.GetNewName()
+		}
+	}
+	delete(s.collections, coll.name())
+	coll.schema.Name = req.GetNewName()
+	s.collections[coll.name()] = coll
+	return merr.Success(), nil
+}
+
+func (s *Server) AlterCollection(ctx context.Context, req *milvuspb.AlterCollectionRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	properties := make(map[string]string)
+	for _, kv := range coll.properties {
+		properties[kv.GetKey()] = kv.GetValue()
+	}
+	for _, kv := range req.GetProperties() {
+		properties[kv.GetKey()] = kv.GetValue()
+	}
+	for _, key := range req.GetDeleteKeys() {
+		delete(properties, key)
+	}
+	coll.properties = coll.properties[:0]
+	keys := lo.Keys(properties)
+	sort.Strings(keys)
+	for _, key := range keys {
+		coll.properties = append(coll.properties, &commonpb.KeyValuePair{Key: key, Value: properties[key]})
+	}
+	coll.updatedAt = allocTimestamp()
+	return merr.Success(), nil
+}
+
+func (s *Server) GetCollectionStatistics(ctx context.Context, req *milvuspb.GetCollectionStatisticsRequest) (*milvuspb.GetCollectionStatisticsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.GetCollectionStatisticsResponse{Status: merr.Status(err)}, nil
+	}
+	return &milvuspb.GetCollectionStatisticsResponse{
+		Status: merr.Success(),
+		Stats:  []*commonpb.KeyValuePair{{Key: "row_count", Value: strconv.Itoa(len(coll.rows))}},
+	}, nil
+}
+
+func (s *Server) CreatePartition(ctx context.Context, req *milvuspb.CreatePartitionRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	if req.GetPartitionName() == "" {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("partition name shall not be empty")), nil
+	}
+	if coll.partition(req.GetPartitionName()) != nil {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("partition %s already exists", req.GetPartitionName())), nil
+	}
+	coll.partitions = append(coll.partitions, &partition{id: s.allocID(), name: req.GetPartitionName(), createdAt: allocTimestamp()})
+	return merr.Success(), nil
+}
+
+func (s *Server) DropPartition(ctx context.Context, req *milvuspb.DropPartitionRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	if req.GetPartitionName() == defaultPartitionName {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("default partition cannot be dropped")), nil
+	}
+	p := coll.partition(req.GetPartitionName())
+	if p == nil {
+		return merr.Success(), nil
+	}
+	if coll.isPartitionLoaded(p) {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("partition %s cannot be dropped before released", p.name)), nil
+	}
+	coll.partitions = lo.Without(coll.partitions, p)
+	coll.rows = lo.Filter(coll.rows, func(r *row, _ int) bool { return r.partition != p.name })
+	coll.rebuildPKIndex()
+	return merr.Success(), nil
+}
+
+func (s *Server) HasPartition(ctx context.Context, req *milvuspb.HasPartitionRequest) (*milvuspb.BoolResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.BoolResponse{Status: merr.Status(err)}, nil
+	}
+	return &milvuspb.BoolResponse{
+		Status: merr.Success(),
+		Value:  coll.partition(req.GetPartitionName()) != nil,
+	}, nil
+}
+
+func (s *Server) ShowPartitions(ctx context.Context, req *milvuspb.ShowPartitionsRequest) (*milvuspb.ShowPartitionsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.ShowPartitionsResponse{Status: merr.Status(err)}, nil
+	}
+	resp := &milvuspb.ShowPartitionsResponse{Status: merr.Success()}
+	for _, p := range coll.partitions {
+		resp.PartitionNames = append(resp.PartitionNames, p.name)
+		resp.PartitionIDs = append(resp.PartitionIDs, p.id)
+		resp.CreatedTimestamps = append(resp.CreatedTimestamps, p.createdAt)
+	}
+	return resp, nil
+}
+
+func (s *Server) GetPartitionStatistics(ctx context.Context, req *milvuspb.GetPartitionStatisticsRequest) (*milvuspb.GetPartitionStatisticsResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.GetPartitionStatisticsResponse{Status: merr.Status(err)}, nil
+	}
+	if coll.partition(req.GetPartitionName()) == nil {
+		return &milvuspb.GetPartitionStatisticsResponse{Status: merr.Status(merr.WrapErrPartitionNotFound(req.GetPartitionName()))}, nil
+	}
+	count := lo.CountBy(coll.rows, func(r *row) bool { return r.partition == req.GetPartitionName() })
+	return &milvuspb.GetPartitionStatisticsResponse{
+		Status: merr.Success(),
+		Stats:  []*commonpb.KeyValuePair{{Key: "row_count", Value: strconv.Itoa(count)}},
+	}, nil
+}
+
+func (s *Server) CreateAlias(ctx context.Context, req *milvuspb.CreateAliasRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, ok := s.collections[req.GetCollectionName()]
+	if !ok {
+		return merr.Status(merr.WrapErrCollectionNotFound(req.GetCollectionName())), nil
+	}
+	if _, ok := s.collections[req.GetAlias()]; ok {
+		return merr.Status(merr.WrapErrAliasCollectionNameConflict("default", req.GetAlias())), nil
+	}
+	if _, ok := s.aliases[req.GetAlias()]; ok {
+		return merr.Status(merr.WrapErrAliasAlreadyExist("default", req.GetAlias())), nil
+	}
+	s.aliases[req.GetAlias()] = coll.name()
+	return merr.Success(), nil
+}
+
+func (s *Server) DropAlias(ctx context.Context, req *milvuspb.DropAliasRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.aliases, req.GetAlias())
+	return merr.Success(), nil
+}
+
+func (s *Server) AlterAlias(ctx context.Context, req *milvuspb.AlterAliasRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, ok := s.collections[req.GetCollectionName()]
+	if !ok {
+		return merr.Status(merr.WrapErrCollectionNotFound(req.GetCollectionName())), nil
+	}
+	if _, ok := s.aliases[req.GetAlias()]; !ok {
+		return merr.Status(merr.WrapErrAliasNotFound("default", req.GetAlias())), nil
+	}
+	s.aliases[req.GetAlias()] = coll.name()
+	return merr.Success(), nil
+}
+
+func (s *Server) DescribeAlias(ctx context.Context, req *milvuspb.DescribeAliasRequest) (*milvuspb.DescribeAliasResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	target, ok := s.aliases[req.GetAlias()]
+	if !ok {
+		return &milvuspb.DescribeAliasResponse{Status: merr.Status(merr.WrapErrAliasNotFound("default", req.GetAlias()))}, nil
+	}
+	return &milvuspb.DescribeAliasResponse{
+		Status:     merr.Success(),
+		DbName:     "default",
+		Alias:      req.GetAlias(),
+		Collection: target,
+	}, nil
+}
+
+func (s *Server) ListAliases(ctx context.Context, req *milvuspb.ListAliasesRequest) (*milvuspb.ListAliasesResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	resp := &milvuspb.ListAliasesResponse{
+		Status:         merr.Success(),
+		DbName:         "default",
+		CollectionName: req.GetCollectionName(),
+	}
+	if req.GetCollectionName() == "" {
+		resp.Aliases = lo.Keys(s.aliases)
+		sort.Strings(resp.Aliases)
+		return resp, nil
+	}
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.ListAliasesResponse{Status: merr.Status(err)}, nil
+	}
+	resp.Aliases = s.aliasesOf(coll.name())
+	return resp, nil
+}
+
+func (s *Server) CreateIndex(ctx context.Context, req *milvuspb.CreateIndexRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	if coll.field(req.GetFieldName()) == nil {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("field %s does not exist", req.GetFieldName())), nil
+	}
+	name := req.GetIndexName()
+	if name == "" {
+		name = req.GetFieldName()
+	}
+	for _, idx := range coll.indexes {
+		if idx.fieldName != req.GetFieldName() && idx.name != name {
+			continue
+		}
+		// creating the same index again is idempotent
+		if idx.fieldName == req.GetFieldName() && idx.name == name &&
+			maps.Equal(funcutil.KeyValuePair2Map(idx.params), funcutil.KeyValuePair2Map(req.GetExtraParams())) {
+			return merr.Success(), nil
+		}
+		return merr.Status(merr.WrapErrIndexDuplicate(name, "at most one distinct index is allowed per field")), nil
+	}
+	coll.indexes = append(coll.indexes, &index{
+		id:        s.allocID(),
+		name:      name,
+		fieldName: req.GetFieldName(),
+		params:    req.GetExtraParams(),
+	})
+	return merr.Success(), nil
+}
+
+func (s *Server) DescribeIndex(ctx context.Context, req *milvuspb.DescribeIndexRequest) (*milvuspb.DescribeIndexResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.DescribeIndexResponse{Status: merr.Status(err)}, nil
+	}
+	resp := &milvuspb.DescribeIndexResponse{Status: merr.Success()}
+	for _, idx := range coll.indexes {
+		if (req.GetIndexName() != "" && idx.name != req.GetIndexName()) || (req.GetFieldName() != "" && idx.fieldName != req.GetFieldName()) {
+			continue
+		}
+		resp.IndexDescriptions = append(resp.IndexDescriptions, &milvuspb.IndexDescription{
+			IndexName:   idx.name,
+			IndexID:     idx.id,
+			Params:      idx.params,
+			FieldName:   idx.fieldName,
+			IndexedRows: int64(len(coll.rows)),
+			TotalRows:   int64(len(coll.rows)),
+			State:       commonpb.IndexState_Finished,
+		})
+	}
+	if len(resp.IndexDescriptions) == 0 {
+		return &milvuspb.DescribeIndexResponse{Status: merr.Status(merr.WrapErrIndexNotFoundForCollection(coll.name()))}, nil
+	}
+	return resp, nil
+}
+
+func (s *Server) DropIndex(ctx context.Context, req *milvuspb.DropIndexRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	if coll.loadState() == commonpb.LoadState_LoadStateLoaded {
+		return merr.Status(merr.WrapErrParameterInvalidMsg("index cannot be dropped, collection is loaded, please release it first")), nil
+	}
+	coll.indexes = lo.Filter(coll.indexes, func(idx *index, _ int) bool {
+		if req.GetIndexName() != "" {
+			return idx.name != req.GetIndexName()
+		}
+		return idx.fieldName != req.GetFieldName()
+	})
+	return merr.Success(), nil
+}
+
+// checkIndexed checks all the vector fields are indexed before loading.
+func (c *collection) checkIndexed() error {
+	for _, field := range c.schema.GetFields() {
+		if !typeutil.IsVectorType(field.GetDataType()) {
+			continue
+		}
+		if !lo.ContainsBy(c.indexes, func(idx *index) bool { return idx.fieldName == field.GetName() }) {
+			return merr.WrapErrIndexNotFoundForCollection(c.name(), fmt.Sprintf("there is no vector index on field %s, please create index firstly", field.GetName()))
+		}
+	}
+	return nil
+}
+
+func (s *Server) LoadCollection(ctx context.Context, req *milvuspb.LoadCollectionRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	if err := coll.checkIndexed(); err != nil {
+		return merr.Status(err), nil
+	}
+	coll.loaded = true
+	return merr.Success(), nil
+}
+
+func (s *Server) ReleaseCollection(ctx context.Context, req *milvuspb.ReleaseCollectionRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	coll.loaded = false
+	for _, p := range coll.partitions {
+		p.loaded = false
+	}
+	return merr.Success(), nil
+}
+
+func (s *Server) LoadPartitions(ctx context.Context, req *milvuspb.LoadPartitionsRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	if err := coll.checkIndexed(); err != nil {
+		return merr.Status(err), nil
+	}
+	partitions := make([]*partition, 0, len(req.GetPartitionNames()))
+	for _, name := range req.GetPartitionNames() {
+		p := coll.partition(name)
+		if p == nil {
+			return merr.Status(merr.WrapErrPartitionNotFound(name)), nil
+		}
+		partitions = append(partitions, p)
+	}
+	for _, p := range partitions {
+		p.loaded = true
+	}
+	return merr.Success(), nil
+}
+
+func (s *Server) ReleasePartitions(ctx context.Context, req *milvuspb.ReleasePartitionsRequest) (*commonpb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return merr.Status(err), nil
+	}
+	// the collection level load is converted into the partition level
+	if coll.loaded {
+		coll.loaded = false
+		for _, p := range coll.partitions {
+			p.loaded = true
+		}
+	}
+	for _, name := range req.GetPartitionNames() {
+		if p := coll.partition(name); p != nil {
+			p.loaded = false
+		}
+	}
+	return merr.Success(), nil
+}
+
+// partitionsLoaded checks whether the partitions are loaded, all partitions are checked if names is empty.
+func (c *collection) partitionsLoaded(names []string) (bool, error) {
+	if len(names) == 0 {
+		return c.loadState() == commonpb.LoadState_LoadStateLoaded, nil
+	}
+	for _, name := range names {
+		p := c.partition(name)
+		if p == nil {
+			return false, merr.WrapErrPartitionNotFound(name)
+		}
+		if !c.isPartitionLoaded(p) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *Server) GetLoadState(ctx context.Context, req *milvuspb.GetLoadStateRequest) (*milvuspb.GetLoadStateResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.GetLoadStateResponse{Status: merr.Status(err)}, nil
+	}
+	loaded, err := coll.partitionsLoaded(req.GetPartitionNames())
+	if err != nil {
+		return &milvuspb.GetLoadStateResponse{Status: merr.Status(err)}, nil
+	}
+	state := commonpb.LoadState_LoadStateNotLoad
+	if loaded {
+		state = commonpb.LoadState_LoadStateLoaded
+	}
+	return &milvuspb.GetLoadStateResponse{Status: merr.Success(), State: state}, nil
+}
+
+func (s *Server) GetLoadingProgress(ctx context.Context, req *milvuspb.GetLoadingProgressRequest) (*milvuspb.GetLoadingProgressResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.GetLoadingProgressResponse{Status: merr.Status(err)}, nil
+	}
+	loaded, err := coll.partitionsLoaded(req.GetPartitionNames())
+	if err != nil {
+		return &milvuspb.GetLoadingProgressResponse{Status: merr.Status(err)}, nil
+	}
+	if !loaded {
+		return &milvuspb.GetLoadingProgressResponse{Status: merr.Status(merr.WrapErrCollectionNotLoaded(coll.name()))}, nil
+	}
+	return &milvuspb.GetLoadingProgressResponse{
+		Status:          merr.Success(),
+		Progress:        100,
+		RefreshProgress: 100,
+	}, nil
+}
@@ -0,0 +1,303 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvustest
+
+import (
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+// The values of a row are kept as go values by field name, nil for null:
+//
+//	Bool                                                  => bool
+//	Int8, Int16, Int32                                    => int32
+//	Int64                                                 => int64
+//	Float                                                 => float32
+//	Double                                                => float64
+//	VarChar, String                                       => string
+//	JSON                                                  => []byte
+//	Array                                                 => *schemapb.ScalarField
+//	FloatVector                                           => []float32
+//	BinaryVector, Float16Vector, BFloat16Vector, Int8Vector => []byte, the raw bytes of one vector
+//	SparseFloatVector                                     => []byte, the serialized sparse row
+type row struct {
+	pk        any
+	partition string
+	values    map[string]any
+}
+
+func isSupportedType(dataType schemapb.DataType) bool {
+	switch dataType {
+	case schemapb.DataType_Bool, schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32, schemapb.DataType_Int64,
+		schemapb.DataType_Float, schemapb.DataType_Double, schemapb.DataType_VarChar, schemapb.DataType_String,
+		schemapb.DataType_JSON, schemapb.DataType_Array,
+		schemapb.DataType_FloatVector, schemapb.DataType_BinaryVector, schemapb.DataType_Float16Vector,
+		schemapb.DataType_BFloat16Vector, schemapb.DataType_Int8Vector, schemapb.DataType_SparseFloatVector:
+		return true
+	default:
+		return false
+	}
+}
+
+// vectorBytes returns the bytes of one vector for the fixed dim vectors stored in bytes.
+func vectorBytes(dataType schemapb.DataType, dim int64) int64 {
+	switch dataType {
+	case schemapb.DataType_BinaryVector:
+		return dim / 8
+	case schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector:
+		return dim * 2
+	default:
+		return dim
+	}
+}
+
+// fieldValues returns the values of the field data, the nullable data could be either compact,
+// which only contains the valid values, or full, which has placeholders for the null values.
+func fieldValues(field *schemapb.FieldSchema, fd *schemapb.FieldData) ([]any, error) {
+	var data []any
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		data = toAny(fd.GetScalars().GetBoolData().GetData())
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		data = toAny(fd.GetScalars().GetIntData().GetData())
+	case schemapb.DataType_Int64:
+		data = toAny(fd.GetScalars().GetLongData().GetData())
+	case schemapb.DataType_Float:
+		data = toAny(fd.GetScalars().GetFloatData().GetData())
+	case schemapb.DataType_Double:
+		data = toAny(fd.GetScalars().GetDoubleData().GetData())
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		data = toAny(fd.GetScalars().GetStringData().GetData())
+	case schemapb.DataType_JSON:
+		data = toAny(fd.GetScalars().GetJsonData().GetData())
+	case schemapb.DataType_Array:
+		data = toAny(fd.GetScalars().GetArrayData().GetData())
+	case schemapb.DataType_FloatVector:
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return nil, err
+		}
+		vectors := fd.GetVectors().GetFloatVector().GetData()
+		if int64(len(vectors))%dim != 0 {
+			return nil, merr.WrapErrParameterInvalidMsg("the length of float vector data %d is not a multiple of dim %d", len(vectors), dim)
+		}
+		for i := int64(0); i < int64(len(vectors)); i += dim {
+			data = append(data, vectors[i:i+dim])
+		}
+	case schemapb.DataType_BinaryVector, schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector, schemapb.DataType_Int8Vector:
+		dim, err := typeutil.GetDim(field)
+		if err != nil {
+			return nil, err
+		}
+		var vectors []byte
+		switch field.GetDataType() {
+		case schemapb.DataType_BinaryVector:
+			vectors = fd.GetVectors().GetBinaryVector()
+		case schemapb.DataType_Float16Vector:
+			vectors = fd.GetVectors().GetFloat16Vector()
+		case schemapb.DataType_BFloat16Vector:
+			vectors = fd.GetVectors().GetBfloat16Vector()
+		default:
+			vectors = fd.GetVectors().GetInt8Vector()
+		}
+		size := vectorBytes(field.GetDataType(), dim)
+		if size == 0 || int64(len(vectors))%size != 0 {
+			return nil, merr.WrapErrParameterInvalidMsg("the length of %s data %d does not match dim %d", field.GetDataType(), len(vectors), dim)
+		}
+		for i := int64(0); i < int64(len(vectors)); i += size {
+			data = append(data, vectors[i:i+size])
+		}
+	case schemapb.DataType_SparseFloatVector:
+		data = toAny(fd.GetVectors().GetSparseFloatVector().GetContents())
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("data type %s of field %s is not supported", field.GetDataType(), field.GetName())
+	}
+
+	validData := fd.GetValidData()
+	if len(validData) == 0 || len(validData) == len(data) {
+		for i, valid := range validData {
+			if !valid {
+				data[i] = nil
+			}
+		}
+		return data, nil
+	}
+	// compact mode, the values only contain the valid ones
+	values := make([]any, len(validData))
+	idx := 0
+	for i, valid := range validData {
+		if !valid {
+			continue
+		}
+		if idx >= len(data) {
+			return nil, merr.WrapErrParameterInvalidMsg("field %s has %d values, less than the valid count", field.GetName(), len(data))
+		}
+		values[i] = data[idx]
+		idx++
+	}
+	return values, nil
+}
+
+func toAny[T any](data []T) []any {
+	result := make([]any, 0, len(data))
+	for _, v := range data {
+		result = append(result, v)
+	}
+	return result
+}
+
+func collect[T any](values []any) ([]T, []bool) {
+	data := make([]T, 0, len(values))
+	valid := make([]bool, 0, len(values))
+	for _, v := range values {
+		if v == nil {
+			var zero T
+			data = append(data, zero)
+			valid = append(valid, false)
+			continue
+		}
+		data = append(data, v.(T))
+		valid = append(valid, true)
+	}
+	return data, valid
+}
+
+// buildFieldData builds the field data of the values, the null values are filled with zero values.
+func buildFieldData(field *schemapb.FieldSchema, values []any) *schemapb.FieldData {
+	fd := &schemapb.FieldData{
+		Type:      field.GetDataType(),
+		FieldName: field.GetName(),
+		FieldId:   field.GetFieldID(),
+		IsDynamic: field.GetIsDynamic(),
+	}
+	var valid []bool
+	switch field.GetDataType() {
+	case schemapb.DataType_Bool:
+		var data []bool
+		data, valid = collect[bool](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: data}}})
+	case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+		var data []int32
+		data, valid = collect[int32](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: data}}})
+	case schemapb.DataType_Int64:
+		var data []int64
+		data, valid = collect[int64](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}}})
+	case schemapb.DataType_Float:
+		var data []float32
+		data, valid = collect[float32](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: data}}})
+	case schemapb.DataType_Double:
+		var data []float64
+		data, valid = collect[float64](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: data}}})
+	case schemapb.DataType_VarChar, schemapb.DataType_String:
+		var data []string
+		data, valid = collect[string](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}}})
+	case schemapb.DataType_JSON:
+		var data [][]byte
+		data, valid = collect[[]byte](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: data}}})
+	case schemapb.DataType_Array:
+		var data []*schemapb.ScalarField
+		data, valid = collect[*schemapb.ScalarField](values)
+		fd.Field = scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_ArrayData{ArrayData: &schemapb.ArrayArray{
+			Data:        data,
+			ElementType: field.GetElementType(),
+		}}})
+	default:
+		fd.Field = &schemapb.FieldData_Vectors{Vectors: buildVectorField(field, values)}
+	}
+	if field.GetNullable() {
+		fd.ValidData = valid
+	}
+	return fd
+}
+
+func scalarField(scalars *schemapb.ScalarField) *schemapb.FieldData_Scalars {
+	return &schemapb.FieldData_Scalars{Scalars: scalars}
+}
+
+func buildVectorField(field *schemapb.FieldSchema, values []any) *schemapb.VectorField {
+	dim, _ := typeutil.GetDim(field)
+	vf := &schemapb.VectorField{Dim: dim}
+	switch field.GetDataType() {
+	case schemapb.DataType_FloatVector:
+		data := make([]float32, 0, int64(len(values))*dim)
+		for _, v := range values {
+			data = append(data, v.([]float32)...)
+		}
+		vf.Data = &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: data}}
+	case schemapb.DataType_SparseFloatVector:
+		contents := make([][]byte, 0, len(values))
+		var maxDim int64
+		for _, v := range values {
+			content := v.([]byte)
+			contents = append(contents, content)
+			// each element is a little endian uint32 index followed by a float32 value
+			for i := 0; i+8 <= len(content); i += 8 {
+				idx := int64(content[i]) | int64(content[i+1])<<8 | int64(content[i+2])<<16 | int64(content[i+3])<<24
+				if idx+1 > maxDim {
+					maxDim = idx + 1
+				}
+			}
+		}
+		vf.Dim = maxDim
+		vf.Data = &schemapb.VectorField_SparseFloatVector{SparseFloatVector: &schemapb.SparseFloatArray{Contents: contents, Dim: maxDim}}
+	default:
+		var data []byte
+		for _, v := range values {
+			data = append(data, v.([]byte)...)
+		}
+		switch field.GetDataType() {
+		case schemapb.DataType_BinaryVector:
+			vf.Data = &schemapb.VectorField_BinaryVector{BinaryVector: data}
+		case schemapb.DataType_Float16Vector:
+			vf.Data = &schemapb.VectorField_Float16Vector{Float16Vector: data}
+		case schemapb.DataType_BFloat16Vector:
+			vf.Data = &schemapb.VectorField_Bfloat16Vector{Bfloat16Vector: data}
+		case schemapb.DataType_Int8Vector:
+			vf.Data = &schemapb.VectorField_Int8Vector{Int8Vector: data}
+		}
+	}
+	return vf
+}
+
+// defaultValue returns the default value of the field, nil if not set.
+func defaultValue(field *schemapb.FieldSchema) any {
+	switch v := field.GetDefaultValue().GetData().(type) {
+	case *schemapb.ValueField_BoolData:
+		return v.BoolData
+	case *schemapb.ValueField_IntData:
+		return v.IntData
+	case *schemapb.ValueField_LongData:
+		return v.LongData
+	case *schemapb.ValueField_FloatData:
+		return v.FloatData
+	case *schemapb.ValueField_DoubleData:
+		return v.DoubleData
+	case *schemapb.ValueField_StringData:
+		return v.StringData
+	case *schemapb.ValueField_BytesData:
+		return v.BytesData
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,639 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvustest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// The filter supports a subset of the milvus boolean expression:
+//
+//	expr    := or
+//	or      := and (("or" | "||") and)*
+//	and     := unary (("and" | "&&") unary)*
+//	unary   := ("not" | "!") unary | compare
+//	compare := operand [("==" | "!=" | "<" | "<=" | ">" | ">=") operand | ["not"] "in" list | "like" string]
+//	operand := "(" expr ")" | literal | list | "{" template "}" | identifier ("[" (string | number) "]")*
+//
+// The identifiers are the field names, or the keys of the dynamic field if it's enabled,
+// json fields and dynamic keys could be accessed by path, e.g. `meta["color"]`.
+
+// valueGetter returns the value of the identifier with the json path of the row.
+type valueGetter func(r *row, name string, path []any) any
+
+type filterExpr interface {
+	eval(r *row, get valueGetter) any
+}
+
+type literalExpr struct {
+	value any
+}
+
+func (e *literalExpr) eval(*row, valueGetter) any { return e.value }
+
+type identExpr struct {
+	name string
+	path []any
+}
+
+func (e *identExpr) eval(r *row, get valueGetter) any { return get(r, e.name, e.path) }
+
+type listExpr struct {
+	items []filterExpr
+}
+
+func (e *listExpr) eval(r *row, get valueGetter) any {
+	values := make([]any, 0, len(e.items))
+	for _, item := range e.items {
+		values = append(values, item.eval(r, get))
+	}
+	return values
+}
+
+type notExpr struct {
+	inner filterExpr
+}
+
+func (e *notExpr) eval(r *row, get valueGetter) any {
+	v, ok := e.inner.eval(r, get).(bool)
+	return ok && !v
+}
+
+type logicalExpr struct {
+	and         bool
+	left, right filterExpr
+}
+
+func (e *logicalExpr) eval(r *row, get valueGetter) any {
+	left, _ := e.left.eval(r, get).(bool)
+	if e.and && !left {
+		return false
+	}
+	if !e.and && left {
+		return true
+	}
+	right, _ := e.right.eval(r, get).(bool)
+	return right
+}
+
+type compareExpr struct {
+	op          string
+	left, right filterExpr
+}
+
+func (e *compareExpr) eval(r *row, get valueGetter) any {
+	left, right := e.left.eval(r, get), e.right.eval(r, get)
+	switch e.op {
+	case "in", "not in":
+		if left == nil {
+			return false
+		}
+		items, _ := right.([]any)
+		found := false
+		for _, item := range items {
+			if c, ok := compareValues(left, item); ok && c == 0 {
+				found = true
+				break
+			}
+		}
+		return found == (e.op == "in")
+	case "like":
+		s, ok := left.(string)
+		pattern, _ := right.(string)
+		return ok && matchLike(s, pattern)
+	}
+	c, ok := compareValues(left, right)
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// compareValues compares the numbers, strings and bools, false is returned if they are not comparable.
+func compareValues(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	if ai, ok := a.(int64); ok {
+		if bi, ok := b.(int64); ok {
+			return compareOrdered(ai, bi), true
+		}
+	}
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			return compareOrdered(af, bf), true
+		}
+		return 0, false
+	}
+	switch av := a.(type) {
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv), true
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			if av == bv {
+				return 0, true
+			}
+			if !av {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+func compareOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// matchLike matches the string with the like pattern, where `%` matches any sequence of characters
+// and `_` matches any single character.
+func matchLike(s, pattern string) bool {
+	sr, pr := []rune(s), []rune(pattern)
+	var match func(i, j int) bool
+	match = func(i, j int) bool {
+		for j < len(pr) {
+			switch pr[j] {
+			case '%':
+				for k := i; k <= len(sr); k++ {
+					if match(k, j+1) {
+						return true
+					}
+				}
+				return false
+			case '_':
+				if i >= len(sr) {
+					return false
+				}
+			default:
+				if i >= len(sr) || sr[i] != pr[j] {
+					return false
+				}
+			}
+			i++
+			j++
+		}
+		return i == len(sr)
+	}
+	return match(0, 0)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsLetter(c) || c == '_' || c == '$':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '-' || runes[i] == '+') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			var sb strings.Builder
+			i++
+			for i < len(runes) && runes[i] != c {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, merr.WrapErrParameterInvalidMsg("unterminated string at %d in filter %q", start, input)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start})
+		default:
+			start := i
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "==", "!=", "<=", ">=", "&&", "||":
+					tokens = append(tokens, token{kind: tokenOp, text: two, pos: start})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("()[]{},<>!-", c) {
+				return nil, merr.WrapErrParameterInvalidMsg("unexpected character %q at %d in filter %q", c, start, input)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: string(c), pos: start})
+			i++
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type filterParser struct {
+	input     string
+	tokens    []token
+	pos       int
+	templates map[string]*schemapb.TemplateValue
+}
+
+// parseFilter parses the filter expression, nil is returned for the empty filter.
+func parseFilter(input string, templates map[string]*schemapb.TemplateValue) (filterExpr, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{input: input, tokens: tokens, templates: templates}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+	return e, nil
+}
+
+func (p *filterParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *filterParser) errorf(format string, args ...any) error {
+	return merr.WrapErrParameterInvalidMsg("invalid filter %q at %d: %s", p.input, p.peek().pos, fmt.Sprintf(format, args...))
+}
+
+// isKeyword checks whether the token is the case-insensitive keyword.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (t token) isOp(ops ...string) bool {
+	if t.kind != tokenOp {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *filterParser) expect(op string) error {
+	if !p.peek().isOp(op) {
+		return p.errorf("expect %s", op)
+	}
+	p.next()
+	return nil
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or") || p.peek().isOp("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and") || p.peek().isOp("&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	if p.peek().isKeyword("not") || p.peek().isOp("!") {
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{inner: inner}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *filterParser) parseCompare() (filterExpr, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	switch {
+	case t.isOp("==", "!=", "<", "<=", ">", ">="):
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &compareExpr{op: t.text, left: left, right: right}, nil
+	case t.isKeyword("in"), t.isKeyword("not") && p.tokens[p.pos+1].isKeyword("in"):
+		op := "in"
+		if t.isKeyword("not") {
+			p.next()
+			op = "not in"
+		}
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &compareExpr{op: op, left: left, right: right}, nil
+	case t.isKeyword("like"):
+		p.next()
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, p.errorf("like pattern shall be string")
+		}
+		return &compareExpr{op: "like", left: left, right: &literalExpr{value: pattern.text}}, nil
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseOperand() (filterExpr, error) {
+	t := p.next()
+	switch {
+	case t.isOp("("):
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(")")
+	case t.isOp("["):
+		list := &listExpr{}
+		for !p.peek().isOp("]") {
+			item, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			list.items = append(list.items, item)
+			if !p.peek().isOp(",") {
+				break
+			}
+			p.next()
+		}
+		return list, p.expect("]")
+	case t.isOp("{"):
+		name := p.next()
+		if name.kind != tokenIdent {
+			return nil, p.errorf("expect template name")
+		}
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		tv, ok := p.templates[name.text]
+		if !ok {
+			return nil, p.errorf("template value of %s not found", name.text)
+		}
+		return &literalExpr{value: templateValue(tv)}, nil
+	case t.isOp("-"):
+		number := p.next()
+		if number.kind != tokenNumber {
+			return nil, p.errorf("expect number after -")
+		}
+		return parseNumber("-" + number.text)
+	case t.kind == tokenNumber:
+		return parseNumber(t.text)
+	case t.kind == tokenString:
+		return &literalExpr{value: t.text}, nil
+	case t.isKeyword("true"):
+		return &literalExpr{value: true}, nil
+	case t.isKeyword("false"):
+		return &literalExpr{value: false}, nil
+	case t.kind == tokenIdent:
+		ident := &identExpr{name: t.text}
+		for p.peek().isOp("[") {
+			p.next()
+			key := p.next()
+			switch key.kind {
+			case tokenString:
+				ident.path = append(ident.path, key.text)
+			case tokenNumber:
+				idx, err := strconv.Atoi(key.text)
+				if err != nil {
+					return nil, p.errorf("invalid index %s", key.text)
+				}
+				ident.path = append(ident.path, idx)
+			default:
+				return nil, p.errorf("expect json key or index")
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+		}
+		return ident, nil
+	}
+	return nil, p.errorf("unexpected %q", t.text)
+}
+
+func parseNumber(text string) (filterExpr, error) {
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return &literalExpr{value: i}, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid number %s", text)
+	}
+	return &literalExpr{value: f}, nil
+}
+
+func templateValue(tv *schemapb.TemplateValue) any {
+	switch v := tv.GetVal().(type) {
+	case *schemapb.TemplateValue_BoolVal:
+		return v.BoolVal
+	case *schemapb.TemplateValue_Int64Val:
+		return v.Int64Val
+	case *schemapb.TemplateValue_FloatVal:
+		return v.FloatVal
+	case *schemapb.TemplateValue_StringVal:
+		return v.StringVal
+	case *schemapb.TemplateValue_ArrayVal:
+		switch arr := v.ArrayVal.GetData().(type) {
+		case *schemapb.TemplateArrayValue_BoolData:
+			return toAny(arr.BoolData.GetData())
+		case *schemapb.TemplateArrayValue_LongData:
+			return toAny(arr.LongData.GetData())
+		case *schemapb.TemplateArrayValue_DoubleData:
+			return toAny(arr.DoubleData.GetData())
+		case *schemapb.TemplateArrayValue_StringData:
+			return toAny(arr.StringData.GetData())
+		}
+	}
+	return nil
+}
+
+// jsonValue decodes the json and walks through the path, numbers are decoded as int64 if possible.
+func jsonValue(data []byte, path []any) any {
+	if len(data) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil
+	}
+	for _, key := range path {
+		switch key := key.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil
+			}
+			v = m[key]
+		case int:
+			arr, ok := v.([]any)
+			if !ok || key < 0 || key >= len(arr) {
+				return nil
+			}
+			v = arr[key]
+		}
+	}
+	return normalizeJSON(v)
+}
+
+func normalizeJSON(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeJSON(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// walkIdents calls fn with the name of each identifier in the expression.
+func walkIdents(e filterExpr, fn func(name string)) {
+	switch e := e.(type) {
+	case *identExpr:
+		fn(e.name)
+	case *listExpr:
+		for _, item := range e.items {
+			walkIdents(item, fn)
+		}
+	case *notExpr:
+		walkIdents(e.inner, fn)
+	case *logicalExpr:
+		walkIdents(e.left, fn)
+		walkIdents(e.right, fn)
+	case *compareExpr:
+		walkIdents(e.left, fn)
+		walkIdents(e.right, fn)
+	}
+}
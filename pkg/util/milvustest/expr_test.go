@@ -0,0 +1,114 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvustest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+)
+
+func TestFilterEval(t *testing.T) {
+	r := &row{values: map[string]any{
+		"id":    int64(10),
+		"age":   int32(30),
+		"score": float32(0.5),
+		"name":  "milvus",
+		"ok":    true,
+		"meta":  []byte(`{"color": "red", "tags": [1, 2, 3], "size": 1.5}`),
+	}}
+	get := func(r *row, name string, path []any) any {
+		v := r.values[name]
+		if data, ok := v.([]byte); ok {
+			return jsonValue(data, path)
+		}
+		return v
+	}
+	templates := map[string]*schemapb.TemplateValue{
+		"age":  {Val: &schemapb.TemplateValue_Int64Val{Int64Val: 30}},
+		"name": {Val: &schemapb.TemplateValue_StringVal{StringVal: "milvus"}},
+		"ids": {Val: &schemapb.TemplateValue_ArrayVal{ArrayVal: &schemapb.TemplateArrayValue{
+			Data: &schemapb.TemplateArrayValue_LongData{LongData: &schemapb.LongArray{Data: []int64{1, 10}}},
+		}}},
+	}
+
+	cases := []struct {
+		expr   string
+		expect bool
+	}{
+		{"id == 10", true},
+		{"id != 10", false},
+		{"age > 20 and age <= 30", true},
+		{"age >= 31 || score < 1", true},
+		{"score == 0.5", true},
+		{"id > -1", true},
+		{"not (id == 10)", false},
+		{"!ok", false},
+		{"ok == true", true},
+		{`name == "milvus"`, true},
+		{`name like "mil%"`, true},
+		{`name like "m_lvus"`, true},
+		{`name like "%x%"`, false},
+		{"id in [1, 2, 10]", true},
+		{"id not in [1, 2, 10]", false},
+		{`name in ["a", 'milvus']`, true},
+		{`meta["color"] == "red"`, true},
+		{`meta["tags"][1] == 2`, true},
+		{`meta["size"] > 1`, true},
+		{`meta["missing"] == 1`, false},
+		{`meta["missing"] in [1]`, false},
+		{"age == {age} && name == {name}", true},
+		{"id in {ids}", true},
+		{`name > 1`, false},
+	}
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			e, err := parseFilter(c.expr, templates)
+			require.NoError(t, err)
+			assert.Equal(t, c.expect, e.eval(r, get))
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	e, err := parseFilter("  ", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+
+	var names []string
+	e, err = parseFilter(`a > 1 and (b in [c, 2] or not d["k"] like "x%")`, nil)
+	require.NoError(t, err)
+	walkIdents(e, func(name string) { names = append(names, name) })
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names)
+
+	for _, expr := range []string{
+		"id ==",
+		"(id == 1",
+		"id == 1 id",
+		`name like 1`,
+		"id == {missing}",
+		`meta[id] == 1`,
+		`name == "unterminated`,
+		"id == - a",
+	} {
+		_, err := parseFilter(expr, nil)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,418 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvustest
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/distance"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/typeutil"
+)
+
+const countStar = "count(*)"
+
+// parseFilter parses the filter, the identifiers shall be fields or keys of the dynamic field.
+func (c *collection) parseFilter(input string, templates map[string]*schemapb.TemplateValue) (filterExpr, error) {
+	filter, err := parseFilter(input, templates)
+	if err != nil {
+		return nil, err
+	}
+	var unknown []string
+	walkIdents(filter, func(name string) {
+		if c.field(name) == nil && c.dynamicField() == nil {
+			unknown = append(unknown, name)
+		}
+	})
+	if len(unknown) > 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("field %s does not exist in collection %s", strings.Join(unknown, ","), c.name())
+	}
+	return filter, nil
+}
+
+// value returns the value of the field or the dynamic key, json values are accessed by path.
+func (c *collection) value(r *row, name string, path []any) any {
+	if field := c.field(name); field != nil {
+		v := r.values[name]
+		if field.GetDataType() == schemapb.DataType_JSON {
+			data, _ := v.([]byte)
+			return jsonValue(data, path)
+		}
+		if len(path) > 0 {
+			return nil
+		}
+		return v
+	}
+	dynamic := c.dynamicField()
+	if dynamic == nil {
+		return nil
+	}
+	data, _ := r.values[dynamic.GetName()].([]byte)
+	return jsonValue(data, append([]any{name}, path...))
+}
+
+func (c *collection) match(filter filterExpr, r *row) bool {
+	if filter == nil {
+		return true
+	}
+	matched, _ := filter.eval(r, c.value).(bool)
+	return matched
+}
+
+// readableRows returns the rows of the loaded partitions matching the filter, ordered by primary key.
+func (c *collection) readableRows(partitionNames []string, filter filterExpr) ([]*row, error) {
+	loaded, err := c.partitionsLoaded(partitionNames)
+	if err != nil {
+		return nil, err
+	}
+	if !loaded {
+		return nil, merr.WrapErrCollectionNotLoaded(c.name())
+	}
+	targets := typeutil.NewSet(partitionNames...)
+	if len(partitionNames) == 0 {
+		for _, p := range c.partitions {
+			if c.isPartitionLoaded(p) {
+				targets.Insert(p.name)
+			}
+		}
+	}
+	var rows []*row
+	for _, r := range c.rows {
+		if targets.Contain(r.partition) && c.match(filter, r) {
+			rows = append(rows, r)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		cmp, _ := compareValues(rows[i].pk, rows[j].pk)
+		return cmp < 0
+	})
+	return rows, nil
+}
+
+// outputFields resolves the output fields, "*" stands for all fields,
+// and the names not in schema are the keys of the dynamic field.
+func (c *collection) outputFields(names []string, withPK bool) ([]*schemapb.FieldSchema, error) {
+	var fields []*schemapb.FieldSchema
+	added := typeutil.NewSet[string]()
+	add := func(field *schemapb.FieldSchema) {
+		if !added.Contain(field.GetName()) {
+			added.Insert(field.GetName())
+			fields = append(fields, field)
+		}
+	}
+	if withPK {
+		add(c.pkField())
+	}
+	for _, name := range names {
+		switch {
+		case name == "*":
+			for _, field := range c.schema.GetFields() {
+				add(field)
+			}
+		case c.field(name) != nil:
+			add(c.field(name))
+		case c.dynamicField() != nil:
+			add(c.dynamicField())
+		default:
+			return nil, merr.WrapErrParameterInvalidMsg("field %s does not exist in collection %s", name, c.name())
+		}
+	}
+	return fields, nil
+}
+
+func buildFieldsData(fields []*schemapb.FieldSchema, rows []*row) []*schemapb.FieldData {
+	fieldsData := make([]*schemapb.FieldData, 0, len(fields))
+	for _, field := range fields {
+		values := make([]any, 0, len(rows))
+		for _, r := range rows {
+			values = append(values, r.values[field.GetName()])
+		}
+		fieldsData = append(fieldsData, buildFieldData(field, values))
+	}
+	return fieldsData
+}
+
+func (s *Server) Query(ctx context.Context, req *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.QueryResults{Status: merr.Status(err)}, nil
+	}
+	filter, err := coll.parseFilter(req.GetExpr(), req.GetExprTemplateValues())
+	if err != nil {
+		return &milvuspb.QueryResults{Status: merr.Status(err)}, nil
+	}
+	rows, err := coll.readableRows(req.GetPartitionNames(), filter)
+	if err != nil {
+		return &milvuspb.QueryResults{Status: merr.Status(err)}, nil
+	}
+
+	for _, name := range req.GetOutputFields() {
+		if strings.EqualFold(name, countStar) {
+			if len(req.GetOutputFields()) > 1 {
+				return &milvuspb.QueryResults{Status: merr.Status(merr.WrapErrParameterInvalidMsg("count(*) shall not be used with other output fields"))}, nil
+			}
+			return &milvuspb.QueryResults{
+				Status:         merr.Success(),
+				CollectionName: coll.name(),
+				OutputFields:   []string{countStar},
+				FieldsData: []*schemapb.FieldData{{
+					Type:      schemapb.DataType_Int64,
+					FieldName: countStar,
+					Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{
+						LongData: &schemapb.LongArray{Data: []int64{int64(len(rows))}},
+					}}},
+				}},
+			}, nil
+		}
+	}
+
+	offset, limit, err := pagination(req.GetQueryParams())
+	if err != nil {
+		return &milvuspb.QueryResults{Status: merr.Status(err)}, nil
+	}
+	rows = paginate(rows, offset, limit)
+
+	fields, err := coll.outputFields(req.GetOutputFields(), true)
+	if err != nil {
+		return &milvuspb.QueryResults{Status: merr.Status(err)}, nil
+	}
+	outputFields := req.GetOutputFields()
+	if !lo.Contains(outputFields, coll.pkField().GetName()) {
+		outputFields = append([]string{coll.pkField().GetName()}, outputFields...)
+	}
+	return &milvuspb.QueryResults{
+		Status:           merr.Success(),
+		CollectionName:   coll.name(),
+		FieldsData:       buildFieldsData(fields, rows),
+		OutputFields:     outputFields,
+		PrimaryFieldName: coll.pkField().GetName(),
+	}, nil
+}
+
+// pagination returns the offset and limit of the params, limit is -1 if not set.
+func pagination(params []*commonpb.KeyValuePair) (int, int, error) {
+	offset, limit := 0, -1
+	for _, kv := range params {
+		var target *int
+		switch kv.GetKey() {
+		case "offset":
+			target = &offset
+		case "limit", "topk":
+			target = &limit
+		default:
+			continue
+		}
+		v, err := strconv.Atoi(kv.GetValue())
+		if err != nil || v < 0 {
+			return 0, 0, merr.WrapErrParameterInvalidMsg("invalid %s: %s", kv.GetKey(), kv.GetValue())
+		}
+		*target = v
+	}
+	return offset, limit, nil
+}
+
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// annsField returns the vector field to search, which could be omitted if there is only one vector field.
+func (c *collection) annsField(name string) (*schemapb.FieldSchema, error) {
+	if name != "" {
+		field := c.field(name)
+		if field == nil || !typeutil.IsVectorType(field.GetDataType()) {
+			return nil, merr.WrapErrParameterInvalidMsg("vector field %s does not exist in collection %s", name, c.name())
+		}
+		return field, nil
+	}
+	vectorFields := typeutil.GetVectorFieldSchemas(c.schema)
+	if len(vectorFields) != 1 {
+		return nil, merr.WrapErrParameterInvalidMsg("multiple anns_fields exist, please specify a anns_field in search_params")
+	}
+	return vectorFields[0], nil
+}
+
+// metricType returns the metric of the search, which shall match the metric of the index.
+func (c *collection) metricType(field *schemapb.FieldSchema, metric string) (string, error) {
+	var indexMetric string
+	for _, idx := range c.indexes {
+		if idx.fieldName == field.GetName() {
+			indexMetric, _ = funcutil.GetAttrByKeyFromRepeatedKV(common.MetricTypeKey, idx.params)
+		}
+	}
+	switch {
+	case metric == "" && indexMetric == "":
+		return distance.COSINE, nil
+	case metric == "":
+		return strings.ToUpper(indexMetric), nil
+	case indexMetric != "" && !strings.EqualFold(metric, indexMetric):
+		return "", merr.WrapErrParameterInvalidMsg("metric type not match: invalid parameter[expected=%s][actual=%s]", indexMetric, metric)
+	}
+	return distance.ValidateMetricType(metric)
+}
+
+func decodeFloatVectors(placeholderGroup []byte, dim int64) ([]float32, int, error) {
+	phg := &commonpb.PlaceholderGroup{}
+	if err := proto.Unmarshal(placeholderGroup, phg); err != nil {
+		return nil, 0, merr.WrapErrParameterInvalidMsg("failed to unmarshal placeholder group: %s", err.Error())
+	}
+	if len(phg.GetPlaceholders()) != 1 || phg.GetPlaceholders()[0].GetType() != commonpb.PlaceholderType_FloatVector {
+		return nil, 0, merr.WrapErrParameterInvalidMsg("only float vector search is supported")
+	}
+	values := phg.GetPlaceholders()[0].GetValues()
+	vectors := make([]float32, 0, int64(len(values))*dim)
+	for _, value := range values {
+		if int64(len(value)) != dim*4 {
+			return nil, 0, merr.WrapErrParameterInvalidMsg("the dim of search vector %d does not match the field dim %d", len(value)/4, dim)
+		}
+		for i := 0; i < len(value); i += 4 {
+			vectors = append(vectors, math.Float32frombits(binary.LittleEndian.Uint32(value[i:])))
+		}
+	}
+	return vectors, len(values), nil
+}
+
+func (s *Server) Search(ctx context.Context, req *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.SearchResults{Status: merr.Status(err)}, nil
+	}
+	results, err := coll.search(req)
+	if err != nil {
+		return &milvuspb.SearchResults{Status: merr.Status(err)}, nil
+	}
+	return &milvuspb.SearchResults{
+		Status:         merr.Success(),
+		Results:        results,
+		CollectionName: coll.name(),
+	}, nil
+}
+
+func (c *collection) search(req *milvuspb.SearchRequest) (*schemapb.SearchResultData, error) {
+	params := funcutil.KeyValuePair2Map(req.GetSearchParams())
+	if req.GetIds() != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("search by primary keys is not supported")
+	}
+	if params["group_by_field"] != "" || len(req.GetSubReqs()) > 0 || req.GetFunctionScore() != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("group by, hybrid search and rerank functions are not supported")
+	}
+	field, err := c.annsField(params["anns_field"])
+	if err != nil {
+		return nil, err
+	}
+	if field.GetDataType() != schemapb.DataType_FloatVector {
+		return nil, merr.WrapErrParameterInvalidMsg("only float vector search is supported, got %s", field.GetDataType())
+	}
+	metric, err := c.metricType(field, params[common.MetricTypeKey])
+	if err != nil {
+		return nil, err
+	}
+	offset, topK, err := pagination(req.GetSearchParams())
+	if err != nil {
+		return nil, err
+	}
+	if topK <= 0 {
+		return nil, merr.WrapErrParameterInvalidMsg("topk shall be positive")
+	}
+	dim, err := typeutil.GetDim(field)
+	if err != nil {
+		return nil, err
+	}
+	queries, nq, err := decodeFloatVectors(req.GetPlaceholderGroup(), dim)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := c.parseFilter(req.GetDsl(), req.GetExprTemplateValues())
+	if err != nil {
+		return nil, err
+	}
+	rows, err := c.readableRows(req.GetPartitionNames(), filter)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := c.outputFields(req.GetOutputFields(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &schemapb.SearchResultData{
+		NumQueries:       int64(nq),
+		TopK:             int64(topK),
+		OutputFields:     req.GetOutputFields(),
+		PrimaryFieldName: c.pkField().GetName(),
+	}
+	var scores []float32
+	if len(rows) > 0 {
+		candidates := make([]float32, 0, int64(len(rows))*dim)
+		for _, r := range rows {
+			candidates = append(candidates, r.values[field.GetName()].([]float32)...)
+		}
+		scores, err = distance.CalcFloatDistance(dim, queries, candidates, metric)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var hits []*row
+	var pks []any
+	for i := 0; i < nq; i++ {
+		order := make([]int, len(rows))
+		for j := range order {
+			order[j] = j
+		}
+		queryScores := scores[i*len(rows) : (i+1)*len(rows)]
+		// smaller distance is better for L2, larger similarity is better for IP and COSINE
+		sort.SliceStable(order, func(a, b int) bool {
+			if metric == distance.L2 {
+				return queryScores[order[a]] < queryScores[order[b]]
+			}
+			return queryScores[order[a]] > queryScores[order[b]]
+		})
+		order = paginate(order, offset, topK)
+		for _, j := range order {
+			hits = append(hits, rows[j])
+			pks = append(pks, rows[j].pk)
+			result.Scores = append(result.Scores, queryScores[j])
+		}
+		result.Topks = append(result.Topks, int64(len(order)))
+	}
+	result.Ids = buildIDs(c.pkField(), pks)
+	result.FieldsData = buildFieldsData(outputs, hits)
+	return result, nil
+}
@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package milvustest provides an in-memory fake of the milvus service for hermetic unit tests.
+//
+// The fake server keeps all the data in memory and serves the common requests of the sdk:
+// collections, partitions, aliases, indexes, load state, insert/upsert/delete, query with the basic
+// boolean filters and brute-force search of float vectors. All the databases share the same namespace,
+// and the requests which are not supported return the Unimplemented grpc error.
+//
+//	server := milvustest.NewServer()
+//	if err := server.Start(); err != nil {
+//		return err
+//	}
+//	defer server.Stop()
+//	cli, err := milvusclient.New(ctx, &milvusclient.ClientConfig{Address: server.Addr()})
+package milvustest
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+	"github.com/milvus-io/milvus/pkg/v3/util/tsoutil"
+)
+
+// Version is the server version returned by GetVersion.
+const Version = "v2.6.0-milvustest"
+
+var _ milvuspb.MilvusServiceServer = (*Server)(nil)
+
+// Server is the in-memory fake of the milvus service, it's safe for concurrent use.
+type Server struct {
+	milvuspb.UnimplementedMilvusServiceServer
+
+	mu          sync.RWMutex
+	collections map[string]*collection
+	aliases     map[string]string
+	nextID      int64
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// NewServer returns an empty fake server, which could be registered into any grpc server
+// with milvuspb.RegisterMilvusServiceServer, or started by Start.
+func NewServer() *Server {
+	return &Server{
+		collections: make(map[string]*collection),
+		aliases:     make(map[string]string),
+		nextID:      1000,
+	}
+}
+
+// Start serves the fake on a random local port, see Addr for the address to connect.
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	s.listener = lis
+	s.grpcServer = grpc.NewServer()
+	milvuspb.RegisterMilvusServiceServer(s.grpcServer, s)
+	go s.grpcServer.Serve(lis)
+	return nil
+}
+
+// Addr returns the address of the started server.
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop stops the started server.
+func (s *Server) Stop() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
+
+// Reset drops all the collections and aliases.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections = make(map[string]*collection)
+	s.aliases = make(map[string]string)
+}
+
+func (s *Server) allocID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func allocTimestamp() uint64 {
+	return tsoutil.ComposeTSByTime(time.Now())
+}
+
+func (s *Server) Connect(ctx context.Context, req *milvuspb.ConnectRequest) (*milvuspb.ConnectResponse, error) {
+	return &milvuspb.ConnectResponse{
+		Status: merr.Success(),
+		ServerInfo: &commonpb.ServerInfo{
+			BuildTags: Version,
+		},
+		Identifier: 1,
+	}, nil
+}
+
+func (s *Server) GetVersion(ctx context.Context, req *milvuspb.GetVersionRequest) (*milvuspb.GetVersionResponse, error) {
+	return &milvuspb.GetVersionResponse{
+		Status:  merr.Success(),
+		Version: Version,
+	}, nil
+}
+
+func (s *Server) CheckHealth(ctx context.Context, req *milvuspb.CheckHealthRequest) (*milvuspb.CheckHealthResponse, error) {
+	return &milvuspb.CheckHealthResponse{
+		Status:    merr.Success(),
+		IsHealthy: true,
+	}, nil
+}
+
+func (s *Server) ListDatabases(ctx context.Context, req *milvuspb.ListDatabasesRequest) (*milvuspb.ListDatabasesResponse, error) {
+	return &milvuspb.ListDatabasesResponse{
+		Status:  merr.Success(),
+		DbNames: []string{"default"},
+	}, nil
+}
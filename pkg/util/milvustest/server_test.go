@@ -0,0 +1,345 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvustest
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+const testCollection = "test_collection"
+
+type ServerSuite struct {
+	suite.Suite
+
+	server *Server
+	conn   *grpc.ClientConn
+	client milvuspb.MilvusServiceClient
+}
+
+func (s *ServerSuite) SetupSuite() {
+	s.server = NewServer()
+	s.Require().NoError(s.server.Start())
+	conn, err := grpc.NewClient(s.server.Addr(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+	s.conn = conn
+	s.client = milvuspb.NewMilvusServiceClient(conn)
+}
+
+func (s *ServerSuite) TearDownSuite() {
+	s.conn.Close()
+	s.server.Stop()
+}
+
+func (s *ServerSuite) SetupTest() {
+	s.server.Reset()
+}
+
+func floatVectorField(name string) *schemapb.FieldSchema {
+	return &schemapb.FieldSchema{
+		Name:       name,
+		DataType:   schemapb.DataType_FloatVector,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+	}
+}
+
+// createLoadedCollection creates the collection with schema (id int64, name varchar, vector float_vector(2)),
+// inserts 4 rows and loads it.
+func (s *ServerSuite) createLoadedCollection(ctx context.Context, dynamic bool) {
+	schema := &schemapb.CollectionSchema{
+		EnableDynamicField: dynamic,
+		Fields: []*schemapb.FieldSchema{
+			{Name: "id", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{Name: "name", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "16"}}},
+			floatVectorField("vector"),
+		},
+	}
+	bs, err := proto.Marshal(schema)
+	s.Require().NoError(err)
+	s.Require().NoError(merr.CheckRPCCall(s.client.CreateCollection(ctx, &milvuspb.CreateCollectionRequest{
+		CollectionName: testCollection,
+		Schema:         bs,
+	})))
+
+	fields := []*schemapb.FieldData{
+		longField("id", 1, 2, 3, 4),
+		stringField("name", "a", "b", "c", "d"),
+		vectorField("vector", 1, 0, 0, 1, -1, 0, 0.7, 0.7),
+	}
+	if dynamic {
+		fields = append(fields, &schemapb.FieldData{
+			Type:      schemapb.DataType_JSON,
+			FieldName: common.MetaFieldName,
+			IsDynamic: true,
+			Field: scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_JsonData{JsonData: &schemapb.JSONArray{Data: [][]byte{
+				[]byte(`{"color": "red"}`), []byte(`{"color": "blue"}`), []byte(`{}`), []byte(`{"color": "red"}`),
+			}}}}),
+		})
+	}
+	resp, err := s.client.Insert(ctx, &milvuspb.InsertRequest{CollectionName: testCollection, FieldsData: fields, NumRows: 4})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal(int64(4), resp.GetInsertCnt())
+	s.Equal([]int64{1, 2, 3, 4}, resp.GetIDs().GetIntId().GetData())
+
+	s.Require().NoError(merr.CheckRPCCall(s.client.CreateIndex(ctx, &milvuspb.CreateIndexRequest{
+		CollectionName: testCollection,
+		FieldName:      "vector",
+		ExtraParams:    []*commonpb.KeyValuePair{{Key: common.MetricTypeKey, Value: "IP"}},
+	})))
+	s.Require().NoError(merr.CheckRPCCall(s.client.LoadCollection(ctx, &milvuspb.LoadCollectionRequest{CollectionName: testCollection})))
+}
+
+func longField(name string, data ...int64) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_Int64,
+		FieldName: name,
+		Field:     scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}}}),
+	}
+}
+
+func stringField(name string, data ...string) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_VarChar,
+		FieldName: name,
+		Field:     scalarField(&schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}}}),
+	}
+}
+
+func vectorField(name string, data ...float32) *schemapb.FieldData {
+	return &schemapb.FieldData{
+		Type:      schemapb.DataType_FloatVector,
+		FieldName: name,
+		Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{
+			Dim:  2,
+			Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: data}},
+		}},
+	}
+}
+
+func placeholderGroup(vectors ...[]float32) *milvuspb.SearchRequest_PlaceholderGroup {
+	ph := &commonpb.PlaceholderValue{Tag: "$0", Type: commonpb.PlaceholderType_FloatVector}
+	for _, vector := range vectors {
+		bs := make([]byte, 4*len(vector))
+		for i, v := range vector {
+			binary.LittleEndian.PutUint32(bs[i*4:], math.Float32bits(v))
+		}
+		ph.Values = append(ph.Values, bs)
+	}
+	bs, _ := proto.Marshal(&commonpb.PlaceholderGroup{Placeholders: []*commonpb.PlaceholderValue{ph}})
+	return &milvuspb.SearchRequest_PlaceholderGroup{PlaceholderGroup: bs}
+}
+
+func searchParams(kvs map[string]string) []*commonpb.KeyValuePair {
+	params := make([]*commonpb.KeyValuePair, 0, len(kvs))
+	for k, v := range kvs {
+		params = append(params, &commonpb.KeyValuePair{Key: k, Value: v})
+	}
+	return params
+}
+
+func (s *ServerSuite) TestCollection() {
+	ctx := context.Background()
+	s.createLoadedCollection(ctx, false)
+
+	has, err := s.client.HasCollection(ctx, &milvuspb.HasCollectionRequest{CollectionName: testCollection})
+	s.NoError(merr.CheckRPCCall(has, err))
+	s.True(has.GetValue())
+
+	desc, err := s.client.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{CollectionName: testCollection})
+	s.NoError(merr.CheckRPCCall(desc, err))
+	s.Equal("id", desc.GetSchema().GetFields()[0].GetName())
+	s.Equal(int64(common.StartOfUserFieldID), desc.GetSchema().GetFields()[0].GetFieldID())
+
+	state, err := s.client.GetLoadState(ctx, &milvuspb.GetLoadStateRequest{CollectionName: testCollection})
+	s.NoError(merr.CheckRPCCall(state, err))
+	s.Equal(commonpb.LoadState_LoadStateLoaded, state.GetState())
+
+	stats, err := s.client.GetCollectionStatistics(ctx, &milvuspb.GetCollectionStatisticsRequest{CollectionName: testCollection})
+	s.NoError(merr.CheckRPCCall(stats, err))
+	s.Equal("4", stats.GetStats()[0].GetValue())
+
+	s.ErrorIs(merr.CheckRPCCall(s.client.DropIndex(ctx, &milvuspb.DropIndexRequest{CollectionName: testCollection, FieldName: "vector"})), merr.ErrParameterInvalid)
+	s.NoError(merr.CheckRPCCall(s.client.ReleaseCollection(ctx, &milvuspb.ReleaseCollectionRequest{CollectionName: testCollection})))
+	query, err := s.client.Query(ctx, &milvuspb.QueryRequest{CollectionName: testCollection, Expr: "id > 0"})
+	s.ErrorIs(merr.CheckRPCCall(query, err), merr.ErrCollectionNotLoaded)
+
+	s.NoError(merr.CheckRPCCall(s.client.DropCollection(ctx, &milvuspb.DropCollectionRequest{CollectionName: testCollection})))
+	desc, err = s.client.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{CollectionName: testCollection})
+	s.ErrorIs(merr.CheckRPCCall(desc, err), merr.ErrCollectionNotFound)
+}
+
+func (s *ServerSuite) TestQuery() {
+	ctx := context.Background()
+	s.createLoadedCollection(ctx, true)
+
+	resp, err := s.client.Query(ctx, &milvuspb.QueryRequest{
+		CollectionName: testCollection,
+		Expr:           `id >= 2 and color == "red" or name == {name}`,
+		OutputFields:   []string{"name", "color"},
+		ExprTemplateValues: map[string]*schemapb.TemplateValue{
+			"name": {Val: &schemapb.TemplateValue_StringVal{StringVal: "c"}},
+		},
+	})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal([]string{"id", "name", "color"}, resp.GetOutputFields())
+	s.Require().Len(resp.GetFieldsData(), 3)
+	s.Equal([]int64{3, 4}, resp.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	s.Equal([]string{"c", "d"}, resp.GetFieldsData()[1].GetScalars().GetStringData().GetData())
+	s.Equal(common.MetaFieldName, resp.GetFieldsData()[2].GetFieldName())
+	s.True(resp.GetFieldsData()[2].GetIsDynamic())
+
+	resp, err = s.client.Query(ctx, &milvuspb.QueryRequest{
+		CollectionName: testCollection,
+		QueryParams:    searchParams(map[string]string{"offset": "1", "limit": "2"}),
+	})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal([]int64{2, 3}, resp.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	resp, err = s.client.Query(ctx, &milvuspb.QueryRequest{
+		CollectionName: testCollection,
+		Expr:           `color in ["red", "blue"]`,
+		OutputFields:   []string{"count(*)"},
+	})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal([]int64{3}, resp.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	resp, err = s.client.Query(ctx, &milvuspb.QueryRequest{CollectionName: testCollection, Expr: "id >"})
+	s.ErrorIs(merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+}
+
+func (s *ServerSuite) TestSearch() {
+	ctx := context.Background()
+	s.createLoadedCollection(ctx, false)
+
+	resp, err := s.client.Search(ctx, &milvuspb.SearchRequest{
+		CollectionName: testCollection,
+		SearchInput:    placeholderGroup([]float32{1, 0}, []float32{0, 1}),
+		SearchParams:   searchParams(map[string]string{"topk": "2", "anns_field": "vector"}),
+		OutputFields:   []string{"name"},
+	})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	results := resp.GetResults()
+	s.Equal(int64(2), results.GetNumQueries())
+	s.Equal([]int64{2, 2}, results.GetTopks())
+	s.Equal([]int64{1, 4, 2, 4}, results.GetIds().GetIntId().GetData())
+	s.Equal([]string{"a", "d", "b", "d"}, results.GetFieldsData()[0].GetScalars().GetStringData().GetData())
+	s.InDelta(1.0, results.GetScores()[0], 1e-6)
+
+	resp, err = s.client.Search(ctx, &milvuspb.SearchRequest{
+		CollectionName: testCollection,
+		Dsl:            "id != 1",
+		SearchInput:    placeholderGroup([]float32{1, 0}),
+		SearchParams:   searchParams(map[string]string{"topk": "1", "offset": "1"}),
+	})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal([]int64{2}, resp.GetResults().GetIds().GetIntId().GetData())
+
+	resp, err = s.client.Search(ctx, &milvuspb.SearchRequest{
+		CollectionName: testCollection,
+		SearchInput:    placeholderGroup([]float32{1, 0}),
+		SearchParams:   searchParams(map[string]string{"topk": "1", common.MetricTypeKey: "L2"}),
+	})
+	s.ErrorIs(merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+
+	resp, err = s.client.Search(ctx, &milvuspb.SearchRequest{
+		CollectionName: testCollection,
+		SearchInput:    placeholderGroup([]float32{1, 0, 0}),
+		SearchParams:   searchParams(map[string]string{"topk": "1"}),
+	})
+	s.ErrorIs(merr.CheckRPCCall(resp, err), merr.ErrParameterInvalid)
+}
+
+func (s *ServerSuite) TestUpsertDelete() {
+	ctx := context.Background()
+	s.createLoadedCollection(ctx, false)
+
+	upsert, err := s.client.Upsert(ctx, &milvuspb.UpsertRequest{
+		CollectionName: testCollection,
+		FieldsData: []*schemapb.FieldData{
+			longField("id", 4, 5),
+			stringField("name", "dd", "e"),
+			vectorField("vector", 0, 1, 1, 1),
+		},
+		NumRows: 2,
+	})
+	s.Require().NoError(merr.CheckRPCCall(upsert, err))
+	s.Equal(int64(2), upsert.GetUpsertCnt())
+
+	del, err := s.client.Delete(ctx, &milvuspb.DeleteRequest{CollectionName: testCollection, Expr: "id in [1, 2]"})
+	s.Require().NoError(merr.CheckRPCCall(del, err))
+	s.Equal(int64(2), del.GetDeleteCnt())
+
+	resp, err := s.client.Query(ctx, &milvuspb.QueryRequest{CollectionName: testCollection, OutputFields: []string{"name"}})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal([]int64{3, 4, 5}, resp.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+	s.Equal([]string{"c", "dd", "e"}, resp.GetFieldsData()[1].GetScalars().GetStringData().GetData())
+
+	insert, err := s.client.Insert(ctx, &milvuspb.InsertRequest{
+		CollectionName: testCollection,
+		FieldsData:     []*schemapb.FieldData{longField("id", 6), vectorField("vector", 1, 1)},
+		NumRows:        1,
+	})
+	s.ErrorIs(merr.CheckRPCCall(insert, err), merr.ErrParameterInvalid)
+}
+
+func (s *ServerSuite) TestPartitionAndAlias() {
+	ctx := context.Background()
+	s.createLoadedCollection(ctx, false)
+
+	s.Require().NoError(merr.CheckRPCCall(s.client.CreatePartition(ctx, &milvuspb.CreatePartitionRequest{CollectionName: testCollection, PartitionName: "p1"})))
+	insert, err := s.client.Insert(ctx, &milvuspb.InsertRequest{
+		CollectionName: testCollection,
+		PartitionName:  "p1",
+		FieldsData:     []*schemapb.FieldData{longField("id", 10), stringField("name", "p"), vectorField("vector", 1, 1)},
+		NumRows:        1,
+	})
+	s.Require().NoError(merr.CheckRPCCall(insert, err))
+
+	s.Require().NoError(merr.CheckRPCCall(s.client.CreateAlias(ctx, &milvuspb.CreateAliasRequest{CollectionName: testCollection, Alias: "alias"})))
+	resp, err := s.client.Query(ctx, &milvuspb.QueryRequest{CollectionName: "alias", PartitionNames: []string{"p1"}})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal([]int64{10}, resp.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	s.Require().NoError(merr.CheckRPCCall(s.client.ReleasePartitions(ctx, &milvuspb.ReleasePartitionsRequest{CollectionName: testCollection, PartitionNames: []string{"p1"}})))
+	resp, err = s.client.Query(ctx, &milvuspb.QueryRequest{CollectionName: "alias", PartitionNames: []string{"p1"}})
+	s.Error(merr.CheckRPCCall(resp, err))
+	resp, err = s.client.Query(ctx, &milvuspb.QueryRequest{CollectionName: "alias"})
+	s.Require().NoError(merr.CheckRPCCall(resp, err))
+	s.Equal([]int64{1, 2, 3, 4}, resp.GetFieldsData()[0].GetScalars().GetLongData().GetData())
+
+	s.Error(merr.CheckRPCCall(s.client.DropCollection(ctx, &milvuspb.DropCollectionRequest{CollectionName: testCollection})))
+	s.Require().NoError(merr.CheckRPCCall(s.client.DropAlias(ctx, &milvuspb.DropAliasRequest{Alias: "alias"})))
+	desc, err := s.client.DescribeAlias(ctx, &milvuspb.DescribeAliasRequest{Alias: "alias"})
+	s.ErrorIs(merr.CheckRPCCall(desc, err), merr.ErrAliasNotFound)
+}
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
@@ -0,0 +1,320 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvustest
+
+import (
+	"context"
+	"maps"
+	"strconv"
+
+	"github.com/milvus-io/milvus-proto/go-api/v3/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v3/schemapb"
+	"github.com/milvus-io/milvus/pkg/v3/common"
+	"github.com/milvus-io/milvus/pkg/v3/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v3/util/merr"
+)
+
+// targetPartition returns the partition to write, the default partition is used if name is empty.
+func (c *collection) targetPartition(name string) (*partition, error) {
+	if name == "" {
+		name = defaultPartitionName
+	}
+	p := c.partition(name)
+	if p == nil {
+		return nil, merr.WrapErrPartitionNotFound(name)
+	}
+	return p, nil
+}
+
+// parseRows converts the field data into the values of each row, only the provided fields are set.
+func (c *collection) parseRows(fieldsData []*schemapb.FieldData, numRows int) ([]map[string]any, error) {
+	rows := make([]map[string]any, numRows)
+	for i := range rows {
+		rows[i] = make(map[string]any)
+	}
+	for _, fd := range fieldsData {
+		field := c.field(fd.GetFieldName())
+		if fd.GetIsDynamic() {
+			field = c.dynamicField()
+		}
+		if field == nil {
+			return nil, merr.WrapErrParameterInvalidMsg("field %s does not exist in collection %s", fd.GetFieldName(), c.name())
+		}
+		values, err := fieldValues(field, fd)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != numRows {
+			return nil, merr.WrapErrParameterInvalidMsg("field %s has %d rows, expected %d", field.GetName(), len(values), numRows)
+		}
+		for i, v := range values {
+			if v == nil {
+				v = defaultValue(field)
+			}
+			if v == nil && !field.GetNullable() {
+				return nil, merr.WrapErrParameterInvalidMsg("field %s is not nullable, but row %d is null", field.GetName(), i)
+			}
+			if err := checkMaxLength(field, v); err != nil {
+				return nil, err
+			}
+			rows[i][field.GetName()] = v
+		}
+	}
+	return rows, nil
+}
+
+func checkMaxLength(field *schemapb.FieldSchema, v any) error {
+	s, ok := v.(string)
+	if !ok || field.GetDataType() != schemapb.DataType_VarChar {
+		return nil
+	}
+	maxLength, err := funcutil.GetAttrByKeyFromRepeatedKV(common.MaxLengthKey, field.GetTypeParams())
+	if err != nil {
+		return nil
+	}
+	if limit, err := strconv.Atoi(maxLength); err == nil && len(s) > limit {
+		return merr.WrapErrParameterInvalidMsg("length of varchar field %s exceeds max length, row length: %d, max length: %d", field.GetName(), len(s), limit)
+	}
+	return nil
+}
+
+// fillMissing fills the fields not provided with the default value or null.
+func (c *collection) fillMissing(values map[string]any) error {
+	for _, field := range c.schema.GetFields() {
+		if _, ok := values[field.GetName()]; ok {
+			continue
+		}
+		switch {
+		case field.GetIsDynamic():
+			values[field.GetName()] = []byte("{}")
+		case defaultValue(field) != nil:
+			values[field.GetName()] = defaultValue(field)
+		case field.GetNullable():
+			values[field.GetName()] = nil
+		default:
+			return merr.WrapErrParameterInvalidMsg("field %s is missing in the inserted data", field.GetName())
+		}
+	}
+	return nil
+}
+
+func (c *collection) allocAutoID() any {
+	id := c.nextAutoID
+	c.nextAutoID++
+	if c.pkField().GetDataType() == schemapb.DataType_VarChar {
+		return strconv.FormatInt(id, 10)
+	}
+	return id
+}
+
+// put inserts the row or replaces the row with the same primary key.
+func (c *collection) put(r *row) {
+	if idx, ok := c.pkIndex[r.pk]; ok {
+		c.rows[idx] = r
+		return
+	}
+	c.pkIndex[r.pk] = len(c.rows)
+	c.rows = append(c.rows, r)
+}
+
+func buildIDs(pkField *schemapb.FieldSchema, pks []any) *schemapb.IDs {
+	if pkField.GetDataType() == schemapb.DataType_VarChar {
+		data := make([]string, 0, len(pks))
+		for _, pk := range pks {
+			data = append(data, pk.(string))
+		}
+		return &schemapb.IDs{IdField: &schemapb.IDs_StrId{StrId: &schemapb.StringArray{Data: data}}}
+	}
+	data := make([]int64, 0, len(pks))
+	for _, pk := range pks {
+		data = append(data, pk.(int64))
+	}
+	return &schemapb.IDs{IdField: &schemapb.IDs_IntId{IntId: &schemapb.LongArray{Data: data}}}
+}
+
+func numRowsOf(numRows uint32, fieldsData []*schemapb.FieldData) int {
+	if numRows > 0 || len(fieldsData) == 0 {
+		return int(numRows)
+	}
+	n, _ := funcutil.GetNumRowOfFieldData(fieldsData[0])
+	return int(n)
+}
+
+func (s *Server) Insert(ctx context.Context, req *milvuspb.InsertRequest) (*milvuspb.MutationResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	p, err := coll.targetPartition(req.GetPartitionName())
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	rows, err := coll.parseRows(req.GetFieldsData(), numRowsOf(req.GetNumRows(), req.GetFieldsData()))
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	pkField := coll.pkField()
+	pks := make([]any, 0, len(rows))
+	for _, values := range rows {
+		if pkField.GetAutoID() {
+			if _, ok := values[pkField.GetName()]; ok {
+				return &milvuspb.MutationResult{Status: merr.Status(merr.WrapErrParameterInvalidMsg("the primary key %s is auto generated, shall not be provided", pkField.GetName()))}, nil
+			}
+			values[pkField.GetName()] = coll.allocAutoID()
+		}
+		if err := coll.fillMissing(values); err != nil {
+			return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+		}
+		pks = append(pks, values[pkField.GetName()])
+	}
+	for i, values := range rows {
+		coll.put(&row{pk: pks[i], partition: p.name, values: values})
+	}
+	return &milvuspb.MutationResult{
+		Status:    merr.Success(),
+		IDs:       buildIDs(pkField, pks),
+		SuccIndex: succIndex(len(pks)),
+		InsertCnt: int64(len(pks)),
+		Timestamp: allocTimestamp(),
+	}, nil
+}
+
+func (s *Server) Upsert(ctx context.Context, req *milvuspb.UpsertRequest) (*milvuspb.MutationResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	p, err := coll.targetPartition(req.GetPartitionName())
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	rows, err := coll.parseRows(req.GetFieldsData(), numRowsOf(req.GetNumRows(), req.GetFieldsData()))
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	pkField := coll.pkField()
+	upserted := make([]*row, 0, len(rows))
+	for _, values := range rows {
+		pk, ok := values[pkField.GetName()]
+		if !ok {
+			return &milvuspb.MutationResult{Status: merr.Status(merr.WrapErrParameterInvalidMsg("the primary key %s shall be provided for upsert", pkField.GetName()))}, nil
+		}
+		// partial update merges the provided fields into the existing row
+		if idx, ok := coll.pkIndex[pk]; ok && req.GetPartialUpdate() {
+			merged := maps.Clone(coll.rows[idx].values)
+			maps.Copy(merged, values)
+			values = merged
+		}
+		if err := coll.fillMissing(values); err != nil {
+			return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+		}
+		upserted = append(upserted, &row{pk: pk, partition: p.name, values: values})
+	}
+
+	pks := make([]any, 0, len(upserted))
+	for _, r := range upserted {
+		coll.put(r)
+		pks = append(pks, r.pk)
+	}
+	return &milvuspb.MutationResult{
+		Status:    merr.Success(),
+		IDs:       buildIDs(pkField, pks),
+		SuccIndex: succIndex(len(pks)),
+		UpsertCnt: int64(len(pks)),
+		Timestamp: allocTimestamp(),
+	}, nil
+}
+
+func succIndex(n int) []uint32 {
+	result := make([]uint32, 0, n)
+	for i := 0; i < n; i++ {
+		result = append(result, uint32(i))
+	}
+	return result
+}
+
+func (s *Server) Delete(ctx context.Context, req *milvuspb.DeleteRequest) (*milvuspb.MutationResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	coll, err := s.getCollection(req.GetCollectionName())
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	if req.GetPartitionName() != "" && coll.partition(req.GetPartitionName()) == nil {
+		return &milvuspb.MutationResult{Status: merr.Status(merr.WrapErrPartitionNotFound(req.GetPartitionName()))}, nil
+	}
+	filter, err := coll.parseFilter(req.GetExpr(), req.GetExprTemplateValues())
+	if err != nil {
+		return &milvuspb.MutationResult{Status: merr.Status(err)}, nil
+	}
+	if filter == nil {
+		return &milvuspb.MutationResult{Status: merr.Status(merr.WrapErrParameterInvalidMsg("delete expression shall not be empty"))}, nil
+	}
+
+	var deleted []any
+	kept := coll.rows[:0]
+	for _, r := range coll.rows {
+		if (req.GetPartitionName() == "" || r.partition == req.GetPartitionName()) && coll.match(filter, r) {
+			deleted = append(deleted, r.pk)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	coll.rows = kept
+	coll.rebuildPKIndex()
+	return &milvuspb.MutationResult{
+		Status:    merr.Success(),
+		IDs:       buildIDs(coll.pkField(), deleted),
+		DeleteCnt: int64(len(deleted)),
+		Timestamp: allocTimestamp(),
+	}, nil
+}
+
+// Flush is a no-op since all the data is visible once written.
+func (s *Server) Flush(ctx context.Context, req *milvuspb.FlushRequest) (*milvuspb.FlushResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	resp := &milvuspb.FlushResponse{
+		Status:          merr.Success(),
+		DbName:          "default",
+		CollSegIDs:      make(map[string]*schemapb.LongArray),
+		FlushCollSegIDs: make(map[string]*schemapb.LongArray),
+		CollSealTimes:   make(map[string]int64),
+		CollFlushTs:     make(map[string]uint64),
+	}
+	ts := allocTimestamp()
+	for _, name := range req.GetCollectionNames() {
+		if _, err := s.getCollection(name); err != nil {
+			return &milvuspb.FlushResponse{Status: merr.Status(err)}, nil
+		}
+		resp.CollSegIDs[name] = &schemapb.LongArray{}
+		resp.FlushCollSegIDs[name] = &schemapb.LongArray{}
+		resp.CollFlushTs[name] = ts
+	}
+	return resp, nil
+}
+
+func (s *Server) GetFlushState(ctx context.Context, req *milvuspb.GetFlushStateRequest) (*milvuspb.GetFlushStateResponse, error) {
+	return &milvuspb.GetFlushStateResponse{
+		Status:  merr.Success(),
+		Flushed: true,
+	}, nil
+}
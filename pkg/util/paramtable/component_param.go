@@ -276,6 +276,16 @@ type commonConfig struct {
 	ExprEnabled           ParamItem `refreshable:"false"`
 	ExprAuthMode          ParamItem `refreshable:"false"`
 
+	JWTEnabled          ParamItem  `refreshable:"false"`
+	JWTIssuer           ParamItem  `refreshable:"false"`
+	JWTAudience         ParamItem  `refreshable:"false"`
+	JWTAlgorithms       ParamItem  `refreshable:"false"`
+	JWKSSource          ParamItem  `refreshable:"false"`
+	JWKSRefreshInterval ParamItem  `refreshable:"false"`
+	JWTUsernameClaim    ParamItem  `refreshable:"false"`
+	JWTRolesClaim       ParamItem  `refreshable:"false"`
+	JWTRoleMapping      ParamGroup `refreshable:"true"`
+
 	ClusterName ParamItem `refreshable:"false"`
 
 	SessionTTL        ParamItem `refreshable:"false"`
@@ -952,6 +962,88 @@ Large numeric passwords require double quotes to avoid yaml parsing precision is
 	}
 	p.ExprAuthMode.Init(base.mgr)
 
+	p.JWTEnabled = ParamItem{
+		Key:          "common.security.jwt.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Whether to accept JWT bearer tokens, e.g. OIDC access tokens, as credentials.
+Only takes effect when the authorization is enabled.`,
+		Export: true,
+	}
+	p.JWTEnabled.Init(base.mgr)
+
+	p.JWTIssuer = ParamItem{
+		Key:          "common.security.jwt.issuer",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "The expected iss claim of the tokens, required when the jwt authentication is enabled.",
+		Export:       true,
+	}
+	p.JWTIssuer.Init(base.mgr)
+
+	p.JWTAudience = ParamItem{
+		Key:          "common.security.jwt.audience",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "The expected aud claim of the tokens, not checked if empty.",
+		Export:       true,
+	}
+	p.JWTAudience.Init(base.mgr)
+
+	p.JWTAlgorithms = ParamItem{
+		Key:          "common.security.jwt.algorithms",
+		Version:      "3.0.0",
+		DefaultValue: "RS256,ES256",
+		Doc:          "The comma separated signature algorithms accepted for the tokens.",
+		Export:       true,
+	}
+	p.JWTAlgorithms.Init(base.mgr)
+
+	p.JWKSSource = ParamItem{
+		Key:          "common.security.jwt.jwksSource",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "The local file path or the http(s) url of the JSON Web Key Set used to verify the tokens.",
+		Export:       true,
+	}
+	p.JWKSSource.Init(base.mgr)
+
+	p.JWKSRefreshInterval = ParamItem{
+		Key:          "common.security.jwt.jwksRefreshInterval",
+		Version:      "3.0.0",
+		DefaultValue: "300",
+		Doc:          "The interval to reload the JSON Web Key Set, in seconds.",
+		Export:       true,
+	}
+	p.JWKSRefreshInterval.Init(base.mgr)
+
+	p.JWTUsernameClaim = ParamItem{
+		Key:          "common.security.jwt.usernameClaim",
+		Version:      "3.0.0",
+		DefaultValue: "sub",
+		Doc:          "The claim used as the Milvus user name, nested claims are separated by dots.",
+		Export:       true,
+	}
+	p.JWTUsernameClaim.Init(base.mgr)
+
+	p.JWTRolesClaim = ParamItem{
+		Key:          "common.security.jwt.rolesClaim",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc: `The claim holding the roles of the user, a string or a list of strings, nested claims are separated by dots.
+The roles from the claim are granted in addition to the roles bound to the user in Milvus.`,
+		Export: true,
+	}
+	p.JWTRolesClaim.Init(base.mgr)
+
+	p.JWTRoleMapping = ParamGroup{
+		KeyPrefix: "common.security.jwt.roleMapping.",
+		Version:   "3.0.0",
+		Export:    true,
+		Doc:       "Maps the values of the roles claim to the Milvus roles, no role is granted from the claim if not set.",
+	}
+	p.JWTRoleMapping.Init(base.mgr)
+
 	p.ClusterName = ParamItem{
 		Key:          "common.cluster.name",
 		Version:      "2.0.0",
@@ -2063,6 +2155,26 @@ type AccessLogConfig struct {
 
 	CacheSize          ParamItem `refreshable:"false"`
 	CacheFlushInterval ParamItem `refreshable:"false"`
+
+	// output format, filters and sampling, reloaded with the access logger
+	OutputFormat   ParamItem `refreshable:"true"`
+	IncludeMethods ParamItem `refreshable:"true"`
+	ExcludeMethods ParamItem `refreshable:"true"`
+	IncludeDbs     ParamItem `refreshable:"true"`
+	ExcludeDbs     ParamItem `refreshable:"true"`
+	SamplingRates  ParamItem `refreshable:"true"`
+	ErrorOnly      ParamItem `refreshable:"true"`
+	MinTimeCost    ParamItem `refreshable:"true"`
+}
+
+// AuditLogConfig is the config of the tamper-evident audit log of the DDL, RBAC and data-destructive operations.
+type AuditLogConfig struct {
+	Enable            ParamItem `refreshable:"false"`
+	RootPath          ParamItem `refreshable:"false"`
+	SealInterval      ParamItem `refreshable:"false"`
+	SealMaxEntries    ParamItem `refreshable:"false"`
+	SealKey           ParamItem `refreshable:"false"`
+	MaxPendingEntries ParamItem `refreshable:"false"`
 }
 
 type proxyConfig struct {
@@ -2115,7 +2227,29 @@ type proxyConfig struct {
 	EnableCachedServiceProvider       ParamItem `refreshable:"true"`
 	MaxSearchAggregationResultEntries ParamItem `refreshable:"true"`
 
+	// dql task queue schedule policy
+	DQLSchedulePolicy        ParamItem  `refreshable:"false"`
+	DQLDefaultDatabaseWeight ParamItem  `refreshable:"true"`
+	DQLDatabaseWeights       ParamGroup `refreshable:"true"`
+
+	// search result cache
+	SearchCacheEnabled         ParamItem `refreshable:"true"`
+	SearchCacheMaxMemorySize   ParamItem `refreshable:"true"`
+	SearchCacheTimestampBucket ParamItem `refreshable:"true"`
+	SearchCacheTTL             ParamItem `refreshable:"true"`
+
+	// coalescing of the identical in-flight search and query requests
+	RequestCoalescingEnabled ParamItem `refreshable:"true"`
+
+	// search across multiple collections
+	FederatedSearchMaxCollections ParamItem `refreshable:"true"`
+
+	// zone aware replica selection
+	ZoneAwareRefreshInterval ParamItem `refreshable:"false"`
+	ZoneAwareOverloadFactor  ParamItem `refreshable:"true"`
+
 	AccessLog AccessLogConfig
+	AuditLog  AuditLogConfig
 
 	// connection manager
 	ConnectionCheckIntervalSeconds ParamItem `refreshable:"true"`
@@ -2294,6 +2428,94 @@ func (p *proxyConfig) init(base *BaseTable) {
 	}
 	p.DCLConcurrency.Init(base.mgr)
 
+	p.DQLSchedulePolicy = ParamItem{
+		Key:          "proxy.scheduler.dqlPolicy.name",
+		Version:      "3.0.0",
+		DefaultValue: "fifo",
+		Doc: `The policy to schedule the search and query tasks in the task queue of the proxy.
+fifo: the tasks are scheduled in the order they arrive.
+user-round-robin: the tasks of the users are scheduled one by one, the empty username is considered the same user.
+database-weighted-fair: the tasks of the databases are scheduled in proportion to the database weights.`,
+		Export: true,
+	}
+	p.DQLSchedulePolicy.Init(base.mgr)
+
+	p.DQLDefaultDatabaseWeight = ParamItem{
+		Key:          "proxy.scheduler.dqlPolicy.defaultDatabaseWeight",
+		Version:      "3.0.0",
+		DefaultValue: "1",
+		Doc:          "The weight of the databases without the configured weight when using the database-weighted-fair policy.",
+		Export:       true,
+	}
+	p.DQLDefaultDatabaseWeight.Init(base.mgr)
+
+	p.DQLDatabaseWeights = ParamGroup{
+		KeyPrefix: "proxy.scheduler.dqlPolicy.databaseWeights.",
+		Version:   "3.0.0",
+		Doc:       "The weights of the databases when using the database-weighted-fair policy, such as databaseWeights.db1: 4, the database names are case-insensitive.",
+		Export:    true,
+	}
+	p.DQLDatabaseWeights.Init(base.mgr)
+
+	p.SearchCacheEnabled = ParamItem{
+		Key:          "proxy.searchCache.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "true",
+		Doc: `Switch of the search result cache of the proxy.
+The cache only takes effect on the collections with the collection.searchCache.enabled property set to true,
+and only caches the searches which are not strong consistency.`,
+		Export: true,
+	}
+	p.SearchCacheEnabled.Init(base.mgr)
+
+	p.SearchCacheMaxMemorySize = ParamItem{
+		Key:          "proxy.searchCache.maxMemorySize",
+		Version:      "3.0.0",
+		DefaultValue: "256",
+		Doc:          "The maximum memory size of the cached search results, unit: MB, the least recently used results are evicted when exceeded.",
+		Export:       true,
+	}
+	p.SearchCacheMaxMemorySize.Init(base.mgr)
+
+	p.SearchCacheTimestampBucket = ParamItem{
+		Key:          "proxy.searchCache.timestampBucket",
+		Version:      "3.0.0",
+		DefaultValue: "5000",
+		Doc: `The guarantee timestamps of the searches are bucketed by this interval to share the cached results, unit: ms.
+The results could be staler than the consistency level by up to this interval.`,
+		Export: true,
+	}
+	p.SearchCacheTimestampBucket.Init(base.mgr)
+
+	p.SearchCacheTTL = ParamItem{
+		Key:          "proxy.searchCache.ttl",
+		Version:      "3.0.0",
+		DefaultValue: "30",
+		Doc:          "The time to live of the cached search results, unit: second.",
+		Export:       true,
+	}
+	p.SearchCacheTTL.Init(base.mgr)
+
+	p.RequestCoalescingEnabled = ParamItem{
+		Key:          "proxy.requestCoalescing.enabled",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc: `Switch of the coalescing of the identical in-flight search and query requests.
+The identical requests arriving while the first one is executing wait for and share its result instead of executing again.
+Only the bounded and eventually consistency requests are coalesced.`,
+		Export: true,
+	}
+	p.RequestCoalescingEnabled.Init(base.mgr)
+
+	p.FederatedSearchMaxCollections = ParamItem{
+		Key:          "proxy.federatedSearch.maxCollections",
+		Version:      "3.0.0",
+		DefaultValue: "16",
+		Doc:          `The maximum number of collections or aliases a federated search could target.`,
+		Export:       true,
+	}
+	p.FederatedSearchMaxCollections.Init(base.mgr)
+
 	p.GinLogging = ParamItem{
 		Key:          "proxy.ginLogging",
 		Version:      "2.2.0",
@@ -2468,6 +2690,132 @@ please adjust in embedded Milvus: false`,
 	}
 	p.AccessLog.Formatter.Init(base.mgr)
 
+	p.AccessLog.OutputFormat = ParamItem{
+		Key:          "proxy.accessLog.outputFormat",
+		Version:      "3.0.0",
+		DefaultValue: "text",
+		Doc:          "Output format of the access log, support text and json. The text format uses the formatters, the json format writes one json object with typed fields per line.",
+		Export:       true,
+	}
+	p.AccessLog.OutputFormat.Init(base.mgr)
+
+	p.AccessLog.IncludeMethods = ParamItem{
+		Key:          "proxy.accessLog.includeMethods",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "Comma separated methods to write the access log for, empty means all methods.",
+		Export:       true,
+	}
+	p.AccessLog.IncludeMethods.Init(base.mgr)
+
+	p.AccessLog.ExcludeMethods = ParamItem{
+		Key:          "proxy.accessLog.excludeMethods",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "Comma separated methods not to write the access log for.",
+		Export:       true,
+	}
+	p.AccessLog.ExcludeMethods.Init(base.mgr)
+
+	p.AccessLog.IncludeDbs = ParamItem{
+		Key:          "proxy.accessLog.includeDbs",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "Comma separated databases to write the access log for, empty means all databases. The requests without database are skipped if it is set.",
+		Export:       true,
+	}
+	p.AccessLog.IncludeDbs.Init(base.mgr)
+
+	p.AccessLog.ExcludeDbs = ParamItem{
+		Key:          "proxy.accessLog.excludeDbs",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "Comma separated databases not to write the access log for.",
+		Export:       true,
+	}
+	p.AccessLog.ExcludeDbs.Init(base.mgr)
+
+	p.AccessLog.SamplingRates = ParamItem{
+		Key:          "proxy.accessLog.samplingRates",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "Comma separated sampling rates of methods in method:rate format, e.g. Search:0.1,HybridSearch:0.1. The failed requests are always written.",
+		Export:       true,
+	}
+	p.AccessLog.SamplingRates.Init(base.mgr)
+
+	p.AccessLog.ErrorOnly = ParamItem{
+		Key:          "proxy.accessLog.errorOnly",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "Whether to write the access log for the failed requests only. If minTimeCost is set as well, the failed or slow requests are written.",
+		Export:       true,
+	}
+	p.AccessLog.ErrorOnly.Init(base.mgr)
+
+	p.AccessLog.MinTimeCost = ParamItem{
+		Key:          "proxy.accessLog.minTimeCost",
+		Version:      "3.0.0",
+		DefaultValue: "0",
+		Doc:          "Write the access log for the requests taking at least this time only, in ms. 0 disables it.",
+		Export:       true,
+	}
+	p.AccessLog.MinTimeCost.Init(base.mgr)
+
+	p.AuditLog.Enable = ParamItem{
+		Key:          "proxy.auditLog.enable",
+		Version:      "3.0.0",
+		DefaultValue: "false",
+		Doc:          "Whether to enable the audit log of the DDL, RBAC and data-destructive operations.",
+		Export:       true,
+	}
+	p.AuditLog.Enable.Init(base.mgr)
+
+	p.AuditLog.RootPath = ParamItem{
+		Key:          "proxy.auditLog.rootPath",
+		Version:      "3.0.0",
+		DefaultValue: "audit_log",
+		Doc:          "The path of the object storage to seal the audit log segments into, relative to the root path of the object storage.",
+		Export:       true,
+	}
+	p.AuditLog.RootPath.Init(base.mgr)
+
+	p.AuditLog.SealInterval = ParamItem{
+		Key:          "proxy.auditLog.sealInterval",
+		Version:      "3.0.0",
+		DefaultValue: "60",
+		Doc:          "The time interval to seal the pending audit log entries into the object storage, in seconds.",
+		Export:       true,
+	}
+	p.AuditLog.SealInterval.Init(base.mgr)
+
+	p.AuditLog.SealMaxEntries = ParamItem{
+		Key:          "proxy.auditLog.sealMaxEntries",
+		Version:      "3.0.0",
+		DefaultValue: "10000",
+		Doc:          "The number of pending audit log entries to trigger sealing before the seal interval.",
+		Export:       true,
+	}
+	p.AuditLog.SealMaxEntries.Init(base.mgr)
+
+	p.AuditLog.SealKey = ParamItem{
+		Key:          "proxy.auditLog.sealKey",
+		Version:      "3.0.0",
+		DefaultValue: "",
+		Doc:          "The key to sign the audit log segments with HMAC-SHA256, it is required when the audit log is enabled.",
+		Export:       true,
+	}
+	p.AuditLog.SealKey.Init(base.mgr)
+
+	p.AuditLog.MaxPendingEntries = ParamItem{
+		Key:          "proxy.auditLog.maxPendingEntries",
+		Version:      "3.0.0",
+		DefaultValue: "100000",
+		Doc:          "The maximum number of audit log entries waiting to be sealed, the audited requests are rejected if it is reached.",
+		Export:       true,
+	}
+	p.AuditLog.MaxPendingEntries.Init(base.mgr)
+
 	p.ShardLeaderCacheInterval = ParamItem{
 		Key:          "proxy.shardLeaderCacheInterval",
 		Version:      "2.2.4",
@@ -2480,7 +2828,7 @@ please adjust in embedded Milvus: false`,
 		Key:          "proxy.replicaSelectionPolicy",
 		Version:      "2.3.0",
 		DefaultValue: "look_aside",
-		Doc:          "replica selection policy in multiple replicas load balancing, support round_robin and look_aside",
+		Doc:          "replica selection policy in multiple replicas load balancing, support round_robin, look_aside, zone_aware and power_of_two_choices",
 	}
 	p.ReplicaSelectionPolicy.Init(base.mgr)
 
@@ -2517,6 +2865,23 @@ please adjust in embedded Milvus: false`,
 	}
 	p.WorkloadToleranceFactor.Init(base.mgr)
 
+	p.ZoneAwareRefreshInterval = ParamItem{
+		Key:          "proxy.zoneAwareRefreshInterval",
+		Version:      "3.0.0",
+		DefaultValue: "10000",
+		Doc:          "time interval to refresh the zones of query nodes for the zone_aware replica selection policy, in ms",
+	}
+	p.ZoneAwareRefreshInterval.Init(base.mgr)
+
+	p.ZoneAwareOverloadFactor = ParamItem{
+		Key:          "proxy.zoneAwareOverloadFactor",
+		Version:      "3.0.0",
+		DefaultValue: "2",
+		Doc: `overload factor of the zone_aware replica selection policy, the query nodes in the same zone are considered overloaded
+		if the least outstanding requests of them is higher than this factor times the least outstanding requests of the other query nodes plus one`,
+	}
+	p.ZoneAwareOverloadFactor.Init(base.mgr)
+
 	p.RetryTimesOnReplica = ParamItem{
 		Key:          "proxy.retryTimesOnReplica",
 		Version:      "2.3.0",
@@ -5325,23 +5690,28 @@ type dataCoordConfig struct {
 	CheckAutoBalanceConfigInterval ParamItem `refreshable:"false"`
 
 	// import
-	FilesPerPreImportTask           ParamItem `refreshable:"true"`
-	ImportTaskRetention             ParamItem `refreshable:"true"`
-	MaxSizeInMBPerImportTask        ParamItem `refreshable:"true"`
-	ImportScheduleInterval          ParamItem `refreshable:"true"`
-	ImportCheckIntervalHigh         ParamItem `refreshable:"true"`
-	ImportCheckIntervalLow          ParamItem `refreshable:"true"`
-	MaxFilesPerImportReq            ParamItem `refreshable:"true"`
-	MaxImportJobNum                 ParamItem `refreshable:"true"`
-	WaitForIndex                    ParamItem `refreshable:"true"`
-	ImportInReplicatingCluster      ParamItem `refreshable:"true"`
-	ImportPreAllocIDExpansionFactor ParamItem `refreshable:"true"`
-	ImportFileNumPerSlot            ParamItem `refreshable:"true"`
-	ImportMemoryLimitPerSlot        ParamItem `refreshable:"true"`
-	MaxSegmentsPerCopyTask          ParamItem `refreshable:"true"`
-	CopySegmentCheckInterval        ParamItem `refreshable:"true"`
-	CopySegmentTaskRetention        ParamItem `refreshable:"true"`
-	CopySegmentJobTimeout           ParamItem `refreshable:"true"`
+	FilesPerPreImportTask             ParamItem `refreshable:"true"`
+	ImportTaskRetention               ParamItem `refreshable:"true"`
+	MaxSizeInMBPerImportTask          ParamItem `refreshable:"true"`
+	ImportScheduleInterval            ParamItem `refreshable:"true"`
+	ImportCheckIntervalHigh           ParamItem `refreshable:"true"`
+	ImportCheckIntervalLow            ParamItem `refreshable:"true"`
+	MaxFilesPerImportReq              ParamItem `refreshable:"true"`
+	MaxImportJobNum                   ParamItem `refreshable:"true"`
+	WaitForIndex                      ParamItem `refreshable:"true"`
+	ImportInReplicatingCluster        ParamItem `refreshable:"true"`
+	ImportPreAllocIDExpansionFactor   ParamItem `refreshable:"true"`
+	ImportFileNumPerSlot              ParamItem `refreshable:"true"`
+	ImportMemoryLimitPerSlot          ParamItem `refreshable:"true"`
+	ImportContinuousScanInterval      ParamItem `refreshable:"true"`
+	ImportContinuousLookbackWindow    ParamItem `refreshable:"true"`
+	ImportContinuousJobMissingTimeout ParamItem `refreshable:"true"`
+	ImportMaxTasksPerDB               ParamItem `refreshable:"true"`
+	ImportMaxTasksPerCollection       ParamItem `refreshable:"true"`
+	MaxSegmentsPerCopyTask            ParamItem `refreshable:"true"`
+	CopySegmentCheckInterval          ParamItem `refreshable:"true"`
+	CopySegmentTaskRetention          ParamItem `refreshable:"true"`
+	CopySegmentJobTimeout             ParamItem `refreshable:"true"`
 
 	ExternalCollectionCheckInterval    ParamItem `refreshable:"true"`
 	ExternalCollectionJobTimeout       ParamItem `refreshable:"true"`
@@ -6597,6 +6967,58 @@ if param targetScalarIndexVersion is not set, the default value is -1, which mea
 	}
 	p.ImportMemoryLimitPerSlot.Init(base.mgr)
 
+	p.ImportContinuousScanInterval = ParamItem{
+		Key:          "dataCoord.import.continuousScanInterval",
+		Version:      "3.0.0",
+		Doc:          "The default interval for scanning the prefix of continuous import, measured in seconds.",
+		DefaultValue: "300",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ImportContinuousScanInterval.Init(base.mgr)
+
+	p.ImportContinuousLookbackWindow = ParamItem{
+		Key:     "dataCoord.import.continuousLookbackWindow",
+		Version: "3.0.0",
+		Doc: "The files modified within this window before the watermark of continuous import are tracked one by one, " +
+			"to tolerate the files landing out of order, measured in seconds.",
+		DefaultValue: "3600",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ImportContinuousLookbackWindow.Init(base.mgr)
+
+	p.ImportContinuousJobMissingTimeout = ParamItem{
+		Key:     "dataCoord.import.continuousJobMissingTimeout",
+		Version: "3.0.0",
+		Doc: "The time the import job submitted by continuous import may be absent from the meta before it is treated as lost, " +
+			"and its files are imported again by the next scan, measured in seconds.",
+		DefaultValue: "600",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ImportContinuousJobMissingTimeout.Init(base.mgr)
+
+	p.ImportMaxTasksPerDB = ParamItem{
+		Key:          "dataCoord.import.maxConcurrentTasksPerDB",
+		Version:      "3.0.0",
+		Doc:          "The maximum number of concurrently scheduled pre-import/import tasks of a database, 0 means no limit.",
+		DefaultValue: "0",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ImportMaxTasksPerDB.Init(base.mgr)
+
+	p.ImportMaxTasksPerCollection = ParamItem{
+		Key:          "dataCoord.import.maxConcurrentTasksPerCollection",
+		Version:      "3.0.0",
+		Doc:          "The maximum number of concurrently scheduled pre-import/import tasks of a collection, 0 means no limit.",
+		DefaultValue: "0",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.ImportMaxTasksPerCollection.Init(base.mgr)
+
 	p.MaxSegmentsPerCopyTask = ParamItem{
 		Key:          "dataCoord.import.maxSegmentsPerCopyTask",
 		Version:      "2.5.0",
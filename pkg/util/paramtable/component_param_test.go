@@ -145,6 +145,13 @@ func TestComponentParam(t *testing.T) {
 		params.Save("common.security.superUsers", "")
 		assert.Equal(t, []string{}, Params.SuperUsers.GetAsStrings())
 
+		assert.False(t, Params.JWTEnabled.GetAsBool())
+		assert.Equal(t, "sub", Params.JWTUsernameClaim.GetValue())
+		assert.Equal(t, []string{"RS256", "ES256"}, Params.JWTAlgorithms.GetAsStrings())
+		assert.Equal(t, int64(300), Params.JWKSRefreshInterval.GetAsInt64())
+		params.SaveGroup(map[string]string{"common.security.jwt.roleMapping.Milvus-Admins": "admin"})
+		assert.Equal(t, map[string]string{"milvus-admins": "admin"}, Params.JWTRoleMapping.GetValue())
+
 		assert.Equal(t, false, Params.PreCreatedTopicEnabled.GetAsBool())
 
 		params.Save("common.preCreatedTopic.names", "topic1,topic2,topic3")
@@ -252,6 +259,31 @@ func TestComponentParam(t *testing.T) {
 		t.Logf("MaxTaskNum: %d", Params.MaxTaskNum.GetAsInt64())
 		assert.Equal(t, int64(1024), Params.MaxTaskNum.GetAsInt64())
 
+		assert.Equal(t, "fifo", Params.DQLSchedulePolicy.GetValue())
+		assert.Equal(t, 1.0, Params.DQLDefaultDatabaseWeight.GetAsFloat())
+		assert.Empty(t, Params.DQLDatabaseWeights.GetValue())
+		params.SaveGroup(map[string]string{Params.DQLDatabaseWeights.KeyPrefix + "DB1": "4"})
+		assert.Equal(t, map[string]string{"db1": "4"}, Params.DQLDatabaseWeights.GetValue())
+
+		assert.True(t, Params.SearchCacheEnabled.GetAsBool())
+		assert.Equal(t, int64(256), Params.SearchCacheMaxMemorySize.GetAsInt64())
+		assert.Equal(t, 5*time.Second, Params.SearchCacheTimestampBucket.GetAsDuration(time.Millisecond))
+		assert.Equal(t, 30*time.Second, Params.SearchCacheTTL.GetAsDuration(time.Second))
+		assert.False(t, Params.RequestCoalescingEnabled.GetAsBool())
+		assert.Equal(t, 16, Params.FederatedSearchMaxCollections.GetAsInt())
+		assert.Equal(t, 10*time.Second, Params.ZoneAwareRefreshInterval.GetAsDuration(time.Millisecond))
+		assert.Equal(t, 2.0, Params.ZoneAwareOverloadFactor.GetAsFloat())
+
+		assert.Equal(t, "text", Params.AccessLog.OutputFormat.GetValue())
+		assert.Empty(t, Params.AccessLog.SamplingRates.GetValue())
+		assert.False(t, Params.AccessLog.ErrorOnly.GetAsBool())
+		assert.Equal(t, int64(0), Params.AccessLog.MinTimeCost.GetAsInt64())
+		assert.False(t, Params.AuditLog.Enable.GetAsBool())
+		assert.Equal(t, "audit_log", Params.AuditLog.RootPath.GetValue())
+		assert.Equal(t, time.Minute, Params.AuditLog.SealInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 10000, Params.AuditLog.SealMaxEntries.GetAsInt())
+		assert.Empty(t, Params.AuditLog.SealKey.GetValue())
+		assert.Equal(t, 100000, Params.AuditLog.MaxPendingEntries.GetAsInt())
 		t.Logf("AccessLog.Enable: %t", Params.AccessLog.Enable.GetAsBool())
 
 		t.Logf("AccessLog.MaxSize: %d", Params.AccessLog.MaxSize.GetAsInt64())
@@ -638,6 +670,11 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, false, Params.ImportInReplicatingCluster.GetAsBool())
 		assert.Equal(t, 4, Params.ImportFileNumPerSlot.GetAsInt())
 		assert.Equal(t, 160*1024*1024, Params.ImportMemoryLimitPerSlot.GetAsInt())
+		assert.Equal(t, 300*time.Second, Params.ImportContinuousScanInterval.GetAsDuration(time.Second))
+		assert.Equal(t, 3600*time.Second, Params.ImportContinuousLookbackWindow.GetAsDuration(time.Second))
+		assert.Equal(t, 600*time.Second, Params.ImportContinuousJobMissingTimeout.GetAsDuration(time.Second))
+		assert.Equal(t, 0, Params.ImportMaxTasksPerDB.GetAsInt())
+		assert.Equal(t, 0, Params.ImportMaxTasksPerCollection.GetAsInt())
 
 		params.Save("datacoord.gracefulStopTimeout", "100")
 		assert.Equal(t, 100*time.Second, Params.GracefulStopTimeout.GetAsDuration(time.Second))
@@ -32,6 +32,7 @@ type httpConfig struct {
 	HSTSIncludeSubDomains ParamItem `refreshable:"false"`
 	EnableHSTS            ParamItem `refreshable:"false"`
 	EnableWebUI           ParamItem `refreshable:"false"`
+	IteratorCursorSecret  ParamItem `refreshable:"false"`
 }
 
 func (p *httpConfig) init(base *BaseTable) {
@@ -170,4 +171,14 @@ func (p *httpConfig) init(base *BaseTable) {
 		Export:       true,
 	}
 	p.EnableWebUI.Init(base.mgr)
+
+	p.IteratorCursorSecret = ParamItem{
+		Key:          "proxy.http.iteratorCursorSecret",
+		DefaultValue: "",
+		Version:      "3.0.0",
+		Doc: `The secret used to sign the cursors of the restful query and search iterators.
+If empty, a random key is generated once for the cluster and shared by all the proxies through etcd.`,
+		Export: true,
+	}
+	p.IteratorCursorSecret.Init(base.mgr)
 }
@@ -38,6 +38,7 @@ func TestHTTPConfig_Init(t *testing.T) {
 	assert.Equal(t, 300*time.Second, cfg.IdleTimeout.GetAsDurationByParse())
 	assert.Equal(t, 16777216, cfg.MaxHeaderBytes.GetAsInt())
 	assert.Equal(t, cfg.EnableWebUI.GetAsBool(), true)
+	assert.Equal(t, "", cfg.IteratorCursorSecret.GetValue())
 }
 
 func TestHTTPConfig_TimeoutOverrides(t *testing.T) {
@@ -23,6 +23,7 @@ var QuotaErrorString = map[commonpb.ErrorCode]string{
 	commonpb.ErrorCode_MemoryQuotaExhausted: "memory quota exceeded, please allocate more resources",
 	commonpb.ErrorCode_DiskQuotaExhausted:   "disk quota exceeded, please allocate more resources",
 	commonpb.ErrorCode_TimeTickLongDelay:    "time tick long delay",
+	commonpb.ErrorCode_RateLimit:            "rate limit exceeded",
 }
 
 func GetQuotaErrorString(errCode commonpb.ErrorCode) string {